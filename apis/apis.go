@@ -21,10 +21,13 @@ import (
 	bouncev1beta1 "github.com/rossigee/provider-mailgun/apis/bounce/v1beta1"
 	complaintv1beta1 "github.com/rossigee/provider-mailgun/apis/complaint/v1beta1"
 	domainv1beta1 "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+	ippoolv1beta1 "github.com/rossigee/provider-mailgun/apis/ippool/v1beta1"
 	mailinglistv1beta1 "github.com/rossigee/provider-mailgun/apis/mailinglist/v1beta1"
+	mailinglistmemberv1beta1 "github.com/rossigee/provider-mailgun/apis/mailinglistmember/v1beta1"
 	routev1beta1 "github.com/rossigee/provider-mailgun/apis/route/v1beta1"
 	smtpcredentialv1beta1 "github.com/rossigee/provider-mailgun/apis/smtpcredential/v1beta1"
 	templatev1beta1 "github.com/rossigee/provider-mailgun/apis/template/v1beta1"
+	templateversionv1beta1 "github.com/rossigee/provider-mailgun/apis/templateversion/v1beta1"
 	unsubscribev1beta1 "github.com/rossigee/provider-mailgun/apis/unsubscribe/v1beta1"
 	v1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
 	webhookv1beta1 "github.com/rossigee/provider-mailgun/apis/webhook/v1beta1"
@@ -39,10 +42,13 @@ func init() {
 		bouncev1beta1.AddToScheme,
 		complaintv1beta1.AddToScheme,
 		domainv1beta1.AddToScheme,
+		ippoolv1beta1.AddToScheme,
 		mailinglistv1beta1.AddToScheme,
+		mailinglistmemberv1beta1.AddToScheme,
 		routev1beta1.AddToScheme,
 		smtpcredentialv1beta1.AddToScheme,
 		templatev1beta1.AddToScheme,
+		templateversionv1beta1.AddToScheme,
 		unsubscribev1beta1.AddToScheme,
 		webhookv1beta1.AddToScheme,
 	)
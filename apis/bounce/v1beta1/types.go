@@ -47,6 +47,15 @@ type BounceParameters struct {
 
 // BounceObservation are the observable fields of a Bounce.
 type BounceObservation struct {
+	// Address is the bounced email address
+	Address string `json:"address,omitempty"`
+
+	// Code is the SMTP error code Mailgun recorded for the bounce
+	Code string `json:"code,omitempty"`
+
+	// Error is the error message Mailgun recorded for the bounce
+	Error string `json:"error,omitempty"`
+
 	// CreatedAt is when the bounce was recorded
 	CreatedAt *string `json:"createdAt,omitempty"`
 }
@@ -28,6 +28,11 @@ type ComplaintParameters struct {
 	// +kubebuilder:validation:Pattern="^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+.[a-zA-Z]{2,}$"
 	Address string `json:"address"`
 
+	// CreatedAt is when the complaint was made, in RFC 2822 format. Mailgun
+	// uses the time the entry is added when it is unset.
+	// +kubebuilder:validation:Optional
+	CreatedAt *string `json:"createdAt,omitempty"`
+
 	// DomainRef references the Domain resource this complaint belongs to
 	// +kubebuilder:validation:Required
 	DomainRef xpv1.Reference `json:"domainRef"`
@@ -39,6 +44,9 @@ type ComplaintParameters struct {
 
 // ComplaintObservation are the observable fields of a Complaint.
 type ComplaintObservation struct {
+	// Address is the email address that complained
+	Address string `json:"address,omitempty"`
+
 	// CreatedAt is when the complaint was recorded
 	CreatedAt *string `json:"createdAt,omitempty"`
 }
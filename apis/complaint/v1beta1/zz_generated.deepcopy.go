@@ -107,6 +107,11 @@ func (in *ComplaintObservation) DeepCopy() *ComplaintObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComplaintParameters) DeepCopyInto(out *ComplaintParameters) {
 	*out = *in
+	if in.CreatedAt != nil {
+		in, out := &in.CreatedAt, &out.CreatedAt
+		*out = new(string)
+		**out = **in
+	}
 	in.DomainRef.DeepCopyInto(&out.DomainRef)
 	if in.DomainSelector != nil {
 		in, out := &in.DomainSelector, &out.DomainSelector
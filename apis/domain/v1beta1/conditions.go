@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition types specific to Domains.
+const (
+	// TypePartiallyConfigured indicates that the domain exists in Mailgun but
+	// some of its settings have not been applied yet.
+	TypePartiallyConfigured xpv1.ConditionType = "PartiallyConfigured"
+
+	// TypeRecipientsPending indicates that some authorized recipients have
+	// not yet confirmed their address.
+	TypeRecipientsPending xpv1.ConditionType = "AuthorizedRecipientsPending"
+
+	// TypeDisabled indicates that Mailgun has disabled the domain.
+	TypeDisabled xpv1.ConditionType = "Disabled"
+
+	// TypeReceivingReady indicates whether the MX records of a receiving
+	// domain are valid, so that it can accept inbound mail.
+	TypeReceivingReady xpv1.ConditionType = "ReceivingReady"
+
+	// TypeTerminalError indicates that Mailgun rejected the domain in a way
+	// that retrying cannot fix, so it is not reconciled again until its spec
+	// changes.
+	TypeTerminalError xpv1.ConditionType = "TerminalError"
+)
+
+// Condition reasons specific to Domains.
+const (
+	ReasonTrackingNotApplied xpv1.ConditionReason = "TrackingNotApplied"
+	ReasonFullyConfigured    xpv1.ConditionReason = "FullyConfigured"
+	ReasonAwaitingConfirm    xpv1.ConditionReason = "AwaitingConfirmation"
+	ReasonAllConfirmed       xpv1.ConditionReason = "AllConfirmed"
+	ReasonDomainDisabled     xpv1.ConditionReason = "DomainDisabled"
+	ReasonDomainEnabled      xpv1.ConditionReason = "DomainEnabled"
+	ReasonMXRecordsValid     xpv1.ConditionReason = "MXRecordsValid"
+	ReasonMXRecordsNotValid  xpv1.ConditionReason = "MXRecordsNotValid"
+	ReasonRejected           xpv1.ConditionReason = "RejectedByMailgun"
+	ReasonSpecChanged        xpv1.ConditionReason = "SpecChanged"
+)
+
+// TrackingNotApplied returns a condition indicating that the domain was
+// created but applying its tracking settings failed.
+func TrackingNotApplied(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePartiallyConfigured,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTrackingNotApplied,
+		Message:            message,
+	}
+}
+
+// FullyConfigured returns a condition indicating that all domain settings
+// have been applied.
+func FullyConfigured() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePartiallyConfigured,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonFullyConfigured,
+	}
+}
+
+// RecipientsPending returns a condition listing authorized recipients that
+// have not confirmed their address yet.
+func RecipientsPending(emails []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRecipientsPending,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAwaitingConfirm,
+		Message:            "awaiting email confirmation from: " + strings.Join(emails, ", "),
+	}
+}
+
+// RecipientsConfirmed returns a condition indicating that every authorized
+// recipient has confirmed their address.
+func RecipientsConfirmed() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRecipientsPending,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAllConfirmed,
+	}
+}
+
+// Disabled returns a condition indicating that Mailgun has disabled the
+// domain, including Mailgun's reason if it gave one. Mailgun does not allow
+// re-enabling a domain through its API, so this usually needs a support
+// request.
+func Disabled(reason string) xpv1.Condition {
+	message := "the domain has been disabled by Mailgun and cannot send or receive mail"
+	if reason != "" {
+		message += ": " + reason
+	}
+	return xpv1.Condition{
+		Type:               TypeDisabled,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDomainDisabled,
+		Message:            message,
+	}
+}
+
+// Enabled returns a condition indicating that the domain is no longer
+// disabled.
+func Enabled() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDisabled,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDomainEnabled,
+	}
+}
+
+// ReceivingReady returns a condition indicating that every MX record of the
+// domain is valid.
+func ReceivingReady() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeReceivingReady,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMXRecordsValid,
+	}
+}
+
+// ReceivingNotReady returns a condition listing the MX records of the domain
+// that Mailgun has not found to be valid yet.
+func ReceivingNotReady(values []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeReceivingReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMXRecordsNotValid,
+		Message:            "MX records not valid yet: " + strings.Join(values, ", "),
+	}
+}
+
+// TerminalError returns a condition indicating that Mailgun rejected the
+// domain at the given generation. The message should carry Mailgun's
+// explanation.
+func TerminalError(message string, generation int64) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeTerminalError,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRejected,
+		Message:            message + "; change the spec to retry",
+	}.WithObservedGeneration(generation)
+}
+
+// TerminalErrorCleared returns a condition indicating that the spec of a
+// rejected domain changed, so it is reconciled again.
+func TerminalErrorCleared() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeTerminalError,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSpecChanged,
+	}
+}
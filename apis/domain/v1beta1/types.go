@@ -21,6 +21,22 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// AnnotationRotateWebhookSigningKey requests a rotation of the HTTP webhook
+// signing key. Each new value of the annotation triggers one rotation; the
+// value last acted on is recorded in status.atProvider.webhookSigningKeyRotation.
+// Mailgun keeps a single signing key per account, so rotating it affects
+// every domain of the account. The new key is written to the connection
+// secrets of the account's other Domains in the same reconcile.
+const AnnotationRotateWebhookSigningKey = "mailgun.crossplane.io/rotate-webhook-signing-key"
+
+// AnnotationRotateDKIMKey requests a new DKIM key for the domain, of the size
+// set by spec.forProvider.dkimKeySize. Each new value of the annotation
+// triggers one rotation; the value last acted on is recorded in
+// status.atProvider.dkimKeyRotation. The new key's DNS records are reported
+// in status.atProvider.sendingDnsRecords and must be published before the
+// domain verifies again.
+const AnnotationRotateDKIMKey = "mailgun.crossplane.io/rotate-dkim-key"
+
 // DomainParameters define the desired state of a Mailgun Domain
 type DomainParameters struct {
 	// Name is the domain name to create
@@ -42,12 +58,24 @@ type DomainParameters struct {
 	// +kubebuilder:default=1024
 	DKIMKeySize *int `json:"dkimKeySize,omitempty"`
 
-	// IPs is a list of IP addresses to whitelist for this domain
+	// IPs are the dedicated IPs the domain sends from. They are assigned
+	// when the domain is created and reconciled after that, unassigning any
+	// others. When unset, the IPs Mailgun assigns are left alone.
 	IPs []string `json:"ips,omitempty"`
 
+	// PoolID is the dedicated IP pool the domain is assigned to when it is
+	// created. Defaults to the ProviderConfig's defaultPoolID.
+	// +optional
+	PoolID *string `json:"poolID,omitempty"`
+
 	// Tracking settings for the domain
 	Tracking *DomainTracking `json:"tracking,omitempty"`
 
+	// Connection settings for delivery from the domain. Only the fields set
+	// are managed.
+	// +optional
+	Connection *DomainConnection `json:"connection,omitempty"`
+
 	// SMTP password for the domain (if not set, will be auto-generated)
 	SMTPPassword *string `json:"smtpPassword,omitempty"`
 
@@ -64,6 +92,32 @@ type DomainParameters struct {
 	// Wildcard setting for the domain
 	// +kubebuilder:default=false
 	Wildcard *bool `json:"wildcard,omitempty"`
+
+	// AuthorizedRecipients is the set of email addresses allowed to receive
+	// mail from a sandbox domain. Mailgun asks each recipient to confirm by
+	// email before they can receive messages. Recipients are account-wide:
+	// an address removed from this list is removed from Mailgun only if this
+	// Domain added it and no other Domain lists it.
+	// +optional
+	// +listType=set
+	AuthorizedRecipients []string `json:"authorizedRecipients,omitempty"`
+
+	// StaticConnectionDetails are published as-is in the connection secret,
+	// for example to pass the sending region on with the SMTP login. The
+	// smtp_login, smtp_password and webhook_signing_key keys are reserved.
+	// +optional
+	StaticConnectionDetails map[string]string `json:"staticConnectionDetails,omitempty"`
+
+	// PublishConnectionDetailsWhenActive withholds the SMTP login and
+	// password from the connection secret while Mailgun does not report the
+	// domain as active, so that nothing sends through it before it is
+	// verified. They are published once the domain becomes active. Mailgun
+	// returns the password only when the domain is created, so meanwhile it
+	// is kept in a Secret named <name>-withheld-smtp-password, owned by the
+	// Domain. That Secret is deleted once the connection secret holds the
+	// password.
+	// +optional
+	PublishConnectionDetailsWhenActive *bool `json:"publishConnectionDetailsWhenActive,omitempty"`
 }
 
 // DomainTracking defines tracking settings for a domain
@@ -79,6 +133,29 @@ type DomainTracking struct {
 	// Unsubscribe tracking enabled
 	// +kubebuilder:default=false
 	Unsubscribe *bool `json:"unsubscribe,omitempty"`
+
+	// UnsubscribeHTMLFooter is the footer Mailgun appends to HTML messages
+	// when unsubscribe tracking is enabled. It should contain the
+	// %unsubscribe_url% variable.
+	// +optional
+	UnsubscribeHTMLFooter *string `json:"unsubscribeHtmlFooter,omitempty"`
+
+	// UnsubscribeTextFooter is the footer Mailgun appends to plain text
+	// messages when unsubscribe tracking is enabled.
+	// +optional
+	UnsubscribeTextFooter *string `json:"unsubscribeTextFooter,omitempty"`
+}
+
+// DomainConnection defines how Mailgun connects to recipients' mail servers
+// when delivering messages sent from a domain
+type DomainConnection struct {
+	// RequireTLS makes Mailgun deliver only over TLS, failing delivery to
+	// servers that do not support it
+	RequireTLS *bool `json:"requireTls,omitempty"`
+
+	// SkipVerification makes Mailgun accept servers whose TLS certificate
+	// or hostname cannot be verified
+	SkipVerification *bool `json:"skipVerification,omitempty"`
 }
 
 // DomainObservation reflects the observed state of a Mailgun Domain
@@ -86,9 +163,22 @@ type DomainObservation struct {
 	// ID is the domain identifier in Mailgun
 	ID string `json:"id,omitempty"`
 
-	// State is the current state of the domain (active, unverified, disabled)
+	// State is the current state of the domain (active, unverified, disabled).
+	// It is set by Mailgun, whose API offers no way to disable or archive a
+	// domain, so there is no field to request a state. To retire a domain but
+	// keep it and its stats in Mailgun, leave Delete out of
+	// spec.managementPolicies, e.g. [Observe, Create, Update, LateInitialize],
+	// before deleting the Domain.
 	State string `json:"state,omitempty"`
 
+	// Disabled is true when Mailgun has disabled the domain, whether it says
+	// so through State or through its separate disabled fields.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// DisabledReason is Mailgun's explanation of why the domain is disabled,
+	// if it gives one.
+	DisabledReason string `json:"disabledReason,omitempty"`
+
 	// CreatedAt is when the domain was created
 	CreatedAt string `json:"createdAt,omitempty"`
 
@@ -98,6 +188,32 @@ type DomainObservation struct {
 	// SMTPPassword is the SMTP password for the domain
 	SMTPPassword string `json:"smtpPassword,omitempty"`
 
+	// WebScheme is the scheme of tracking URLs. It is the value reported by
+	// Mailgun, or the value last applied by the provider when Mailgun does
+	// not report one.
+	WebScheme string `json:"webScheme,omitempty"`
+
+	// SpamAction is how Mailgun handles spam for the domain. When the
+	// domain's own endpoint does not report it, it is read from the domains
+	// list, but only if spec.forProvider.spamAction is set.
+	SpamAction string `json:"spamAction,omitempty"`
+
+	// Wildcard is the wildcard setting last applied by the provider. Mailgun
+	// does not report it, so it is what drift is detected against.
+	Wildcard *bool `json:"wildcard,omitempty"`
+
+	// Tracking is the tracking configuration Mailgun reports for the domain.
+	// It is only read when spec.forProvider.tracking is set.
+	Tracking *DomainTracking `json:"tracking,omitempty"`
+
+	// IPs are the dedicated IPs Mailgun reports the domain sends from. They
+	// are only read when spec.forProvider.ips is set.
+	IPs []string `json:"ips,omitempty"`
+
+	// Connection is the connection configuration Mailgun reports for the
+	// domain. It is only read when spec.forProvider.connection is set.
+	Connection *DomainConnection `json:"connection,omitempty"`
+
 	// RequiredDNSRecords contains the DNS records that need to be configured
 	RequiredDNSRecords []DNSRecord `json:"requiredDnsRecords,omitempty"`
 
@@ -106,6 +222,54 @@ type DomainObservation struct {
 
 	// Sending DNS records for outgoing mail
 	SendingDNSRecords []DNSRecord `json:"sendingDnsRecords,omitempty"`
+
+	// AuthorizedRecipients listed or added by this Domain that exist in
+	// Mailgun
+	AuthorizedRecipients []AuthorizedRecipient `json:"authorizedRecipients,omitempty"`
+
+	// WebhookSigningKeyRotation is the value of the
+	// mailgun.crossplane.io/rotate-webhook-signing-key annotation for which
+	// the signing key was last rotated.
+	WebhookSigningKeyRotation string `json:"webhookSigningKeyRotation,omitempty"`
+
+	// DKIMKeyRotation is the value of the mailgun.crossplane.io/rotate-dkim-key
+	// annotation for which the DKIM key was last rotated.
+	DKIMKeyRotation string `json:"dkimKeyRotation,omitempty"`
+
+	// LastOperationMessage is the message Mailgun returned for the last
+	// create or update of the domain, e.g. "Domain has been created".
+	LastOperationMessage string `json:"lastOperationMessage,omitempty"`
+
+	// StateHistory lists the most recent changes of State, oldest first,
+	// e.g. when an unverified domain became active.
+	StateHistory []DomainStateTransition `json:"stateHistory,omitempty"`
+}
+
+// DomainStateTransition is an observed change of a domain's state
+type DomainStateTransition struct {
+	// From is the state before the change
+	From string `json:"from"`
+
+	// To is the state after the change
+	To string `json:"to"`
+
+	// ObservedAt is when the provider saw the change. Mailgun does not say
+	// when it happened, so it is accurate to within a poll interval.
+	ObservedAt metav1.Time `json:"observedAt"`
+}
+
+// AuthorizedRecipient is a sandbox authorized recipient as seen in Mailgun
+type AuthorizedRecipient struct {
+	// Email is the recipient address
+	Email string `json:"email"`
+
+	// Activated is true once the recipient has confirmed by email
+	Activated bool `json:"activated"`
+
+	// Added is true when this Domain added the recipient to Mailgun. Only
+	// such recipients are ever removed by it.
+	// +optional
+	Added bool `json:"added,omitempty"`
 }
 
 // DNSRecord represents a DNS record required for domain configuration
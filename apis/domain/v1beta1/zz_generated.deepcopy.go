@@ -24,6 +24,21 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizedRecipient) DeepCopyInto(out *AuthorizedRecipient) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizedRecipient.
+func (in *AuthorizedRecipient) DeepCopy() *AuthorizedRecipient {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizedRecipient)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSRecord) DeepCopyInto(out *DNSRecord) {
 	*out = *in
@@ -76,6 +91,31 @@ func (in *Domain) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainConnection) DeepCopyInto(out *DomainConnection) {
+	*out = *in
+	if in.RequireTLS != nil {
+		in, out := &in.RequireTLS, &out.RequireTLS
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SkipVerification != nil {
+		in, out := &in.SkipVerification, &out.SkipVerification
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainConnection.
+func (in *DomainConnection) DeepCopy() *DomainConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DomainList) DeepCopyInto(out *DomainList) {
 	*out = *in
@@ -111,6 +151,26 @@ func (in *DomainList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DomainObservation) DeepCopyInto(out *DomainObservation) {
 	*out = *in
+	if in.Wildcard != nil {
+		in, out := &in.Wildcard, &out.Wildcard
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Tracking != nil {
+		in, out := &in.Tracking, &out.Tracking
+		*out = new(DomainTracking)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IPs != nil {
+		in, out := &in.IPs, &out.IPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(DomainConnection)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RequiredDNSRecords != nil {
 		in, out := &in.RequiredDNSRecords, &out.RequiredDNSRecords
 		*out = make([]DNSRecord, len(*in))
@@ -132,6 +192,18 @@ func (in *DomainObservation) DeepCopyInto(out *DomainObservation) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AuthorizedRecipients != nil {
+		in, out := &in.AuthorizedRecipients, &out.AuthorizedRecipients
+		*out = make([]AuthorizedRecipient, len(*in))
+		copy(*out, *in)
+	}
+	if in.StateHistory != nil {
+		in, out := &in.StateHistory, &out.StateHistory
+		*out = make([]DomainStateTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainObservation.
@@ -167,11 +239,21 @@ func (in *DomainParameters) DeepCopyInto(out *DomainParameters) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PoolID != nil {
+		in, out := &in.PoolID, &out.PoolID
+		*out = new(string)
+		**out = **in
+	}
 	if in.Tracking != nil {
 		in, out := &in.Tracking, &out.Tracking
 		*out = new(DomainTracking)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(DomainConnection)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SMTPPassword != nil {
 		in, out := &in.SMTPPassword, &out.SMTPPassword
 		*out = new(string)
@@ -192,6 +274,23 @@ func (in *DomainParameters) DeepCopyInto(out *DomainParameters) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.AuthorizedRecipients != nil {
+		in, out := &in.AuthorizedRecipients, &out.AuthorizedRecipients
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StaticConnectionDetails != nil {
+		in, out := &in.StaticConnectionDetails, &out.StaticConnectionDetails
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PublishConnectionDetailsWhenActive != nil {
+		in, out := &in.PublishConnectionDetailsWhenActive, &out.PublishConnectionDetailsWhenActive
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainParameters.
@@ -221,6 +320,22 @@ func (in *DomainSpec) DeepCopy() *DomainSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainStateTransition) DeepCopyInto(out *DomainStateTransition) {
+	*out = *in
+	in.ObservedAt.DeepCopyInto(&out.ObservedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainStateTransition.
+func (in *DomainStateTransition) DeepCopy() *DomainStateTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainStateTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DomainStatus) DeepCopyInto(out *DomainStatus) {
 	*out = *in
@@ -256,6 +371,16 @@ func (in *DomainTracking) DeepCopyInto(out *DomainTracking) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.UnsubscribeHTMLFooter != nil {
+		in, out := &in.UnsubscribeHTMLFooter, &out.UnsubscribeHTMLFooter
+		*out = new(string)
+		**out = **in
+	}
+	if in.UnsubscribeTextFooter != nil {
+		in, out := &in.UnsubscribeTextFooter, &out.UnsubscribeTextFooter
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainTracking.
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IPPoolParameters are the configurable fields of an IPPool.
+type IPPoolParameters struct {
+	// Name of the pool
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Description of the pool
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// IPs are the dedicated IPs of the account that belong to the pool. IPs
+	// added to or removed from the pool outside the provider are put back
+	// as listed here.
+	// +optional
+	// +listType=set
+	IPs []string `json:"ips,omitempty"`
+}
+
+// IPPoolObservation are the observable fields of an IPPool.
+type IPPoolObservation struct {
+	// PoolID is the identifier Mailgun assigned to the pool
+	PoolID string `json:"poolId,omitempty"`
+
+	// Name of the pool
+	Name string `json:"name,omitempty"`
+
+	// Description of the pool
+	Description string `json:"description,omitempty"`
+
+	// IPs are the IPs in the pool
+	IPs []string `json:"ips,omitempty"`
+
+	// IsLinked indicates whether domains are assigned to the pool
+	IsLinked bool `json:"isLinked,omitempty"`
+}
+
+// An IPPoolSpec defines the desired state of an IPPool.
+type IPPoolSpec struct {
+	xpv1.ManagedResourceSpec `json:",inline"`
+	ForProvider              IPPoolParameters `json:"forProvider"`
+}
+
+// An IPPoolStatus represents the observed state of an IPPool.
+type IPPoolStatus struct {
+	xpv1.ConditionedStatus `json:",inline"`
+	AtProvider             IPPoolObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="POOL",type="string",JSONPath=".spec.forProvider.name"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,mailgun}
+//
+// This is the Crossplane v2 namespaced version.
+// An IPPool is a managed resource that represents a Mailgun dedicated IP
+// pool. Its external name is the pool ID Mailgun assigns.
+type IPPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IPPoolSpec   `json:"spec"`
+	Status IPPoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// IPPoolList contains a list of IPPool
+type IPPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IPPool `json:"items"`
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition types specific to MailingLists.
+const (
+	// TypeAccessLevelChangeBlocked indicates that the desired access level
+	// restricts who may post to the list and the change was not confirmed.
+	TypeAccessLevelChangeBlocked xpv1.ConditionType = "AccessLevelChangeBlocked"
+)
+
+// Condition reasons specific to MailingLists.
+const (
+	ReasonChangeNotConfirmed xpv1.ConditionReason = "ChangeNotConfirmed"
+	ReasonAccessLevelInSync  xpv1.ConditionReason = "AccessLevelInSync"
+)
+
+// AccessLevelChangeBlocked returns a condition indicating that an access
+// level change is waiting for confirmation.
+func AccessLevelChangeBlocked(current, desired string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeAccessLevelChangeBlocked,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonChangeNotConfirmed,
+		Message: fmt.Sprintf("access level change from %q to %q changes who may post to the list; "+
+			"set the %s annotation to %q to apply it", current, desired, AnnotationConfirmAccessLevel, desired),
+	}
+}
+
+// AccessLevelInSync returns a condition indicating that no access level
+// change is blocked.
+func AccessLevelInSync() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeAccessLevelChangeBlocked,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAccessLevelInSync,
+	}
+}
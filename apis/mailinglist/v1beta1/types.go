@@ -21,6 +21,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// AnnotationConfirmAccessLevel confirms a change of an existing list to a
+// more restrictive access level, e.g. from everyone to readonly, or to
+// everyone. It must be set to the new access level; until then the access
+// level is left as is. It is removed once the change has been applied.
+const AnnotationConfirmAccessLevel = "mailgun.crossplane.io/confirm-access-level"
+
 // MailingListParameters define the desired state of a Mailgun MailingList
 type MailingListParameters struct {
 	// Address is the mailing list email address
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MailingListMemberParameters are the configurable fields of a
+// MailingListMember.
+type MailingListMemberParameters struct {
+	// ListAddress is the email address of the mailing list the member
+	// belongs to
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern="^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+.[a-zA-Z]{2,}$"
+	ListAddress string `json:"listAddress"`
+
+	// Address is the member's email address
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern="^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+.[a-zA-Z]{2,}$"
+	Address string `json:"address"`
+
+	// Name is the member's display name
+	// +kubebuilder:validation:Optional
+	Name *string `json:"name,omitempty"`
+
+	// Vars are custom variables for the member
+	// +kubebuilder:validation:Optional
+	Vars map[string]string `json:"vars,omitempty"`
+
+	// Subscribed indicates if the member receives the list's mail
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	Subscribed *bool `json:"subscribed,omitempty"`
+
+	// Upsert makes creating the member update an existing member with the
+	// same address instead of failing
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	Upsert *bool `json:"upsert,omitempty"`
+}
+
+// MailingListMemberObservation are the observable fields of a
+// MailingListMember.
+type MailingListMemberObservation struct {
+	// Address is the member's email address
+	Address string `json:"address,omitempty"`
+
+	// Name is the member's display name
+	Name string `json:"name,omitempty"`
+
+	// Vars are the member's custom variables
+	Vars map[string]string `json:"vars,omitempty"`
+
+	// Subscribed indicates if the member receives the list's mail
+	Subscribed bool `json:"subscribed,omitempty"`
+}
+
+// A MailingListMemberSpec defines the desired state of a MailingListMember.
+type MailingListMemberSpec struct {
+	xpv1.ManagedResourceSpec `json:",inline"`
+	ForProvider              MailingListMemberParameters `json:"forProvider"`
+}
+
+// A MailingListMemberStatus represents the observed state of a
+// MailingListMember.
+type MailingListMemberStatus struct {
+	xpv1.ConditionedStatus `json:",inline"`
+	AtProvider             MailingListMemberObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,mailgun}
+//
+// This is the Crossplane v2 namespaced version.
+// A MailingListMember is a managed resource that represents a member of a
+// Mailgun mailing list.
+type MailingListMember struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MailingListMemberSpec   `json:"spec"`
+	Status MailingListMemberStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MailingListMemberList contains a list of MailingListMember
+type MailingListMemberList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MailingListMember `json:"items"`
+}
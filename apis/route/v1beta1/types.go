@@ -21,7 +21,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// RouteParameters define the desired state of a Mailgun Route
+// MaxRouteActions is the largest number of actions Mailgun accepts on a
+// single route
+const MaxRouteActions = 10
+
+// RouteParameters define the desired state of a Mailgun Route. Mailgun's
+// routes API has no enabled or disabled state, so a route cannot be paused:
+// it matches incoming messages until it is deleted.
 type RouteParameters struct {
 	// Priority determines the order in which routes are processed (0-100, lower = higher priority)
 	// +kubebuilder:validation:Minimum=0
@@ -39,6 +45,7 @@ type RouteParameters struct {
 	// Actions define what to do with messages matching the expression
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
 	Actions []RouteAction `json:"actions"`
 }
 
@@ -52,6 +59,12 @@ type RouteAction struct {
 	// Destination is where to forward messages (for forward action)
 	// Required for forward actions
 	Destination *string `json:"destination,omitempty"`
+
+	// Notify is the URL Mailgun calls when a message has been stored (for
+	// store action). A Destination on a store action is treated as its notify
+	// URL for compatibility.
+	// +optional
+	Notify *string `json:"notify,omitempty"`
 }
 
 // RouteObservation reflects the observed state of a Mailgun Route
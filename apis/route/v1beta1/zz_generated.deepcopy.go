@@ -59,6 +59,11 @@ func (in *RouteAction) DeepCopyInto(out *RouteAction) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Notify != nil {
+		in, out := &in.Notify, &out.Notify
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteAction.
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition types specific to SMTPCredentials.
+const (
+	// TypeDegradedConnectionSecret indicates that the connection secret has
+	// no SMTP password, so it cannot be used to send mail.
+	TypeDegradedConnectionSecret xpv1.ConditionType = "DegradedConnectionSecret"
+)
+
+// Condition reasons specific to SMTPCredentials.
+const (
+	ReasonPasswordUnavailable xpv1.ConditionReason = "PasswordUnavailable"
+	ReasonPasswordStored      xpv1.ConditionReason = "PasswordStored"
+)
+
+// PasswordUnavailable returns a condition indicating that the connection
+// secret was written without a password.
+func PasswordUnavailable() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDegradedConnectionSecret,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPasswordUnavailable,
+		Message: "the connection secret has an empty smtp_password because Mailgun does not return the passwords it generates; " +
+			"set spec.forProvider.password and rotate the credential to store a usable one",
+	}
+}
+
+// PasswordStored returns a condition indicating that the connection secret
+// holds the SMTP password.
+func PasswordStored() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDegradedConnectionSecret,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPasswordStored,
+	}
+}
@@ -21,11 +21,34 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// AnnotationPreviousLogin records the login of the credential replaced by an
+// overlapping rotation until it is deleted. It is kept in an annotation
+// because the reconciler persists the annotations a Create sets but discards
+// its status changes.
+const AnnotationPreviousLogin = "mailgun.crossplane.io/previous-login"
+
+// AnnotationPreviousLoginDeleteAfter records when the credential named by
+// AnnotationPreviousLogin is deleted, in RFC 3339 format.
+const AnnotationPreviousLoginDeleteAfter = "mailgun.crossplane.io/previous-login-delete-after"
+
 // SMTPCredentialParameters are the configurable fields of a SMTPCredential.
 type SMTPCredentialParameters struct {
-	// Domain is the domain this SMTP credential belongs to.
-	// +kubebuilder:validation:Required
-	Domain string `json:"domain"`
+	// Domain is the domain this SMTP credential belongs to. It is required unless
+	// DomainRef or DomainSelector is set.
+	// +optional
+	// +crossplane:generate:reference:type=github.com/rossigee/provider-mailgun/apis/domain/v1beta1.Domain
+	// +crossplane:generate:reference:extractor=github.com/rossigee/provider-mailgun/apis/domain/v1beta1.DomainName()
+	Domain string `json:"domain,omitempty"`
+
+	// DomainRef references the Domain this SMTP credential belongs to. Domain is
+	// resolved from it once the Domain is ready.
+	// +optional
+	DomainRef *xpv1.NamespacedReference `json:"domainRef,omitempty"`
+
+	// DomainSelector selects the Domain this SMTP credential belongs to from those
+	// in the same namespace when DomainRef is not set.
+	// +optional
+	DomainSelector *xpv1.NamespacedSelector `json:"domainSelector,omitempty"`
 
 	// Login is the SMTP username (email address).
 	// +kubebuilder:validation:Required
@@ -36,6 +59,32 @@ type SMTPCredentialParameters struct {
 	// +optional
 	// +kubebuilder:validation:MinLength=8
 	Password *string `json:"password,omitempty"`
+
+	// RequiredConnectionKeys lists connection secret keys that must be
+	// present and non-empty once the credential is created, for example
+	// smtp_password when a composition relies on it. If any required key is
+	// missing the new credential is deleted again and the creation fails.
+	// Mailgun does not reliably return generated passwords, so smtp_password
+	// is only enforced when Password is set; otherwise a missing password is
+	// reported by the PasswordUnavailable condition.
+	// +optional
+	RequiredConnectionKeys []string `json:"requiredConnectionKeys,omitempty"`
+
+	// OverlapPeriod makes rotations create the new credential before the
+	// current one is deleted, and keeps the current one valid for this long
+	// afterwards so consumers can pick up the new connection secret. As
+	// Mailgun identifies credentials by login, rotations alternate between
+	// Login and a login with "-rotated" appended to its local part. Without
+	// it the current credential is deleted before the new one is created.
+	// +optional
+	OverlapPeriod *metav1.Duration `json:"overlapPeriod,omitempty"`
+
+	// StaticConnectionDetails are fixed key/value pairs added to the
+	// connection secret alongside the ones Mailgun provides, for example
+	// the region. They must not set smtp_host, smtp_port,
+	// smtp_username or smtp_password.
+	// +optional
+	StaticConnectionDetails map[string]string `json:"staticConnectionDetails,omitempty"`
 }
 
 // SMTPCredentialObservation are the observable fields of a SMTPCredential.
@@ -52,6 +101,15 @@ type SMTPCredentialObservation struct {
 
 	// State indicates if the credential is active.
 	State string `json:"state,omitempty"`
+
+	// PreviousLogin is the login of the credential replaced by the last
+	// rotation, which stays valid until PreviousLoginDeleteAfter. It mirrors
+	// the mailgun.crossplane.io/previous-login annotation.
+	PreviousLogin string `json:"previousLogin,omitempty"`
+
+	// PreviousLoginDeleteAfter is when the overlap period of the last
+	// rotation ends and PreviousLogin is deleted.
+	PreviousLoginDeleteAfter *metav1.Time `json:"previousLoginDeleteAfter,omitempty"`
 }
 
 // A SMTPCredentialSpec defines the desired state of a SMTPCredential.
@@ -21,6 +21,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	"github.com/crossplane/crossplane/apis/v2/core/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -86,6 +88,10 @@ func (in *SMTPCredentialList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SMTPCredentialObservation) DeepCopyInto(out *SMTPCredentialObservation) {
 	*out = *in
+	if in.PreviousLoginDeleteAfter != nil {
+		in, out := &in.PreviousLoginDeleteAfter, &out.PreviousLoginDeleteAfter
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SMTPCredentialObservation.
@@ -101,11 +107,38 @@ func (in *SMTPCredentialObservation) DeepCopy() *SMTPCredentialObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SMTPCredentialParameters) DeepCopyInto(out *SMTPCredentialParameters) {
 	*out = *in
+	if in.DomainRef != nil {
+		in, out := &in.DomainRef, &out.DomainRef
+		*out = new(v2.NamespacedReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DomainSelector != nil {
+		in, out := &in.DomainSelector, &out.DomainSelector
+		*out = new(v2.NamespacedSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Password != nil {
 		in, out := &in.Password, &out.Password
 		*out = new(string)
 		**out = **in
 	}
+	if in.RequiredConnectionKeys != nil {
+		in, out := &in.RequiredConnectionKeys, &out.RequiredConnectionKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OverlapPeriod != nil {
+		in, out := &in.OverlapPeriod, &out.OverlapPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.StaticConnectionDetails != nil {
+		in, out := &in.StaticConnectionDetails, &out.StaticConnectionDetails
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SMTPCredentialParameters.
@@ -139,7 +172,7 @@ func (in *SMTPCredentialSpec) DeepCopy() *SMTPCredentialSpec {
 func (in *SMTPCredentialStatus) DeepCopyInto(out *SMTPCredentialStatus) {
 	*out = *in
 	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
-	out.AtProvider = in.AtProvider
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SMTPCredentialStatus.
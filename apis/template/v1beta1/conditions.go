@@ -0,0 +1,183 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition types specific to Templates.
+const (
+	// TypeEngineChangeRejected indicates that the desired engine differs
+	// from the active version's engine and the change cannot be applied.
+	TypeEngineChangeRejected xpv1.ConditionType = "EngineChangeRejected"
+
+	// TypeDomainsNotSynced indicates that the template could not be
+	// reconciled on some of spec.forProvider.domains.
+	TypeDomainsNotSynced xpv1.ConditionType = "DomainsNotSynced"
+
+	// TypeTemplateInvalid indicates that the template content failed the
+	// syntax check of its engine and was not submitted to Mailgun.
+	TypeTemplateInvalid xpv1.ConditionType = "TemplateInvalid"
+
+	// TypeVersionLimitApproaching indicates that the template has nearly as
+	// many versions as Mailgun allows, so creating more will soon fail.
+	TypeVersionLimitApproaching xpv1.ConditionType = "VersionLimitApproaching"
+
+	// TypeActiveVersionConflict indicates that a version this Template does
+	// not write to is active, for example one activated by a TemplateVersion,
+	// so spec.forProvider.template is not applied to it.
+	TypeActiveVersionConflict xpv1.ConditionType = "ActiveVersionConflict"
+)
+
+// Condition reasons specific to Templates.
+const (
+	ReasonEngineChangeNotAllowed xpv1.ConditionReason = "RecreateOnEngineChangeDisabled"
+	ReasonEngineInSync           xpv1.ConditionReason = "EngineInSync"
+	ReasonDomainsFailed          xpv1.ConditionReason = "DomainsFailed"
+	ReasonDomainsSynced          xpv1.ConditionReason = "AllDomainsSynced"
+	ReasonSyntaxError            xpv1.ConditionReason = "SyntaxError"
+	ReasonSyntaxValid            xpv1.ConditionReason = "SyntaxValid"
+	ReasonNearVersionLimit       xpv1.ConditionReason = "NearVersionLimit"
+	ReasonBelowVersionLimit      xpv1.ConditionReason = "BelowVersionLimit"
+	ReasonActivatedElsewhere     xpv1.ConditionReason = "ActivatedElsewhere"
+	ReasonOwnVersionActive       xpv1.ConditionReason = "OwnVersionActive"
+)
+
+// EngineChangeRejected returns a condition indicating that an engine change
+// was requested but recreation is not enabled.
+func EngineChangeRejected(current, desired string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeEngineChangeRejected,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonEngineChangeNotAllowed,
+		Message: fmt.Sprintf("engine cannot be changed in place from %q to %q; "+
+			"set spec.forProvider.recreateOnEngineChange to create a new version with the new engine", current, desired),
+	}
+}
+
+// EngineInSync returns a condition indicating that the desired engine matches
+// the active version.
+func EngineInSync() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeEngineChangeRejected,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonEngineInSync,
+	}
+}
+
+// DomainsNotSynced returns a condition indicating that the template could not
+// be reconciled on the named domains.
+func DomainsNotSynced(domains []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDomainsNotSynced,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDomainsFailed,
+		Message:            fmt.Sprintf("template is not synced on %s; see status.atProvider.domains", strings.Join(domains, ", ")),
+	}
+}
+
+// DomainsSynced returns a condition indicating that the template is synced
+// on all of spec.forProvider.domains.
+func DomainsSynced() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDomainsNotSynced,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDomainsSynced,
+	}
+}
+
+// TemplateInvalid returns a condition indicating that the template content
+// failed its syntax check. The message should locate the error.
+func TemplateInvalid(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeTemplateInvalid,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSyntaxError,
+		Message:            message,
+	}
+}
+
+// TemplateValid returns a condition indicating that the template content
+// passed its syntax check after previously failing it.
+func TemplateValid() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeTemplateInvalid,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSyntaxValid,
+	}
+}
+
+// VersionLimitApproaching returns a condition indicating that the template
+// has count versions, close to the limit Mailgun allows.
+func VersionLimitApproaching(count, limit int) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeVersionLimitApproaching,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNearVersionLimit,
+		Message: fmt.Sprintf("template has %d of at most %d versions; "+
+			"delete versions that are no longer needed before creating more", count, limit),
+	}
+}
+
+// VersionLimitClear returns a condition indicating that the template is no
+// longer near its version limit.
+func VersionLimitClear() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeVersionLimitApproaching,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonBelowVersionLimit,
+	}
+}
+
+// ActiveVersionConflict returns a condition indicating that the version
+// tagged active is active rather than the version tagged own, which this
+// Template writes its content to.
+func ActiveVersionConflict(active, own string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeActiveVersionConflict,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonActivatedElsewhere,
+		Message: fmt.Sprintf("version %q is active instead of %q; its content is left alone, "+
+			"so remove spec.forProvider.template or activate %q again", active, own, own),
+	}
+}
+
+// ActiveVersionOwned returns a condition indicating that the version this
+// Template writes its content to is active again.
+func ActiveVersionOwned() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeActiveVersionConflict,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonOwnVersionActive,
+	}
+}
@@ -21,11 +21,41 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// AnnotationRenderPreview holds a JSON object of sample variables. When set,
+// the active version of the template is rendered with them whenever they or
+// the active content change, and the (truncated) output is reported in
+// status.atProvider.renderedPreview.
+const AnnotationRenderPreview = "mailgun.crossplane.io/render-preview"
+
 // TemplateParameters are the configurable fields of a Template.
 type TemplateParameters struct {
-	// Domain is the domain this template belongs to.
-	// +kubebuilder:validation:Required
-	Domain string `json:"domain"`
+	// Domain is the domain this template belongs to. It is required unless
+	// DomainRef or DomainSelector is set.
+	// +optional
+	// +crossplane:generate:reference:type=github.com/rossigee/provider-mailgun/apis/domain/v1beta1.Domain
+	// +crossplane:generate:reference:extractor=github.com/rossigee/provider-mailgun/apis/domain/v1beta1.DomainName()
+	Domain string `json:"domain,omitempty"`
+
+	// DomainRef references the Domain this template belongs to. Domain is
+	// resolved from it once the Domain is ready.
+	// +optional
+	DomainRef *xpv1.NamespacedReference `json:"domainRef,omitempty"`
+
+	// DomainSelector selects the Domain this template belongs to from those
+	// in the same namespace when DomainRef is not set.
+	// +optional
+	DomainSelector *xpv1.NamespacedSelector `json:"domainSelector,omitempty"`
+
+	// Domains lists further domains the same template is kept on. The
+	// template is created on each with the same content, and its description
+	// and the content of its active version are kept in sync; tagged
+	// versions, engine changes and previews only apply to Domain. A failure
+	// on one of these domains is reported in status without affecting the
+	// others. Removing a domain from the list leaves its template in place.
+	// Ignored when the external-name targets a version.
+	// +optional
+	// +listType=set
+	Domains []string `json:"domains,omitempty"`
 
 	// Name is the template name identifier.
 	// +kubebuilder:validation:Required
@@ -36,7 +66,10 @@ type TemplateParameters struct {
 	// +optional
 	Description *string `json:"description,omitempty"`
 
-	// Template contains the template content for the initial version.
+	// Template contains the template content. Changes to it are written
+	// to the active version, or to the version targeted by a <name>:<tag>
+	// external-name. They are not written to an active version the Template
+	// did not create, such as one activated by a TemplateVersion.
 	// +optional
 	Template *string `json:"template,omitempty"`
 
@@ -53,6 +86,25 @@ type TemplateParameters struct {
 	// Tag for organizing templates.
 	// +optional
 	Tag *string `json:"tag,omitempty"`
+
+	// RecreateOnEngineChange allows a change of Engine to be applied by
+	// creating a new active version with the new engine. Mailgun cannot
+	// change the engine of an existing version, so without this the change
+	// is rejected.
+	// +optional
+	RecreateOnEngineChange *bool `json:"recreateOnEngineChange,omitempty"`
+
+	// ActivateVersion makes the version targeted by a <name>:<tag>
+	// external-name the active one. It is ignored unless the external-name
+	// targets a version.
+	// +optional
+	ActivateVersion *bool `json:"activateVersion,omitempty"`
+
+	// SkipValidation disables the syntax check of Template made before it is
+	// submitted to Mailgun. The check looks for unbalanced {{ }} tags and
+	// unclosed sections of the selected engine.
+	// +optional
+	SkipValidation *bool `json:"skipValidation,omitempty"`
 }
 
 // TemplateObservation are the observable fields of a Template.
@@ -69,11 +121,53 @@ type TemplateObservation struct {
 	// CreatedBy indicates who created the template.
 	CreatedBy string `json:"createdBy,omitempty"`
 
-	// VersionCount is the number of versions for this template.
+	// VersionCount is the number of versions for this template. Mailgun
+	// allows at most 40; the VersionLimitApproaching condition is set as
+	// the count nears that.
 	VersionCount int `json:"versionCount,omitempty"`
 
 	// ActiveVersion contains information about the active version.
 	ActiveVersion *TemplateVersion `json:"activeVersion,omitempty"`
+
+	// ActiveContent is the content of the active version as Mailgun
+	// returned it. It is not kept in status.
+	ActiveContent string `json:"-"`
+
+	// ContentVersionTag is the tag of the version this Template last wrote
+	// spec.forProvider.template to. When another version is active its
+	// content is left alone and the ActiveVersionConflict condition is set.
+	ContentVersionTag string `json:"contentVersionTag,omitempty"`
+
+	// Version is the version targeted by a <name>:<tag> external-name.
+	Version *TemplateVersion `json:"version,omitempty"`
+
+	// RenderedPreview is the active version rendered with the variables in
+	// the mailgun.crossplane.io/render-preview annotation, truncated.
+	RenderedPreview string `json:"renderedPreview,omitempty"`
+
+	// RenderPreviewError explains why the preview could not be rendered.
+	RenderPreviewError string `json:"renderPreviewError,omitempty"`
+
+	// RenderedPreviewOf is a hash of the variables and active content the
+	// preview was rendered from.
+	RenderedPreviewOf string `json:"renderedPreviewOf,omitempty"`
+
+	// Domains is the state of the template on each of spec.forProvider.domains.
+	Domains []TemplateDomainStatus `json:"domains,omitempty"`
+}
+
+// TemplateDomainStatus is the state of the template on one of the further
+// domains it is kept on
+type TemplateDomainStatus struct {
+	// Domain the template is kept on.
+	Domain string `json:"domain"`
+
+	// Synced indicates that the template exists on the domain with the
+	// desired description.
+	Synced bool `json:"synced"`
+
+	// Error is the last error reconciling the template on the domain.
+	Error string `json:"error,omitempty"`
 }
 
 // TemplateVersion represents a template version
@@ -119,6 +213,11 @@ type TemplateStatus struct {
 //
 // This is the Crossplane v2 namespaced version.
 // A Template is a managed resource that represents a Mailgun email template.
+// A Template whose crossplane.io/external-name is <name>:<tag> manages the
+// version of template <name> tagged <tag> rather than the template as a
+// whole: the version is created, kept in sync with spec.forProvider.template
+// and comment, optionally activated, and deleted on its own. The template
+// itself must already exist.
 type Template struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
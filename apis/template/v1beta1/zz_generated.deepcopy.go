@@ -21,6 +21,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"github.com/crossplane/crossplane/apis/v2/core/v2"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -51,6 +52,21 @@ func (in *Template) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateDomainStatus) DeepCopyInto(out *TemplateDomainStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateDomainStatus.
+func (in *TemplateDomainStatus) DeepCopy() *TemplateDomainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateDomainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TemplateList) DeepCopyInto(out *TemplateList) {
 	*out = *in
@@ -91,6 +107,16 @@ func (in *TemplateObservation) DeepCopyInto(out *TemplateObservation) {
 		*out = new(TemplateVersion)
 		**out = **in
 	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(TemplateVersion)
+		**out = **in
+	}
+	if in.Domains != nil {
+		in, out := &in.Domains, &out.Domains
+		*out = make([]TemplateDomainStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateObservation.
@@ -106,6 +132,21 @@ func (in *TemplateObservation) DeepCopy() *TemplateObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TemplateParameters) DeepCopyInto(out *TemplateParameters) {
 	*out = *in
+	if in.DomainRef != nil {
+		in, out := &in.DomainRef, &out.DomainRef
+		*out = new(v2.NamespacedReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DomainSelector != nil {
+		in, out := &in.DomainSelector, &out.DomainSelector
+		*out = new(v2.NamespacedSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Domains != nil {
+		in, out := &in.Domains, &out.Domains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Description != nil {
 		in, out := &in.Description, &out.Description
 		*out = new(string)
@@ -131,6 +172,21 @@ func (in *TemplateParameters) DeepCopyInto(out *TemplateParameters) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.RecreateOnEngineChange != nil {
+		in, out := &in.RecreateOnEngineChange, &out.RecreateOnEngineChange
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ActivateVersion != nil {
+		in, out := &in.ActivateVersion, &out.ActivateVersion
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SkipValidation != nil {
+		in, out := &in.SkipValidation, &out.SkipValidation
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateParameters.
@@ -0,0 +1,7 @@
+package v1beta1
+
+import xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+
+func (in *TemplateVersion) SetWriteConnectionSecretToReference(r *xpv2.LocalSecretReference) {
+	in.Spec.WriteConnectionSecretToReference = r
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TemplateVersionParameters are the configurable fields of a TemplateVersion.
+type TemplateVersionParameters struct {
+	// Domain is the domain the template belongs to
+	// +kubebuilder:validation:Required
+	Domain string `json:"domain"`
+
+	// TemplateName is the name of the template the version belongs to. The
+	// template must already exist, for example managed by a Template.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9._-]+$`
+	TemplateName string `json:"templateName"`
+
+	// Tag identifies the version within the template
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9._-]+$`
+	Tag string `json:"tag"`
+
+	// Engine is the template engine of the version. Mailgun cannot change
+	// the engine of an existing version, so it only applies on creation.
+	// +optional
+	// +kubebuilder:validation:Enum=mustache;handlebars
+	// +kubebuilder:default=mustache
+	Engine *string `json:"engine,omitempty"`
+
+	// Template is the content of the version
+	// +kubebuilder:validation:Required
+	Template string `json:"template"`
+
+	// Comment describes the version
+	// +optional
+	Comment *string `json:"comment,omitempty"`
+
+	// Active makes this the version used for sending. Mailgun has no way to
+	// deactivate a version other than activating another, so setting it to
+	// false leaves the version as it is.
+	// +optional
+	Active *bool `json:"active,omitempty"`
+}
+
+// TemplateVersionObservation are the observable fields of a TemplateVersion.
+type TemplateVersionObservation struct {
+	// Tag identifying the version
+	Tag string `json:"tag,omitempty"`
+
+	// Engine used for the version
+	Engine string `json:"engine,omitempty"`
+
+	// CreatedAt is when the version was created
+	CreatedAt string `json:"createdAt,omitempty"`
+
+	// Comment describing the version
+	Comment string `json:"comment,omitempty"`
+
+	// Active indicates if this is the active version of the template
+	Active bool `json:"active,omitempty"`
+}
+
+// A TemplateVersionSpec defines the desired state of a TemplateVersion.
+type TemplateVersionSpec struct {
+	xpv1.ManagedResourceSpec `json:",inline"`
+	ForProvider              TemplateVersionParameters `json:"forProvider"`
+}
+
+// A TemplateVersionStatus represents the observed state of a TemplateVersion.
+type TemplateVersionStatus struct {
+	xpv1.ConditionedStatus `json:",inline"`
+	AtProvider             TemplateVersionObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TEMPLATE",type="string",JSONPath=".spec.forProvider.templateName"
+// +kubebuilder:printcolumn:name="TAG",type="string",JSONPath=".spec.forProvider.tag"
+// +kubebuilder:printcolumn:name="ACTIVE",type="boolean",JSONPath=".status.atProvider.active"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,mailgun}
+//
+// This is the Crossplane v2 namespaced version.
+// A TemplateVersion is a managed resource that represents a single version
+// of a Mailgun template.
+type TemplateVersion struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TemplateVersionSpec   `json:"spec"`
+	Status TemplateVersionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TemplateVersionList contains a list of TemplateVersion
+type TemplateVersionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TemplateVersion `json:"items"`
+}
@@ -28,7 +28,17 @@ type UnsubscribeParameters struct {
 	// +kubebuilder:validation:Pattern="^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+.[a-zA-Z]{2,}$"
 	Address string `json:"address"`
 
-	// Tags is a comma-separated list of tags associated with the unsubscribe
+	// Tag is the tag whose mail the address is unsubscribed from, or "*" for
+	// all mail, the default. It is part of the unsubscribe's identity:
+	// changing it unsubscribes the address from the new tag and resubscribes
+	// it to the old one.
+	// +kubebuilder:validation:Optional
+	Tag *string `json:"tag,omitempty"`
+
+	// Tags is used as the tag when Tag is unset.
+	//
+	// Deprecated: use Tag. Mailgun unsubscribes an address from one tag at a
+	// time.
 	// +kubebuilder:validation:Optional
 	Tags *string `json:"tags,omitempty"`
 
@@ -43,6 +53,16 @@ type UnsubscribeParameters struct {
 
 // UnsubscribeObservation are the observable fields of an Unsubscribe.
 type UnsubscribeObservation struct {
+	// Address is the unsubscribed email address
+	Address string `json:"address,omitempty"`
+
+	// Tags lists every tag Mailgun has unsubscribed the address from,
+	// including any not managed by this resource.
+	Tags []string `json:"tags,omitempty"`
+
+	// Tag is the tag this resource last unsubscribed the address from.
+	Tag string `json:"tag,omitempty"`
+
 	// CreatedAt is when the unsubscribe was recorded
 	CreatedAt *string `json:"createdAt,omitempty"`
 }
@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition types shared by Mailgun managed resources.
+const (
+	// TypePlanLimited indicates that the Mailgun account plan does not
+	// include a feature the resource requires.
+	TypePlanLimited xpv1.ConditionType = "PlanLimited"
+
+	// TypeRateLimited indicates that Mailgun is rate limiting the provider,
+	// so reconciliation of the resource is being slowed down.
+	TypeRateLimited xpv1.ConditionType = "RateLimited"
+
+	// TypeReconcileTimedOut indicates that the last reconcile of the resource
+	// was cancelled by the reconcile watchdog.
+	TypeReconcileTimedOut xpv1.ConditionType = "ReconcileTimedOut"
+
+	// TypeImmutableFieldChanged indicates that a spec field identifying the
+	// external resource was edited, so the resource is no longer reconciled.
+	TypeImmutableFieldChanged xpv1.ConditionType = "ImmutableFieldChanged"
+
+	// TypeProviderConfigNotFound indicates that the ProviderConfig the
+	// resource references does not exist.
+	TypeProviderConfigNotFound xpv1.ConditionType = "ProviderConfigNotFound"
+)
+
+// Condition reasons shared by Mailgun managed resources.
+const (
+	ReasonPlanLimited   xpv1.ConditionReason = "FeatureNotInPlan"
+	ReasonPlanSupported xpv1.ConditionReason = "FeatureInPlan"
+	ReasonRateLimited   xpv1.ConditionReason = "TooManyRequests"
+	ReasonNotLimited    xpv1.ConditionReason = "RequestsAccepted"
+	ReasonTimedOut      xpv1.ConditionReason = "DeadlineExceeded"
+	ReasonInTime        xpv1.ConditionReason = "CompletedInTime"
+	ReasonFieldChanged  xpv1.ConditionReason = "FieldChanged"
+	ReasonFieldsKept    xpv1.ConditionReason = "FieldsUnchanged"
+	ReasonPCMissing     xpv1.ConditionReason = "ProviderConfigMissing"
+	ReasonPCFound       xpv1.ConditionReason = "ProviderConfigFound"
+)
+
+// PlanLimited returns a condition indicating that the Mailgun account plan
+// rejected the request. The message should carry Mailgun's explanation.
+func PlanLimited(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePlanLimited,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPlanLimited,
+		Message:            message,
+	}
+}
+
+// PlanSupported returns a condition indicating that the Mailgun account plan
+// accepted the request.
+func PlanSupported() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePlanLimited,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPlanSupported,
+	}
+}
+
+// RateLimited returns a condition indicating that Mailgun rejected a request
+// with 429 Too Many Requests. The message should carry Mailgun's explanation.
+func RateLimited(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRateLimited,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRateLimited,
+		Message:            message,
+	}
+}
+
+// NotRateLimited returns a condition indicating that Mailgun accepted the
+// request after previously rate limiting it.
+func NotRateLimited() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRateLimited,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNotLimited,
+	}
+}
+
+// ReconcileTimedOut returns a condition indicating that a reconcile ran past
+// its deadline and was cancelled. The message should say which operation was
+// interrupted.
+func ReconcileTimedOut(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeReconcileTimedOut,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTimedOut,
+		Message:            message,
+	}
+}
+
+// ReconcileInTime returns a condition indicating that a reconcile completed
+// within its deadline after a previous one timed out.
+func ReconcileInTime() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeReconcileTimedOut,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInTime,
+	}
+}
+
+// ImmutableFieldChanged returns a condition indicating that an immutable field
+// was edited. The message should name the fields and their original values.
+func ImmutableFieldChanged(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeImmutableFieldChanged,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonFieldChanged,
+		Message:            message,
+	}
+}
+
+// ImmutableFieldsUnchanged returns a condition indicating that the immutable
+// fields match the external resource again after a previous edit.
+func ImmutableFieldsUnchanged() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeImmutableFieldChanged,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonFieldsKept,
+	}
+}
+
+// ProviderConfigNotFound returns a condition indicating that the referenced
+// ProviderConfig does not exist. The message should name it.
+func ProviderConfigNotFound(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeProviderConfigNotFound,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPCMissing,
+		Message:            message,
+	}
+}
+
+// ProviderConfigFound returns a condition indicating that the referenced
+// ProviderConfig exists after previously being missing.
+func ProviderConfigFound() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeProviderConfigNotFound,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPCFound,
+	}
+}
@@ -26,16 +26,53 @@ type ProviderConfigSpec struct {
 	// Credentials required to authenticate to this provider.
 	Credentials ProviderCredentials `json:"credentials"`
 
-	// APIBaseURL is the base URL for Mailgun API requests.
+	// APIBaseURL is the base URL for Mailgun API requests. It overrides the
+	// URL picked by Region, for example to use a proxy.
 	// For US region: https://api.mailgun.net/v3
 	// For EU region: https://api.eu.mailgun.net/v3
-	// +kubebuilder:default="https://api.mailgun.net/v3"
+	// +optional
 	APIBaseURL *string `json:"apiBaseURL,omitempty"`
 
-	// Region specifies the Mailgun region (US or EU).
-	// +kubebuilder:validation:Enum=US;EU
+	// Region specifies the Mailgun region (US or EU) the account was created
+	// in, which picks the API requests are sent to. Matched
+	// case-insensitively.
+	// +kubebuilder:validation:Enum=US;EU;us;eu
 	// +kubebuilder:default="US"
 	Region *string `json:"region,omitempty"`
+
+	// ErrorVerbosity controls how much detail Mailgun API errors carry.
+	// Terse errors include the status code and Mailgun's message. Verbose
+	// errors also include the request method and path and a snippet of the
+	// response body. Defaults to the provider's --error-verbosity flag.
+	// +optional
+	// +kubebuilder:validation:Enum=Terse;Verbose
+	ErrorVerbosity *string `json:"errorVerbosity,omitempty"`
+
+	// ProxyURL is the HTTP(S) proxy Mailgun API requests are sent through,
+	// for example http://proxy.example.com:3128. Defaults to the provider's
+	// --proxy-url flag, falling back to the HTTP_PROXY, HTTPS_PROXY and
+	// NO_PROXY environment variables.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^https?://`
+	ProxyURL *string `json:"proxyURL,omitempty"`
+
+	// AcceptLanguage is sent as the Accept-Language header of Mailgun API
+	// requests so that error messages come back in a consistent language,
+	// for example en or de-DE, en;q=0.8. Defaults to the provider's
+	// --accept-language flag, which defaults to en.
+	// +optional
+	AcceptLanguage *string `json:"acceptLanguage,omitempty"`
+
+	// DefaultPoolID is the dedicated IP pool new domains are assigned to when
+	// they do not set poolID themselves.
+	// +optional
+	DefaultPoolID *string `json:"defaultPoolID,omitempty"`
+
+	// APIVersions overrides the version of the Mailgun API calls of each
+	// kind are made against, keyed by kind, for example webhooks: v4. Kinds
+	// not listed use the version the provider defaults to for them.
+	// +optional
+	APIVersions map[string]string `json:"apiVersions,omitempty"`
 }
 
 // ProviderCredentials required to authenticate.
@@ -45,6 +82,31 @@ type ProviderCredentials struct {
 	Source xpv1.CredentialsSource `json:"source"`
 
 	xpv1.CommonCredentialSelectors `json:",inline"`
+
+	// APIKeyField is the field holding the API key when the credentials are
+	// a JSON object. Credentials that are not JSON are used as the raw API
+	// key.
+	// +optional
+	// +kubebuilder:default=api_key
+	APIKeyField *string `json:"apiKeyField,omitempty"`
+
+	// ExternalStore reads the credentials from a file written by an
+	// external secret store, such as the Secrets Store CSI driver or a Vault
+	// agent. The file has the same format as the credentials secret. While
+	// the file is absent the source above is used instead.
+	// +optional
+	ExternalStore *ExternalStoreCredentials `json:"externalStore,omitempty"`
+}
+
+// ExternalStoreCredentials locate credentials provided by an external secret
+// store.
+type ExternalStoreCredentials struct {
+	// Path of the credentials file, relative to the directory set by the
+	// provider's --external-secret-store-dir flag, or absolute within it.
+	// Paths leaving that directory are rejected, and while the flag is unset
+	// external stores cannot be used.
+	// +kubebuilder:validation:Required
+	Path string `json:"path"`
 }
 
 // A ProviderConfigStatus reflects the observed state of a ProviderConfig.
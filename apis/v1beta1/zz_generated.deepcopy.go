@@ -24,6 +24,21 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalStoreCredentials) DeepCopyInto(out *ExternalStoreCredentials) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalStoreCredentials.
+func (in *ExternalStoreCredentials) DeepCopy() *ExternalStoreCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalStoreCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderConfig) DeepCopyInto(out *ProviderConfig) {
 	*out = *in
@@ -97,6 +112,33 @@ func (in *ProviderConfigSpec) DeepCopyInto(out *ProviderConfigSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ErrorVerbosity != nil {
+		in, out := &in.ErrorVerbosity, &out.ErrorVerbosity
+		*out = new(string)
+		**out = **in
+	}
+	if in.ProxyURL != nil {
+		in, out := &in.ProxyURL, &out.ProxyURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.AcceptLanguage != nil {
+		in, out := &in.AcceptLanguage, &out.AcceptLanguage
+		*out = new(string)
+		**out = **in
+	}
+	if in.DefaultPoolID != nil {
+		in, out := &in.DefaultPoolID, &out.DefaultPoolID
+		*out = new(string)
+		**out = **in
+	}
+	if in.APIVersions != nil {
+		in, out := &in.APIVersions, &out.APIVersions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigSpec.
@@ -187,6 +229,16 @@ func (in *ProviderConfigUsageList) DeepCopyObject() runtime.Object {
 func (in *ProviderCredentials) DeepCopyInto(out *ProviderCredentials) {
 	*out = *in
 	in.CommonCredentialSelectors.DeepCopyInto(&out.CommonCredentialSelectors)
+	if in.APIKeyField != nil {
+		in, out := &in.APIKeyField, &out.APIKeyField
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExternalStore != nil {
+		in, out := &in.ExternalStore, &out.ExternalStore
+		*out = new(ExternalStoreCredentials)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderCredentials.
@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition types specific to Webhooks.
+const (
+	// TypeEventsNotSynced indicates that the webhooks of some of
+	// spec.forProvider.events could not be reconciled.
+	TypeEventsNotSynced xpv1.ConditionType = "EventsNotSynced"
+)
+
+// Condition reasons specific to Webhooks.
+const (
+	ReasonEventsFailed xpv1.ConditionReason = "EventsFailed"
+	ReasonEventsSynced xpv1.ConditionReason = "AllEventsSynced"
+)
+
+// EventsNotSynced returns a condition indicating that the webhooks of the
+// named event types could not be reconciled.
+func EventsNotSynced(events []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeEventsNotSynced,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonEventsFailed,
+		Message:            fmt.Sprintf("webhook is not synced for %s; see status.atProvider.events", strings.Join(events, ", ")),
+	}
+}
+
+// EventsSynced returns a condition indicating that the webhooks of all of
+// spec.forProvider.events are synced.
+func EventsSynced() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeEventsNotSynced,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonEventsSynced,
+	}
+}
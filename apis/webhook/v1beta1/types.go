@@ -23,28 +23,85 @@ import (
 
 // WebhookParameters define the desired state of a Mailgun Webhook
 type WebhookParameters struct {
-	// DomainRef references the Domain this webhook belongs to
-	// +kubebuilder:validation:Required
-	DomainRef xpv1.Reference `json:"domainRef"`
+	// Domain is the domain this webhook belongs to. It is required unless
+	// DomainRef or DomainSelector is set.
+	// +optional
+	// +crossplane:generate:reference:type=github.com/rossigee/provider-mailgun/apis/domain/v1beta1.Domain
+	// +crossplane:generate:reference:extractor=github.com/rossigee/provider-mailgun/apis/domain/v1beta1.DomainName()
+	Domain string `json:"domain,omitempty"`
+
+	// DomainRef references the Domain this webhook belongs to. Domain is
+	// resolved from it once the Domain is ready.
+	// +optional
+	DomainRef *xpv1.NamespacedReference `json:"domainRef,omitempty"`
 
-	// DomainSelector selects the Domain this webhook belongs to
-	DomainSelector *xpv1.Selector `json:"domainSelector,omitempty"`
+	// DomainSelector selects the Domain this webhook belongs to from those
+	// in the same namespace when DomainRef is not set.
+	// +optional
+	DomainSelector *xpv1.NamespacedSelector `json:"domainSelector,omitempty"`
 
 	// EventType specifies the type of event this webhook handles
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:Enum=accepted;delivered;temporary_fail;permanent_fail;clicked;opened;unsubscribed;complained;stored
 	EventType string `json:"eventType"`
 
-	// URL is the callback URL for the webhook
-	// +kubebuilder:validation:Required
+	// Events maps further event types to the callback URL that receives them.
+	// An empty URL means the same URL as EventType. Each event is kept as its
+	// own Mailgun webhook on the domain, with the same credentials, and its
+	// drift and failures are reported in status.atProvider.events. An event
+	// removed from the map has its Mailgun webhook deleted.
+	// +optional
+	Events map[string]string `json:"events,omitempty"`
+
+	// URL is the callback URL for the webhook. It is required unless
+	// ServiceRef is set.
+	// +optional
 	// +kubebuilder:validation:Pattern="^https?://.*"
-	URL string `json:"url"`
+	URL string `json:"url,omitempty"`
+
+	// ServiceRef points the webhook at a LoadBalancer Service in the
+	// Webhook's namespace. When set, the callback URL is resolved from the
+	// Service's external ingress hostname or IP, which Mailgun can reach, and
+	// takes precedence over URL.
+	// +optional
+	ServiceRef *ServiceReference `json:"serviceRef,omitempty"`
 
 	// Username for basic authentication (optional)
 	Username *string `json:"username,omitempty"`
 
-	// Password for basic authentication (optional)
+	// Password for basic authentication (optional). Prefer PasswordSecretRef,
+	// which keeps the password out of the resource.
 	Password *string `json:"password,omitempty"`
+
+	// PasswordSecretRef selects the key of a Secret in the Webhook's namespace
+	// that holds the basic authentication password. It takes precedence over
+	// Password.
+	// +optional
+	PasswordSecretRef *xpv1.LocalSecretKeySelector `json:"passwordSecretRef,omitempty"`
+}
+
+// ServiceReference identifies a port of a LoadBalancer Service, in the
+// Webhook's namespace, that receives webhooks
+type ServiceReference struct {
+	// Name of the Service
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Port is the Service port the receiver listens on
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Path is appended to the external address, e.g. /hooks/mailgun
+	// +optional
+	// +kubebuilder:validation:Pattern="^/.*"
+	Path *string `json:"path,omitempty"`
+
+	// Scheme of the resolved URL. Defaults to http.
+	// +optional
+	// +kubebuilder:validation:Enum=http;https
+	Scheme *string `json:"scheme,omitempty"`
 }
 
 // WebhookObservation reflects the observed state of a Mailgun Webhook
@@ -66,6 +123,31 @@ type WebhookObservation struct {
 
 	// Domain is the domain this webhook belongs to
 	Domain string `json:"domain,omitempty"`
+
+	// Events is the state of the webhook of each of spec.forProvider.events.
+	Events []WebhookEventStatus `json:"events,omitempty"`
+
+	// CredentialsRotation is the value of the
+	// mailgun.crossplane.io/force-rotate-credentials annotation for which the
+	// credentials were last pushed to Mailgun.
+	CredentialsRotation string `json:"credentialsRotation,omitempty"`
+}
+
+// WebhookEventStatus is the state of the webhook of one of the further event
+// types a Webhook manages
+type WebhookEventStatus struct {
+	// EventType of the webhook.
+	EventType string `json:"eventType"`
+
+	// URL is the callback URL Mailgun has for the event.
+	URL string `json:"url,omitempty"`
+
+	// Synced indicates that the webhook exists with the desired URL and
+	// credentials.
+	Synced bool `json:"synced"`
+
+	// Error is the last error reconciling the webhook of the event.
+	Error string `json:"error,omitempty"`
 }
 
 // A WebhookSpec defines the desired state of a Webhook.
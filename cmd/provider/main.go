@@ -18,17 +18,30 @@ package main
 
 import (
 	"context"
+	"fmt"
 	xpcontroller "github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
 	"github.com/rossigee/provider-mailgun/apis"
+	"github.com/rossigee/provider-mailgun/internal/clients"
 	"github.com/rossigee/provider-mailgun/internal/controller"
+	"github.com/rossigee/provider-mailgun/internal/description"
+	"github.com/rossigee/provider-mailgun/internal/eventlabel"
+	"github.com/rossigee/provider-mailgun/internal/failfast"
 	"github.com/rossigee/provider-mailgun/internal/features"
 	"github.com/rossigee/provider-mailgun/internal/health"
+	"github.com/rossigee/provider-mailgun/internal/observecache"
+	"github.com/rossigee/provider-mailgun/internal/readonly"
+	"github.com/rossigee/provider-mailgun/internal/resilience"
+	"github.com/rossigee/provider-mailgun/internal/shutdown"
+	"github.com/rossigee/provider-mailgun/internal/strictdelete"
 	"github.com/rossigee/provider-mailgun/internal/tracing"
 	"github.com/rossigee/provider-mailgun/internal/version"
+	"github.com/rossigee/provider-mailgun/internal/warmup"
+	"github.com/rossigee/provider-mailgun/internal/watchdog"
 	"gopkg.in/alecthomas/kingpin.v2"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"os"
 	"path/filepath"
@@ -37,6 +50,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"strings"
 )
 
 func main() {
@@ -48,11 +62,47 @@ func main() {
 		leaderElection           = app.Flag("leader-election", "Use leader election for the controller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
 		maxReconcileRate         = app.Flag("max-reconcile-rate", "The global maximum rate per second at which resources may checked for drift from the desired state.").Default("100").Int()
 		enableManagementPolicies = app.Flag("enable-management-policies", "Enable support for Management Policies.").Default("true").Bool()
+		enableDomainCacheWarmup  = app.Flag("domain-cache-warmup", "List all domains once per account at startup to seed the first round of Domain observations.").Default("false").Bool()
+		connectionWarmup         = app.Flag("connection-warmup", "Make one lightweight Mailgun call per ProviderConfig at startup so the first reconciles reuse established connections.").Default("false").Bool()
+		descriptionMetadata      = app.Flag("description-metadata", "Append a managed-by tag to the description of Mailgun routes, templates and mailing lists.").Default("false").Bool()
+		descriptionMetadataKeys  = app.Flag("description-metadata-key", "Label or annotation key whose value is added to propagated descriptions. May be repeated.").Strings()
+		failOnMissingDelete      = app.Flag("fail-on-missing-delete", "Fail the deletion of resources whose Mailgun resource was deleted outside of the provider instead of removing their finalizer.").Default("false").Bool()
+		failFastMissingPC        = app.Flag("fail-fast-on-missing-providerconfig", "Set the ProviderConfigNotFound condition of resources whose ProviderConfig does not exist and fail their reconciles before connecting to Mailgun, retrying with the controller backoff.").Default("false").Bool()
+		waitForDependents        = app.Flag("domain-deletion-waits-for-dependents", "Hold the deletion of a Domain until the webhooks, bounces, complaints and unsubscribes referencing it are deleted.").Default("true").Bool()
+		haltOnTerminalError      = app.Flag("domain-halt-on-terminal-error", "Stop reconciling a Domain that Mailgun rejected as invalid, setting its TerminalError condition, until its spec changes.").Default("true").Bool()
+		rotationLock             = app.Flag("smtp-credential-rotation-lock", "Serialize the creates and credential rotations of each SMTPCredential within the provider, so concurrent reconciles of one resource cannot delete each other's credentials.").Default("true").Bool()
+		errorVerbosity           = app.Flag("error-verbosity", "Default detail level of Mailgun API errors (Terse or Verbose); a ProviderConfig may override it.").Default(clients.ErrorVerbosityTerse).String()
+		acceptLanguage           = app.Flag("accept-language", "Default Accept-Language header of Mailgun API requests; a ProviderConfig may override it.").Default(clients.DefaultAcceptLanguage).String()
+		proxyURL                 = app.Flag("proxy-url", "HTTP(S) proxy for Mailgun API requests; a ProviderConfig may override it. Defaults to the standard proxy environment variables.").String()
+		observeCacheTTL          = app.Flag("observe-cache-ttl", "Reuse the last observation of a resource for this long instead of calling Mailgun; written resources are always re-observed. 0 disables the cache.").Default("0s").Duration()
+		coalesceWindow           = app.Flag("coalesce-window", "Share domain-scoped Mailgun reads, such as a domain GET or a domain's SMTP credential list, between reconciles issued within this window. 0 disables coalescing.").Default("0s").Duration()
+		createRetryAttempts      = app.Flag("create-retry-attempts", "Attempts made within one reconcile to create a Domain while Mailgun responds with server errors. 1 disables retries.").Default("3").Int()
+		reconcileTimeout         = app.Flag("reconcile-watchdog", "Cancel a reconcile still talking to Mailgun after this long and set its ReconcileTimedOut condition. 0 disables the watchdog.").Default("0s").Duration()
+		readOnly                 = app.Flag("read-only", "Observe managed resources without changing anything in Mailgun, e.g. to freeze changes during an incident. Creates, updates and deletes fail until it is turned off.").Default("false").Bool()
+		retryableMessages        = app.Flag("retryable-error-message", "Fragment of a Mailgun 400 or 409 error message, e.g. \"domain is being processed\", whose errors are retried like server errors when creating a Domain. May be repeated.").Strings()
+		eventLabel               = app.Flag("event-label", "Label, such as the instance name, appended to the message of every event the provider emits, to tell apart the events of several provider instances.").String()
+		readinessPC              = app.Flag("readiness-providerconfig", "ProviderConfig, as namespace/name, whose credentials the readiness probe uses to verify that Mailgun is reachable and accepts the API key. Unset, readiness only checks the Kubernetes API.").String()
+		externalStoreDir         = app.Flag("external-secret-store-dir", "Directory, as mounted into the provider pod, of the files ProviderConfigs may read credentials from with credentials.externalStore. Unset, external stores are refused.").String()
+		shutdownGracePeriod      = app.Flag("shutdown-grace-period", "How long in-flight Mailgun operations may run to completion after a shutdown signal.").Default("30s").Duration()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
+	kingpin.FatalIfError(clients.SetDefaultErrorVerbosity(*errorVerbosity), "Invalid --error-verbosity")
+	kingpin.FatalIfError(clients.SetDefaultProxyURL(*proxyURL), "Invalid --proxy-url")
+	kingpin.FatalIfError(clients.SetDefaultAcceptLanguage(*acceptLanguage), "Invalid --accept-language")
+	observecache.SetDefaultTTL(*observeCacheTTL)
+	clients.SetDefaultCoalesceWindow(*coalesceWindow)
+	clients.SetExternalStoreDir(*externalStoreDir)
+	resilience.SetDefaultCreateAttempts(*createRetryAttempts)
+	resilience.SetRetryableMessages(*retryableMessages)
+	watchdog.SetDefaultTimeout(*reconcileTimeout)
+	readonly.SetEnabled(*readOnly)
+	strictdelete.SetEnabled(*failOnMissingDelete)
+	failfast.SetEnabled(*failFastMissingPC)
+	eventlabel.SetLabel(*eventLabel)
 
 	zl := zap.New(zap.UseDevMode(*debug))
 	log := logging.NewLogrLogger(zl.WithName("provider-mailgun"))
+	clients.SetLogger(log.WithValues("component", "mailgun-client"))
 
 	shutdownTracing := tracing.Init("provider-mailgun")
 	defer shutdownTracing(context.Background())
@@ -77,8 +127,30 @@ func main() {
 		"max-reconcile-rate", *maxReconcileRate,
 		"leader-election", *leaderElection,
 		"management-policies", *enableManagementPolicies,
+		"domain-cache-warmup", *enableDomainCacheWarmup,
+		"connection-warmup", *connectionWarmup,
+		"description-metadata", *descriptionMetadata,
+		"fail-on-missing-delete", *failOnMissingDelete,
+		"fail-fast-on-missing-providerconfig", *failFastMissingPC,
+		"domain-deletion-waits-for-dependents", *waitForDependents,
+		"domain-halt-on-terminal-error", *haltOnTerminalError,
+		"accept-language", *acceptLanguage,
+		"observe-cache-ttl", observeCacheTTL.String(),
+		"coalesce-window", coalesceWindow.String(),
+		"create-retry-attempts", *createRetryAttempts,
+		"retryable-error-messages", *retryableMessages,
+		"reconcile-watchdog", reconcileTimeout.String(),
+		"read-only", *readOnly,
+		"event-label", *eventLabel,
+		"readiness-providerconfig", *readinessPC,
+		"shutdown-grace-period", shutdownGracePeriod.String(),
 		"debug-mode", *debug)
 
+	if *readOnly {
+		log.Info("READ-ONLY MODE: no Mailgun resources will be created, updated or deleted; " +
+			"those reconciles fail until the provider is restarted without --read-only")
+	}
+
 	log.Debug("Detailed startup configuration",
 		"sync-interval", syncInterval.String(),
 		"poll-interval", pollInterval.String(),
@@ -91,7 +163,12 @@ func main() {
 	namespace, err := getWatchNamespace()
 	kingpin.FatalIfError(err, "Cannot get watch namespace")
 
+	// Let in-flight Mailgun writes finish within the grace period on shutdown
+	drainer := shutdown.Default()
+	drainer.SetGracePeriod(*shutdownGracePeriod)
+
 	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		GracefulShutdownTimeout:       shutdownGracePeriod,
 		LeaderElection:                *leaderElection,
 		LeaderElectionID:              "crossplane-leader-election-provider-mailgun",
 		LeaderElectionResourceLock:    resourcelock.LeasesResourceLock,
@@ -111,6 +188,22 @@ func main() {
 	if *enableManagementPolicies {
 		featureFlags.Enable(features.EnableAlphaManagementPolicies)
 	}
+	if *enableDomainCacheWarmup {
+		featureFlags.Enable(features.EnableDomainCacheWarmup)
+	}
+	if *waitForDependents {
+		featureFlags.Enable(features.EnableDependentDeletionOrdering)
+	}
+	if *haltOnTerminalError {
+		featureFlags.Enable(features.EnableTerminalErrorHalt)
+	}
+	if *rotationLock {
+		featureFlags.Enable(features.EnableRotationLock)
+	}
+	if *descriptionMetadata {
+		featureFlags.Enable(features.EnableDescriptionMetadata)
+		description.SetKeys(*descriptionMetadataKeys)
+	}
 
 	// Setup rate limiter
 	rateLimiter := ratelimiter.NewGlobal(*maxReconcileRate)
@@ -127,16 +220,50 @@ func main() {
 	// Setup all controllers
 	kingpin.FatalIfError(controller.Setup(mgr, o), "Cannot setup Mailgun controllers")
 
-	// Add health checks to the manager's built-in endpoints.
-	// nil for mailgunCheck: no ProviderConfig is available at startup to
-	// create a Mailgun API client. ReadyzCheck verifies Kubernetes API
-	// connectivity; external API checks happen during reconciliation.
-	healthChecker := health.NewHealthChecker(mgr.GetClient(), nil)
+	// Warm up connections once the manager's caches have synced
+	if *connectionWarmup {
+		kingpin.FatalIfError(mgr.Add(warmup.NewProbe(mgr.GetClient(), log.WithValues("component", "connection-warmup"))), "Cannot add connection warm-up")
+	}
+
+	// Add health checks to the manager's built-in endpoints. Mailgun is only
+	// checked with the credentials of a named ProviderConfig, as none is
+	// known to exist otherwise.
+	var mailgunCheck func(context.Context) error
+	if *readinessPC != "" {
+		pc, err := parseProviderConfigName(*readinessPC)
+		kingpin.FatalIfError(err, "Invalid --readiness-providerconfig")
+		mailgunCheck = health.Cached(health.ProviderConfigCheck(mgr.GetClient(), pc), health.MailgunCheckTTL)
+	}
+	healthChecker := health.NewHealthChecker(mgr.GetClient(), mailgunCheck)
 	kingpin.FatalIfError(mgr.AddHealthzCheck("mailgun-provider", healthChecker.HealthzCheck), "Cannot add healthz check")
 	kingpin.FatalIfError(mgr.AddReadyzCheck("mailgun-provider", healthChecker.ReadyzCheck), "Cannot add readyz check")
 
+	// Start the grace period as soon as the signal arrives, so the manager's
+	// own shutdown and the drain below share one deadline.
+	stop := ctrl.SetupSignalHandler()
+	go func() {
+		<-stop.Done()
+		drainer.Shutdown()
+	}()
+
 	log.Info("Starting manager")
-	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
+	startErr := mgr.Start(stop)
+
+	log.Info("Waiting for in-flight operations to complete", "grace-period", shutdownGracePeriod.String())
+	if !drainer.Wait() {
+		log.Info("Grace period elapsed with operations still in flight")
+	}
+	kingpin.FatalIfError(startErr, "Cannot start controller manager")
+}
+
+// parseProviderConfigName parses a ProviderConfig reference of the form
+// namespace/name
+func parseProviderConfigName(s string) (types.NamespacedName, error) {
+	namespace, name, ok := strings.Cut(s, "/")
+	if !ok || namespace == "" || name == "" {
+		return types.NamespacedName{}, fmt.Errorf("%q must be namespace/name", s)
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
 }
 
 // getWatchNamespace returns the namespace the operator should be watching for changes
@@ -0,0 +1,47 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// minAPIKeyLength is well below the length of any key Mailgun issues; it
+// only catches placeholders and truncated values.
+const minAPIKeyLength = 16
+
+// ValidateAPIKey checks that key looks like a Mailgun API key, so that
+// obviously broken credentials fail at Connect rather than with a 401 from
+// Mailgun. The check is deliberately lenient: any key of reasonable length
+// without whitespace or control characters passes, whatever its prefix.
+// Errors never include the key itself.
+func ValidateAPIKey(key string) error {
+	switch {
+	case key == "":
+		return errors.New("mailgun API key is empty")
+	case strings.HasPrefix(key, "pubkey-"):
+		return errors.New("mailgun API key is a public validation key; use a private API key instead")
+	case strings.IndexFunc(key, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) >= 0:
+		return errors.New("mailgun API key contains whitespace or control characters")
+	case key == "key-" || len(key) < minAPIKeyLength:
+		return errors.Errorf("mailgun API key is too short (%d characters)", len(key))
+	}
+	return nil
+}
@@ -19,14 +19,31 @@ package clients
 import (
 	"context"
 	"fmt"
-	bouncetypes "github.com/rossigee/provider-mailgun/apis/bounce/v1beta1"
 	"net/url"
 	"strings"
+
+	bouncetypes "github.com/rossigee/provider-mailgun/apis/bounce/v1beta1"
 )
 
+// bouncesPath returns the path of a domain's bounces table. Unlike most
+// domain-scoped resources it is not nested under /domains.
+func bouncesPath(domain string) string {
+	return fmt.Sprintf("/%s/bounces", url.PathEscape(domain))
+}
+
+// observation converts a client Bounce to an API BounceObservation
+func (b *Bounce) observation() *bouncetypes.BounceObservation {
+	return &bouncetypes.BounceObservation{
+		Address:   b.Address,
+		Code:      b.Code,
+		Error:     b.Error,
+		CreatedAt: &b.CreatedAt,
+	}
+}
+
 // CreateBounce creates a new bounce suppression entry for a domain
 func (c *mailgunClient) CreateBounce(ctx context.Context, domain string, bounce *bouncetypes.BounceParameters) (*bouncetypes.BounceObservation, error) {
-	path := fmt.Sprintf("/domains/%s/bounces", url.PathEscape(domain))
+	path := bouncesPath(domain)
 
 	params := map[string]interface{}{
 		"address": bounce.Address,
@@ -39,7 +56,7 @@ func (c *mailgunClient) CreateBounce(ctx context.Context, domain string, bounce
 	}
 
 	body := strings.NewReader(createFormData(params))
-	resp, err := c.makeRequest(ctx, "POST", path, body)
+	resp, err := c.makeRequest(ctx, APIBounces, "POST", path, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create bounce: %w", err)
 	}
@@ -49,19 +66,23 @@ func (c *mailgunClient) CreateBounce(ctx context.Context, domain string, bounce
 		return nil, fmt.Errorf("failed to handle response: %w", err)
 	}
 
-	// Convert client Bounce to API BounceObservation
-	observation := &bouncetypes.BounceObservation{
-		CreatedAt: &result.CreatedAt,
+	// Mailgun only acknowledges the addition, so report what was sent
+	result.Address = bounce.Address
+	if bounce.Code != nil {
+		result.Code = *bounce.Code
+	}
+	if bounce.Error != nil {
+		result.Error = *bounce.Error
 	}
 
-	return observation, nil
+	return result.observation(), nil
 }
 
 // GetBounce retrieves a bounce suppression entry
 func (c *mailgunClient) GetBounce(ctx context.Context, domain, address string) (*bouncetypes.BounceObservation, error) {
-	path := fmt.Sprintf("/domains/%s/bounces/%s", url.PathEscape(domain), url.PathEscape(address))
+	path := bouncesPath(domain) + "/" + url.PathEscape(address)
 
-	resp, err := c.makeRequest(ctx, "GET", path, nil)
+	resp, err := c.makeRequest(ctx, APIBounces, "GET", path, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get bounce: %w", err)
 	}
@@ -71,28 +92,22 @@ func (c *mailgunClient) GetBounce(ctx context.Context, domain, address string) (
 		return nil, fmt.Errorf("failed to handle response: %w", err)
 	}
 
-	// Convert client Bounce to API BounceObservation
-	observation := &bouncetypes.BounceObservation{
-		CreatedAt: &result.CreatedAt,
-	}
-
-	return observation, nil
+	return result.observation(), nil
 }
 
 // DeleteBounce deletes a bounce suppression entry
 func (c *mailgunClient) DeleteBounce(ctx context.Context, domain, address string) error {
-	path := fmt.Sprintf("/domains/%s/bounces/%s", url.PathEscape(domain), url.PathEscape(address))
+	path := bouncesPath(domain) + "/" + url.PathEscape(address)
 
-	resp, err := c.makeRequest(ctx, "DELETE", path, nil)
+	resp, err := c.makeRequest(ctx, APIBounces, "DELETE", path, nil)
 	if err != nil {
 		return fmt.Errorf("failed to delete bounce: %w", err)
 	}
 
-	// For DELETE operations, we usually don't need to parse the response
-	var result interface{}
-	if err := c.handleResponse(resp, &result); err != nil {
-		// Many DELETE endpoints return empty responses, so we ignore parse errors
-		return nil
+	// Errors, including the 404 for an address that is not in the table,
+	// are returned so the caller can decide whether they matter
+	if err := c.handleResponse(resp, nil); err != nil {
+		return fmt.Errorf("failed to handle response: %w", err)
 	}
 
 	return nil
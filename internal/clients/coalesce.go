@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/rossigee/provider-mailgun/internal/coalesce"
+)
+
+var (
+	coalescerMu sync.RWMutex
+	coalescer   *coalesce.Group
+)
+
+// SetDefaultCoalesceWindow enables sharing of domain-scoped reads, such as a
+// domain GET or the list of a domain's SMTP credentials, between all clients
+// of the same Mailgun account for the supplied window. A window of zero or
+// less disables coalescing.
+func SetDefaultCoalesceWindow(window time.Duration) {
+	coalescerMu.Lock()
+	defer coalescerMu.Unlock()
+	coalescer = nil
+	if window > 0 {
+		coalescer = coalesce.NewGroup(window)
+	}
+}
+
+func defaultCoalescer() *coalesce.Group {
+	coalescerMu.RLock()
+	defer coalescerMu.RUnlock()
+	return coalescer
+}
+
+// coalesceKey identifies a read of path by this client's Mailgun account
+// without keeping the API key itself in memory.
+func (c *mailgunClient) coalesceKey(path string) string {
+	sum := sha256.Sum256([]byte(c.config.BaseURL + "\x00" + c.config.APIKey))
+	return hex.EncodeToString(sum[:]) + path
+}
+
+// coalesced runs read, sharing its result with other reads of path when
+// coalescing is enabled. Callers must not modify the returned value.
+func (c *mailgunClient) coalesced(ctx context.Context, path string, read func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	g := defaultCoalescer()
+	if g == nil {
+		return read(ctx)
+	}
+	return g.Do(ctx, c.coalesceKey(path), read)
+}
+
+// forgetCoalesced drops any shared result of path after a write changed it
+func (c *mailgunClient) forgetCoalesced(path string) {
+	if g := defaultCoalescer(); g != nil {
+		g.Forget(c.coalesceKey(path))
+	}
+}
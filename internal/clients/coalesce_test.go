@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	smtpcredentialtypes "github.com/rossigee/provider-mailgun/apis/smtpcredential/v1beta1"
+)
+
+func TestCoalescedDomainReads(t *testing.T) {
+	var mu sync.Mutex
+	gets := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		if r.Method == http.MethodGet {
+			gets[r.URL.Path]++
+		}
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/v3/domains/mg.example.com":
+			_, _ = w.Write([]byte(`{"domain": {"name": "mg.example.com", "state": "active"}}`))
+		case "/v3/domains/mg.example.com/credentials":
+			if r.Method == http.MethodPost {
+				_, _ = w.Write([]byte(`{"message": "Created 1 credentials pair(s)", "credentials": {"c@mg.example.com": "s3cret"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"items": [{"login": "a@mg.example.com"}, {"login": "b@mg.example.com"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	SetDefaultCoalesceWindow(time.Minute)
+	defer SetDefaultCoalesceWindow(0)
+
+	config := &Config{APIKey: "test-key", BaseURL: server.URL + "/v3", HTTPClient: &http.Client{}}
+	ctx := context.Background()
+
+	// Each managed resource connects with its own client
+	var wg sync.WaitGroup
+	for _, login := range []string{"a@mg.example.com", "b@mg.example.com", "a@mg.example.com"} {
+		wg.Add(2)
+		go func(login string) {
+			defer wg.Done()
+			cred, err := NewClient(config).GetSMTPCredential(ctx, "mg.example.com", login)
+			assert.NoError(t, err)
+			assert.Equal(t, login, cred.Login)
+		}(login)
+		go func() {
+			defer wg.Done()
+			domain, err := NewClient(config).GetDomain(ctx, "mg.example.com")
+			assert.NoError(t, err)
+			assert.Equal(t, "mg.example.com", domain.ID)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, gets["/v3/domains/mg.example.com"], "resources on one domain should share a single domain GET")
+	assert.Equal(t, 1, gets["/v3/domains/mg.example.com/credentials"], "credentials of one domain should share a single list")
+
+	// A write invalidates the shared list
+	_, err := NewClient(config).CreateSMTPCredential(ctx, "mg.example.com", &smtpcredentialtypes.SMTPCredentialParameters{Login: "c@mg.example.com"})
+	require.NoError(t, err)
+	_, err = NewClient(config).GetSMTPCredential(ctx, "mg.example.com", "a@mg.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 2, gets["/v3/domains/mg.example.com/credentials"])
+
+	// Without a window every read goes to Mailgun
+	SetDefaultCoalesceWindow(0)
+	_, err = NewClient(config).GetDomain(ctx, "mg.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 2, gets["/v3/domains/mg.example.com"])
+}
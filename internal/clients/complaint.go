@@ -21,23 +21,35 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+
+	complainttypes "github.com/rossigee/provider-mailgun/apis/complaint/v1beta1"
 )
 
-// CreateComplaint creates a new complaint suppression entry for a domain
-func (c *mailgunClient) CreateComplaint(ctx context.Context, domain string, complaint interface{}) (interface{}, error) {
-	// Type assert to ComplaintSpec
-	complaintSpec, ok := complaint.(*ComplaintSpec)
-	if !ok {
-		return nil, fmt.Errorf("invalid complaint parameter type")
+// complaintsPath returns the path of a domain's complaints table. Like the
+// bounces table it is not nested under /domains.
+func complaintsPath(domain string) string {
+	return fmt.Sprintf("/%s/complaints", url.PathEscape(domain))
+}
+
+// observation converts a client Complaint to an API ComplaintObservation
+func (c *Complaint) observation() *complainttypes.ComplaintObservation {
+	return &complainttypes.ComplaintObservation{
+		Address:   c.Address,
+		CreatedAt: &c.CreatedAt,
 	}
-	path := fmt.Sprintf("/domains/%s/complaints", url.PathEscape(domain))
+}
 
+// CreateComplaint creates a new complaint suppression entry for a domain
+func (c *mailgunClient) CreateComplaint(ctx context.Context, domain string, complaint *complainttypes.ComplaintParameters) (*complainttypes.ComplaintObservation, error) {
 	params := map[string]interface{}{
-		"address": complaintSpec.Address,
+		"address": complaint.Address,
+	}
+	if complaint.CreatedAt != nil {
+		params["created_at"] = *complaint.CreatedAt
 	}
 
 	body := strings.NewReader(createFormData(params))
-	resp, err := c.makeRequest(ctx, "POST", path, body)
+	resp, err := c.makeRequest(ctx, APIComplaints, "POST", complaintsPath(domain), body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create complaint: %w", err)
 	}
@@ -47,14 +59,20 @@ func (c *mailgunClient) CreateComplaint(ctx context.Context, domain string, comp
 		return nil, fmt.Errorf("failed to handle response: %w", err)
 	}
 
-	return interface{}(&result), nil
+	// Mailgun only acknowledges the addition, so report what was sent
+	result.Address = complaint.Address
+	if complaint.CreatedAt != nil {
+		result.CreatedAt = *complaint.CreatedAt
+	}
+
+	return result.observation(), nil
 }
 
 // GetComplaint retrieves a complaint suppression entry
-func (c *mailgunClient) GetComplaint(ctx context.Context, domain, address string) (interface{}, error) {
-	path := fmt.Sprintf("/domains/%s/complaints/%s", url.PathEscape(domain), url.PathEscape(address))
+func (c *mailgunClient) GetComplaint(ctx context.Context, domain, address string) (*complainttypes.ComplaintObservation, error) {
+	path := complaintsPath(domain) + "/" + url.PathEscape(address)
 
-	resp, err := c.makeRequest(ctx, "GET", path, nil)
+	resp, err := c.makeRequest(ctx, APIComplaints, "GET", path, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get complaint: %w", err)
 	}
@@ -64,23 +82,22 @@ func (c *mailgunClient) GetComplaint(ctx context.Context, domain, address string
 		return nil, fmt.Errorf("failed to handle response: %w", err)
 	}
 
-	return interface{}(&result), nil
+	return result.observation(), nil
 }
 
 // DeleteComplaint deletes a complaint suppression entry
 func (c *mailgunClient) DeleteComplaint(ctx context.Context, domain, address string) error {
-	path := fmt.Sprintf("/domains/%s/complaints/%s", url.PathEscape(domain), url.PathEscape(address))
+	path := complaintsPath(domain) + "/" + url.PathEscape(address)
 
-	resp, err := c.makeRequest(ctx, "DELETE", path, nil)
+	resp, err := c.makeRequest(ctx, APIComplaints, "DELETE", path, nil)
 	if err != nil {
 		return fmt.Errorf("failed to delete complaint: %w", err)
 	}
 
-	// For DELETE operations, we usually don't need to parse the response
-	var result interface{}
-	if err := c.handleResponse(resp, &result); err != nil {
-		// Many DELETE endpoints return empty responses, so we ignore parse errors
-		return nil
+	// Errors, including the 404 for an address that is not in the table,
+	// are returned so the caller can decide whether they matter
+	if err := c.handleResponse(resp, nil); err != nil {
+		return fmt.Errorf("failed to handle response: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/rossigee/provider-mailgun/apis/v1beta1"
+)
+
+var externalStoreDir string
+
+// SetExternalStoreDir sets the directory external secret store files must be
+// in. Any ProviderConfig may name a file, so while it is unset credentials
+// are not read from external stores at all.
+func SetExternalStoreDir(dir string) {
+	if dir != "" {
+		dir = filepath.Clean(dir)
+	}
+	externalStoreDir = dir
+}
+
+// externalStorePath returns the file that path names within the external
+// store directory. Paths may be relative to it or absolute, but must not
+// leave it, whether through ".." or through symlinks.
+func externalStorePath(path string) (string, error) {
+	if externalStoreDir == "" {
+		return "", errors.New("external secret stores are disabled; the provider must be started with --external-secret-store-dir")
+	}
+	for _, elem := range strings.Split(filepath.ToSlash(path), "/") {
+		if elem == ".." {
+			return "", errors.Errorf("external secret store path %q must not contain ..", path)
+		}
+	}
+	full := path
+	if !filepath.IsAbs(full) {
+		full = filepath.Join(externalStoreDir, full)
+	}
+	if !within(externalStoreDir, full) {
+		return "", errors.Errorf("external secret store path %q is outside %s", path, externalStoreDir)
+	}
+
+	resolved, err := filepath.EvalSymlinks(full)
+	if errors.Is(err, fs.ErrNotExist) {
+		return full, nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "cannot resolve external secret store path")
+	}
+	dir, err := filepath.EvalSymlinks(externalStoreDir)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot resolve external secret store directory")
+	}
+	if !within(dir, resolved) {
+		return "", errors.Errorf("external secret store path %q resolves outside %s", path, externalStoreDir)
+	}
+	return resolved, nil
+}
+
+// within reports whether path is dir or inside it
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, filepath.Clean(path))
+	return err == nil && filepath.IsLocal(rel)
+}
+
+// ExtractCredentials returns the credentials of a ProviderConfig. Those of an
+// external secret store are used when its file is present; otherwise they
+// are extracted from the configured source, usually a Kubernetes secret.
+func ExtractCredentials(ctx context.Context, c client.Client, creds v1beta1.ProviderCredentials) ([]byte, error) {
+	if creds.ExternalStore != nil && creds.ExternalStore.Path != "" {
+		path, err := externalStorePath(creds.ExternalStore.Path)
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(path)
+		if err == nil {
+			return data, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, errors.Wrap(err, "cannot read credentials from external secret store")
+		}
+	}
+
+	return resource.CommonCredentialExtractor(ctx, creds.Source, c, creds.CommonCredentialSelectors)
+}
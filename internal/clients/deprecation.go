@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/rossigee/provider-mailgun/internal/metrics"
+)
+
+// Headers Mailgun uses to announce upcoming API changes.
+const (
+	HeaderDeprecation = "Deprecation"
+	HeaderSunset      = "Sunset"
+	HeaderWarning     = "Warning"
+)
+
+// reportedWarnings holds the warnings already logged, so each one is logged
+// once per process however often the endpoint is called.
+var reportedWarnings sync.Map
+
+// reportDeprecation logs any deprecation or warning headers of resp and
+// counts calls to deprecated endpoints.
+func reportDeprecation(resp *http.Response) {
+	deprecation := resp.Header.Get(HeaderDeprecation)
+	warnings := resp.Header.Values(HeaderWarning)
+	if deprecation == "" && len(warnings) == 0 {
+		return
+	}
+
+	method, path := "", ""
+	if resp.Request != nil && resp.Request.URL != nil {
+		method, path = resp.Request.Method, resp.Request.URL.Path
+	}
+	endpoint := endpointLabel(method, path)
+
+	if deprecation != "" {
+		metrics.RecordDeprecatedAPIRequest(endpoint)
+		if _, seen := reportedWarnings.LoadOrStore("deprecation\x00"+endpoint, struct{}{}); !seen {
+			requestLogger.Info("Mailgun API endpoint is deprecated",
+				"endpoint", endpoint,
+				"deprecation", deprecation,
+				"sunset", resp.Header.Get(HeaderSunset),
+				"method", method,
+				"path", path)
+		}
+	}
+
+	for _, warning := range warnings {
+		if _, seen := reportedWarnings.LoadOrStore("warning\x00"+warning, struct{}{}); !seen {
+			requestLogger.Info("Mailgun API returned a warning",
+				"warning", warning,
+				"endpoint", endpoint,
+				"method", method,
+				"path", path)
+		}
+	}
+}
+
+// pathPlaceholders maps the collections of the Mailgun API to the
+// placeholder standing in for the names of their members in endpoint labels.
+var pathPlaceholders = map[string]string{
+	"bounces":      "{address}",
+	"complaints":   "{address}",
+	"credentials":  "{login}",
+	"domains":      "{domain}",
+	"ip_pools":     "{id}",
+	"ips":          "{ip}",
+	"keys":         "{selector}",
+	"lists":        "{list}",
+	"members":      "{address}",
+	"routes":       "{id}",
+	"templates":    "{template}",
+	"unsubscribes": "{address}",
+	"versions":     "{version}",
+}
+
+// accountPaths are the collections found directly under an API version.
+// Any other segment there is the domain of a domain-scoped path such as
+// /v3/example.com/bounces.
+var accountPaths = map[string]bool{
+	"accounts": true,
+	"domains":  true,
+	"ip_pools": true,
+	"ips":      true,
+	"lists":    true,
+	"routes":   true,
+	"sandbox":  true,
+}
+
+// endpointLabel identifies an endpoint by method and the shape of its path,
+// such as "GET /v3/domains/{domain}/templates/{template}" or
+// "GET /v3/{domain}/bounces", so that the names of individual domains,
+// addresses, lists or routes do not end up in metric labels.
+func endpointLabel(method, path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := range segments {
+		if i == 0 {
+			continue
+		}
+		prev := segments[i-1]
+		switch {
+		case isAPIVersion(prev) && !accountPaths[segments[i]]:
+			segments[i] = "{domain}"
+		case pathPlaceholders[prev] != "" && segments[i] != "pages":
+			segments[i] = pathPlaceholders[prev]
+		}
+	}
+	return strings.TrimSpace(method + " /" + strings.Join(segments, "/"))
+}
+
+// isAPIVersion reports whether segment is an API version such as "v3".
+func isAPIVersion(segment string) bool {
+	if len(segment) < 2 || segment[0] != 'v' {
+		return false
+	}
+	for _, r := range segment[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
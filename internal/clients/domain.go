@@ -18,14 +18,68 @@ package clients
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	domaintypes "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
 )
 
+// domainResponse is the body Mailgun returns for a single domain. The DNS
+// records are siblings of the domain object rather than part of it.
+type domainResponse struct {
+	Message             string      `json:"message,omitempty"`
+	Domain              *Domain     `json:"domain"`
+	ReceivingDNSRecords []DNSRecord `json:"receiving_dns_records,omitempty"`
+	SendingDNSRecords   []DNSRecord `json:"sending_dns_records,omitempty"`
+}
+
+// observation converts the response to an API DomainObservation. Records
+// nested in the domain object are used when the top-level ones are absent.
+func (r *domainResponse) observation() *domaintypes.DomainObservation {
+	receiving := r.ReceivingDNSRecords
+	if len(receiving) == 0 {
+		receiving = r.Domain.ReceivingDNSRecords
+	}
+	sending := r.SendingDNSRecords
+	if len(sending) == 0 {
+		sending = r.Domain.SendingDNSRecords
+	}
+
+	disabled, reason := r.Domain.disabled()
+	return &domaintypes.DomainObservation{
+		ID:                   r.Domain.Name, // Mailgun uses name as ID
+		State:                r.Domain.State,
+		Disabled:             disabled,
+		DisabledReason:       reason,
+		CreatedAt:            r.Domain.CreatedAt,
+		SMTPLogin:            r.Domain.SMTPLogin,
+		SMTPPassword:         r.Domain.SMTPPassword,
+		WebScheme:            r.Domain.WebScheme,
+		SpamAction:           r.Domain.SpamAction,
+		RequiredDNSRecords:   convertDNSRecords(r.Domain.RequiredDNSRecords),
+		ReceivingDNSRecords:  convertDNSRecords(receiving),
+		SendingDNSRecords:    convertDNSRecords(sending),
+		LastOperationMessage: r.Message,
+	}
+}
+
+// disabled reports whether Mailgun has disabled the domain, by any of the
+// fields it uses to say so, along with its reason if one is given
+func (d *Domain) disabled() (bool, string) {
+	if d.Disabled != nil {
+		reason := d.Disabled.Reason
+		if d.Disabled.Note != "" {
+			reason = strings.TrimSpace(reason + " " + d.Disabled.Note)
+		}
+		return true, reason
+	}
+	return d.IsDisabled || d.State == "disabled", ""
+}
+
 // convertDNSRecords converts client DNSRecord slice to API DNSRecord slice
 func convertDNSRecords(clientRecords []DNSRecord) []domaintypes.DNSRecord {
 	if clientRecords == nil {
@@ -75,63 +129,49 @@ func (c *mailgunClient) CreateDomain(ctx context.Context, domain *domaintypes.Do
 	if len(domain.IPs) > 0 {
 		params["ips"] = strings.Join(domain.IPs, ",")
 	}
+	poolID := c.config.DefaultPoolID
+	if domain.PoolID != nil && *domain.PoolID != "" {
+		poolID = *domain.PoolID
+	}
+	if poolID != "" {
+		params["pool_id"] = poolID
+	}
 
 	body := strings.NewReader(createFormData(params))
-	resp, err := c.makeRequest(ctx, "POST", "/domains", body)
+	resp, err := c.makeRequest(ctx, APIDomains, "POST", "/domains", body)
+	c.forgetCoalesced(fmt.Sprintf("/domains/%s", url.PathEscape(domain.Name)))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create domain")
 	}
 
-	var result struct {
-		Domain *Domain `json:"domain"`
-	}
+	var result domainResponse
 	if err := c.handleResponse(resp, &result); err != nil {
 		return nil, errors.Wrap(err, "failed to handle response")
 	}
 
-	// Convert client Domain to API DomainObservation
-	observation := &domaintypes.DomainObservation{
-		ID:                  result.Domain.Name, // Mailgun uses name as ID
-		State:               result.Domain.State,
-		CreatedAt:           result.Domain.CreatedAt,
-		SMTPLogin:           result.Domain.SMTPLogin,
-		SMTPPassword:        result.Domain.SMTPPassword,
-		RequiredDNSRecords:  convertDNSRecords(result.Domain.RequiredDNSRecords),
-		ReceivingDNSRecords: convertDNSRecords(result.Domain.ReceivingDNSRecords),
-		SendingDNSRecords:   convertDNSRecords(result.Domain.SendingDNSRecords),
-	}
-
-	return observation, nil
+	return result.observation(), nil
 }
 
 // GetDomain retrieves a domain from Mailgun
 func (c *mailgunClient) GetDomain(ctx context.Context, name string) (*domaintypes.DomainObservation, error) {
 	path := fmt.Sprintf("/domains/%s", url.PathEscape(name))
-	resp, err := c.makeRequest(ctx, "GET", path, nil)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get domain")
-	}
-
-	var result struct {
-		Domain *Domain `json:"domain"`
-	}
-	if err := c.handleResponse(resp, &result); err != nil {
-		return nil, errors.Wrap(err, "failed to handle response")
-	}
+	shared, err := c.coalesced(ctx, path, func(ctx context.Context) (interface{}, error) {
+		resp, err := c.makeRequest(ctx, APIDomains, "GET", path, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get domain")
+		}
 
-	// Convert client Domain to API DomainObservation
-	observation := &domaintypes.DomainObservation{
-		ID:                  result.Domain.Name, // Mailgun uses name as ID
-		State:               result.Domain.State,
-		CreatedAt:           result.Domain.CreatedAt,
-		SMTPLogin:           result.Domain.SMTPLogin,
-		SMTPPassword:        result.Domain.SMTPPassword,
-		RequiredDNSRecords:  convertDNSRecords(result.Domain.RequiredDNSRecords),
-		ReceivingDNSRecords: convertDNSRecords(result.Domain.ReceivingDNSRecords),
-		SendingDNSRecords:   convertDNSRecords(result.Domain.SendingDNSRecords),
+		var result domainResponse
+		if err := c.handleResponse(resp, &result); err != nil {
+			return nil, errors.Wrap(err, "failed to handle response")
+		}
+		return &result, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return observation, nil
+	return shared.(*domainResponse).observation(), nil
 }
 
 // UpdateDomain updates an existing domain in Mailgun
@@ -150,37 +190,352 @@ func (c *mailgunClient) UpdateDomain(ctx context.Context, name string, domain *d
 
 	body := strings.NewReader(createFormData(params))
 	path := fmt.Sprintf("/domains/%s", url.PathEscape(name))
-	resp, err := c.makeRequest(ctx, "PUT", path, body)
+	resp, err := c.makeRequest(ctx, APIDomains, "PUT", path, body)
+	c.forgetCoalesced(path)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to update domain")
 	}
 
+	var result domainResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, errors.Wrap(err, "failed to handle response")
+	}
+
+	return result.observation(), nil
+}
+
+// dkimSelectorPrefix starts the selectors of DKIM keys generated by
+// UpdateDKIMKey, which are told apart by the time they were generated
+const dkimSelectorPrefix = "mg"
+
+// UpdateDKIMKey makes the domain sign with a newly generated DKIM key of
+// keySize bits and returns the DNS record that publishes it. The key is
+// generated under a new selector and then activated. Only the new key's
+// record is returned; the previous key's record may be left in DNS until
+// mail signed with it is delivered. A key that cannot be activated is
+// deleted again, so that retries do not leave unused keys behind.
+func (c *mailgunClient) UpdateDKIMKey(ctx context.Context, name string, keySize int) ([]domaintypes.DNSRecord, error) {
+	params := map[string]interface{}{
+		"signing_domain": name,
+		"selector":       dkimSelectorPrefix + time.Now().UTC().Format("20060102150405"),
+		"bits":           keySize,
+	}
+
+	body := strings.NewReader(createFormData(params))
+	resp, err := c.makeRequest(ctx, APIDomainKeys, "POST", "/dkim/keys", body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create DKIM key")
+	}
+
+	var key struct {
+		Selector  string    `json:"selector"`
+		DNSRecord DNSRecord `json:"dns_record"`
+	}
+	if err := c.handleResponse(resp, &key); err != nil {
+		return nil, errors.Wrap(err, "failed to handle response")
+	}
+
+	if err := c.activateDKIMKey(ctx, name, key.Selector); err != nil {
+		if derr := c.deleteDKIMKey(ctx, name, key.Selector); derr != nil {
+			requestLogger.Debug("Cannot delete DKIM key that failed to activate", "domain", name, "selector", key.Selector, "error", derr)
+		}
+		return nil, err
+	}
+	return convertDNSRecords([]DNSRecord{key.DNSRecord}), nil
+}
+
+// activateDKIMKey makes the domain sign with the key of selector
+func (c *mailgunClient) activateDKIMKey(ctx context.Context, name, selector string) error {
+	path := fmt.Sprintf("/domains/%s/keys/%s/activate", url.PathEscape(name), url.PathEscape(selector))
+	resp, err := c.makeRequest(ctx, APIDomainKeyActivation, "PUT", path, nil)
+	c.forgetCoalesced(fmt.Sprintf("/domains/%s", url.PathEscape(name)))
+	if err != nil {
+		return errors.Wrap(err, "failed to activate DKIM key")
+	}
+
+	var result interface{}
+	if err := c.handleResponse(resp, &result); err != nil {
+		return errors.Wrap(err, "failed to handle response")
+	}
+	return nil
+}
+
+// deleteDKIMKey deletes the domain's key of selector
+func (c *mailgunClient) deleteDKIMKey(ctx context.Context, name, selector string) error {
+	query := url.Values{"signing_domain": {name}, "selector": {selector}}
+	resp, err := c.makeRequest(ctx, APIDomainKeys, "DELETE", "/dkim/keys?"+query.Encode(), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete DKIM key")
+	}
+	if err := c.handleResponse(resp, nil); err != nil {
+		return errors.Wrap(err, "failed to handle response")
+	}
+	return nil
+}
+
+// ListDomains returns a page of domains along with the total number of
+// domains in the account
+func (c *mailgunClient) ListDomains(ctx context.Context, limit, skip int) ([]*domaintypes.DomainObservation, int, error) {
+	path := fmt.Sprintf("/domains?limit=%d&skip=%d", limit, skip)
+	resp, err := c.makeRequest(ctx, APIDomains, "GET", path, nil)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to list domains")
+	}
+
+	var result struct {
+		TotalCount int      `json:"total_count"`
+		Items      []Domain `json:"items"`
+	}
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, 0, errors.Wrap(err, "failed to handle response")
+	}
+
+	domains := make([]*domaintypes.DomainObservation, 0, len(result.Items))
+	for i := range result.Items {
+		d := &result.Items[i]
+		disabled, reason := d.disabled()
+		domains = append(domains, &domaintypes.DomainObservation{
+			ID:             d.Name, // Mailgun uses name as ID
+			State:          d.State,
+			Disabled:       disabled,
+			DisabledReason: reason,
+			CreatedAt:      d.CreatedAt,
+			SMTPLogin:      d.SMTPLogin,
+			SMTPPassword:   d.SMTPPassword,
+			WebScheme:      d.WebScheme,
+			SpamAction:     d.SpamAction,
+		})
+	}
+
+	return domains, result.TotalCount, nil
+}
+
+// DomainPageSize is the number of domains ListAllDomains requests per page
+const DomainPageSize = 100
+
+// ListAllDomains returns every domain in the account, listing as many pages
+// as it takes.
+func ListAllDomains(ctx context.Context, c Client) ([]*domaintypes.DomainObservation, error) {
+	var all []*domaintypes.DomainObservation
+	for skip := 0; ; skip += DomainPageSize {
+		page, total, err := c.ListDomains(ctx, DomainPageSize, skip)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < DomainPageSize || len(all) >= total {
+			return all, nil
+		}
+	}
+}
+
+// UpdateDomainTracking applies the set tracking settings of a domain. Each
+// tracking type has its own endpoint, so unset fields are left untouched.
+func (c *mailgunClient) UpdateDomainTracking(ctx context.Context, name string, tracking *domaintypes.DomainTracking) error {
+	if tracking == nil {
+		return nil
+	}
+
+	settings := []struct {
+		kind   string
+		active *bool
+	}{
+		{"click", tracking.Click},
+		{"open", tracking.Open},
+		{"unsubscribe", tracking.Unsubscribe},
+	}
+
+	for _, setting := range settings {
+		params := map[string]interface{}{}
+		if setting.active != nil {
+			params["active"] = *setting.active
+		}
+		if setting.kind == "unsubscribe" {
+			if tracking.UnsubscribeHTMLFooter != nil {
+				params["html_footer"] = *tracking.UnsubscribeHTMLFooter
+			}
+			if tracking.UnsubscribeTextFooter != nil {
+				params["text_footer"] = *tracking.UnsubscribeTextFooter
+			}
+		}
+		if len(params) == 0 {
+			continue
+		}
+
+		body := strings.NewReader(createFormData(params))
+		path := fmt.Sprintf("/domains/%s/tracking/%s", url.PathEscape(name), setting.kind)
+		resp, err := c.makeRequest(ctx, APIDomains, "PUT", path, body)
+		if err != nil {
+			return errors.Wrapf(err, "failed to update %s tracking", setting.kind)
+		}
+		if err := c.handleResponse(resp, nil); err != nil {
+			return errors.Wrapf(err, "failed to update %s tracking", setting.kind)
+		}
+	}
+
+	return nil
+}
+
+// trackingActive is the active setting of a tracking type. Mailgun reports
+// it as a boolean or, for click tracking limited to HTML messages, as
+// "htmlonly".
+type trackingActive bool
+
+func (a *trackingActive) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case bool:
+		*a = trackingActive(v)
+	case string:
+		*a = trackingActive(v == "true" || v == "yes" || v == "htmlonly")
+	}
+	return nil
+}
+
+// GetDomainTracking returns the tracking configuration of a domain
+func (c *mailgunClient) GetDomainTracking(ctx context.Context, name string) (*domaintypes.DomainTracking, error) {
+	path := fmt.Sprintf("/domains/%s/tracking", url.PathEscape(name))
+	resp, err := c.makeRequest(ctx, APIDomains, "GET", path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get domain tracking")
+	}
+
+	var result struct {
+		Tracking struct {
+			Click struct {
+				Active trackingActive `json:"active"`
+			} `json:"click"`
+			Open struct {
+				Active trackingActive `json:"active"`
+			} `json:"open"`
+			Unsubscribe struct {
+				Active     trackingActive `json:"active"`
+				HTMLFooter string         `json:"html_footer"`
+				TextFooter string         `json:"text_footer"`
+			} `json:"unsubscribe"`
+		} `json:"tracking"`
+	}
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, errors.Wrap(err, "failed to handle response")
+	}
+
+	t := result.Tracking
+	click, open, unsubscribe := bool(t.Click.Active), bool(t.Open.Active), bool(t.Unsubscribe.Active)
+	return &domaintypes.DomainTracking{
+		Click:                 &click,
+		Open:                  &open,
+		Unsubscribe:           &unsubscribe,
+		UnsubscribeHTMLFooter: &t.Unsubscribe.HTMLFooter,
+		UnsubscribeTextFooter: &t.Unsubscribe.TextFooter,
+	}, nil
+}
+
+// GetDomainConnection returns the connection settings of a domain
+func (c *mailgunClient) GetDomainConnection(ctx context.Context, name string) (*domaintypes.DomainConnection, error) {
+	path := fmt.Sprintf("/domains/%s/connection", url.PathEscape(name))
+	resp, err := c.makeRequest(ctx, APIDomains, "GET", path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get domain connection settings")
+	}
+
+	var result struct {
+		Connection struct {
+			RequireTLS       bool `json:"require_tls"`
+			SkipVerification bool `json:"skip_verification"`
+		} `json:"connection"`
+	}
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, errors.Wrap(err, "failed to handle response")
+	}
+
+	return &domaintypes.DomainConnection{
+		RequireTLS:       &result.Connection.RequireTLS,
+		SkipVerification: &result.Connection.SkipVerification,
+	}, nil
+}
+
+// UpdateDomainConnection applies the set connection settings of a domain.
+// Unset fields are left untouched.
+func (c *mailgunClient) UpdateDomainConnection(ctx context.Context, name string, connection *domaintypes.DomainConnection) error {
+	if connection == nil {
+		return nil
+	}
+
+	params := map[string]interface{}{}
+	if connection.RequireTLS != nil {
+		params["require_tls"] = *connection.RequireTLS
+	}
+	if connection.SkipVerification != nil {
+		params["skip_verification"] = *connection.SkipVerification
+	}
+	if len(params) == 0 {
+		return nil
+	}
+
+	body := strings.NewReader(createFormData(params))
+	path := fmt.Sprintf("/domains/%s/connection", url.PathEscape(name))
+	resp, err := c.makeRequest(ctx, APIDomains, "PUT", path, body)
+	if err != nil {
+		return errors.Wrap(err, "failed to update domain connection settings")
+	}
+	if err := c.handleResponse(resp, nil); err != nil {
+		return errors.Wrap(err, "failed to handle response")
+	}
+	return nil
+}
+
+// ListDomainIPs returns the dedicated IPs the domain sends from
+func (c *mailgunClient) ListDomainIPs(ctx context.Context, name string) ([]string, error) {
+	path := fmt.Sprintf("/domains/%s/ips", url.PathEscape(name))
+	resp, err := c.makeRequest(ctx, APIDomains, "GET", path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list domain IPs")
+	}
+
 	var result struct {
-		Domain *Domain `json:"domain"`
+		Items []string `json:"items"`
 	}
 	if err := c.handleResponse(resp, &result); err != nil {
 		return nil, errors.Wrap(err, "failed to handle response")
 	}
+	return result.Items, nil
+}
 
-	// Convert client Domain to API DomainObservation
-	observation := &domaintypes.DomainObservation{
-		ID:                  result.Domain.Name, // Mailgun uses name as ID
-		State:               result.Domain.State,
-		CreatedAt:           result.Domain.CreatedAt,
-		SMTPLogin:           result.Domain.SMTPLogin,
-		SMTPPassword:        result.Domain.SMTPPassword,
-		RequiredDNSRecords:  convertDNSRecords(result.Domain.RequiredDNSRecords),
-		ReceivingDNSRecords: convertDNSRecords(result.Domain.ReceivingDNSRecords),
-		SendingDNSRecords:   convertDNSRecords(result.Domain.SendingDNSRecords),
+// AssignDomainIP makes the domain send from the dedicated IP
+func (c *mailgunClient) AssignDomainIP(ctx context.Context, name, ip string) error {
+	body := strings.NewReader(createFormData(map[string]interface{}{"ip": ip}))
+	path := fmt.Sprintf("/domains/%s/ips", url.PathEscape(name))
+	resp, err := c.makeRequest(ctx, APIDomains, "POST", path, body)
+	if err != nil {
+		return errors.Wrap(err, "failed to assign domain IP")
+	}
+	if err := c.handleResponse(resp, nil); err != nil {
+		return errors.Wrap(err, "failed to handle response")
 	}
+	return nil
+}
 
-	return observation, nil
+// UnassignDomainIP stops the domain sending from the dedicated IP
+func (c *mailgunClient) UnassignDomainIP(ctx context.Context, name, ip string) error {
+	path := fmt.Sprintf("/domains/%s/ips/%s", url.PathEscape(name), url.PathEscape(ip))
+	resp, err := c.makeRequest(ctx, APIDomains, "DELETE", path, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to unassign domain IP")
+	}
+	if err := c.handleResponse(resp, nil); err != nil {
+		return errors.Wrap(err, "failed to handle response")
+	}
+	return nil
 }
 
 // DeleteDomain deletes a domain from Mailgun
 func (c *mailgunClient) DeleteDomain(ctx context.Context, name string) error {
 	path := fmt.Sprintf("/domains/%s", name)
-	resp, err := c.makeRequest(ctx, "DELETE", path, nil)
+	resp, err := c.makeRequest(ctx, APIDomains, "DELETE", path, nil)
+	c.forgetCoalesced(fmt.Sprintf("/domains/%s", url.PathEscape(name)))
 	if err != nil {
 		return errors.Wrap(err, "failed to delete domain")
 	}
@@ -19,8 +19,10 @@ package clients
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -53,6 +55,7 @@ func TestCreateDomain(t *testing.T) {
 
 				w.WriteHeader(http.StatusOK)
 				response := map[string]interface{}{
+					"message": "Domain DNS records have been created",
 					"domain": map[string]interface{}{
 						"name":          "test.com",
 						"type":          "sending",
@@ -88,6 +91,7 @@ func TestCreateDomain(t *testing.T) {
 						Valid:    boolPtr(false), // "unknown" -> false
 					},
 				},
+				LastOperationMessage: "Domain DNS records have been created",
 			},
 			expectedError: false,
 		},
@@ -175,6 +179,48 @@ func TestCreateDomain(t *testing.T) {
 	}
 }
 
+func TestCreateDomainPool(t *testing.T) {
+	tests := []struct {
+		name          string
+		defaultPoolID string
+		poolID        *string
+		expectedPool  string
+	}{
+		{name: "default pool applied when unset", defaultPoolID: "default-pool", expectedPool: "default-pool"},
+		{name: "resource pool overrides default", defaultPoolID: "default-pool", poolID: stringPtr("dedicated"), expectedPool: "dedicated"},
+		{name: "no pool", expectedPool: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.ParseForm()
+				sent = r.PostForm["pool_id"]
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"domain": map[string]interface{}{"name": "pool.com", "state": "unverified"},
+				})
+			}))
+			defer server.Close()
+
+			client := NewClient(&Config{
+				APIKey:        "test-key",
+				BaseURL:       server.URL + "/v3",
+				HTTPClient:    &http.Client{},
+				DefaultPoolID: tt.defaultPoolID,
+			})
+
+			_, err := client.CreateDomain(context.Background(), &domaintypes.DomainParameters{Name: "pool.com", PoolID: tt.poolID})
+			require.NoError(t, err)
+			if tt.expectedPool == "" {
+				assert.Empty(t, sent)
+			} else {
+				assert.Equal(t, []string{tt.expectedPool}, sent)
+			}
+		})
+	}
+}
+
 func TestGetDomain(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -212,6 +258,94 @@ func TestGetDomain(t *testing.T) {
 			},
 			expectedError: false,
 		},
+		{
+			name:       "DNS records alongside the domain",
+			domainName: "example.com",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{
+					"domain": {"name": "example.com", "state": "active"},
+					"receiving_dns_records": [{"record_type": "MX", "value": "mxa.mailgun.org", "priority": 10}],
+					"sending_dns_records": [{"name": "mx._domainkey.example.com", "record_type": "TXT", "value": "k=rsa; p=KEY"}]
+				}`))
+			},
+			expectedDomain: &domaintypes.DomainObservation{
+				ID:                  "example.com",
+				State:               "active",
+				ReceivingDNSRecords: []domaintypes.DNSRecord{{Type: "MX", Value: "mxa.mailgun.org", Priority: intPtr(10)}},
+				SendingDNSRecords:   []domaintypes.DNSRecord{{Name: "mx._domainkey.example.com", Type: "TXT", Value: "k=rsa; p=KEY"}},
+			},
+			expectedError: false,
+		},
+		{
+			name:       "web scheme reported",
+			domainName: "example.com",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"domain": {"name": "example.com", "state": "active", "web_scheme": "https"}}`))
+			},
+			expectedDomain: &domaintypes.DomainObservation{
+				ID:        "example.com",
+				State:     "active",
+				WebScheme: "https",
+			},
+			expectedError: false,
+		},
+		{
+			name:       "record validity as a string",
+			domainName: "example.com",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{
+					"domain": {"name": "example.com", "state": "active"},
+					"receiving_dns_records": [
+						{"record_type": "MX", "value": "mxa.mailgun.org", "priority": 10, "valid": "valid"},
+						{"record_type": "MX", "value": "mxb.mailgun.org", "valid": "invalid"},
+						{"record_type": "MX", "value": "mxc.mailgun.org", "valid": "unknown"}
+					]
+				}`))
+			},
+			expectedDomain: &domaintypes.DomainObservation{
+				ID:    "example.com",
+				State: "active",
+				ReceivingDNSRecords: []domaintypes.DNSRecord{
+					{Type: "MX", Value: "mxa.mailgun.org", Priority: intPtr(10), Valid: boolPtr(true)},
+					{Type: "MX", Value: "mxb.mailgun.org", Valid: boolPtr(false)},
+					{Type: "MX", Value: "mxc.mailgun.org"},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name:       "disabled through is_disabled",
+			domainName: "example.com",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"domain": {"name": "example.com", "state": "active", "is_disabled": true}}`))
+			},
+			expectedDomain: &domaintypes.DomainObservation{
+				ID:       "example.com",
+				State:    "active",
+				Disabled: true,
+			},
+			expectedError: false,
+		},
+		{
+			name:       "disabled with a reason",
+			domainName: "example.com",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"domain": {"name": "example.com", "state": "disabled", "is_disabled": true,
+					"disabled": {"code": "bounces", "reason": "High bounce rate.", "note": "Contact support.", "permanently": false}}}`))
+			},
+			expectedDomain: &domaintypes.DomainObservation{
+				ID:             "example.com",
+				State:          "disabled",
+				Disabled:       true,
+				DisabledReason: "High bounce rate. Contact support.",
+			},
+			expectedError: false,
+		},
 		{
 			name:       "domain not found",
 			domainName: "notfound.com",
@@ -407,6 +541,204 @@ func TestDeleteDomain(t *testing.T) {
 	}
 }
 
+func TestUpdateDomainTracking(t *testing.T) {
+	updates := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		require.NoError(t, r.ParseForm())
+		updates[r.URL.Path] = r.FormValue("active")
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "Domain tracking settings have been updated"})
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL + "/v3", HTTPClient: &http.Client{}})
+
+	err := client.UpdateDomainTracking(context.Background(), "example.com", &domaintypes.DomainTracking{
+		Click: boolPtr(true),
+		Open:  boolPtr(false),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"/v3/domains/example.com/tracking/click": "true",
+		"/v3/domains/example.com/tracking/open":  "false",
+	}, updates, "only set tracking types should be updated")
+
+	require.NoError(t, client.UpdateDomainTracking(context.Background(), "example.com", nil))
+	assert.Len(t, updates, 2)
+}
+
+func TestUpdateDomainTrackingFooters(t *testing.T) {
+	var form url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/domains/example.com/tracking/unsubscribe", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		form = r.PostForm
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "Domain tracking settings have been updated"})
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL + "/v3", HTTPClient: &http.Client{}})
+
+	// Footers can be changed without touching whether tracking is active
+	html := "<p><a href=\"%unsubscribe_url%\">Unsubscribe</a></p>"
+	err := client.UpdateDomainTracking(context.Background(), "example.com", &domaintypes.DomainTracking{
+		UnsubscribeHTMLFooter: &html,
+		UnsubscribeTextFooter: stringPtr("Unsubscribe: %unsubscribe_url%"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"html_footer": {html},
+		"text_footer": {"Unsubscribe: %unsubscribe_url%"},
+	}, form)
+}
+
+func TestGetDomainTracking(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/v3/domains/example.com/tracking", r.URL.Path)
+		_, _ = w.Write([]byte(`{"tracking":{"click":{"active":"htmlonly"},"open":{"active":false},"unsubscribe":{"active":true,"html_footer":"<p>bye</p>","text_footer":"bye"}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL + "/v3", HTTPClient: &http.Client{}})
+
+	tracking, err := client.GetDomainTracking(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, &domaintypes.DomainTracking{
+		Click:                 boolPtr(true),
+		Open:                  boolPtr(false),
+		Unsubscribe:           boolPtr(true),
+		UnsubscribeHTMLFooter: stringPtr("<p>bye</p>"),
+		UnsubscribeTextFooter: stringPtr("bye"),
+	}, tracking, "htmlonly click tracking should count as active")
+}
+
+func TestDomainConnection(t *testing.T) {
+	var form url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/domains/example.com/connection", r.URL.Path)
+		switch r.Method {
+		case "GET":
+			_, _ = w.Write([]byte(`{"connection":{"require_tls":true,"skip_verification":false}}`))
+		case "PUT":
+			require.NoError(t, r.ParseForm())
+			form = r.PostForm
+			_, _ = w.Write([]byte(`{"message":"Domain connection settings have been updated, may take 10 minutes to fully propagate","require_tls":false,"skip_verification":true}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL + "/v3", HTTPClient: &http.Client{}})
+
+	connection, err := client.GetDomainConnection(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, &domaintypes.DomainConnection{RequireTLS: boolPtr(true), SkipVerification: boolPtr(false)}, connection)
+
+	// Only the set settings are sent
+	err = client.UpdateDomainConnection(context.Background(), "example.com", &domaintypes.DomainConnection{SkipVerification: boolPtr(true)})
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{"skip_verification": {"true"}}, form)
+
+	form = nil
+	require.NoError(t, client.UpdateDomainConnection(context.Background(), "example.com", &domaintypes.DomainConnection{}))
+	assert.Nil(t, form, "nothing should be sent when no setting is managed")
+}
+
+func TestAuthorizedRecipients(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case "GET":
+			_, _ = w.Write([]byte(`{"recipients":[{"email":"alice@example.com","activated":true,"created_at":"2025-01-01T00:00:00Z"}]}`))
+		case "POST":
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "bob@example.com", r.FormValue("email"))
+			_, _ = w.Write([]byte(`{"recipient":{"email":"bob@example.com","activated":false}}`))
+		case "DELETE":
+			_, _ = w.Write([]byte(`{"message":"Recipient removed"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL + "/v3", HTTPClient: &http.Client{}})
+
+	recipients, err := client.ListAuthorizedRecipients(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []domaintypes.AuthorizedRecipient{{Email: "alice@example.com", Activated: true}}, recipients)
+
+	added, err := client.AddAuthorizedRecipient(context.Background(), "bob@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, &domaintypes.AuthorizedRecipient{Email: "bob@example.com", Activated: false}, added)
+
+	require.NoError(t, client.DeleteAuthorizedRecipient(context.Background(), "bob@example.com"))
+
+	assert.Equal(t, []string{
+		"GET /v5/sandbox/auth_recipients",
+		"POST /v5/sandbox/auth_recipients",
+		"DELETE /v5/sandbox/auth_recipients/bob@example.com",
+	}, requests, "recipients live under API v5 regardless of the configured version")
+}
+
+func TestWebhookSigningKey(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case "GET":
+			_, _ = w.Write([]byte(`{"http_signing_key":"old-key"}`))
+		case "POST":
+			_, _ = w.Write([]byte(`{"message":"success","http_signing_key":"new-key"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL + "/v3", HTTPClient: &http.Client{}})
+
+	key, err := client.GetWebhookSigningKey(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "old-key", key)
+
+	key, err = client.RotateWebhookSigningKey(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "new-key", key)
+
+	assert.Equal(t, []string{
+		"GET /v5/accounts/http_signing_key",
+		"POST /v5/accounts/http_signing_key",
+	}, requests)
+}
+
+func TestUpdateDKIMKeyActivationFailure(t *testing.T) {
+	var requests []string
+	var deleted url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case "POST":
+			_, _ = w.Write([]byte(`{"selector":"mg20250601000000","dns_record":{"name":"mg20250601000000._domainkey.example.com","record_type":"TXT","value":"k=rsa; p=NEW"}}`))
+		case "PUT":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Key could not be activated"}`))
+		case "DELETE":
+			deleted = r.URL.Query()
+			_, _ = w.Write([]byte(`{"message":"success"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL + "/v3", HTTPClient: &http.Client{}})
+
+	_, err := client.UpdateDKIMKey(context.Background(), "example.com", 2048)
+	require.Error(t, err)
+	assert.Equal(t, []string{
+		"POST /v1/dkim/keys",
+		"PUT /v4/domains/example.com/keys/mg20250601000000/activate",
+		"DELETE /v1/dkim/keys",
+	}, requests, "a key that cannot be activated should be deleted")
+	assert.Equal(t, url.Values{"signing_domain": {"example.com"}, "selector": {"mg20250601000000"}}, deleted)
+}
+
 // Helper functions
 func stringPtr(s string) *string {
 	return &s
@@ -419,3 +751,30 @@ func boolPtr(b bool) *bool {
 func intPtr(i int) *int {
 	return &i
 }
+
+func TestListAllDomains(t *testing.T) {
+	const total = 230
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/v3/domains", r.URL.Path)
+		var limit, skip int
+		_, _ = fmt.Sscan(r.URL.Query().Get("limit"), &limit)
+		_, _ = fmt.Sscan(r.URL.Query().Get("skip"), &skip)
+
+		items := []map[string]interface{}{}
+		for i := skip; i < total && i < skip+limit; i++ {
+			items = append(items, map[string]interface{}{"name": fmt.Sprintf("d%03d.example.com", i), "state": "active"})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"total_count": total, "items": items})
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL + "/v3", HTTPClient: &http.Client{}})
+	domains, err := ListAllDomains(context.Background(), client)
+	require.NoError(t, err)
+	require.Len(t, domains, total)
+	assert.Equal(t, "d000.example.com", domains[0].ID)
+	assert.Equal(t, "d229.example.com", domains[total-1].ID)
+	assert.Equal(t, 3, requests, "all pages should be listed")
+}
@@ -0,0 +1,329 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	mgerrors "github.com/rossigee/provider-mailgun/internal/errors"
+)
+
+// Error verbosity levels.
+const (
+	// ErrorVerbosityTerse reports the status code and Mailgun's message.
+	ErrorVerbosityTerse = "Terse"
+	// ErrorVerbosityVerbose additionally reports the request method and path
+	// and a snippet of the response body.
+	ErrorVerbosityVerbose = "Verbose"
+)
+
+// maxBodySnippet bounds how much of a response body verbose errors include
+const maxBodySnippet = 512
+
+var defaultErrorVerbosity = ErrorVerbosityTerse
+
+// ParseErrorVerbosity returns the canonical verbosity level for v, matched
+// case-insensitively.
+func ParseErrorVerbosity(v string) (string, error) {
+	switch {
+	case strings.EqualFold(v, ErrorVerbosityTerse):
+		return ErrorVerbosityTerse, nil
+	case strings.EqualFold(v, ErrorVerbosityVerbose):
+		return ErrorVerbosityVerbose, nil
+	default:
+		return "", errors.Errorf("invalid error verbosity %q: must be %s or %s", v, ErrorVerbosityTerse, ErrorVerbosityVerbose)
+	}
+}
+
+// SetDefaultErrorVerbosity sets the verbosity used when a ProviderConfig does
+// not specify one.
+func SetDefaultErrorVerbosity(v string) error {
+	level, err := ParseErrorVerbosity(v)
+	if err != nil {
+		return err
+	}
+	defaultErrorVerbosity = level
+	return nil
+}
+
+// APIError is returned when the Mailgun API responds with an error status.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Message is the "message" field of the Mailgun error body, if any.
+	Message string
+	// Body is the raw response body.
+	Body string
+	// Method and Path identify the request that failed.
+	Method string
+	Path   string
+	// Verbosity controls how much of the above Error includes.
+	Verbosity string
+	// RetryAfter is how long Mailgun asked callers to wait before retrying
+	// a 429 response, or zero if it did not say.
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	detail := e.Message
+	if detail == "" {
+		detail = e.Body
+	}
+
+	if e.Verbosity != ErrorVerbosityVerbose {
+		return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, detail)
+	}
+
+	snippet := e.Body
+	if len(snippet) > maxBodySnippet {
+		snippet = snippet[:maxBodySnippet] + "..."
+	}
+	return fmt.Sprintf("API request failed with status %d: %s (request: %s %s, response body: %s)",
+		e.StatusCode, detail, e.Method, e.Path, snippet)
+}
+
+// newAPIError builds an APIError from an error response and its body
+func newAPIError(resp *http.Response, body []byte, verbosity string) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode, Body: string(body), Verbosity: verbosity}
+	if resp.Request != nil && resp.Request.URL != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Path = resp.Request.URL.Path
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		apiErr.RetryAfter = retryAfter(resp.Header, time.Now())
+	}
+
+	var payload struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		// Some endpoints, and proxies in front of Mailgun, answer errors
+		// with plain text or an HTML page rather than JSON
+		apiErr.Message = textMessage(body)
+		return apiErr
+	}
+	apiErr.Message = payload.Message
+	return apiErr
+}
+
+// htmlTag matches the tags of an HTML error page
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// textMessage returns a non-JSON error body as a one-line message, with any
+// HTML tags removed and its length bounded
+func textMessage(body []byte) string {
+	text := htmlTag.ReplaceAllString(string(body), " ")
+	text = strings.Join(strings.Fields(text), " ")
+	if len(text) > maxBodySnippet {
+		text = text[:maxBodySnippet] + "..."
+	}
+	return text
+}
+
+// planLimitedPhrases are fragments of Mailgun error messages returned when a
+// feature is not available on the account's plan. They are specific enough
+// not to match messages that merely contain a word such as "plan".
+var planLimitedPhrases = []string{
+	"upgrade your plan",
+	"please upgrade",
+	"not available on your plan",
+	"not available on your current plan",
+	"not included in your plan",
+	"require a subscription",
+	"requires a subscription",
+	"not available on your account",
+	"not enabled for your account",
+}
+
+// IsPlanLimited reports whether err is a Mailgun API error caused by the
+// account plan not including the requested feature
+func IsPlanLimited(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.StatusCode != 402 && apiErr.StatusCode != 403 {
+		return false
+	}
+	// Only Mailgun's own JSON errors are trusted to be about the plan, not
+	// text that happens to mention it
+	if !json.Valid([]byte(apiErr.Body)) {
+		return false
+	}
+
+	msg := strings.ToLower(apiErr.Message)
+	for _, phrase := range planLimitedPhrases {
+		if strings.Contains(msg, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// invalidDomainPhrases are fragments of the messages Mailgun rejects a domain
+// name with
+var invalidDomainPhrases = []string{
+	"invalid domain",
+	"not a valid domain",
+	"domain name is invalid",
+}
+
+// IsInvalidDomain reports whether err is a Mailgun API error rejecting a
+// domain name as malformed, which no retry of the same request can fix
+func IsInvalidDomain(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		return false
+	}
+
+	msg := strings.ToLower(apiErr.Message)
+	for _, phrase := range invalidDomainPhrases {
+		if strings.Contains(msg, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDomainNotFound reports whether err is a Mailgun API error saying that the
+// domain a request was made under does not exist
+func IsDomainNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound &&
+		strings.Contains(strings.ToLower(apiErr.Message), "domain not found")
+}
+
+// IsServerError reports whether err is a Mailgun API error with a 5xx status
+func IsServerError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= 500
+}
+
+// IsAlreadyExists reports whether err is a Mailgun API error saying that the
+// resource being created already exists
+func IsAlreadyExists(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Code() == mgerrors.ErrorCodeResourceConflict
+}
+
+// IsRateLimited reports whether err is a Mailgun API error with a 429 status
+func IsRateLimited(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests
+}
+
+// RateLimitReset returns how long to wait before retrying err, when it is a
+// Mailgun API error with a 429 status that said so
+func RateLimitReset(err error) (time.Duration, bool) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests || apiErr.RetryAfter <= 0 {
+		return 0, false
+	}
+	return apiErr.RetryAfter, true
+}
+
+// retryAfter returns the wait requested by the Retry-After header, in
+// seconds or as an HTTP date, or failing that by X-RateLimit-Reset, the Unix
+// time at which Mailgun's rate limit window resets. It returns zero if
+// neither is set to a time after now.
+func retryAfter(h http.Header, now time.Time) time.Duration {
+	if v := strings.TrimSpace(h.Get("Retry-After")); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			return max(time.Duration(secs)*time.Second, 0)
+		}
+		if at, err := http.ParseTime(v); err == nil {
+			return max(at.Sub(now), 0)
+		}
+	}
+	if v := strings.TrimSpace(h.Get("X-RateLimit-Reset")); v != "" {
+		if secs, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return max(time.Unix(secs, 0).Sub(now), 0)
+		}
+	}
+	return 0
+}
+
+// ErrorMessage returns the Mailgun message carried by err, or err's text if
+// err is not a Mailgun API error
+func ErrorMessage(err error) string {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Message != "" {
+		return apiErr.Message
+	}
+	return err.Error()
+}
+
+// alreadyExistsPhrases are fragments of the messages Mailgun answers a 400
+// with when the resource being created exists
+var alreadyExistsPhrases = []string{
+	"already exists",
+	"already been taken",
+}
+
+// Code returns the provider error code of the error, refining the one of its
+// status by Mailgun's message where that says more
+func (e *APIError) Code() mgerrors.ErrorCode {
+	switch {
+	case IsPlanLimited(e):
+		return mgerrors.ErrorCodePlanLimited
+	case IsDomainNotFound(e):
+		return mgerrors.ErrorCodeDomainNotConfigured
+	case IsInvalidDomain(e):
+		return mgerrors.ErrorCodeInvalidSpec
+	case e.StatusCode == http.StatusBadRequest:
+		msg := strings.ToLower(e.Message)
+		for _, phrase := range alreadyExistsPhrases {
+			if strings.Contains(msg, phrase) {
+				return mgerrors.ErrorCodeResourceConflict
+			}
+		}
+	}
+	return mgerrors.CodeFromHTTPStatus(e.StatusCode)
+}
+
+// ErrorCode returns the provider error code of err, so that conditions and
+// metrics can be labeled by it. Errors that are neither Mailgun API errors,
+// provider errors nor timeouts are ErrorCodeUnknown. A nil error has no code.
+func ErrorCode(err error) mgerrors.ErrorCode {
+	if err == nil {
+		return ""
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code()
+	}
+	var providerErr *mgerrors.ProviderError
+	if errors.As(err, &providerErr) {
+		return providerErr.Code
+	}
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return mgerrors.ErrorCodeNetworkTimeout
+	}
+	return mgerrors.ErrorCodeUnknown
+}
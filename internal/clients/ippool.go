@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	ippooltypes "github.com/rossigee/provider-mailgun/apis/ippool/v1beta1"
+)
+
+// convertIPPool converts a client IPPool to an API IPPoolObservation
+func convertIPPool(pool *IPPool) *ippooltypes.IPPoolObservation {
+	return &ippooltypes.IPPoolObservation{
+		PoolID:      pool.PoolID,
+		Name:        pool.Name,
+		Description: pool.Description,
+		IPs:         pool.IPs,
+		IsLinked:    pool.IsLinked,
+	}
+}
+
+// DiffIPs returns the desired IPs missing from observed and the observed IPs
+// that are not desired, each sorted and listed once
+func DiffIPs(desired, observed []string) (add, remove []string) {
+	in := make(map[string]bool, len(observed))
+	for _, ip := range observed {
+		in[ip] = true
+	}
+	want := make(map[string]bool, len(desired))
+	for _, ip := range desired {
+		if !in[ip] && !want[ip] {
+			add = append(add, ip)
+		}
+		want[ip] = true
+	}
+	removed := make(map[string]bool)
+	for _, ip := range observed {
+		if !want[ip] && !removed[ip] {
+			remove = append(remove, ip)
+		}
+		removed[ip] = true
+	}
+	sort.Strings(add)
+	sort.Strings(remove)
+	return add, remove
+}
+
+// CreateIPPool creates a dedicated IP pool holding the IPs of pool
+func (c *mailgunClient) CreateIPPool(ctx context.Context, pool *ippooltypes.IPPoolParameters) (*ippooltypes.IPPoolObservation, error) {
+	params := map[string]interface{}{
+		"name": pool.Name,
+	}
+	if pool.Description != nil {
+		params["description"] = *pool.Description
+	}
+	if len(pool.IPs) > 0 {
+		params["ip"] = pool.IPs
+	}
+
+	body := strings.NewReader(createFormData(params))
+	resp, err := c.makeRequest(ctx, APIIPPools, "POST", "/ip_pools", body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create IP pool")
+	}
+
+	// Mailgun only returns the ID of the new pool
+	var result struct {
+		PoolID string `json:"pool_id"`
+	}
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, errors.Wrap(err, "failed to handle response")
+	}
+
+	created := &IPPool{PoolID: result.PoolID, Name: pool.Name, IPs: pool.IPs}
+	if pool.Description != nil {
+		created.Description = *pool.Description
+	}
+	return convertIPPool(created), nil
+}
+
+// GetIPPool retrieves a dedicated IP pool by its ID
+func (c *mailgunClient) GetIPPool(ctx context.Context, id string) (*ippooltypes.IPPoolObservation, error) {
+	path := fmt.Sprintf("/ip_pools/%s", url.PathEscape(id))
+	resp, err := c.makeRequest(ctx, APIIPPools, "GET", path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get IP pool")
+	}
+
+	var result IPPool
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, errors.Wrap(err, "failed to handle response")
+	}
+	if result.PoolID == "" {
+		result.PoolID = id
+	}
+
+	return convertIPPool(&result), nil
+}
+
+// UpdateIPPool sets the name and description of a dedicated IP pool and
+// adds and removes the supplied IPs
+func (c *mailgunClient) UpdateIPPool(ctx context.Context, id string, pool *ippooltypes.IPPoolParameters, add, remove []string) error {
+	params := map[string]interface{}{
+		"name": pool.Name,
+	}
+	if pool.Description != nil {
+		params["description"] = *pool.Description
+	}
+	if len(add) > 0 {
+		params["add_ip"] = add
+	}
+	if len(remove) > 0 {
+		params["remove_ip"] = remove
+	}
+
+	body := strings.NewReader(createFormData(params))
+	path := fmt.Sprintf("/ip_pools/%s", url.PathEscape(id))
+	resp, err := c.makeRequest(ctx, APIIPPools, "PATCH", path, body)
+	if err != nil {
+		return errors.Wrap(err, "failed to update IP pool")
+	}
+
+	if err := c.handleResponse(resp, nil); err != nil {
+		return errors.Wrap(err, "failed to handle response")
+	}
+
+	return nil
+}
+
+// DeleteIPPool deletes a dedicated IP pool. Mailgun refuses to delete a
+// pool that domains are still assigned to.
+func (c *mailgunClient) DeleteIPPool(ctx context.Context, id string) error {
+	path := fmt.Sprintf("/ip_pools/%s", url.PathEscape(id))
+	resp, err := c.makeRequest(ctx, APIIPPools, "DELETE", path, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete IP pool")
+	}
+
+	if err := c.handleResponse(resp, nil); err != nil {
+		return errors.Wrap(err, "failed to handle response")
+	}
+
+	return nil
+}
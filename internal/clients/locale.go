@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultAcceptLanguage is the language Mailgun is asked to answer in, so
+// that error messages can be matched and are consistent for users.
+const DefaultAcceptLanguage = "en"
+
+// acceptLanguagePattern matches Accept-Language values such as "en",
+// "en-GB" or "de-DE, en;q=0.8"
+var acceptLanguagePattern = regexp.MustCompile(`^(\*|[A-Za-z]{1,8}(-[A-Za-z0-9]{1,8})*)(;q=[01](\.[0-9]{1,3})?)?(\s*,\s*(\*|[A-Za-z]{1,8}(-[A-Za-z0-9]{1,8})*)(;q=[01](\.[0-9]{1,3})?)?)*$`)
+
+var defaultAcceptLanguage = DefaultAcceptLanguage
+
+// ParseAcceptLanguage validates an Accept-Language header value
+func ParseAcceptLanguage(v string) (string, error) {
+	if !acceptLanguagePattern.MatchString(v) {
+		return "", errors.Errorf("invalid Accept-Language %q: must be a list of language tags such as en or en-GB", v)
+	}
+	return v, nil
+}
+
+// SetDefaultAcceptLanguage sets the Accept-Language sent when a
+// ProviderConfig does not specify one.
+func SetDefaultAcceptLanguage(v string) error {
+	lang, err := ParseAcceptLanguage(v)
+	if err != nil {
+		return err
+	}
+	defaultAcceptLanguage = lang
+	return nil
+}
+
+// setAcceptLanguage adds the Accept-Language header to req, using the
+// default when the client config does not set one
+func (c *mailgunClient) setAcceptLanguage(req *http.Request) {
+	lang := c.config.AcceptLanguage
+	if lang == "" {
+		lang = defaultAcceptLanguage
+	}
+	req.Header.Set("Accept-Language", lang)
+}
@@ -24,19 +24,26 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/pkg/errors"
 	bouncetypes "github.com/rossigee/provider-mailgun/apis/bounce/v1beta1"
+	complainttypes "github.com/rossigee/provider-mailgun/apis/complaint/v1beta1"
 	domaintypes "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+	ippooltypes "github.com/rossigee/provider-mailgun/apis/ippool/v1beta1"
 	mailinglisttypes "github.com/rossigee/provider-mailgun/apis/mailinglist/v1beta1"
+	mailinglistmembertypes "github.com/rossigee/provider-mailgun/apis/mailinglistmember/v1beta1"
 	routetypes "github.com/rossigee/provider-mailgun/apis/route/v1beta1"
 	smtpcredentialtypes "github.com/rossigee/provider-mailgun/apis/smtpcredential/v1beta1"
 	templatetypes "github.com/rossigee/provider-mailgun/apis/template/v1beta1"
+	unsubscribetypes "github.com/rossigee/provider-mailgun/apis/unsubscribe/v1beta1"
 	v1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
 	webhooktypes "github.com/rossigee/provider-mailgun/apis/webhook/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/metrics"
+	"github.com/rossigee/provider-mailgun/internal/readonly"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -58,6 +65,24 @@ type Client interface {
 	GetDomain(ctx context.Context, name string) (*domaintypes.DomainObservation, error)
 	UpdateDomain(ctx context.Context, name string, domain *domaintypes.DomainParameters) (*domaintypes.DomainObservation, error)
 	DeleteDomain(ctx context.Context, name string) error
+	ListDomains(ctx context.Context, limit, skip int) ([]*domaintypes.DomainObservation, int, error)
+	UpdateDomainTracking(ctx context.Context, name string, tracking *domaintypes.DomainTracking) error
+	GetDomainTracking(ctx context.Context, name string) (*domaintypes.DomainTracking, error)
+	GetDomainConnection(ctx context.Context, name string) (*domaintypes.DomainConnection, error)
+	UpdateDomainConnection(ctx context.Context, name string, connection *domaintypes.DomainConnection) error
+	UpdateDKIMKey(ctx context.Context, name string, keySize int) ([]domaintypes.DNSRecord, error)
+	ListDomainIPs(ctx context.Context, name string) ([]string, error)
+	AssignDomainIP(ctx context.Context, name, ip string) error
+	UnassignDomainIP(ctx context.Context, name, ip string) error
+
+	// Sandbox authorized recipient operations
+	ListAuthorizedRecipients(ctx context.Context) ([]domaintypes.AuthorizedRecipient, error)
+	AddAuthorizedRecipient(ctx context.Context, email string) (*domaintypes.AuthorizedRecipient, error)
+	DeleteAuthorizedRecipient(ctx context.Context, email string) error
+
+	// Webhook signing key operations
+	GetWebhookSigningKey(ctx context.Context) (string, error)
+	RotateWebhookSigningKey(ctx context.Context) (string, error)
 
 	// MailingList operations
 	CreateMailingList(ctx context.Context, list *mailinglisttypes.MailingListParameters) (*mailinglisttypes.MailingListObservation, error)
@@ -65,11 +90,20 @@ type Client interface {
 	UpdateMailingList(ctx context.Context, address string, list *mailinglisttypes.MailingListParameters) (*mailinglisttypes.MailingListObservation, error)
 	DeleteMailingList(ctx context.Context, address string) error
 
+	// MailingListMember operations
+	CreateMailingListMember(ctx context.Context, listAddress string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error)
+	GetMailingListMember(ctx context.Context, listAddress, address string) (*mailinglistmembertypes.MailingListMemberObservation, error)
+	UpdateMailingListMember(ctx context.Context, listAddress, address string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error)
+	DeleteMailingListMember(ctx context.Context, listAddress, address string) error
+	AddMailingListMembers(ctx context.Context, listAddress string, members []MailingListMemberSpec, upsert bool) error
+	ListMailingListMembers(ctx context.Context, listAddress string) ([]*mailinglistmembertypes.MailingListMemberObservation, error)
+
 	// Route operations
 	CreateRoute(ctx context.Context, route *routetypes.RouteParameters) (*routetypes.RouteObservation, error)
 	GetRoute(ctx context.Context, id string) (*routetypes.RouteObservation, error)
 	UpdateRoute(ctx context.Context, id string, route *routetypes.RouteParameters) (*routetypes.RouteObservation, error)
 	DeleteRoute(ctx context.Context, id string) error
+	ListRoutes(ctx context.Context, limit, skip int) ([]*routetypes.RouteObservation, int, error)
 
 	// Webhook operations
 	CreateWebhook(ctx context.Context, domain string, webhook *webhooktypes.WebhookParameters) (*webhooktypes.WebhookObservation, error)
@@ -88,21 +122,33 @@ type Client interface {
 	GetTemplate(ctx context.Context, domain, name string) (*templatetypes.TemplateObservation, error)
 	UpdateTemplate(ctx context.Context, domain, name string, template *templatetypes.TemplateParameters) (*templatetypes.TemplateObservation, error)
 	DeleteTemplate(ctx context.Context, domain, name string) error
+	CreateTemplateVersion(ctx context.Context, domain, name string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error)
+	GetTemplateVersion(ctx context.Context, domain, name, tag string) (*templatetypes.TemplateVersion, string, error)
+	UpdateTemplateVersion(ctx context.Context, domain, name, tag string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error)
+	DeleteTemplateVersion(ctx context.Context, domain, name, tag string) error
+	ListTemplateVersions(ctx context.Context, domain, name string) ([]templatetypes.TemplateVersion, error)
+	RenderTemplate(ctx context.Context, domain, name string, vars map[string]interface{}) (string, error)
 
 	// Bounce suppression operations
 	CreateBounce(ctx context.Context, domain string, bounce *bouncetypes.BounceParameters) (*bouncetypes.BounceObservation, error)
 	GetBounce(ctx context.Context, domain, address string) (*bouncetypes.BounceObservation, error)
 	DeleteBounce(ctx context.Context, domain, address string) error
 
-	// Complaint suppression operations (temporarily using interface until types exist)
-	CreateComplaint(ctx context.Context, domain string, complaint interface{}) (interface{}, error)
-	GetComplaint(ctx context.Context, domain, address string) (interface{}, error)
+	// Complaint suppression operations
+	CreateComplaint(ctx context.Context, domain string, complaint *complainttypes.ComplaintParameters) (*complainttypes.ComplaintObservation, error)
+	GetComplaint(ctx context.Context, domain, address string) (*complainttypes.ComplaintObservation, error)
 	DeleteComplaint(ctx context.Context, domain, address string) error
 
-	// Unsubscribe suppression operations (temporarily using interface until types exist)
-	CreateUnsubscribe(ctx context.Context, domain string, unsubscribe interface{}) (interface{}, error)
-	GetUnsubscribe(ctx context.Context, domain, address string) (interface{}, error)
-	DeleteUnsubscribe(ctx context.Context, domain, address string) error
+	// Unsubscribe suppression operations
+	CreateUnsubscribe(ctx context.Context, domain string, unsubscribe *unsubscribetypes.UnsubscribeParameters) (*unsubscribetypes.UnsubscribeObservation, error)
+	GetUnsubscribe(ctx context.Context, domain, address string) (*unsubscribetypes.UnsubscribeObservation, error)
+	DeleteUnsubscribe(ctx context.Context, domain, address, tag string) error
+
+	// Dedicated IP pool operations
+	CreateIPPool(ctx context.Context, pool *ippooltypes.IPPoolParameters) (*ippooltypes.IPPoolObservation, error)
+	GetIPPool(ctx context.Context, id string) (*ippooltypes.IPPoolObservation, error)
+	UpdateIPPool(ctx context.Context, id string, pool *ippooltypes.IPPoolParameters, add, remove []string) error
+	DeleteIPPool(ctx context.Context, id string) error
 }
 
 // Config holds the configuration for the Mailgun client
@@ -110,12 +156,29 @@ type Config struct {
 	APIKey     string
 	BaseURL    string
 	HTTPClient *http.Client
-}
 
-// Credentials represents the structure of the credentials secret
-type Credentials struct {
-	APIKey string `json:"api_key"`
-	Region string `json:"region,omitempty"`
+	// Region is the region of the account, RegionUS or RegionEU. It picks
+	// the endpoints the provider publishes, such as the SMTP server.
+	Region string
+
+	// ErrorVerbosity is ErrorVerbosityTerse or ErrorVerbosityVerbose.
+	ErrorVerbosity string
+
+	// ProxyURL is the HTTP(S) proxy requests are sent through. When nil the
+	// standard proxy environment variables apply.
+	ProxyURL *url.URL
+
+	// AcceptLanguage is sent as the Accept-Language header. When empty the
+	// provider default applies.
+	AcceptLanguage string
+
+	// DefaultPoolID is the IP pool new domains are assigned to when they do
+	// not specify one.
+	DefaultPoolID string
+
+	// APIVersions overrides the version of the Mailgun API used for each
+	// kind of call, e.g. "v4" for APIWebhooks.
+	APIVersions map[API]string
 }
 
 // mailgunClient implements the Client interface
@@ -126,23 +189,47 @@ type mailgunClient struct {
 // NewClient creates a new Mailgun client
 func NewClient(config *Config) Client {
 	if config.HTTPClient == nil {
-		transport := &http.Transport{
-			MaxIdleConns:        10,
-			IdleConnTimeout:     30 * time.Second,
-			DisableKeepAlives:   false, // Enable keep-alives with proper connection management
-			TLSHandshakeTimeout: 10 * time.Second,
-			DisableCompression:  false,
-			MaxIdleConnsPerHost: 2,    // Limit concurrent connections per host
-			ForceAttemptHTTP2:   true, // Enable HTTP/2 which works better with Mailgun
-		}
 		config.HTTPClient = &http.Client{
 			Timeout:   defaultTimeout,
-			Transport: transport,
+			Transport: sharedTransport(config),
 		}
 	}
 	return &mailgunClient{config: config}
 }
 
+var (
+	transportsMu sync.Mutex
+	transports   = map[string]*http.Transport{}
+)
+
+// sharedTransport returns the transport used by every client with the same
+// proxy, so that connections opened by one reconcile are reused by the next
+// rather than each client dialling and handshaking afresh.
+func sharedTransport(config *Config) *http.Transport {
+	key := ""
+	if config.ProxyURL != nil {
+		key = config.ProxyURL.String()
+	}
+
+	transportsMu.Lock()
+	defer transportsMu.Unlock()
+	if t, ok := transports[key]; ok {
+		return t
+	}
+	t := &http.Transport{
+		Proxy:               proxyFunc(config),
+		MaxIdleConns:        10,
+		IdleConnTimeout:     30 * time.Second,
+		DisableKeepAlives:   false, // Enable keep-alives with proper connection management
+		TLSHandshakeTimeout: 10 * time.Second,
+		DisableCompression:  false,
+		MaxIdleConnsPerHost: 2,    // Limit concurrent connections per host
+		ForceAttemptHTTP2:   true, // Enable HTTP/2 which works better with Mailgun
+	}
+	transports[key] = t
+	return t
+}
+
 // getProviderConfigReference extracts the provider config reference from a managed resource
 func getProviderConfigReference(mg resource.Managed) *xpv1.ProviderConfigReference {
 	// Type switch to handle different resource types and access their ProviderConfigReference
@@ -188,46 +275,143 @@ func UseProviderConfig(ctx context.Context, c client.Client, mg resource.Managed
 
 	// Note: ProviderConfig usage tracking is optional
 
-	data, err := resource.CommonCredentialExtractor(ctx, pc.Spec.Credentials.Source, c, pc.Spec.Credentials.CommonCredentialSelectors)
+	return ConfigFromProviderConfig(ctx, c, pc)
+}
+
+// defaultAPIKeyField is the field of JSON credentials holding the API key
+// when the ProviderConfig does not name another
+const defaultAPIKeyField = "api_key"
+
+// apiKeyFromCredentials returns the API key in data, which is either a JSON
+// object holding it in field or the raw key itself
+func apiKeyFromCredentials(data []byte, field string) (string, error) {
+	var creds map[string]interface{}
+	if err := json.Unmarshal(data, &creds); err != nil {
+		apiKey := strings.TrimSpace(string(data))
+		if apiKey == "" {
+			return "", errors.New("mailgun API key not found in credentials")
+		}
+		return apiKey, nil
+	}
+
+	apiKey, _ := creds[field].(string)
+	if apiKey == "" {
+		return "", errors.Errorf("mailgun API key not found in credentials: no %q field", field)
+	}
+	return apiKey, nil
+}
+
+// ConfigFromProviderConfig builds the client configuration described by a
+// ProviderConfig, reading its credentials.
+func ConfigFromProviderConfig(ctx context.Context, c client.Client, pc *v1beta1.ProviderConfig) (*Config, error) {
+	data, err := ExtractCredentials(ctx, c, pc.Spec.Credentials)
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot get credentials")
 	}
 
-	// Try to parse as JSON first (new format)
-	var creds Credentials
-	var apiKey string
-	if err := json.Unmarshal(data, &creds); err == nil && creds.APIKey != "" {
-		// JSON format with api_key field
-		apiKey = creds.APIKey
-	} else {
-		// Fall back to treating the entire data as the API key (legacy format)
-		apiKey = strings.TrimSpace(string(data))
-		if apiKey == "" {
-			return nil, errors.New("mailgun API key not found in credentials")
+	field := defaultAPIKeyField
+	if pc.Spec.Credentials.APIKeyField != nil && *pc.Spec.Credentials.APIKeyField != "" {
+		field = *pc.Spec.Credentials.APIKeyField
+	}
+	apiKey, err := apiKeyFromCredentials(data, field)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateAPIKey(apiKey); err != nil {
+		return nil, errors.Wrap(err, "invalid credentials")
+	}
+
+	baseURL, err := baseURLFor(pc)
+	if err != nil {
+		return nil, err
+	}
+
+	verbosity := defaultErrorVerbosity
+	if pc.Spec.ErrorVerbosity != nil {
+		if verbosity, err = ParseErrorVerbosity(*pc.Spec.ErrorVerbosity); err != nil {
+			return nil, err
 		}
 	}
 
-	if apiKey == "" {
-		return nil, errors.New("mailgun API key not found in credentials")
+	proxyURL := defaultProxyURL
+	if pc.Spec.ProxyURL != nil && *pc.Spec.ProxyURL != "" {
+		if proxyURL, err = ParseProxyURL(*pc.Spec.ProxyURL); err != nil {
+			return nil, err
+		}
 	}
 
-	baseURL := DefaultBaseURL
-	if pc.Spec.APIBaseURL != nil {
-		baseURL = *pc.Spec.APIBaseURL
-	} else if pc.Spec.Region != nil && *pc.Spec.Region == "EU" {
-		baseURL = EUBaseURL
+	var acceptLanguage string
+	if pc.Spec.AcceptLanguage != nil && *pc.Spec.AcceptLanguage != "" {
+		if acceptLanguage, err = ParseAcceptLanguage(*pc.Spec.AcceptLanguage); err != nil {
+			return nil, err
+		}
+	}
+
+	var defaultPoolID string
+	if pc.Spec.DefaultPoolID != nil {
+		defaultPoolID = *pc.Spec.DefaultPoolID
+	}
+
+	apiVersions, err := ParseAPIVersions(pc.Spec.APIVersions)
+	if err != nil {
+		return nil, err
 	}
 
 	return &Config{
-		APIKey:  apiKey,
-		BaseURL: baseURL,
+		APIKey:         apiKey,
+		BaseURL:        baseURL,
+		Region:         regionFor(pc, baseURL),
+		ErrorVerbosity: verbosity,
+		ProxyURL:       proxyURL,
+		AcceptLanguage: acceptLanguage,
+		DefaultPoolID:  defaultPoolID,
+		APIVersions:    apiVersions,
 	}, nil
 }
 
-// Helper method to make HTTP requests
-func (c *mailgunClient) makeRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
-	url := fmt.Sprintf("%s%s", c.config.BaseURL, path)
+// makeRequest makes an HTTP request for a call of kind api, against the
+// version of the Mailgun API that kind is served under
+func (c *mailgunClient) makeRequest(ctx context.Context, api API, method, path string, body io.Reader) (*http.Response, error) {
+	return c.makeRequestTo(ctx, method, c.apiURL(api, path), body)
+}
+
+// apiRoot strips a trailing version segment such as "/v3" from a base URL
+func apiRoot(baseURL string) string {
+	root := strings.TrimSuffix(baseURL, "/")
+	i := strings.LastIndex(root, "/v")
+	if i < 0 || i+2 == len(root) {
+		return root
+	}
+	for _, r := range root[i+2:] {
+		if r < '0' || r > '9' {
+			return root
+		}
+	}
+	return root[:i]
+}
+
+// makeRequestTo makes an HTTP request to an absolute URL
+func (c *mailgunClient) makeRequestTo(ctx context.Context, method, url string, body io.Reader) (*http.Response, error) {
+	// Observes may write too, e.g. while rotating keys, so read-only mode is
+	// also enforced here rather than only on Create, Update and Delete
+	if readonly.Enabled() && !isReadRequest(method, url) {
+		return nil, readonly.Refuse(method + " " + strings.TrimPrefix(url, apiRoot(c.config.BaseURL)))
+	}
+
+	return c.doRequest(ctx, method, url, body)
+}
 
+// isReadRequest reports whether a request leaves Mailgun unchanged. Template
+// rendering is a POST but only reads the template.
+func isReadRequest(method, url string) bool {
+	if method == http.MethodGet || method == http.MethodHead {
+		return true
+	}
+	return method == http.MethodPost && strings.HasSuffix(url, "/render")
+}
+
+// doRequest performs an HTTP request, retrying on 502 Bad Gateway
+func (c *mailgunClient) doRequest(ctx context.Context, method, url string, body io.Reader) (*http.Response, error) {
 	// Store the original body data for retries
 	var originalBodyData []byte
 	if body != nil {
@@ -247,21 +431,30 @@ func (c *mailgunClient) makeRequest(ctx context.Context, method, path string, bo
 		return nil, errors.Wrap(err, "failed to create request")
 	}
 
+	// Retries of a call share its correlation ID
+	requestID := newRequestID()
+	log := recordRequestID(ctx, requestID)
+
 	req.SetBasicAuth("api", c.config.APIKey)
 	req.Header.Set("User-Agent", "crossplane-provider-mailgun")
+	req.Header.Set(HeaderRequestID, requestID)
+	c.setAcceptLanguage(req)
 
 	if originalBodyData != nil {
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	}
 
-	// Retry logic for 502 Bad Gateway errors
+	// Retry 502 Bad Gateway errors, and 429s once Mailgun's rate limit resets
 	var resp *http.Response
+	var wait time.Duration
 	maxRetries := 3
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if attempt > 0 {
-			// Wait before retry with exponential backoff
-			waitTime := time.Duration(attempt) * 2 * time.Second
-			time.Sleep(waitTime)
+			select {
+			case <-ctx.Done():
+				return nil, errors.Wrap(ctx.Err(), "request cancelled while waiting to retry")
+			case <-time.After(wait):
+			}
 
 			// Recreate the request for retry using stored body data
 			var retryBody io.Reader
@@ -274,44 +467,75 @@ func (c *mailgunClient) makeRequest(ctx context.Context, method, path string, bo
 			}
 			req.SetBasicAuth("api", c.config.APIKey)
 			req.Header.Set("User-Agent", "crossplane-provider-mailgun")
+			req.Header.Set(HeaderRequestID, requestID)
+			c.setAcceptLanguage(req)
 			if originalBodyData != nil {
 				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 			}
 		}
 
+		// Wait longer before each retry
+		wait = time.Duration(attempt+1) * 2 * time.Second
+
 		resp, err = c.config.HTTPClient.Do(req)
 		if err != nil {
+			log.Debug("Mailgun API request failed", "method", method, "path", req.URL.Path, "attempt", attempt+1, "error", err)
 			if attempt == maxRetries {
 				return nil, errors.Wrap(err, "failed to execute request after retries")
 			}
 			continue
 		}
 
-		// If it's not a 502, return the response (success or other error)
-		if resp.StatusCode != 502 {
+		log.Debug("Mailgun API request", "method", method, "path", req.URL.Path, "attempt", attempt+1, "status", resp.StatusCode)
+
+		switch resp.StatusCode {
+		case http.StatusBadGateway:
+		case http.StatusTooManyRequests:
+			metrics.RecordRateLimitedRequest(endpointLabel(method, req.URL.Path))
+			if reset := retryAfter(resp.Header, time.Now()); reset > 0 {
+				wait = reset
+			}
+			// A limit that resets too late is left to the next reconcile
+			if wait > maxRateLimitWait || !waitFits(ctx, wait) {
+				return resp, nil
+			}
+		default:
 			return resp, nil
 		}
 
-		// If it's a 502 and we have retries left, close this response and try again
+		// Close this response and try again if we have retries left
 		if attempt < maxRetries {
 			_ = resp.Body.Close()
 			continue
 		}
 
-		// Max retries reached with 502, return the last response
+		// Max retries reached, return the last response
 		return resp, nil
 	}
 
 	return resp, nil
 }
 
+// maxRateLimitWait bounds how long a request waits for Mailgun's rate limit
+// to reset before the 429 is returned
+const maxRateLimitWait = 30 * time.Second
+
+// waitFits reports whether ctx is still live after waiting for d
+func waitFits(ctx context.Context, d time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > d
+}
+
 // Helper method to handle API responses
 func (c *mailgunClient) handleResponse(resp *http.Response, target interface{}) error {
 	defer func() { _ = resp.Body.Close() }()
+	reportDeprecation(resp)
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return errors.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		apiErr := newAPIError(resp, body, c.config.ErrorVerbosity)
+		metrics.RecordMailgunAPIError(endpointLabel(apiErr.Method, apiErr.Path), string(apiErr.Code()))
+		return apiErr
 	}
 
 	if target != nil {
@@ -327,17 +551,29 @@ func (c *mailgunClient) handleResponse(resp *http.Response, target interface{})
 func createFormData(params map[string]interface{}) string {
 	values := url.Values{}
 	for key, value := range params {
-		if value != nil {
-			values.Add(key, fmt.Sprintf("%v", value))
+		switch v := value.(type) {
+		case nil:
+		case []string:
+			// Repeated parameters, such as a route's actions
+			for _, item := range v {
+				values.Add(key, item)
+			}
+		default:
+			values.Add(key, fmt.Sprintf("%v", v))
 		}
 	}
 	return values.Encode()
 }
 
-// IsNotFound checks if an error represents a "not found" condition
+// IsNotFound checks if an error represents a "not found" condition, such as
+// Mailgun's 404 for an address that is not in a suppression table
 func IsNotFound(err error) bool {
 	if err == nil {
 		return false
 	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return true
+	}
 	return strings.Contains(err.Error(), "404") || strings.Contains(strings.ToLower(err.Error()), "not found")
 }
@@ -46,7 +46,7 @@ func (c *mailgunClient) CreateMailingList(ctx context.Context, list *mailinglist
 	}
 
 	body := strings.NewReader(createFormData(params))
-	resp, err := c.makeRequest(ctx, "POST", "/lists", body)
+	resp, err := c.makeRequest(ctx, APIMailingLists, "POST", "/lists", body)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create mailing list")
 	}
@@ -75,7 +75,7 @@ func (c *mailgunClient) CreateMailingList(ctx context.Context, list *mailinglist
 // GetMailingList retrieves a mailing list from Mailgun
 func (c *mailgunClient) GetMailingList(ctx context.Context, address string) (*mailinglisttypes.MailingListObservation, error) {
 	path := fmt.Sprintf("/lists/%s", url.PathEscape(address))
-	resp, err := c.makeRequest(ctx, "GET", path, nil)
+	resp, err := c.makeRequest(ctx, APIMailingLists, "GET", path, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get mailing list")
 	}
@@ -120,7 +120,7 @@ func (c *mailgunClient) UpdateMailingList(ctx context.Context, address string, l
 
 	body := strings.NewReader(createFormData(params))
 	path := fmt.Sprintf("/lists/%s", url.PathEscape(address))
-	resp, err := c.makeRequest(ctx, "PUT", path, body)
+	resp, err := c.makeRequest(ctx, APIMailingLists, "PUT", path, body)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to update mailing list")
 	}
@@ -132,6 +132,11 @@ func (c *mailgunClient) UpdateMailingList(ctx context.Context, address string, l
 		return nil, errors.Wrap(err, "failed to handle response")
 	}
 
+	// If result.List is nil, fetch the updated list
+	if result.List == nil {
+		return c.GetMailingList(ctx, address)
+	}
+
 	// Convert client MailingList to API MailingListObservation
 	observation := &mailinglisttypes.MailingListObservation{
 		Address:         result.List.Address,
@@ -149,7 +154,7 @@ func (c *mailgunClient) UpdateMailingList(ctx context.Context, address string, l
 // DeleteMailingList deletes a mailing list from Mailgun
 func (c *mailgunClient) DeleteMailingList(ctx context.Context, address string) error {
 	path := fmt.Sprintf("/lists/%s", url.PathEscape(address))
-	resp, err := c.makeRequest(ctx, "DELETE", path, nil)
+	resp, err := c.makeRequest(ctx, APIMailingLists, "DELETE", path, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to delete mailing list")
 	}
@@ -19,6 +19,7 @@ package clients
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -254,6 +255,34 @@ func TestUpdateMailingList(t *testing.T) {
 	assert.Equal(t, "members", result.AccessLevel)
 }
 
+func TestUpdateMailingListWithoutList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/lists/update@example.com", r.URL.Path)
+		if r.Method == "PUT" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"message": "Mailing list has been updated",
+			})
+			return
+		}
+		assert.Equal(t, "GET", r.Method)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"list": map[string]interface{}{
+				"address":          "update@example.com",
+				"reply_preference": "sender",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL + "/v3", HTTPClient: &http.Client{}})
+
+	result, err := client.UpdateMailingList(context.Background(), "update@example.com", &mailinglisttypes.MailingListParameters{
+		ReplyPreference: stringPtr("sender"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "sender", result.ReplyPreference, "the list should be read back when the update does not return it")
+}
+
 func TestDeleteMailingList(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -310,3 +339,117 @@ func TestDeleteMailingList(t *testing.T) {
 		})
 	}
 }
+
+func TestAddMailingListMembersInBatches(t *testing.T) {
+	var batches []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/v3/lists/big@example.com/members.json", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "yes", r.PostForm.Get("upsert"))
+
+		var members []MailingListMemberSpec
+		require.NoError(t, json.Unmarshal([]byte(r.PostForm.Get("members")), &members))
+		batches = append(batches, len(members))
+
+		// Fail the second batch only
+		if len(batches) == 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"message": "invalid member"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"message": "Mailing list has been updated"})
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL + "/v3", HTTPClient: &http.Client{}})
+
+	members := make([]MailingListMemberSpec, 2500)
+	for i := range members {
+		members[i] = MailingListMemberSpec{Address: fmt.Sprintf("user%d@example.org", i)}
+	}
+
+	err := client.AddMailingListMembers(context.Background(), "big@example.com", members, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to add members 1001 to 2000")
+	assert.NotContains(t, err.Error(), "members 1 to 1000")
+	assert.Equal(t, []int{1000, 1000, 500}, batches, "a failed batch should not stop the rest")
+}
+
+func TestMemberVarsUnmarshal(t *testing.T) {
+	cases := map[string]struct {
+		data string
+		want MemberVars
+	}{
+		"Strings": {data: `{"role":"admin","team":"ops"}`, want: MemberVars{"role": "admin", "team": "ops"}},
+		"Complex": {
+			data: `{"age": 26, "vip": true, "tags": ["a", "b"], "profile": {"city": "Oslo", "zip": null}, "none": null}`,
+			want: MemberVars{
+				"age":     "26",
+				"vip":     "true",
+				"tags":    `["a","b"]`,
+				"profile": `{"city":"Oslo","zip":null}`,
+				"none":    "",
+			},
+		},
+		"EncodedObject": {data: `"{\"role\":\"admin\",\"level\":3}"`, want: MemberVars{"role": "admin", "level": "3"}},
+		"Null":          {data: `null`},
+		"EmptyString":   {data: `""`},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var vars MemberVars
+			require.NoError(t, json.Unmarshal([]byte(tc.data), &vars))
+			assert.Equal(t, tc.want, vars)
+		})
+	}
+
+	var vars MemberVars
+	assert.Error(t, json.Unmarshal([]byte(`["not", "an", "object"]`), &vars))
+
+	// Vars sent by the provider read back unchanged
+	sent := map[string]string{"profile": `{"city":"Oslo"}`, "quote": `say "hi"`, "empty": ""}
+	encoded, err := json.Marshal(sent)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(encoded, &vars))
+	assert.Equal(t, MemberVars(sent), vars)
+}
+
+func TestListMailingListMembers(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/lists/team@example.com/members/pages", r.URL.Path)
+		queries = append(queries, r.URL.RawQuery)
+
+		// A full first page, then a short one
+		var items []map[string]interface{}
+		if r.URL.Query().Get("page") == "" {
+			for i := range memberPageSize {
+				items = append(items, map[string]interface{}{"address": fmt.Sprintf("user%03d@example.org", i), "subscribed": true})
+			}
+			items[0]["name"] = "Alice"
+			items[0]["vars"] = map[string]interface{}{"plan": "pro", "seats": 5, "billing": map[string]interface{}{"annual": true}}
+		} else {
+			items = append(items, map[string]interface{}{"address": "zed@example.org", "subscribed": false, "vars": map[string]interface{}{}})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"items": items, "paging": map[string]string{}})
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL + "/v3", HTTPClient: &http.Client{}})
+
+	members, err := client.ListMailingListMembers(context.Background(), "team@example.com")
+	require.NoError(t, err)
+	require.Len(t, members, memberPageSize+1)
+	assert.Equal(t, []string{"limit=100", "limit=100&page=next&address=user099%40example.org"}, queries)
+
+	alice := members[0]
+	assert.Equal(t, "Alice", alice.Name)
+	assert.True(t, alice.Subscribed)
+	assert.Equal(t, map[string]string{"plan": "pro", "seats": "5", "billing": `{"annual":true}`}, alice.Vars)
+
+	zed := members[memberPageSize]
+	assert.Equal(t, "zed@example.org", zed.Address)
+	assert.False(t, zed.Subscribed)
+	assert.Empty(t, zed.Vars)
+}
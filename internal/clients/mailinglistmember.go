@@ -0,0 +1,257 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	mailinglistmembertypes "github.com/rossigee/provider-mailgun/apis/mailinglistmember/v1beta1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// membersPath returns the path of a mailing list's members
+func membersPath(listAddress string) string {
+	return fmt.Sprintf("/lists/%s/members", url.PathEscape(listAddress))
+}
+
+// yesNo renders a flag the way Mailgun's member endpoints expect it
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// memberParams returns the form parameters that set a member's name, vars
+// and subscription
+func memberParams(member *mailinglistmembertypes.MailingListMemberParameters) (map[string]interface{}, error) {
+	params := map[string]interface{}{}
+
+	if member.Name != nil {
+		params["name"] = *member.Name
+	}
+	if member.Vars != nil {
+		vars, err := json.Marshal(member.Vars)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encode member vars")
+		}
+		params["vars"] = string(vars)
+	}
+	if member.Subscribed != nil {
+		params["subscribed"] = yesNo(*member.Subscribed)
+	}
+
+	return params, nil
+}
+
+// observation converts a client MailingListMember to an API
+// MailingListMemberObservation
+func (m *MailingListMember) observation() *mailinglistmembertypes.MailingListMemberObservation {
+	observation := &mailinglistmembertypes.MailingListMemberObservation{
+		Address: m.Address,
+		Vars:    m.Vars,
+		// Mailgun subscribes members unless told otherwise
+		Subscribed: m.Subscribed == nil || *m.Subscribed,
+	}
+	if m.Name != nil {
+		observation.Name = *m.Name
+	}
+	return observation
+}
+
+// CreateMailingListMember adds a member to a mailing list. With Upsert set an
+// existing member with the same address is updated instead.
+func (c *mailgunClient) CreateMailingListMember(ctx context.Context, listAddress string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	params, err := memberParams(member)
+	if err != nil {
+		return nil, err
+	}
+	params["address"] = member.Address
+	if member.Upsert != nil {
+		params["upsert"] = yesNo(*member.Upsert)
+	}
+
+	body := strings.NewReader(createFormData(params))
+	resp, err := c.makeRequest(ctx, APIMailingLists, "POST", membersPath(listAddress), body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create mailing list member")
+	}
+
+	var result struct {
+		Member *MailingListMember `json:"member"`
+	}
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, errors.Wrap(err, "failed to handle response")
+	}
+
+	if result.Member == nil {
+		return c.GetMailingListMember(ctx, listAddress, member.Address)
+	}
+
+	return result.Member.observation(), nil
+}
+
+// GetMailingListMember retrieves a member of a mailing list
+func (c *mailgunClient) GetMailingListMember(ctx context.Context, listAddress, address string) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	path := membersPath(listAddress) + "/" + url.PathEscape(address)
+	resp, err := c.makeRequest(ctx, APIMailingLists, "GET", path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get mailing list member")
+	}
+
+	var result struct {
+		Member *MailingListMember `json:"member"`
+	}
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, errors.Wrap(err, "failed to handle response")
+	}
+
+	if result.Member == nil {
+		return nil, errors.Errorf("member %s of mailing list %s not found (404)", address, listAddress)
+	}
+
+	return result.Member.observation(), nil
+}
+
+// memberPageSize is the number of members ListMailingListMembers requests
+// per page
+const memberPageSize = 100
+
+// membersPage is a page of the members of a mailing list
+type membersPage struct {
+	Items []MailingListMember `json:"items"`
+}
+
+// ListMailingListMembers returns every member of a mailing list, listing
+// all pages
+func (c *mailgunClient) ListMailingListMembers(ctx context.Context, listAddress string) ([]*mailinglistmembertypes.MailingListMemberObservation, error) {
+	path := membersPath(listAddress) + "/pages"
+
+	var members []*mailinglistmembertypes.MailingListMemberObservation
+	query := fmt.Sprintf("?limit=%d", memberPageSize)
+	for {
+		resp, err := c.makeRequest(ctx, APIMailingLists, "GET", path+query, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list mailing list members")
+		}
+
+		var page membersPage
+		if err := c.handleResponse(resp, &page); err != nil {
+			return nil, errors.Wrap(err, "failed to handle response")
+		}
+		for i := range page.Items {
+			members = append(members, page.Items[i].observation())
+		}
+		if len(page.Items) < memberPageSize {
+			return members, nil
+		}
+		query = fmt.Sprintf("?limit=%d&page=next&address=%s", memberPageSize, url.QueryEscape(page.Items[len(page.Items)-1].Address))
+	}
+}
+
+// UpdateMailingListMember updates the name, vars and subscription of a
+// member of a mailing list
+func (c *mailgunClient) UpdateMailingListMember(ctx context.Context, listAddress, address string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	params, err := memberParams(member)
+	if err != nil {
+		return nil, err
+	}
+
+	body := strings.NewReader(createFormData(params))
+	path := membersPath(listAddress) + "/" + url.PathEscape(address)
+	resp, err := c.makeRequest(ctx, APIMailingLists, "PUT", path, body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update mailing list member")
+	}
+
+	var result struct {
+		Member *MailingListMember `json:"member"`
+	}
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, errors.Wrap(err, "failed to handle response")
+	}
+
+	if result.Member == nil {
+		return c.GetMailingListMember(ctx, listAddress, address)
+	}
+
+	return result.Member.observation(), nil
+}
+
+// DeleteMailingListMember removes a member from a mailing list
+func (c *mailgunClient) DeleteMailingListMember(ctx context.Context, listAddress, address string) error {
+	path := membersPath(listAddress) + "/" + url.PathEscape(address)
+	resp, err := c.makeRequest(ctx, APIMailingLists, "DELETE", path, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete mailing list member")
+	}
+
+	if err := c.handleResponse(resp, nil); err != nil {
+		return errors.Wrap(err, "failed to handle response")
+	}
+
+	return nil
+}
+
+// maxMembersPerImport is the number of members Mailgun accepts in one bulk
+// import
+const maxMembersPerImport = 1000
+
+// AddMailingListMembers adds members to a mailing list in bulk, in as few
+// requests as Mailgun allows. With upsert set existing members with the same
+// addresses are updated instead. A failed batch does not stop the rest being
+// added; the errors of every failed batch are returned together.
+func (c *mailgunClient) AddMailingListMembers(ctx context.Context, listAddress string, members []MailingListMemberSpec, upsert bool) error {
+	var errs []error
+	for start := 0; start < len(members); start += maxMembersPerImport {
+		end := min(start+maxMembersPerImport, len(members))
+		if err := c.addMailingListMembers(ctx, listAddress, members[start:end], upsert); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to add members %d to %d", start+1, end))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// addMailingListMembers adds a single batch of members to a mailing list
+func (c *mailgunClient) addMailingListMembers(ctx context.Context, listAddress string, members []MailingListMemberSpec, upsert bool) error {
+	encoded, err := json.Marshal(members)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode members")
+	}
+
+	params := map[string]interface{}{
+		"members": string(encoded),
+		"upsert":  yesNo(upsert),
+	}
+
+	body := strings.NewReader(createFormData(params))
+	resp, err := c.makeRequest(ctx, APIMailingLists, "POST", membersPath(listAddress)+".json", body)
+	if err != nil {
+		return errors.Wrap(err, "failed to add mailing list members")
+	}
+
+	if err := c.handleResponse(resp, nil); err != nil {
+		return errors.Wrap(err, "failed to handle response")
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+var defaultProxyURL *url.URL
+
+// ParseProxyURL parses and validates an HTTP(S) proxy URL
+func ParseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid proxy URL %q", raw)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.Errorf("invalid proxy URL %q: scheme must be http or https", raw)
+	}
+	if u.Host == "" {
+		return nil, errors.Errorf("invalid proxy URL %q: missing host", raw)
+	}
+	return u, nil
+}
+
+// SetDefaultProxyURL sets the proxy used when a ProviderConfig does not
+// specify one. An empty string clears it.
+func SetDefaultProxyURL(raw string) error {
+	if raw == "" {
+		defaultProxyURL = nil
+		return nil
+	}
+	u, err := ParseProxyURL(raw)
+	if err != nil {
+		return err
+	}
+	defaultProxyURL = u
+	return nil
+}
+
+// proxyFunc returns the transport proxy function for a client config. Without
+// an explicit proxy the standard HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+// environment variables apply.
+func proxyFunc(config *Config) func(*http.Request) (*url.URL, error) {
+	if config.ProxyURL != nil {
+		return http.ProxyURL(config.ProxyURL)
+	}
+	return http.ProxyFromEnvironment
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	domaintypes "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+)
+
+// authorizedRecipientsPath is the account-wide sandbox recipients endpoint,
+// which lives under API v5
+const authorizedRecipientsPath = "/sandbox/auth_recipients"
+
+// ListAuthorizedRecipients lists the sandbox authorized recipients of the account
+func (c *mailgunClient) ListAuthorizedRecipients(ctx context.Context) ([]domaintypes.AuthorizedRecipient, error) {
+	resp, err := c.makeRequest(ctx, APIAuthorizedRecipients, "GET", authorizedRecipientsPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list authorized recipients: %w", err)
+	}
+
+	var result struct {
+		Recipients []AuthorizedRecipient `json:"recipients"`
+	}
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to handle response: %w", err)
+	}
+
+	recipients := make([]domaintypes.AuthorizedRecipient, 0, len(result.Recipients))
+	for _, r := range result.Recipients {
+		recipients = append(recipients, domaintypes.AuthorizedRecipient{Email: r.Email, Activated: r.Activated})
+	}
+	return recipients, nil
+}
+
+// AddAuthorizedRecipient adds a sandbox authorized recipient. Mailgun sends
+// the recipient a confirmation email; until it is confirmed the recipient is
+// not activated.
+func (c *mailgunClient) AddAuthorizedRecipient(ctx context.Context, email string) (*domaintypes.AuthorizedRecipient, error) {
+	params := map[string]interface{}{
+		"email": email,
+	}
+
+	body := strings.NewReader(createFormData(params))
+	resp, err := c.makeRequest(ctx, APIAuthorizedRecipients, "POST", authorizedRecipientsPath, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add authorized recipient: %w", err)
+	}
+
+	var result struct {
+		Recipient *AuthorizedRecipient `json:"recipient"`
+	}
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to handle response: %w", err)
+	}
+
+	if result.Recipient == nil {
+		return &domaintypes.AuthorizedRecipient{Email: email}, nil
+	}
+	return &domaintypes.AuthorizedRecipient{Email: result.Recipient.Email, Activated: result.Recipient.Activated}, nil
+}
+
+// DeleteAuthorizedRecipient removes a sandbox authorized recipient
+func (c *mailgunClient) DeleteAuthorizedRecipient(ctx context.Context, email string) error {
+	path := fmt.Sprintf("%s/%s", authorizedRecipientsPath, url.PathEscape(email))
+
+	resp, err := c.makeRequest(ctx, APIAuthorizedRecipients, "DELETE", path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete authorized recipient: %w", err)
+	}
+
+	if err := c.handleResponse(resp, nil); err != nil {
+		return fmt.Errorf("failed to handle response: %w", err)
+	}
+	return nil
+}
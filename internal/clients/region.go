@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
+)
+
+// Mailgun regions. Accounts are served by the API of the region they were
+// created in, and the other region's API answers 404 for their resources.
+const (
+	RegionUS = "US"
+	RegionEU = "EU"
+)
+
+// ParseRegion returns the canonical region for r, matched case-insensitively.
+func ParseRegion(r string) (string, error) {
+	switch {
+	case strings.EqualFold(r, RegionUS):
+		return RegionUS, nil
+	case strings.EqualFold(r, RegionEU):
+		return RegionEU, nil
+	default:
+		return "", errors.Errorf("invalid region %q: must be %s or %s", r, RegionUS, RegionEU)
+	}
+}
+
+// baseURLFor returns the base URL of the API requests for pc are sent to.
+// The region picks the API unless a base URL is set explicitly. The US base
+// URL does not count, as earlier versions of the ProviderConfig CRD defaulted
+// every ProviderConfig to it, EU ones included.
+func baseURLFor(pc *v1beta1.ProviderConfig) (string, error) {
+	region := RegionUS
+	if pc.Spec.Region != nil && *pc.Spec.Region != "" {
+		var err error
+		if region, err = ParseRegion(*pc.Spec.Region); err != nil {
+			return "", err
+		}
+	}
+
+	if pc.Spec.APIBaseURL != nil && *pc.Spec.APIBaseURL != "" && *pc.Spec.APIBaseURL != DefaultBaseURL {
+		return *pc.Spec.APIBaseURL, nil
+	}
+	if region == RegionEU {
+		return EUBaseURL, nil
+	}
+	return DefaultBaseURL, nil
+}
+
+// SMTP servers of each region
+const (
+	smtpHostUS = "smtp.mailgun.org"
+	smtpHostEU = "smtp.eu.mailgun.org"
+)
+
+// SMTPHost returns the SMTP server of region, that of the US when the region
+// is not known.
+func SMTPHost(region string) string {
+	if region == RegionEU {
+		return smtpHostEU
+	}
+	return smtpHostUS
+}
+
+// regionFor returns the region of the account pc connects to through
+// baseURL: the region pc sets or, when it sets none, EU for the EU API.
+func regionFor(pc *v1beta1.ProviderConfig, baseURL string) string {
+	if pc.Spec.Region != nil && *pc.Spec.Region != "" {
+		if region, err := ParseRegion(*pc.Spec.Region); err == nil {
+			return region
+		}
+	}
+	if apiRoot(baseURL) == apiRoot(EUBaseURL) {
+		return RegionEU
+	}
+	return RegionUS
+}
@@ -124,6 +124,79 @@ func TestRouteOperations(t *testing.T) {
 	}
 }
 
+func TestRouteActions(t *testing.T) {
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		sent = r.PostForm["action"]
+		_, _ = w.Write([]byte(`{"route": {
+			"id": "route_123",
+			"expression": "catch_all()",
+			"actions": ["forward(\"https://example.com/hook\")", "store(notify=\"https://example.com/notify\")", "stop()"]
+		}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL + "/v3", HTTPClient: &http.Client{}})
+
+	actions := []routetypes.RouteAction{
+		{Type: "forward", Destination: stringPtr("https://example.com/hook")},
+		{Type: "store", Notify: stringPtr("https://example.com/notify")},
+		{Type: "stop"},
+	}
+	route, err := client.CreateRoute(context.Background(), &routetypes.RouteParameters{Expression: "catch_all()", Actions: actions})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		`forward("https://example.com/hook")`,
+		`store(notify="https://example.com/notify")`,
+		`stop()`,
+	}, sent, "each action should be sent as its own parameter")
+	assert.Equal(t, actions, route.Actions, "actions returned by Mailgun should parse back to the desired ones")
+
+	// Earlier versions carried the notify URL of a store action in Destination
+	_, err = client.CreateRoute(context.Background(), &routetypes.RouteParameters{
+		Expression: "catch_all()",
+		Actions:    []routetypes.RouteAction{{Type: "store", Destination: stringPtr("https://example.com/notify")}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{`store(notify="https://example.com/notify")`}, sent)
+}
+
+func TestFindRoutesByExpression(t *testing.T) {
+	const total = 150
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/v3/routes", r.URL.Path)
+		var limit, skip int
+		_, _ = fmt.Sscan(r.URL.Query().Get("limit"), &limit)
+		_, _ = fmt.Sscan(r.URL.Query().Get("skip"), &skip)
+
+		items := []map[string]interface{}{}
+		for i := skip; i < total && i < skip+limit; i++ {
+			expression := fmt.Sprintf(`match_recipient("user%d@example.com")`, i)
+			if i%50 == 0 {
+				expression = `catch_all()`
+			}
+			items = append(items, map[string]interface{}{"id": fmt.Sprintf("route_%d", i), "expression": expression})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"total_count": total, "items": items})
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL + "/v3", HTTPClient: &http.Client{}})
+	matches, err := FindRoutesByExpression(context.Background(), client, " catch_all() ")
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(matches))
+	for _, m := range matches {
+		ids = append(ids, m.ID)
+	}
+	assert.Equal(t, []string{"route_0", "route_50", "route_100"}, ids)
+	assert.Equal(t, 2, requests, "all pages should be listed")
+}
+
 // Webhook Client Tests
 func TestWebhookOperations(t *testing.T) {
 	tests := []struct {
@@ -421,6 +494,131 @@ func TestTemplateOperations(t *testing.T) {
 	}
 }
 
+func TestCreateTemplateVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/v3/domains/example.com/templates/welcome/versions", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "handlebars", r.FormValue("engine"))
+		assert.Equal(t, "handlebars-2", r.FormValue("tag"))
+		assert.Equal(t, "yes", r.FormValue("active"))
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"message": "new version of the template has been stored",
+			"template": map[string]interface{}{
+				"name": "welcome",
+				"version": map[string]interface{}{
+					"tag":    "handlebars-2",
+					"engine": "handlebars",
+					"active": true,
+				},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL + "/v3", HTTPClient: &http.Client{}})
+
+	version, err := client.CreateTemplateVersion(context.Background(), "example.com", "welcome", &templatetypes.TemplateParameters{
+		Template: stringPtr("Hello {{name}}"),
+		Engine:   stringPtr("handlebars"),
+		Tag:      stringPtr("handlebars-2"),
+	}, true)
+	require.NoError(t, err)
+	assert.Equal(t, "handlebars-2", version.Tag)
+	assert.Equal(t, "handlebars", version.Engine)
+	assert.True(t, version.Active)
+}
+
+func TestListTemplateVersionsPages(t *testing.T) {
+	var pivots []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/v3/domains/example.com/templates/welcome/versions", r.URL.Path)
+		assert.Equal(t, "100", r.URL.Query().Get("limit"))
+		pivots = append(pivots, r.URL.Query().Get("p"))
+
+		// A full page of 100 versions, then a partial one
+		count := 100
+		if len(pivots) == 2 {
+			count = 3
+		}
+		versions := make([]map[string]interface{}, count)
+		for i := range versions {
+			versions[i] = map[string]interface{}{"tag": fmt.Sprintf("v%d-%d", len(pivots), i)}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"template": map[string]interface{}{"name": "welcome", "versions": versions},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL + "/v3", HTTPClient: &http.Client{}})
+
+	versions, err := client.ListTemplateVersions(context.Background(), "example.com", "welcome")
+	require.NoError(t, err)
+	assert.Len(t, versions, 103)
+	assert.Equal(t, []string{"", "v1-99"}, pivots, "the next page should start after the last tag")
+}
+
+func TestRenderTemplate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/v3/domains/example.com/templates/welcome/render", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		assert.JSONEq(t, `{"name":"Alice"}`, r.FormValue("variables"))
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"rendered": "<p>Hello Alice</p>",
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL + "/v3", HTTPClient: &http.Client{}})
+
+	rendered, err := client.RenderTemplate(context.Background(), "example.com", "welcome", map[string]interface{}{"name": "Alice"})
+	require.NoError(t, err)
+	assert.Equal(t, "<p>Hello Alice</p>", rendered)
+}
+
+func TestDeleteBounceMissing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/v3/example.com/bounces/user@example.org", r.URL.Path)
+
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"message": "Address not found in bounces table",
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL + "/v3", HTTPClient: &http.Client{}})
+
+	err := client.DeleteBounce(context.Background(), "example.com", "user@example.org")
+	require.Error(t, err)
+	assert.True(t, IsNotFound(err), "a missing bounce should be reported as not found")
+}
+
+func TestGetUnsubscribeCommaSeparatedTags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/v3/example.com/unsubscribes/user@example.org", r.URL.Path)
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"address": "user@example.org",
+			"tags":    "newsletter, promotions",
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL + "/v3", HTTPClient: &http.Client{}})
+
+	unsubscribe, err := client.GetUnsubscribe(context.Background(), "example.com", "user@example.org")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"newsletter", "promotions"}, unsubscribe.Tags)
+}
+
 // Error handling tests
 func TestErrorHandling(t *testing.T) {
 	tests := []struct {
@@ -675,3 +873,16 @@ func TestContextCancellation(t *testing.T) {
 		})
 	}
 }
+
+func TestDiffIPs(t *testing.T) {
+	add, remove := DiffIPs(
+		[]string{"10.0.0.3", "10.0.0.1", "10.0.0.3"},
+		[]string{"10.0.0.2", "10.0.0.1", "10.0.0.2"},
+	)
+	assert.Equal(t, []string{"10.0.0.3"}, add)
+	assert.Equal(t, []string{"10.0.0.2"}, remove)
+
+	add, remove = DiffIPs([]string{"10.0.0.1"}, []string{"10.0.0.1"})
+	assert.Empty(t, add)
+	assert.Empty(t, remove)
+}
@@ -0,0 +1,404 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	bouncetypes "github.com/rossigee/provider-mailgun/apis/bounce/v1beta1"
+	complainttypes "github.com/rossigee/provider-mailgun/apis/complaint/v1beta1"
+	domaintypes "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+	ippooltypes "github.com/rossigee/provider-mailgun/apis/ippool/v1beta1"
+	mailinglisttypes "github.com/rossigee/provider-mailgun/apis/mailinglist/v1beta1"
+	mailinglistmembertypes "github.com/rossigee/provider-mailgun/apis/mailinglistmember/v1beta1"
+	routetypes "github.com/rossigee/provider-mailgun/apis/route/v1beta1"
+	smtpcredentialtypes "github.com/rossigee/provider-mailgun/apis/smtpcredential/v1beta1"
+	templatetypes "github.com/rossigee/provider-mailgun/apis/template/v1beta1"
+	unsubscribetypes "github.com/rossigee/provider-mailgun/apis/unsubscribe/v1beta1"
+	webhooktypes "github.com/rossigee/provider-mailgun/apis/webhook/v1beta1"
+)
+
+// replayDir holds one recorded Mailgun exchange per Client method, named
+// after the method. Each fixture lists the requests the method is expected
+// to make, in order, with the responses to replay, and the JSON encoding of
+// the value the method should return.
+const replayDir = "testdata/replay"
+
+type replayFixture struct {
+	Exchanges []replayExchange `json:"exchanges"`
+	Expected  json.RawMessage  `json:"expected"`
+}
+
+type replayExchange struct {
+	Request struct {
+		Method string `json:"method"`
+		Path   string `json:"path"`
+		Query  string `json:"query,omitempty"`
+		// Form lists form values the request must carry; others are ignored
+		Form map[string][]string `json:"form,omitempty"`
+	} `json:"request"`
+	Response struct {
+		Status int             `json:"status"`
+		Body   json.RawMessage `json:"body"`
+	} `json:"response"`
+}
+
+// listResult combines the two results of ListDomains for comparison
+type listResult struct {
+	Items []*domaintypes.DomainObservation `json:"items"`
+	Total int                              `json:"total"`
+}
+
+// routeListResult combines the two results of ListRoutes for comparison
+type routeListResult struct {
+	Items []*routetypes.RouteObservation `json:"items"`
+	Total int                            `json:"total"`
+}
+
+// versionResult combines the two results of GetTemplateVersion
+type versionResult struct {
+	Version *templatetypes.TemplateVersion `json:"version"`
+	Content string                         `json:"content"`
+}
+
+// replayCalls invokes each Client method with the arguments its fixture was
+// recorded for.
+var replayCalls = map[string]func(ctx context.Context, c Client) (interface{}, error){
+	"CreateDomain": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.CreateDomain(ctx, &domaintypes.DomainParameters{Name: "mg.example.com", SpamAction: stringPtr("tag"), WebScheme: stringPtr("https")})
+	},
+	"GetDomain": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.GetDomain(ctx, "mg.example.com")
+	},
+	"UpdateDomain": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.UpdateDomain(ctx, "mg.example.com", &domaintypes.DomainParameters{Name: "mg.example.com", WebScheme: stringPtr("http")})
+	},
+	"DeleteDomain": func(ctx context.Context, c Client) (interface{}, error) {
+		return nil, c.DeleteDomain(ctx, "mg.example.com")
+	},
+	"ListDomains": func(ctx context.Context, c Client) (interface{}, error) {
+		items, total, err := c.ListDomains(ctx, 2, 0)
+		return listResult{Items: items, Total: total}, err
+	},
+	"GetDomainConnection": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.GetDomainConnection(ctx, "mg.example.com")
+	},
+	"UpdateDomainConnection": func(ctx context.Context, c Client) (interface{}, error) {
+		return nil, c.UpdateDomainConnection(ctx, "mg.example.com", &domaintypes.DomainConnection{RequireTLS: boolPtr(true)})
+	},
+	"UpdateDKIMKey": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.UpdateDKIMKey(ctx, "mg.example.com", 2048)
+	},
+	"ListDomainIPs": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.ListDomainIPs(ctx, "mg.example.com")
+	},
+	"AssignDomainIP": func(ctx context.Context, c Client) (interface{}, error) {
+		return nil, c.AssignDomainIP(ctx, "mg.example.com", "192.0.2.10")
+	},
+	"UnassignDomainIP": func(ctx context.Context, c Client) (interface{}, error) {
+		return nil, c.UnassignDomainIP(ctx, "mg.example.com", "192.0.2.10")
+	},
+	"GetDomainTracking": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.GetDomainTracking(ctx, "mg.example.com")
+	},
+	"UpdateDomainTracking": func(ctx context.Context, c Client) (interface{}, error) {
+		return nil, c.UpdateDomainTracking(ctx, "mg.example.com", &domaintypes.DomainTracking{Click: boolPtr(true), Open: boolPtr(false)})
+	},
+	"ListAuthorizedRecipients": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.ListAuthorizedRecipients(ctx)
+	},
+	"AddAuthorizedRecipient": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.AddAuthorizedRecipient(ctx, "bob@example.com")
+	},
+	"DeleteAuthorizedRecipient": func(ctx context.Context, c Client) (interface{}, error) {
+		return nil, c.DeleteAuthorizedRecipient(ctx, "bob@example.com")
+	},
+	"GetWebhookSigningKey": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.GetWebhookSigningKey(ctx)
+	},
+	"RotateWebhookSigningKey": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.RotateWebhookSigningKey(ctx)
+	},
+	"CreateMailingList": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.CreateMailingList(ctx, &mailinglisttypes.MailingListParameters{
+			Address:     "team@mg.example.com",
+			Name:        stringPtr("Team"),
+			AccessLevel: stringPtr("members"),
+		})
+	},
+	"GetMailingList": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.GetMailingList(ctx, "team@mg.example.com")
+	},
+	"UpdateMailingList": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.UpdateMailingList(ctx, "team@mg.example.com", &mailinglisttypes.MailingListParameters{
+			Address:     "team@mg.example.com",
+			Description: stringPtr("Engineering team"),
+		})
+	},
+	"DeleteMailingList": func(ctx context.Context, c Client) (interface{}, error) {
+		return nil, c.DeleteMailingList(ctx, "team@mg.example.com")
+	},
+	"CreateMailingListMember": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.CreateMailingListMember(ctx, "team@mg.example.com", &mailinglistmembertypes.MailingListMemberParameters{
+			Address:    "alice@example.org",
+			Name:       stringPtr("Alice"),
+			Vars:       map[string]string{"role": "admin"},
+			Subscribed: boolPtr(true),
+			Upsert:     boolPtr(true),
+		})
+	},
+	"GetMailingListMember": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.GetMailingListMember(ctx, "team@mg.example.com", "alice@example.org")
+	},
+	"UpdateMailingListMember": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.UpdateMailingListMember(ctx, "team@mg.example.com", "alice@example.org", &mailinglistmembertypes.MailingListMemberParameters{
+			Address:    "alice@example.org",
+			Subscribed: boolPtr(false),
+		})
+	},
+	"DeleteMailingListMember": func(ctx context.Context, c Client) (interface{}, error) {
+		return nil, c.DeleteMailingListMember(ctx, "team@mg.example.com", "alice@example.org")
+	},
+	"AddMailingListMembers": func(ctx context.Context, c Client) (interface{}, error) {
+		return nil, c.AddMailingListMembers(ctx, "team@mg.example.com", []MailingListMemberSpec{
+			{Address: "alice@example.org", Name: stringPtr("Alice"), Vars: map[string]string{"role": "admin"}},
+			{Address: "bob@example.org", Subscribed: boolPtr(false)},
+		}, true)
+	},
+	"ListMailingListMembers": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.ListMailingListMembers(ctx, "team@mg.example.com")
+	},
+	"CreateRoute": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.CreateRoute(ctx, &routetypes.RouteParameters{
+			Priority:   intPtr(10),
+			Expression: `match_recipient("support@mg.example.com")`,
+			Actions: []routetypes.RouteAction{
+				{Type: "forward", Destination: stringPtr("https://example.com/inbound")},
+				{Type: "stop"},
+			},
+		})
+	},
+	"GetRoute": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.GetRoute(ctx, "4f3bad2335335426750048c6")
+	},
+	"UpdateRoute": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.UpdateRoute(ctx, "4f3bad2335335426750048c6", &routetypes.RouteParameters{
+			Priority:   intPtr(5),
+			Expression: `match_recipient("support@mg.example.com")`,
+			Actions:    []routetypes.RouteAction{{Type: "store", Notify: stringPtr("https://example.com/notify")}},
+		})
+	},
+	"DeleteRoute": func(ctx context.Context, c Client) (interface{}, error) {
+		return nil, c.DeleteRoute(ctx, "4f3bad2335335426750048c6")
+	},
+	"ListRoutes": func(ctx context.Context, c Client) (interface{}, error) {
+		items, total, err := c.ListRoutes(ctx, 2, 0)
+		return routeListResult{Items: items, Total: total}, err
+	},
+	"CreateWebhook": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.CreateWebhook(ctx, "mg.example.com", &webhooktypes.WebhookParameters{EventType: "delivered", URL: "https://example.com/hooks/delivered"})
+	},
+	"GetWebhook": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.GetWebhook(ctx, "mg.example.com", "delivered")
+	},
+	"UpdateWebhook": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.UpdateWebhook(ctx, "mg.example.com", "delivered", &webhooktypes.WebhookParameters{EventType: "delivered", URL: "https://example.com/hooks/v2/delivered"})
+	},
+	"DeleteWebhook": func(ctx context.Context, c Client) (interface{}, error) {
+		return nil, c.DeleteWebhook(ctx, "mg.example.com", "delivered")
+	},
+	"CreateSMTPCredential": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.CreateSMTPCredential(ctx, "mg.example.com", &smtpcredentialtypes.SMTPCredentialParameters{Domain: "mg.example.com", Login: "mailer@mg.example.com"})
+	},
+	"GetSMTPCredential": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.GetSMTPCredential(ctx, "mg.example.com", "mailer@mg.example.com")
+	},
+	"UpdateSMTPCredential": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.UpdateSMTPCredential(ctx, "mg.example.com", "mailer@mg.example.com", "n3w-s3cret")
+	},
+	"DeleteSMTPCredential": func(ctx context.Context, c Client) (interface{}, error) {
+		return nil, c.DeleteSMTPCredential(ctx, "mg.example.com", "mailer@mg.example.com")
+	},
+	"CreateTemplate": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.CreateTemplate(ctx, "mg.example.com", &templatetypes.TemplateParameters{
+			Domain:   "mg.example.com",
+			Name:     "welcome",
+			Template: stringPtr("<p>Hello {{name}}</p>"),
+			Engine:   stringPtr("handlebars"),
+			Tag:      stringPtr("v1"),
+		})
+	},
+	"GetTemplate": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.GetTemplate(ctx, "mg.example.com", "welcome")
+	},
+	"UpdateTemplate": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.UpdateTemplate(ctx, "mg.example.com", "welcome", &templatetypes.TemplateParameters{
+			Domain:      "mg.example.com",
+			Name:        "welcome",
+			Description: stringPtr("Welcome email"),
+		})
+	},
+	"DeleteTemplate": func(ctx context.Context, c Client) (interface{}, error) {
+		return nil, c.DeleteTemplate(ctx, "mg.example.com", "welcome")
+	},
+	"CreateTemplateVersion": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.CreateTemplateVersion(ctx, "mg.example.com", "welcome", &templatetypes.TemplateParameters{
+			Template: stringPtr("<p>Hi {{name}}</p>"),
+			Tag:      stringPtr("v2"),
+		}, true)
+	},
+	"GetTemplateVersion": func(ctx context.Context, c Client) (interface{}, error) {
+		version, content, err := c.GetTemplateVersion(ctx, "mg.example.com", "welcome", "v2")
+		return versionResult{Version: version, Content: content}, err
+	},
+	"UpdateTemplateVersion": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.UpdateTemplateVersion(ctx, "mg.example.com", "welcome", "v2", &templatetypes.TemplateParameters{
+			Template: stringPtr("<p>Hello {{name}}</p>"),
+			Comment:  stringPtr("Final"),
+		}, true)
+	},
+	"DeleteTemplateVersion": func(ctx context.Context, c Client) (interface{}, error) {
+		return nil, c.DeleteTemplateVersion(ctx, "mg.example.com", "welcome", "v2")
+	},
+	"ListTemplateVersions": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.ListTemplateVersions(ctx, "mg.example.com", "welcome")
+	},
+	"RenderTemplate": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.RenderTemplate(ctx, "mg.example.com", "welcome", map[string]interface{}{"name": "Alice"})
+	},
+	"CreateBounce": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.CreateBounce(ctx, "mg.example.com", &bouncetypes.BounceParameters{Address: "bounced@example.org", Code: stringPtr("550")})
+	},
+	"GetBounce": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.GetBounce(ctx, "mg.example.com", "bounced@example.org")
+	},
+	"DeleteBounce": func(ctx context.Context, c Client) (interface{}, error) {
+		return nil, c.DeleteBounce(ctx, "mg.example.com", "bounced@example.org")
+	},
+	"CreateComplaint": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.CreateComplaint(ctx, "mg.example.com", &complainttypes.ComplaintParameters{Address: "angry@example.org"})
+	},
+	"GetComplaint": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.GetComplaint(ctx, "mg.example.com", "angry@example.org")
+	},
+	"DeleteComplaint": func(ctx context.Context, c Client) (interface{}, error) {
+		return nil, c.DeleteComplaint(ctx, "mg.example.com", "angry@example.org")
+	},
+	"CreateUnsubscribe": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.CreateUnsubscribe(ctx, "mg.example.com", &unsubscribetypes.UnsubscribeParameters{Address: "gone@example.org", Tag: stringPtr("newsletter")})
+	},
+	"GetUnsubscribe": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.GetUnsubscribe(ctx, "mg.example.com", "gone@example.org")
+	},
+	"DeleteUnsubscribe": func(ctx context.Context, c Client) (interface{}, error) {
+		return nil, c.DeleteUnsubscribe(ctx, "mg.example.com", "gone@example.org", "newsletter")
+	},
+	"CreateIPPool": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.CreateIPPool(ctx, &ippooltypes.IPPoolParameters{Name: "warmup", Description: stringPtr("New IPs warming up"), IPs: []string{"192.0.2.10", "192.0.2.11"}})
+	},
+	"GetIPPool": func(ctx context.Context, c Client) (interface{}, error) {
+		return c.GetIPPool(ctx, "60140bc1fee3e84dec5abeeb")
+	},
+	"UpdateIPPool": func(ctx context.Context, c Client) (interface{}, error) {
+		return nil, c.UpdateIPPool(ctx, "60140bc1fee3e84dec5abeeb", &ippooltypes.IPPoolParameters{Name: "warmup"}, []string{"192.0.2.12"}, []string{"192.0.2.10"})
+	},
+	"DeleteIPPool": func(ctx context.Context, c Client) (interface{}, error) {
+		return nil, c.DeleteIPPool(ctx, "60140bc1fee3e84dec5abeeb")
+	},
+}
+
+func TestReplayCoversClient(t *testing.T) {
+	var missing []string
+	client := reflect.TypeOf((*Client)(nil)).Elem()
+	for i := 0; i < client.NumMethod(); i++ {
+		name := client.Method(i).Name
+		if _, ok := replayCalls[name]; !ok {
+			missing = append(missing, name)
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(replayDir, name+".json")); err != nil {
+			missing = append(missing, name+" (fixture)")
+		}
+	}
+	sort.Strings(missing)
+	assert.Empty(t, missing, "every Client method needs a replay call and a fixture in %s", replayDir)
+}
+
+func TestReplay(t *testing.T) {
+	for name, call := range replayCalls {
+		t.Run(name, func(t *testing.T) {
+			raw, err := os.ReadFile(filepath.Join(replayDir, name+".json"))
+			require.NoError(t, err)
+			var fixture replayFixture
+			require.NoError(t, json.Unmarshal(raw, &fixture))
+
+			next := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if next >= len(fixture.Exchanges) {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				ex := fixture.Exchanges[next]
+				next++
+
+				assert.Equal(t, ex.Request.Method, r.Method)
+				assert.Equal(t, ex.Request.Path, r.URL.Path)
+				assert.Equal(t, ex.Request.Query, r.URL.RawQuery)
+				if len(ex.Request.Form) > 0 {
+					require.NoError(t, r.ParseForm())
+					for key, values := range ex.Request.Form {
+						assert.Equal(t, values, r.PostForm[key], "form value %q", key)
+					}
+				}
+
+				status := ex.Response.Status
+				if status == 0 {
+					status = http.StatusOK
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(status)
+				_, _ = w.Write(ex.Response.Body)
+			}))
+			defer server.Close()
+
+			client := NewClient(&Config{APIKey: "test-key", BaseURL: server.URL + "/v3", HTTPClient: &http.Client{}})
+			got, err := call(context.Background(), client)
+			require.NoError(t, err)
+			assert.Equal(t, len(fixture.Exchanges), next, "every recorded exchange should be replayed")
+
+			encoded, err := json.Marshal(got)
+			require.NoError(t, err)
+			expected := string(fixture.Expected)
+			if expected == "" {
+				expected = "null"
+			}
+			assert.JSONEq(t, expected, string(encoded))
+		})
+	}
+}
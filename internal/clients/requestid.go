@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rossigee/provider-mailgun/internal/tracing"
+)
+
+// HeaderRequestID carries the correlation ID of an API call. It is quoted
+// in provider logs and traces so a call can be matched to Mailgun's records
+// when raising a support ticket.
+const HeaderRequestID = "X-Request-ID"
+
+var requestLogger = logging.NewNopLogger()
+
+// SetLogger sets the logger API calls are logged to at debug level.
+func SetLogger(l logging.Logger) {
+	requestLogger = l
+}
+
+// newRequestID returns a random 128-bit correlation ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// recordRequestID attaches the correlation ID of an API call to the current
+// span, if any, and returns a logger carrying it.
+func recordRequestID(ctx context.Context, requestID string) logging.Logger {
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String(tracing.AttrRequestID, requestID))
+	return requestLogger.WithValues("request-id", requestID)
+}
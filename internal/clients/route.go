@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -37,11 +38,71 @@ func convertRouteActions(clientActions []RouteAction) []routetypes.RouteAction {
 		apiActions[i] = routetypes.RouteAction{
 			Type:        action.Type,
 			Destination: action.Destination,
+			Notify:      action.Notify,
 		}
 	}
 	return apiActions
 }
 
+// formatRouteActions renders actions in Mailgun's expression syntax, e.g.
+// forward("https://example.com/hook"), store(notify="https://...") or stop()
+func formatRouteActions(actions []routetypes.RouteAction) []string {
+	formatted := make([]string, len(actions))
+	for i, action := range actions {
+		var arg string
+		switch {
+		case action.Type == "store":
+			if notify := StoreNotifyURL(action); notify != "" {
+				arg = "notify=" + strconv.Quote(notify)
+			}
+		case action.Destination != nil && *action.Destination != "":
+			arg = strconv.Quote(*action.Destination)
+		}
+		formatted[i] = fmt.Sprintf("%s(%s)", action.Type, arg)
+	}
+	return formatted
+}
+
+// StoreNotifyURL returns the notify URL of a store action, falling back to
+// its Destination as earlier versions of the provider used that field.
+func StoreNotifyURL(action routetypes.RouteAction) string {
+	if action.Notify != nil {
+		return *action.Notify
+	}
+	if action.Destination != nil {
+		return *action.Destination
+	}
+	return ""
+}
+
+// parseRouteAction parses an action in Mailgun's expression syntax. It is
+// the inverse of formatRouteActions.
+func parseRouteAction(s string) RouteAction {
+	s = strings.TrimSpace(s)
+	open := strings.Index(s, "(")
+	if open < 0 || !strings.HasSuffix(s, ")") {
+		return RouteAction{Type: s}
+	}
+
+	action := RouteAction{Type: strings.TrimSpace(s[:open])}
+	arg := strings.TrimSpace(s[open+1 : len(s)-1])
+	notify := strings.HasPrefix(arg, "notify=")
+	arg = strings.TrimSpace(strings.TrimPrefix(arg, "notify="))
+	if unquoted, err := strconv.Unquote(arg); err == nil {
+		arg = unquoted
+	} else {
+		arg = strings.Trim(arg, `"'`)
+	}
+	switch {
+	case arg == "":
+	case notify:
+		action.Notify = &arg
+	default:
+		action.Destination = &arg
+	}
+	return action
+}
+
 // CreateRoute creates a new route in Mailgun
 func (c *mailgunClient) CreateRoute(ctx context.Context, route *routetypes.RouteParameters) (*routetypes.RouteObservation, error) {
 	params := map[string]interface{}{
@@ -55,21 +116,13 @@ func (c *mailgunClient) CreateRoute(ctx context.Context, route *routetypes.Route
 		params["description"] = *route.Description
 	}
 
-	// Convert actions to string array format
+	// Mailgun expects one action parameter per action, in order
 	if len(route.Actions) > 0 {
-		actionStrs := make([]string, len(route.Actions))
-		for i, action := range route.Actions {
-			if action.Destination != nil {
-				actionStrs[i] = fmt.Sprintf("%s(\"%s\")", action.Type, *action.Destination)
-			} else {
-				actionStrs[i] = action.Type
-			}
-		}
-		params["action"] = strings.Join(actionStrs, ",")
+		params["action"] = formatRouteActions(route.Actions)
 	}
 
 	body := strings.NewReader(createFormData(params))
-	resp, err := c.makeRequest(ctx, "POST", "/routes", body)
+	resp, err := c.makeRequest(ctx, APIRoutes, "POST", "/routes", body)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create route")
 	}
@@ -97,7 +150,7 @@ func (c *mailgunClient) CreateRoute(ctx context.Context, route *routetypes.Route
 // GetRoute retrieves a route from Mailgun
 func (c *mailgunClient) GetRoute(ctx context.Context, id string) (*routetypes.RouteObservation, error) {
 	path := fmt.Sprintf("/routes/%s", url.PathEscape(id))
-	resp, err := c.makeRequest(ctx, "GET", path, nil)
+	resp, err := c.makeRequest(ctx, APIRoutes, "GET", path, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get route")
 	}
@@ -135,22 +188,14 @@ func (c *mailgunClient) UpdateRoute(ctx context.Context, id string, route *route
 		params["description"] = *route.Description
 	}
 
-	// Convert actions to string array format
+	// Mailgun expects one action parameter per action, in order
 	if len(route.Actions) > 0 {
-		actionStrs := make([]string, len(route.Actions))
-		for i, action := range route.Actions {
-			if action.Destination != nil {
-				actionStrs[i] = fmt.Sprintf("%s(\"%s\")", action.Type, *action.Destination)
-			} else {
-				actionStrs[i] = action.Type
-			}
-		}
-		params["action"] = strings.Join(actionStrs, ",")
+		params["action"] = formatRouteActions(route.Actions)
 	}
 
 	body := strings.NewReader(createFormData(params))
 	path := fmt.Sprintf("/routes/%s", url.PathEscape(id))
-	resp, err := c.makeRequest(ctx, "PUT", path, body)
+	resp, err := c.makeRequest(ctx, APIRoutes, "PUT", path, body)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to update route")
 	}
@@ -178,7 +223,7 @@ func (c *mailgunClient) UpdateRoute(ctx context.Context, id string, route *route
 // DeleteRoute deletes a route from Mailgun
 func (c *mailgunClient) DeleteRoute(ctx context.Context, id string) error {
 	path := fmt.Sprintf("/routes/%s", url.PathEscape(id))
-	resp, err := c.makeRequest(ctx, "DELETE", path, nil)
+	resp, err := c.makeRequest(ctx, APIRoutes, "DELETE", path, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to delete route")
 	}
@@ -189,3 +234,65 @@ func (c *mailgunClient) DeleteRoute(ctx context.Context, id string) error {
 
 	return nil
 }
+
+// routePageSize is the number of routes FindRoutesByExpression requests per
+// page
+const routePageSize = 100
+
+// ListRoutes returns a page of routes along with the total number of routes
+// in the account
+func (c *mailgunClient) ListRoutes(ctx context.Context, limit, skip int) ([]*routetypes.RouteObservation, int, error) {
+	path := fmt.Sprintf("/routes?limit=%d&skip=%d", limit, skip)
+	resp, err := c.makeRequest(ctx, APIRoutes, "GET", path, nil)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to list routes")
+	}
+
+	var result struct {
+		TotalCount int     `json:"total_count"`
+		Items      []Route `json:"items"`
+	}
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, 0, errors.Wrap(err, "failed to handle response")
+	}
+
+	routes := make([]*routetypes.RouteObservation, 0, len(result.Items))
+	for i := range result.Items {
+		r := &result.Items[i]
+		routes = append(routes, &routetypes.RouteObservation{
+			ID:          r.ID,
+			Expression:  r.Expression,
+			Priority:    r.Priority,
+			Description: r.Description,
+			Actions:     convertRouteActions(r.Actions),
+			CreatedAt:   r.CreatedAt,
+		})
+	}
+
+	return routes, result.TotalCount, nil
+}
+
+// FindRoutesByExpression returns every route whose expression matches the
+// supplied one, ignoring surrounding whitespace. Mailgun does not filter
+// routes server-side, so all pages are listed.
+func FindRoutesByExpression(ctx context.Context, c Client, expression string) ([]*routetypes.RouteObservation, error) {
+	expression = strings.TrimSpace(expression)
+
+	var matches []*routetypes.RouteObservation
+	seen := 0
+	for skip := 0; ; skip += routePageSize {
+		page, total, err := c.ListRoutes(ctx, routePageSize, skip)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range page {
+			if strings.TrimSpace(r.Expression) == expression {
+				matches = append(matches, r)
+			}
+		}
+		seen += len(page)
+		if len(page) < routePageSize || seen >= total {
+			return matches, nil
+		}
+	}
+}
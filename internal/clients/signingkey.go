@@ -0,0 +1,59 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"fmt"
+)
+
+// webhookSigningKeyPath is the account-wide HTTP webhook signing key
+// endpoint, which lives under API v5
+const webhookSigningKeyPath = "/accounts/http_signing_key"
+
+type signingKeyResponse struct {
+	HTTPSigningKey string `json:"http_signing_key"`
+}
+
+// GetWebhookSigningKey returns the key Mailgun signs webhook requests with
+func (c *mailgunClient) GetWebhookSigningKey(ctx context.Context) (string, error) {
+	resp, err := c.makeRequest(ctx, APIWebhookSigningKey, "GET", webhookSigningKeyPath, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get webhook signing key: %w", err)
+	}
+
+	var result signingKeyResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return "", fmt.Errorf("failed to handle response: %w", err)
+	}
+	return result.HTTPSigningKey, nil
+}
+
+// RotateWebhookSigningKey replaces the webhook signing key with a newly
+// generated one and returns it. The previous key stops working immediately.
+func (c *mailgunClient) RotateWebhookSigningKey(ctx context.Context) (string, error) {
+	resp, err := c.makeRequest(ctx, APIWebhookSigningKey, "POST", webhookSigningKeyPath, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to rotate webhook signing key: %w", err)
+	}
+
+	var result signingKeyResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return "", fmt.Errorf("failed to handle response: %w", err)
+	}
+	return result.HTTPSigningKey, nil
+}
@@ -59,17 +59,19 @@ func (c *mailgunClient) CreateSMTPCredential(ctx context.Context, domain string,
 	// If no password provided, Mailgun will generate one
 
 	body := strings.NewReader(createFormData(params))
-	resp, err := c.makeRequest(ctx, "POST", path, body)
+	resp, err := c.makeRequest(ctx, APISMTPCredentials, "POST", path, body)
+	c.forgetCoalesced(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create SMTP credential: %w", err)
 	}
 
 	// Read the response body once
 	defer func() { _ = resp.Body.Close() }()
+	reportDeprecation(resp)
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, body, c.config.ErrorVerbosity)
 	}
 
 	// Read response body
@@ -131,20 +133,27 @@ func (c *mailgunClient) GetSMTPCredential(ctx context.Context, domain, login str
 	// List all credentials and find the matching one
 	path := fmt.Sprintf("/domains/%s/credentials", url.PathEscape(domain))
 
-	resp, err := c.makeRequest(ctx, "GET", path, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get SMTP credentials: %w", err)
-	}
+	// The list is shared by every credential of the domain
+	shared, err := c.coalesced(ctx, path, func(ctx context.Context) (interface{}, error) {
+		resp, err := c.makeRequest(ctx, APISMTPCredentials, "GET", path, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get SMTP credentials: %w", err)
+		}
 
-	var result struct {
-		Items []SMTPCredential `json:"items"`
-	}
-	if err := c.handleResponse(resp, &result); err != nil {
-		return nil, fmt.Errorf("failed to handle response: %w", err)
+		var result struct {
+			Items []SMTPCredential `json:"items"`
+		}
+		if err := c.handleResponse(resp, &result); err != nil {
+			return nil, fmt.Errorf("failed to handle response: %w", err)
+		}
+		return result.Items, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Find the credential with matching login
-	for _, cred := range result.Items {
+	for _, cred := range shared.([]SMTPCredential) {
 		if cred.Login == login {
 			return convertSMTPCredentialToObservation(&cred), nil
 		}
@@ -162,7 +171,8 @@ func (c *mailgunClient) UpdateSMTPCredential(ctx context.Context, domain, login
 	}
 
 	body := strings.NewReader(createFormData(params))
-	resp, err := c.makeRequest(ctx, "PUT", path, body)
+	resp, err := c.makeRequest(ctx, APISMTPCredentials, "PUT", path, body)
+	c.forgetCoalesced(fmt.Sprintf("/domains/%s/credentials", url.PathEscape(domain)))
 	if err != nil {
 		return nil, fmt.Errorf("failed to update SMTP credential: %w", err)
 	}
@@ -183,7 +193,8 @@ func (c *mailgunClient) UpdateSMTPCredential(ctx context.Context, domain, login
 func (c *mailgunClient) DeleteSMTPCredential(ctx context.Context, domain, login string) error {
 	path := fmt.Sprintf("/domains/%s/credentials/%s", url.PathEscape(domain), url.PathEscape(login))
 
-	resp, err := c.makeRequest(ctx, "DELETE", path, nil)
+	resp, err := c.makeRequest(ctx, APISMTPCredentials, "DELETE", path, nil)
+	c.forgetCoalesced(fmt.Sprintf("/domains/%s/credentials", url.PathEscape(domain)))
 	if err != nil {
 		return fmt.Errorf("failed to delete SMTP credential: %w", err)
 	}
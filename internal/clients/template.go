@@ -18,6 +18,7 @@ package clients
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
@@ -50,7 +51,7 @@ func (c *mailgunClient) CreateTemplate(ctx context.Context, domain string, templ
 	}
 
 	body := strings.NewReader(createFormData(params))
-	resp, err := c.makeRequest(ctx, "POST", path, body)
+	resp, err := c.makeRequest(ctx, APITemplates, "POST", path, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create template: %w", err)
 	}
@@ -79,7 +80,7 @@ func (c *mailgunClient) GetTemplate(ctx context.Context, domain, name string) (*
 	path := fmt.Sprintf("/domains/%s/templates/%s", url.PathEscape(domain), url.PathEscape(name))
 
 	// Request with active flag to get the active version content
-	resp, err := c.makeRequest(ctx, "GET", path+"?active=yes", nil)
+	resp, err := c.makeRequest(ctx, APITemplates, "GET", path+"?active=yes", nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get template: %w", err)
 	}
@@ -93,11 +94,15 @@ func (c *mailgunClient) GetTemplate(ctx context.Context, domain, name string) (*
 
 	// Convert client Template to API TemplateObservation
 	observation := &templatetypes.TemplateObservation{
-		Name:         result.Template.Name,
-		Description:  result.Template.Description,
-		CreatedAt:    result.Template.CreatedAt,
-		CreatedBy:    result.Template.CreatedBy,
-		VersionCount: len(result.Template.Versions),
+		Name:          result.Template.Name,
+		Description:   result.Template.Description,
+		CreatedAt:     result.Template.CreatedAt,
+		CreatedBy:     result.Template.CreatedBy,
+		VersionCount:  len(result.Template.Versions),
+		ActiveVersion: convertTemplateVersion(result.Template.Version),
+	}
+	if result.Template.Version != nil {
+		observation.ActiveContent = result.Template.Version.Template
 	}
 
 	return observation, nil
@@ -115,7 +120,7 @@ func (c *mailgunClient) UpdateTemplate(ctx context.Context, domain, name string,
 	}
 
 	body := strings.NewReader(createFormData(params))
-	resp, err := c.makeRequest(ctx, "PUT", path, body)
+	resp, err := c.makeRequest(ctx, APITemplates, "PUT", path, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update template: %w", err)
 	}
@@ -149,7 +154,7 @@ func (c *mailgunClient) UpdateTemplate(ctx context.Context, domain, name string,
 func (c *mailgunClient) DeleteTemplate(ctx context.Context, domain, name string) error {
 	path := fmt.Sprintf("/domains/%s/templates/%s", url.PathEscape(domain), url.PathEscape(name))
 
-	resp, err := c.makeRequest(ctx, "DELETE", path, nil)
+	resp, err := c.makeRequest(ctx, APITemplates, "DELETE", path, nil)
 	if err != nil {
 		return fmt.Errorf("failed to delete template: %w", err)
 	}
@@ -162,3 +167,231 @@ func (c *mailgunClient) DeleteTemplate(ctx context.Context, domain, name string)
 
 	return nil
 }
+
+// CreateTemplateVersion adds a new version to an existing template. When
+// active is true the new version becomes the one used for sending.
+func (c *mailgunClient) CreateTemplateVersion(ctx context.Context, domain, name string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	path := fmt.Sprintf("/domains/%s/templates/%s/versions", url.PathEscape(domain), url.PathEscape(name))
+
+	params := map[string]interface{}{}
+	if version.Template != nil {
+		params["template"] = *version.Template
+	}
+	if version.Tag != nil {
+		params["tag"] = *version.Tag
+	}
+	if version.Engine != nil {
+		params["engine"] = *version.Engine
+	}
+	if version.Comment != nil {
+		params["comment"] = *version.Comment
+	}
+	if active {
+		params["active"] = "yes"
+	}
+
+	body := strings.NewReader(createFormData(params))
+	resp, err := c.makeRequest(ctx, APITemplates, "POST", path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create template version: %w", err)
+	}
+
+	var result struct {
+		Template *Template `json:"template"`
+	}
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to handle response: %w", err)
+	}
+
+	if result.Template == nil || result.Template.Version == nil {
+		return nil, fmt.Errorf("template version response for %s did not include a version", name)
+	}
+
+	return convertTemplateVersion(result.Template.Version), nil
+}
+
+// CreateVersion adds a version to a template that must already exist.
+// Mailgun makes the first version of a template the active one, so the
+// template is never created for a version, which could then neither stay
+// inactive nor take the template with it when deleted.
+func CreateVersion(ctx context.Context, c Client, domain, name string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	created, err := c.CreateTemplateVersion(ctx, domain, name, version, active)
+	if err != nil && IsNotFound(err) {
+		return nil, fmt.Errorf("template %s does not exist on domain %s: %w", name, domain, err)
+	}
+	return created, err
+}
+
+// VersionUpToDate reports whether a version has the desired content and
+// comment and, when activate is set, is active. A nil content or comment is
+// not managed. The engine of a version cannot be changed, so it is not
+// compared.
+func VersionUpToDate(version *templatetypes.TemplateVersion, content string, template, comment *string, activate bool) bool {
+	if template != nil && *template != content {
+		return false
+	}
+	if comment != nil && *comment != version.Comment {
+		return false
+	}
+	return !activate || version.Active
+}
+
+// GetTemplateVersion retrieves a single version of a template by tag,
+// returning it along with its content
+func (c *mailgunClient) GetTemplateVersion(ctx context.Context, domain, name, tag string) (*templatetypes.TemplateVersion, string, error) {
+	path := fmt.Sprintf("/domains/%s/templates/%s/versions/%s", url.PathEscape(domain), url.PathEscape(name), url.PathEscape(tag))
+
+	resp, err := c.makeRequest(ctx, APITemplates, "GET", path, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get template version: %w", err)
+	}
+
+	var result struct {
+		Template *Template `json:"template"`
+	}
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, "", fmt.Errorf("failed to handle response: %w", err)
+	}
+
+	if result.Template == nil || result.Template.Version == nil {
+		return nil, "", fmt.Errorf("template version %s of %s not found (404)", tag, name)
+	}
+
+	return convertTemplateVersion(result.Template.Version), result.Template.Version.Template, nil
+}
+
+// UpdateTemplateVersion updates the content and comment of a template
+// version. When active is true the version becomes the one used for sending;
+// Mailgun offers no way to deactivate a version other than activating another.
+func (c *mailgunClient) UpdateTemplateVersion(ctx context.Context, domain, name, tag string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	path := fmt.Sprintf("/domains/%s/templates/%s/versions/%s", url.PathEscape(domain), url.PathEscape(name), url.PathEscape(tag))
+
+	params := map[string]interface{}{}
+	if version.Template != nil {
+		params["template"] = *version.Template
+	}
+	if version.Comment != nil {
+		params["comment"] = *version.Comment
+	}
+	if active {
+		params["active"] = "yes"
+	}
+
+	body := strings.NewReader(createFormData(params))
+	resp, err := c.makeRequest(ctx, APITemplates, "PUT", path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update template version: %w", err)
+	}
+
+	var result struct {
+		Template *Template `json:"template"`
+	}
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to handle response: %w", err)
+	}
+
+	// Mailgun only echoes the tag, so read the version back
+	if result.Template == nil || result.Template.Version == nil || result.Template.Version.CreatedAt == "" {
+		updated, _, err := c.GetTemplateVersion(ctx, domain, name, tag)
+		return updated, err
+	}
+
+	return convertTemplateVersion(result.Template.Version), nil
+}
+
+// DeleteTemplateVersion deletes a single version of a template
+func (c *mailgunClient) DeleteTemplateVersion(ctx context.Context, domain, name, tag string) error {
+	path := fmt.Sprintf("/domains/%s/templates/%s/versions/%s", url.PathEscape(domain), url.PathEscape(name), url.PathEscape(tag))
+
+	resp, err := c.makeRequest(ctx, APITemplates, "DELETE", path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete template version: %w", err)
+	}
+
+	if err := c.handleResponse(resp, nil); err != nil {
+		return fmt.Errorf("failed to handle response: %w", err)
+	}
+
+	return nil
+}
+
+// templateVersionPageSize is the number of versions ListTemplateVersions
+// requests per page
+const templateVersionPageSize = 100
+
+// ListTemplateVersions returns every version of a template. Mailgun pages
+// versions by the tag of the last one returned.
+func (c *mailgunClient) ListTemplateVersions(ctx context.Context, domain, name string) ([]templatetypes.TemplateVersion, error) {
+	path := fmt.Sprintf("/domains/%s/templates/%s/versions", url.PathEscape(domain), url.PathEscape(name))
+
+	var versions []templatetypes.TemplateVersion
+	query := fmt.Sprintf("?limit=%d", templateVersionPageSize)
+	for {
+		resp, err := c.makeRequest(ctx, APITemplates, "GET", path+query, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list template versions: %w", err)
+		}
+
+		var result struct {
+			Template *Template `json:"template"`
+		}
+		if err := c.handleResponse(resp, &result); err != nil {
+			return nil, fmt.Errorf("failed to handle response: %w", err)
+		}
+		if result.Template == nil {
+			return versions, nil
+		}
+
+		page := result.Template.Versions
+		for i := range page {
+			versions = append(versions, *convertTemplateVersion(&page[i]))
+		}
+		if len(page) < templateVersionPageSize {
+			return versions, nil
+		}
+		query = fmt.Sprintf("?limit=%d&page=next&p=%s", templateVersionPageSize, url.QueryEscape(page[len(page)-1].Tag))
+	}
+}
+
+// convertTemplateVersion converts a client TemplateVersion to the API type
+func convertTemplateVersion(version *TemplateVersion) *templatetypes.TemplateVersion {
+	if version == nil {
+		return nil
+	}
+	return &templatetypes.TemplateVersion{
+		Tag:       version.Tag,
+		Engine:    version.Engine,
+		CreatedAt: version.CreatedAt,
+		Comment:   version.Comment,
+		Active:    version.Active,
+	}
+}
+
+// RenderTemplate renders the active version of a template with the supplied
+// variables and returns the result. It does not modify the template.
+func (c *mailgunClient) RenderTemplate(ctx context.Context, domain, name string, vars map[string]interface{}) (string, error) {
+	variables, err := json.Marshal(vars)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode template variables: %w", err)
+	}
+
+	params := map[string]interface{}{
+		"variables": string(variables),
+	}
+
+	body := strings.NewReader(createFormData(params))
+	path := fmt.Sprintf("/domains/%s/templates/%s/render", url.PathEscape(domain), url.PathEscape(name))
+	resp, err := c.makeRequest(ctx, APITemplates, "POST", path, body)
+	if err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+
+	var result struct {
+		Rendered string `json:"rendered"`
+	}
+	if err := c.handleResponse(resp, &result); err != nil {
+		return "", fmt.Errorf("failed to handle response: %w", err)
+	}
+
+	return result.Rendered, nil
+}
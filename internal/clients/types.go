@@ -16,17 +16,43 @@ limitations under the License.
 
 package clients
 
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
 // Domain represents a Mailgun domain
 type Domain struct {
-	Name                string      `json:"name"`
-	Type                string      `json:"type,omitempty"`
-	State               string      `json:"state,omitempty"`
-	CreatedAt           string      `json:"created_at,omitempty"`
-	SMTPLogin           string      `json:"smtp_login,omitempty"`
-	SMTPPassword        string      `json:"smtp_password,omitempty"`
-	RequiredDNSRecords  []DNSRecord `json:"required_dns_records,omitempty"`
-	ReceivingDNSRecords []DNSRecord `json:"receiving_dns_records,omitempty"`
-	SendingDNSRecords   []DNSRecord `json:"sending_dns_records,omitempty"`
+	Name                string          `json:"name"`
+	Type                string          `json:"type,omitempty"`
+	State               string          `json:"state,omitempty"`
+	CreatedAt           string          `json:"created_at,omitempty"`
+	SMTPLogin           string          `json:"smtp_login,omitempty"`
+	SMTPPassword        string          `json:"smtp_password,omitempty"`
+	WebScheme           string          `json:"web_scheme,omitempty"`
+	SpamAction          string          `json:"spam_action,omitempty"`
+	IsDisabled          bool            `json:"is_disabled,omitempty"`
+	Disabled            *DomainDisabled `json:"disabled,omitempty"`
+	RequiredDNSRecords  []DNSRecord     `json:"required_dns_records,omitempty"`
+	ReceivingDNSRecords []DNSRecord     `json:"receiving_dns_records,omitempty"`
+	SendingDNSRecords   []DNSRecord     `json:"sending_dns_records,omitempty"`
+}
+
+// DomainDisabled describes why Mailgun disabled a domain
+type DomainDisabled struct {
+	Code        string `json:"code,omitempty"`
+	Note        string `json:"note,omitempty"`
+	Permanently bool   `json:"permanently,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// AuthorizedRecipient represents a sandbox authorized recipient
+type AuthorizedRecipient struct {
+	Email     string `json:"email"`
+	Activated bool   `json:"activated"`
+	CreatedAt string `json:"created_at,omitempty"`
 }
 
 // DomainSpec represents the parameters for creating/updating a domain
@@ -51,6 +77,40 @@ type DNSRecord struct {
 	Valid    *bool  `json:"valid,omitempty"`
 }
 
+// UnmarshalJSON accepts the validity of a record as a boolean or as the
+// "valid", "invalid" or "unknown" string Mailgun reports. Unknown validity is
+// left unset.
+func (r *DNSRecord) UnmarshalJSON(data []byte) error {
+	type record DNSRecord
+	var raw struct {
+		record
+		Valid json.RawMessage `json:"valid,omitempty"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*r = DNSRecord(raw.record)
+
+	var valid bool
+	var state string
+	switch {
+	case len(raw.Valid) == 0 || string(raw.Valid) == "null":
+	case json.Unmarshal(raw.Valid, &valid) == nil:
+		r.Valid = &valid
+	case json.Unmarshal(raw.Valid, &state) == nil:
+		switch state {
+		case "valid":
+			valid = true
+			r.Valid = &valid
+		case "invalid":
+			r.Valid = &valid
+		}
+	default:
+		return json.Unmarshal(raw.Valid, &r.Valid)
+	}
+	return nil
+}
+
 // MailingList represents a Mailgun mailing list
 type MailingList struct {
 	Address         string `json:"address"`
@@ -74,6 +134,59 @@ type MailingListSpec struct {
 
 // MailingListMember represents a member of a mailing list
 type MailingListMember struct {
+	Address    string     `json:"address"`
+	Name       *string    `json:"name,omitempty"`
+	Vars       MemberVars `json:"vars,omitempty"`
+	Subscribed *bool      `json:"subscribed,omitempty"`
+}
+
+// MemberVars are the custom variables of a mailing list member. Mailgun
+// stores them as an arbitrary JSON object; string values are kept as they
+// are, null as an empty string and any other value as its compact JSON
+// encoding, so that vars set outside the provider can still be compared
+// with the spec.
+type MemberVars map[string]string
+
+// UnmarshalJSON accepts the vars as a JSON object or, as some responses
+// return them, as a string holding one.
+func (v *MemberVars) UnmarshalJSON(data []byte) error {
+	var encoded string
+	if err := json.Unmarshal(data, &encoded); err == nil {
+		if encoded == "" {
+			*v = nil
+			return nil
+		}
+		data = []byte(encoded)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return errors.Wrap(err, "member vars are not a JSON object")
+	}
+	if raw == nil {
+		*v = nil
+		return nil
+	}
+
+	vars := make(MemberVars, len(raw))
+	for key, value := range raw {
+		var s string
+		if err := json.Unmarshal(value, &s); err == nil {
+			vars[key] = s
+			continue
+		}
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, value); err != nil {
+			return errors.Wrapf(err, "member var %q", key)
+		}
+		vars[key] = compact.String()
+	}
+	*v = vars
+	return nil
+}
+
+// MailingListMemberSpec describes a member added by AddMailingListMembers
+type MailingListMemberSpec struct {
 	Address    string            `json:"address"`
 	Name       *string           `json:"name,omitempty"`
 	Vars       map[string]string `json:"vars,omitempty"`
@@ -102,6 +215,34 @@ type RouteSpec struct {
 type RouteAction struct {
 	Type        string  `json:"action"`
 	Destination *string `json:"destination,omitempty"`
+	Notify      *string `json:"notify,omitempty"`
+}
+
+// UnmarshalJSON accepts actions in Mailgun's expression syntax, such as
+// "forward(\"https://example.com\")", as well as structured objects.
+func (a *RouteAction) UnmarshalJSON(data []byte) error {
+	var expr string
+	if err := json.Unmarshal(data, &expr); err == nil {
+		*a = parseRouteAction(expr)
+		return nil
+	}
+
+	var obj struct {
+		Action      string  `json:"action"`
+		Type        string  `json:"type"`
+		Destination *string `json:"destination"`
+		Notify      *string `json:"notify"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	a.Type = obj.Action
+	if a.Type == "" {
+		a.Type = obj.Type
+	}
+	a.Destination = obj.Destination
+	a.Notify = obj.Notify
+	return nil
 }
 
 // Webhook represents a Mailgun webhook
@@ -187,20 +328,18 @@ type Complaint struct {
 	CreatedAt string `json:"created_at,omitempty"`
 }
 
-// ComplaintSpec represents the parameters for creating a complaint entry
-type ComplaintSpec struct {
-	Address string `json:"address"`
-}
-
 // Unsubscribe represents an unsubscribe suppression entry
 type Unsubscribe struct {
-	Address   string `json:"address"`
-	Tags      string `json:"tags,omitempty"`
-	CreatedAt string `json:"created_at,omitempty"`
+	Address   string          `json:"address"`
+	Tags      unsubscribeTags `json:"tags,omitempty"`
+	CreatedAt string          `json:"created_at,omitempty"`
 }
 
-// UnsubscribeSpec represents the parameters for creating an unsubscribe entry
-type UnsubscribeSpec struct {
-	Address string  `json:"address"`
-	Tags    *string `json:"tags,omitempty"`
+// IPPool represents a dedicated IP pool
+type IPPool struct {
+	PoolID      string   `json:"pool_id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	IPs         []string `json:"ips,omitempty"`
+	IsLinked    bool     `json:"is_linked,omitempty"`
 }
@@ -18,29 +18,81 @@ package clients
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
+
+	unsubscribetypes "github.com/rossigee/provider-mailgun/apis/unsubscribe/v1beta1"
 )
 
-// CreateUnsubscribe creates a new unsubscribe suppression entry for a domain
-func (c *mailgunClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe interface{}) (interface{}, error) {
-	// Type assert to UnsubscribeSpec
-	unsubscribeSpec, ok := unsubscribe.(*UnsubscribeSpec)
-	if !ok {
-		return nil, fmt.Errorf("invalid unsubscribe parameter type")
+// DefaultUnsubscribeTag is the tag that stands for all of a domain's mail
+const DefaultUnsubscribeTag = "*"
+
+// UnsubscribeTag returns the tag the parameters unsubscribe the address from,
+// falling back to the deprecated Tags field and then to all mail.
+func UnsubscribeTag(unsubscribe *unsubscribetypes.UnsubscribeParameters) string {
+	switch {
+	case unsubscribe.Tag != nil && strings.TrimSpace(*unsubscribe.Tag) != "":
+		return strings.TrimSpace(*unsubscribe.Tag)
+	case unsubscribe.Tags != nil && strings.TrimSpace(*unsubscribe.Tags) != "":
+		return strings.TrimSpace(*unsubscribe.Tags)
+	default:
+		return DefaultUnsubscribeTag
 	}
-	path := fmt.Sprintf("/domains/%s/unsubscribes", url.PathEscape(domain))
+}
 
-	params := map[string]interface{}{
-		"address": unsubscribeSpec.Address,
+// unsubscribeTags are the tags of an unsubscribe entry. Mailgun lists them as
+// an array, but some responses carry a single comma-separated string.
+type unsubscribeTags []string
+
+// UnmarshalJSON accepts either form of the tags
+func (t *unsubscribeTags) UnmarshalJSON(data []byte) error {
+	var tags []string
+	if err := json.Unmarshal(data, &tags); err == nil {
+		*t = tags
+		return nil
+	}
+
+	var joined string
+	if err := json.Unmarshal(data, &joined); err != nil {
+		return err
+	}
+	*t = nil
+	for _, tag := range strings.Split(joined, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			*t = append(*t, tag)
+		}
+	}
+	return nil
+}
+
+// unsubscribesPath returns the path of a domain's unsubscribes table. Like
+// the bounces table it is not nested under /domains.
+func unsubscribesPath(domain string) string {
+	return fmt.Sprintf("/%s/unsubscribes", url.PathEscape(domain))
+}
+
+// observation converts a client Unsubscribe to an API UnsubscribeObservation
+func (u *Unsubscribe) observation() *unsubscribetypes.UnsubscribeObservation {
+	return &unsubscribetypes.UnsubscribeObservation{
+		Address:   u.Address,
+		Tags:      u.Tags,
+		CreatedAt: &u.CreatedAt,
 	}
-	if unsubscribeSpec.Tags != nil {
-		params["tags"] = *unsubscribeSpec.Tags
+}
+
+// CreateUnsubscribe unsubscribes an address from a tag of a domain's mail,
+// or from all of it
+func (c *mailgunClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe *unsubscribetypes.UnsubscribeParameters) (*unsubscribetypes.UnsubscribeObservation, error) {
+	tag := UnsubscribeTag(unsubscribe)
+	params := map[string]interface{}{
+		"address": unsubscribe.Address,
+		"tag":     tag,
 	}
 
 	body := strings.NewReader(createFormData(params))
-	resp, err := c.makeRequest(ctx, "POST", path, body)
+	resp, err := c.makeRequest(ctx, APIUnsubscribes, "POST", unsubscribesPath(domain), body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create unsubscribe: %w", err)
 	}
@@ -50,14 +102,21 @@ func (c *mailgunClient) CreateUnsubscribe(ctx context.Context, domain string, un
 		return nil, fmt.Errorf("failed to handle response: %w", err)
 	}
 
-	return interface{}(&result), nil
+	// Mailgun only acknowledges the addition, so report what was sent
+	result.Address = unsubscribe.Address
+	if len(result.Tags) == 0 {
+		result.Tags = unsubscribeTags{tag}
+	}
+
+	return result.observation(), nil
 }
 
-// GetUnsubscribe retrieves an unsubscribe suppression entry
-func (c *mailgunClient) GetUnsubscribe(ctx context.Context, domain, address string) (interface{}, error) {
-	path := fmt.Sprintf("/domains/%s/unsubscribes/%s", url.PathEscape(domain), url.PathEscape(address))
+// GetUnsubscribe retrieves an unsubscribe suppression entry, listing every
+// tag its address is unsubscribed from
+func (c *mailgunClient) GetUnsubscribe(ctx context.Context, domain, address string) (*unsubscribetypes.UnsubscribeObservation, error) {
+	path := unsubscribesPath(domain) + "/" + url.PathEscape(address)
 
-	resp, err := c.makeRequest(ctx, "GET", path, nil)
+	resp, err := c.makeRequest(ctx, APIUnsubscribes, "GET", path, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get unsubscribe: %w", err)
 	}
@@ -67,23 +126,26 @@ func (c *mailgunClient) GetUnsubscribe(ctx context.Context, domain, address stri
 		return nil, fmt.Errorf("failed to handle response: %w", err)
 	}
 
-	return interface{}(&result), nil
+	return result.observation(), nil
 }
 
-// DeleteUnsubscribe deletes an unsubscribe suppression entry
-func (c *mailgunClient) DeleteUnsubscribe(ctx context.Context, domain, address string) error {
-	path := fmt.Sprintf("/domains/%s/unsubscribes/%s", url.PathEscape(domain), url.PathEscape(address))
+// DeleteUnsubscribe resubscribes an address to a tag of a domain's mail. An
+// empty tag resubscribes it to everything, removing the entry.
+func (c *mailgunClient) DeleteUnsubscribe(ctx context.Context, domain, address, tag string) error {
+	path := unsubscribesPath(domain) + "/" + url.PathEscape(address)
+	if tag != "" {
+		path += "?tag=" + url.QueryEscape(tag)
+	}
 
-	resp, err := c.makeRequest(ctx, "DELETE", path, nil)
+	resp, err := c.makeRequest(ctx, APIUnsubscribes, "DELETE", path, nil)
 	if err != nil {
 		return fmt.Errorf("failed to delete unsubscribe: %w", err)
 	}
 
-	// For DELETE operations, we usually don't need to parse the response
-	var result interface{}
-	if err := c.handleResponse(resp, &result); err != nil {
-		// Many DELETE endpoints return empty responses, so we ignore parse errors
-		return nil
+	// Errors, including the 404 for an address that is not in the table,
+	// are returned so the caller can decide whether they matter
+	if err := c.handleResponse(resp, nil); err != nil {
+		return fmt.Errorf("failed to handle response: %w", err)
 	}
 
 	return nil
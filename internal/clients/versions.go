@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// An API is a kind of Mailgun API call. Each kind is served under its own
+// version of the Mailgun API, e.g. domains under v3 and authorized
+// recipients under v5.
+type API string
+
+// Kinds of Mailgun API calls.
+const (
+	APIDomains              API = "domains"
+	APIRoutes               API = "routes"
+	APIMailingLists         API = "lists"
+	APITemplates            API = "templates"
+	APIWebhooks             API = "webhooks"
+	APISMTPCredentials      API = "credentials"
+	APIBounces              API = "bounces"
+	APIComplaints           API = "complaints"
+	APIUnsubscribes         API = "unsubscribes"
+	APIAuthorizedRecipients API = "recipients"
+	APIWebhookSigningKey    API = "signingkeys"
+	APIIPPools              API = "ip_pools"
+	APIDomainKeys           API = "domainkeys"
+	APIDomainKeyActivation  API = "domainkeyactivation"
+)
+
+// baseAPIVersion is the version of the Mailgun API a base URL without a
+// version segment is taken to serve
+const baseAPIVersion = "v3"
+
+// defaultAPIVersions is the version of the Mailgun API each kind of call is
+// made against, unless the client config overrides it
+var defaultAPIVersions = map[API]string{
+	APIDomains:              "v3",
+	APIRoutes:               "v3",
+	APIMailingLists:         "v3",
+	APITemplates:            "v3",
+	APIWebhooks:             "v3",
+	APISMTPCredentials:      "v3",
+	APIBounces:              "v3",
+	APIComplaints:           "v3",
+	APIUnsubscribes:         "v3",
+	APIAuthorizedRecipients: "v5",
+	APIWebhookSigningKey:    "v5",
+	APIIPPools:              "v3",
+	APIDomainKeys:           "v1",
+	APIDomainKeyActivation:  "v4",
+}
+
+// apiVersionPattern matches Mailgun API versions such as "v3"
+var apiVersionPattern = regexp.MustCompile(`^v[0-9]+$`)
+
+// ParseAPIVersions validates per-kind API version overrides, keyed by kind
+func ParseAPIVersions(versions map[string]string) (map[API]string, error) {
+	if len(versions) == 0 {
+		return nil, nil
+	}
+	parsed := make(map[API]string, len(versions))
+	for kind, version := range versions {
+		if _, ok := defaultAPIVersions[API(kind)]; !ok {
+			return nil, errors.Errorf("invalid API kind %q: must be one of %s", kind, strings.Join(apiKinds(), ", "))
+		}
+		if !apiVersionPattern.MatchString(version) {
+			return nil, errors.Errorf("invalid API version %q for %s: must be a version such as v3", version, kind)
+		}
+		parsed[API(kind)] = version
+	}
+	return parsed, nil
+}
+
+// apiKinds returns the known kinds of API calls, sorted
+func apiKinds() []string {
+	kinds := make([]string, 0, len(defaultAPIVersions))
+	for api := range defaultAPIVersions {
+		kinds = append(kinds, string(api))
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// apiVersion returns the version of the Mailgun API calls of kind api are
+// made against
+func (c *mailgunClient) apiVersion(api API) string {
+	if v := c.config.APIVersions[api]; v != "" {
+		return v
+	}
+	if v := defaultAPIVersions[api]; v != "" {
+		return v
+	}
+	return baseURLVersion(c.config.BaseURL)
+}
+
+// apiURL returns the URL of path for a call of kind api. Calls against the
+// version of the configured base URL use the base URL as it is, so that a
+// custom one, such as a proxy or test server, is kept intact.
+func (c *mailgunClient) apiURL(api API, path string) string {
+	version := c.apiVersion(api)
+	if version == baseURLVersion(c.config.BaseURL) {
+		return c.config.BaseURL + path
+	}
+	return apiRoot(c.config.BaseURL) + "/" + version + path
+}
+
+// baseURLVersion returns the version segment a base URL ends with, such as
+// "v3", or baseAPIVersion if it has none
+func baseURLVersion(baseURL string) string {
+	root := apiRoot(baseURL)
+	if root == strings.TrimSuffix(baseURL, "/") {
+		return baseAPIVersion
+	}
+	return strings.TrimPrefix(strings.TrimSuffix(baseURL, "/")[len(root):], "/")
+}
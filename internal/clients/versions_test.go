@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIURL(t *testing.T) {
+	cases := map[string]struct {
+		baseURL  string
+		versions map[API]string
+		api      API
+		want     string
+	}{
+		"BaseVersion": {
+			baseURL: DefaultBaseURL,
+			api:     APIDomains,
+			want:    "https://api.mailgun.net/v3/domains",
+		},
+		"OtherVersion": {
+			baseURL: EUBaseURL,
+			api:     APIAuthorizedRecipients,
+			want:    "https://api.eu.mailgun.net/v5/domains",
+		},
+		"Override": {
+			baseURL:  DefaultBaseURL,
+			versions: map[API]string{APIWebhooks: "v4"},
+			api:      APIWebhooks,
+			want:     "https://api.mailgun.net/v4/domains",
+		},
+		"BaseURLWithoutVersion": {
+			baseURL: "https://mailgun-proxy.internal",
+			api:     APIRoutes,
+			want:    "https://mailgun-proxy.internal/domains",
+		},
+		"OtherVersionOfBaseURLWithoutVersion": {
+			baseURL: "https://mailgun-proxy.internal/",
+			api:     APIWebhookSigningKey,
+			want:    "https://mailgun-proxy.internal/v5/domains",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := &mailgunClient{config: &Config{BaseURL: tc.baseURL, APIVersions: tc.versions}}
+			assert.Equal(t, tc.want, c.apiURL(tc.api, "/domains"))
+		})
+	}
+}
+
+func TestParseAPIVersions(t *testing.T) {
+	got, err := ParseAPIVersions(map[string]string{"webhooks": "v4", "templates": "v4"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[API]string{APIWebhooks: "v4", APITemplates: "v4"}, got)
+
+	for name, versions := range map[string]map[string]string{
+		"UnknownKind":    {"validations": "v4"},
+		"InvalidVersion": {"webhooks": "4"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseAPIVersions(versions)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestAPIVersionPerKind(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	c := NewClient(&Config{
+		APIKey:      "test-key",
+		BaseURL:     server.URL + "/v3",
+		HTTPClient:  &http.Client{},
+		APIVersions: map[API]string{APIWebhooks: "v4", APITemplates: "v4"},
+	})
+	ctx := context.Background()
+
+	calls := map[string]struct {
+		call func()
+		want string
+	}{
+		"Domains":              {func() { _, _ = c.GetDomain(ctx, "mg.example.com") }, "/v3/domains/mg.example.com"},
+		"Routes":               {func() { _, _ = c.GetRoute(ctx, "route-1") }, "/v3/routes/route-1"},
+		"MailingLists":         {func() { _, _ = c.GetMailingList(ctx, "list@mg.example.com") }, "/v3/lists/list@mg.example.com"},
+		"Webhooks":             {func() { _, _ = c.GetWebhook(ctx, "mg.example.com", "opened") }, "/v4/domains/mg.example.com/webhooks/opened"},
+		"Templates":            {func() { _, _ = c.GetTemplate(ctx, "mg.example.com", "welcome") }, "/v4/domains/mg.example.com/templates/welcome"},
+		"AuthorizedRecipients": {func() { _, _ = c.ListAuthorizedRecipients(ctx) }, "/v5/sandbox/auth_recipients"},
+		"WebhookSigningKey":    {func() { _, _ = c.GetWebhookSigningKey(ctx) }, "/v5/accounts/http_signing_key"},
+	}
+	for name, tc := range calls {
+		t.Run(name, func(t *testing.T) {
+			mu.Lock()
+			paths = nil
+			mu.Unlock()
+
+			tc.call()
+
+			mu.Lock()
+			defer mu.Unlock()
+			if assert.NotEmpty(t, paths) {
+				assert.Equal(t, tc.want, paths[0])
+			}
+		})
+	}
+}
@@ -57,7 +57,7 @@ func (c *mailgunClient) CreateWebhook(ctx context.Context, domain string, webhoo
 
 	body := strings.NewReader(createFormData(params))
 	path := fmt.Sprintf("/domains/%s/webhooks/%s", url.PathEscape(domain), url.PathEscape(webhook.EventType))
-	resp, err := c.makeRequest(ctx, "POST", path, body)
+	resp, err := c.makeRequest(ctx, APIWebhooks, "POST", path, body)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create webhook")
 	}
@@ -79,7 +79,7 @@ func (c *mailgunClient) CreateWebhook(ctx context.Context, domain string, webhoo
 // GetWebhook retrieves a webhook from Mailgun
 func (c *mailgunClient) GetWebhook(ctx context.Context, domain, eventType string) (*webhooktypes.WebhookObservation, error) {
 	path := fmt.Sprintf("/domains/%s/webhooks/%s", domain, eventType)
-	resp, err := c.makeRequest(ctx, "GET", path, nil)
+	resp, err := c.makeRequest(ctx, APIWebhooks, "GET", path, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get webhook")
 	}
@@ -113,7 +113,7 @@ func (c *mailgunClient) UpdateWebhook(ctx context.Context, domain, eventType str
 
 	body := strings.NewReader(createFormData(params))
 	path := fmt.Sprintf("/domains/%s/webhooks/%s", domain, eventType)
-	resp, err := c.makeRequest(ctx, "PUT", path, body)
+	resp, err := c.makeRequest(ctx, APIWebhooks, "PUT", path, body)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to update webhook")
 	}
@@ -135,7 +135,7 @@ func (c *mailgunClient) UpdateWebhook(ctx context.Context, domain, eventType str
 // DeleteWebhook deletes a webhook from Mailgun
 func (c *mailgunClient) DeleteWebhook(ctx context.Context, domain, eventType string) error {
 	path := fmt.Sprintf("/domains/%s/webhooks/%s", domain, eventType)
-	resp, err := c.makeRequest(ctx, "DELETE", path, nil)
+	resp, err := c.makeRequest(ctx, APIWebhooks, "DELETE", path, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to delete webhook")
 	}
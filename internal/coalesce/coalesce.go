@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package coalesce shares the result of identical Mailgun reads issued close
+// together, so a burst of reconciles for resources on one domain costs a
+// single API call.
+package coalesce
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type call struct {
+	done    chan struct{}
+	val     interface{}
+	err     error
+	expires time.Time
+}
+
+// A Group coalesces calls by key. Callers arriving while a call for their key
+// is in flight wait for its result, and a successful result is reused by
+// later callers until the window has elapsed. Errors are only shared with the
+// callers that were waiting for them.
+type Group struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+// NewGroup returns a Group that reuses results for the supplied window.
+func NewGroup(window time.Duration) *Group {
+	return &Group{window: window, now: time.Now, calls: make(map[string]*call)}
+}
+
+// Do returns the result of fn for key, calling it only if no call for key is
+// in flight or was completed within the window. fn runs with the context of
+// the caller that started it.
+func (g *Group) Do(ctx context.Context, key string, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	g.prune()
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-c.done:
+			return c.val, c.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	c := &call{done: make(chan struct{})}
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	c.val, c.err = fn(ctx)
+
+	g.mu.Lock()
+	if c.err != nil {
+		if g.calls[key] == c {
+			delete(g.calls, key)
+		}
+	} else {
+		c.expires = g.now().Add(g.window)
+	}
+	g.mu.Unlock()
+	close(c.done)
+
+	return c.val, c.err
+}
+
+// Forget drops the result held for key, so the next call reads afresh. It
+// should be called after writes that change what key reads.
+func (g *Group) Forget(key string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.calls, key)
+}
+
+// prune drops expired results. It must be called with mu held.
+func (g *Group) prune() {
+	now := g.now()
+	for key, c := range g.calls {
+		if !c.expires.IsZero() && now.After(c.expires) {
+			delete(g.calls, key)
+		}
+	}
+}
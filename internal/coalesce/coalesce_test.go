@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coalesce
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupSharesInFlightCalls(t *testing.T) {
+	g := NewGroup(time.Minute)
+	release := make(chan struct{})
+	var calls int32
+
+	fn := func(context.Context) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return "domain", nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]interface{}, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := g.Do(context.Background(), "mg.example.com", fn)
+			assert.NoError(t, err)
+			results[i] = v
+		}(i)
+	}
+
+	// Let every caller reach the group before the call completes
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for _, v := range results {
+		assert.Equal(t, "domain", v)
+	}
+}
+
+func TestGroupWindow(t *testing.T) {
+	now := time.Now()
+	g := NewGroup(time.Second)
+	g.now = func() time.Time { return now }
+
+	var calls int
+	fn := func(context.Context) (interface{}, error) {
+		calls++
+		return calls, nil
+	}
+
+	v, err := g.Do(context.Background(), "key", fn)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	v, _ = g.Do(context.Background(), "key", fn)
+	assert.Equal(t, 1, v, "a result within the window should be reused")
+
+	v, _ = g.Do(context.Background(), "other", fn)
+	assert.Equal(t, 2, v, "keys should not share results")
+
+	now = now.Add(2 * time.Second)
+	v, _ = g.Do(context.Background(), "key", fn)
+	assert.Equal(t, 3, v, "an expired result should be read afresh")
+
+	g.Forget("key")
+	v, _ = g.Do(context.Background(), "key", fn)
+	assert.Equal(t, 4, v, "a forgotten result should be read afresh")
+}
+
+func TestGroupDoesNotKeepErrors(t *testing.T) {
+	g := NewGroup(time.Minute)
+	var calls int
+	fn := func(context.Context) (interface{}, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("boom")
+		}
+		return "ok", nil
+	}
+
+	_, err := g.Do(context.Background(), "key", fn)
+	assert.Error(t, err)
+
+	v, err := g.Do(context.Background(), "key", fn)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", v)
+}
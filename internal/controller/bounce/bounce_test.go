@@ -31,13 +31,18 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	v1beta1 "github.com/rossigee/provider-mailgun/apis/bounce/v1beta1"
+	complainttypes "github.com/rossigee/provider-mailgun/apis/complaint/v1beta1"
+	unsubscribetypes "github.com/rossigee/provider-mailgun/apis/unsubscribe/v1beta1"
 	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
 	domaintypes "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+	ippooltypes "github.com/rossigee/provider-mailgun/apis/ippool/v1beta1"
 	mailinglisttypes "github.com/rossigee/provider-mailgun/apis/mailinglist/v1beta1"
+	mailinglistmembertypes "github.com/rossigee/provider-mailgun/apis/mailinglistmember/v1beta1"
 	routetypes "github.com/rossigee/provider-mailgun/apis/route/v1beta1"
 	smtpcredentialtypes "github.com/rossigee/provider-mailgun/apis/smtpcredential/v1beta1"
 	templatetypes "github.com/rossigee/provider-mailgun/apis/template/v1beta1"
 	webhooktypes "github.com/rossigee/provider-mailgun/apis/webhook/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
 )
 
 // MockBounceClient for testing
@@ -53,6 +58,7 @@ func (m *MockBounceClient) CreateBounce(ctx context.Context, domain string, boun
 
 	key := domain + "/" + bounce.Address
 	result := &v1beta1.BounceObservation{
+		Address:   bounce.Address,
 		CreatedAt: stringPtr("2025-01-01T00:00:00Z"),
 	}
 
@@ -106,6 +112,50 @@ func (m *MockBounceClient) DeleteDomain(ctx context.Context, name string) error
 	return errors.New("not implemented")
 }
 
+func (m *MockBounceClient) ListAuthorizedRecipients(ctx context.Context) ([]domaintypes.AuthorizedRecipient, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockBounceClient) AddAuthorizedRecipient(ctx context.Context, email string) (*domaintypes.AuthorizedRecipient, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockBounceClient) DeleteAuthorizedRecipient(ctx context.Context, email string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockBounceClient) GetWebhookSigningKey(ctx context.Context) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *MockBounceClient) RotateWebhookSigningKey(ctx context.Context) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *MockBounceClient) UpdateDomainTracking(ctx context.Context, name string, tracking *domaintypes.DomainTracking) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockBounceClient) GetDomainTracking(ctx context.Context, name string) (*domaintypes.DomainTracking, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockBounceClient) GetDomainConnection(ctx context.Context, name string) (*domaintypes.DomainConnection, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockBounceClient) UpdateDomainConnection(ctx context.Context, name string, connection *domaintypes.DomainConnection) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockBounceClient) UpdateDKIMKey(ctx context.Context, name string, keySize int) ([]domaintypes.DNSRecord, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockBounceClient) ListDomains(ctx context.Context, limit, skip int) ([]*domaintypes.DomainObservation, int, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
 // MailingList operations
 func (m *MockBounceClient) CreateMailingList(ctx context.Context, list *mailinglisttypes.MailingListParameters) (*mailinglisttypes.MailingListObservation, error) {
 	return nil, errors.New("not implemented")
@@ -123,6 +173,30 @@ func (m *MockBounceClient) DeleteMailingList(ctx context.Context, address string
 	return errors.New("not implemented")
 }
 
+func (m *MockBounceClient) CreateMailingListMember(ctx context.Context, listAddress string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockBounceClient) GetMailingListMember(ctx context.Context, listAddress, address string) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockBounceClient) UpdateMailingListMember(ctx context.Context, listAddress, address string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockBounceClient) DeleteMailingListMember(ctx context.Context, listAddress, address string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockBounceClient) AddMailingListMembers(ctx context.Context, listAddress string, members []clients.MailingListMemberSpec, upsert bool) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockBounceClient) ListMailingListMembers(ctx context.Context, listAddress string) ([]*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
 // Route operations
 func (m *MockBounceClient) CreateRoute(ctx context.Context, route *routetypes.RouteParameters) (*routetypes.RouteObservation, error) {
 	return nil, errors.New("not implemented")
@@ -140,6 +214,10 @@ func (m *MockBounceClient) DeleteRoute(ctx context.Context, id string) error {
 	return errors.New("not implemented")
 }
 
+func (m *MockBounceClient) ListRoutes(ctx context.Context, limit, skip int) ([]*routetypes.RouteObservation, int, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
 // Webhook operations
 func (m *MockBounceClient) CreateWebhook(ctx context.Context, domain string, webhook *webhooktypes.WebhookParameters) (*webhooktypes.WebhookObservation, error) {
 	return nil, errors.New("not implemented")
@@ -191,11 +269,35 @@ func (m *MockBounceClient) DeleteTemplate(ctx context.Context, domain, name stri
 	return errors.New("not implemented")
 }
 
-func (m *MockBounceClient) CreateComplaint(ctx context.Context, domain string, complaint interface{}) (interface{}, error) {
+func (m *MockBounceClient) CreateTemplateVersion(ctx context.Context, domain, name string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockBounceClient) GetTemplateVersion(ctx context.Context, domain, name, tag string) (*templatetypes.TemplateVersion, string, error) {
+	return nil, "", errors.New("not implemented")
+}
+
+func (m *MockBounceClient) UpdateTemplateVersion(ctx context.Context, domain, name, tag string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockBounceClient) DeleteTemplateVersion(ctx context.Context, domain, name, tag string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockBounceClient) ListTemplateVersions(ctx context.Context, domain, name string) ([]templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockBounceClient) RenderTemplate(ctx context.Context, domain, name string, vars map[string]interface{}) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *MockBounceClient) CreateComplaint(ctx context.Context, domain string, complaint *complainttypes.ComplaintParameters) (*complainttypes.ComplaintObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockBounceClient) GetComplaint(ctx context.Context, domain, address string) (interface{}, error) {
+func (m *MockBounceClient) GetComplaint(ctx context.Context, domain, address string) (*complainttypes.ComplaintObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
@@ -203,15 +305,43 @@ func (m *MockBounceClient) DeleteComplaint(ctx context.Context, domain, address
 	return errors.New("not implemented")
 }
 
-func (m *MockBounceClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe interface{}) (interface{}, error) {
+func (m *MockBounceClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe *unsubscribetypes.UnsubscribeParameters) (*unsubscribetypes.UnsubscribeObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockBounceClient) GetUnsubscribe(ctx context.Context, domain, address string) (interface{}, error) {
+func (m *MockBounceClient) GetUnsubscribe(ctx context.Context, domain, address string) (*unsubscribetypes.UnsubscribeObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockBounceClient) DeleteUnsubscribe(ctx context.Context, domain, address string) error {
+func (m *MockBounceClient) DeleteUnsubscribe(ctx context.Context, domain, address, tag string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockBounceClient) CreateIPPool(ctx context.Context, pool *ippooltypes.IPPoolParameters) (*ippooltypes.IPPoolObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockBounceClient) GetIPPool(ctx context.Context, id string) (*ippooltypes.IPPoolObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockBounceClient) ListDomainIPs(ctx context.Context, name string) ([]string, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockBounceClient) AssignDomainIP(ctx context.Context, name, ip string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockBounceClient) UnassignDomainIP(ctx context.Context, name, ip string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockBounceClient) UpdateIPPool(ctx context.Context, id string, pool *ippooltypes.IPPoolParameters, add, remove []string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockBounceClient) DeleteIPPool(ctx context.Context, id string) error {
 	return errors.New("not implemented")
 }
 
@@ -381,6 +511,7 @@ func TestBounceCreate(t *testing.T) {
 				bounce, exists := mockClient.bounces[key]
 				assert.True(t, exists, "Bounce should be created")
 				assert.NotNil(t, bounce.CreatedAt, "CreatedAt should be set")
+				assert.Equal(t, "new@example.com", tc.args.mg.(*v1beta1.Bounce).Status.AtProvider.Address, "the created bounce should be reported in status")
 			}
 		})
 	}
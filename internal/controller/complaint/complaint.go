@@ -35,6 +35,16 @@ import (
 	domainv1beta1 "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
 	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
 	"github.com/rossigee/provider-mailgun/internal/clients"
+	"github.com/rossigee/provider-mailgun/internal/eventlabel"
+	"github.com/rossigee/provider-mailgun/internal/failfast"
+	"github.com/rossigee/provider-mailgun/internal/immutable"
+	"github.com/rossigee/provider-mailgun/internal/metrics"
+	"github.com/rossigee/provider-mailgun/internal/observecache"
+	"github.com/rossigee/provider-mailgun/internal/ratelimit"
+	"github.com/rossigee/provider-mailgun/internal/readonly"
+	"github.com/rossigee/provider-mailgun/internal/shutdown"
+	"github.com/rossigee/provider-mailgun/internal/strictdelete"
+	"github.com/rossigee/provider-mailgun/internal/watchdog"
 )
 
 const (
@@ -50,14 +60,14 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1beta1.ComplaintGroupVersionKind),
-		managed.WithExternalConnector(&connector{
+		managed.WithExternalConnector(failfast.Wrap(mgr.GetClient(), readonly.Wrap(shutdown.Wrap(strictdelete.Wrap(watchdog.Wrap(observecache.Wrap(ratelimit.Wrap(immutable.Wrap(&connector{
 			kube:         mgr.GetClient(),
 			usage:        resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
 			newServiceFn: clients.NewClient,
-		}),
+		}, immutableFields))))))))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
+		managed.WithRecorder(eventlabel.Wrap(event.NewAPIRecorder(mgr.GetEventRecorder(name)))))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -67,6 +77,18 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		Complete(r)
 }
 
+// immutableFields returns the fields that identify the Mailgun complaint
+func immutableFields(mg resource.Managed) map[string]string {
+	cr, ok := mg.(*v1beta1.Complaint)
+	if !ok {
+		return nil
+	}
+	return map[string]string{
+		"spec.forProvider.address":        cr.Spec.ForProvider.Address,
+		"spec.forProvider.domainRef.name": cr.Spec.ForProvider.DomainRef.Name,
+	}
+}
+
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
@@ -112,7 +134,7 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	}
 
 	cd := pc.Spec.Credentials
-	_, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	_, err := clients.ExtractCredentials(ctx, c.kube, cd)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
@@ -124,7 +146,7 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 
 	svc := c.newServiceFn(config)
 
-	return &external{service: svc, kube: c.kube}, nil
+	return &external{service: svc, kube: c.kube, reconcile: metrics.NewReconcileTimer(v1beta1.ComplaintKind)}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
@@ -132,14 +154,20 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 type external struct {
 	service clients.Client
 	kube    client.Client
+
+	// reconcile times the work done by this client for one reconcile
+	reconcile *metrics.ReconcileTimer
 }
 
 func (c *external) Disconnect(ctx context.Context) error {
 	// No persistent connections to clean up
+	c.reconcile.Record()
 	return nil
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.Complaint)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotComplaint)
@@ -168,15 +196,12 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.Wrap(err, "cannot get complaint")
 	}
 
-	// complaint is interface{}, convert to Complaint struct for status
-	if complaintData, ok := complaint.(*clients.Complaint); ok {
-		cr.Status.AtProvider = v1beta1.ComplaintObservation{
-			CreatedAt: &complaintData.CreatedAt,
-		}
-	}
+	cr.Status.AtProvider = *complaint
 
 	cr.Status.SetConditions(xpv1.Available())
 
+	// A complaint has no fields to update; the address identifies it, so an
+	// existing record is always up to date
 	return managed.ExternalObservation{
 		ResourceExists:          true,
 		ResourceUpToDate:        true,
@@ -185,6 +210,8 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.Complaint)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotComplaint)
@@ -198,27 +225,28 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.Wrap(err, "cannot resolve domain name")
 	}
 
-	complaintSpec := &clients.ComplaintSpec{
-		Address: cr.Spec.ForProvider.Address,
-	}
-
-	_, err = c.service.CreateComplaint(ctx, domainName, complaintSpec)
+	complaint, err := c.service.CreateComplaint(ctx, domainName, &cr.Spec.ForProvider)
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, "cannot create complaint")
 	}
 
 	meta.SetExternalName(cr, cr.Spec.ForProvider.Address)
+	cr.Status.AtProvider = *complaint
 
 	return managed.ExternalCreation{}, nil
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	defer c.reconcile.Start()()
+
 	// Complaint entries cannot be updated in Mailgun API
 	// They can only be created or deleted
 	return managed.ExternalUpdate{}, nil
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.Complaint)
 	if !ok {
 		return managed.ExternalDelete{}, errors.New(errNotComplaint)
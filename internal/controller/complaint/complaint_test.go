@@ -0,0 +1,448 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package complaint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	bouncetypes "github.com/rossigee/provider-mailgun/apis/bounce/v1beta1"
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/complaint/v1beta1"
+	domaintypes "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+	ippooltypes "github.com/rossigee/provider-mailgun/apis/ippool/v1beta1"
+	mailinglisttypes "github.com/rossigee/provider-mailgun/apis/mailinglist/v1beta1"
+	mailinglistmembertypes "github.com/rossigee/provider-mailgun/apis/mailinglistmember/v1beta1"
+	routetypes "github.com/rossigee/provider-mailgun/apis/route/v1beta1"
+	smtpcredentialtypes "github.com/rossigee/provider-mailgun/apis/smtpcredential/v1beta1"
+	templatetypes "github.com/rossigee/provider-mailgun/apis/template/v1beta1"
+	unsubscribetypes "github.com/rossigee/provider-mailgun/apis/unsubscribe/v1beta1"
+	webhooktypes "github.com/rossigee/provider-mailgun/apis/webhook/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
+)
+
+// MockComplaintClient for testing
+type MockComplaintClient struct {
+	complaints map[string]*v1beta1.ComplaintObservation
+	err        error
+}
+
+func (m *MockComplaintClient) CreateComplaint(ctx context.Context, domain string, complaint *v1beta1.ComplaintParameters) (*v1beta1.ComplaintObservation, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	key := domain + "/" + complaint.Address
+	result := &v1beta1.ComplaintObservation{
+		Address:   complaint.Address,
+		CreatedAt: stringPtr("2025-01-01T00:00:00Z"),
+	}
+
+	if m.complaints == nil {
+		m.complaints = make(map[string]*v1beta1.ComplaintObservation)
+	}
+	m.complaints[key] = result
+
+	return result, nil
+}
+
+func (m *MockComplaintClient) GetComplaint(ctx context.Context, domain, address string) (*v1beta1.ComplaintObservation, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	key := domain + "/" + address
+	if complaint, exists := m.complaints[key]; exists {
+		return complaint, nil
+	}
+
+	return nil, errors.New("address not found in complaints table (404)")
+}
+
+func (m *MockComplaintClient) DeleteComplaint(ctx context.Context, domain, address string) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	key := domain + "/" + address
+	if _, exists := m.complaints[key]; !exists {
+		return errors.New("address not found in complaints table (404)")
+	}
+	delete(m.complaints, key)
+	return nil
+}
+
+// Implement other required client methods as no-ops with v1beta1 types
+
+// Domain operations
+func (m *MockComplaintClient) CreateDomain(ctx context.Context, domain *domaintypes.DomainParameters) (*domaintypes.DomainObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) GetDomain(ctx context.Context, name string) (*domaintypes.DomainObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) UpdateDomain(ctx context.Context, name string, domain *domaintypes.DomainParameters) (*domaintypes.DomainObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) DeleteDomain(ctx context.Context, name string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) ListAuthorizedRecipients(ctx context.Context) ([]domaintypes.AuthorizedRecipient, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) AddAuthorizedRecipient(ctx context.Context, email string) (*domaintypes.AuthorizedRecipient, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) DeleteAuthorizedRecipient(ctx context.Context, email string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) GetWebhookSigningKey(ctx context.Context) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) RotateWebhookSigningKey(ctx context.Context) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) UpdateDomainTracking(ctx context.Context, name string, tracking *domaintypes.DomainTracking) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) GetDomainTracking(ctx context.Context, name string) (*domaintypes.DomainTracking, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) GetDomainConnection(ctx context.Context, name string) (*domaintypes.DomainConnection, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) UpdateDomainConnection(ctx context.Context, name string, connection *domaintypes.DomainConnection) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) UpdateDKIMKey(ctx context.Context, name string, keySize int) ([]domaintypes.DNSRecord, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) ListDomains(ctx context.Context, limit, skip int) ([]*domaintypes.DomainObservation, int, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
+// MailingList operations
+func (m *MockComplaintClient) CreateMailingList(ctx context.Context, list *mailinglisttypes.MailingListParameters) (*mailinglisttypes.MailingListObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) GetMailingList(ctx context.Context, address string) (*mailinglisttypes.MailingListObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) UpdateMailingList(ctx context.Context, address string, list *mailinglisttypes.MailingListParameters) (*mailinglisttypes.MailingListObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) DeleteMailingList(ctx context.Context, address string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) CreateMailingListMember(ctx context.Context, listAddress string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) GetMailingListMember(ctx context.Context, listAddress, address string) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) UpdateMailingListMember(ctx context.Context, listAddress, address string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) DeleteMailingListMember(ctx context.Context, listAddress, address string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) AddMailingListMembers(ctx context.Context, listAddress string, members []clients.MailingListMemberSpec, upsert bool) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) ListMailingListMembers(ctx context.Context, listAddress string) ([]*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+// Route operations
+func (m *MockComplaintClient) CreateRoute(ctx context.Context, route *routetypes.RouteParameters) (*routetypes.RouteObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) GetRoute(ctx context.Context, id string) (*routetypes.RouteObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) UpdateRoute(ctx context.Context, id string, route *routetypes.RouteParameters) (*routetypes.RouteObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) DeleteRoute(ctx context.Context, id string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) ListRoutes(ctx context.Context, limit, skip int) ([]*routetypes.RouteObservation, int, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
+// Webhook operations
+func (m *MockComplaintClient) CreateWebhook(ctx context.Context, domain string, webhook *webhooktypes.WebhookParameters) (*webhooktypes.WebhookObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) GetWebhook(ctx context.Context, domain, eventType string) (*webhooktypes.WebhookObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) UpdateWebhook(ctx context.Context, domain, eventType string, webhook *webhooktypes.WebhookParameters) (*webhooktypes.WebhookObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) DeleteWebhook(ctx context.Context, domain, eventType string) error {
+	return errors.New("not implemented")
+}
+
+// SMTPCredential operations
+func (m *MockComplaintClient) CreateSMTPCredential(ctx context.Context, domain string, credential *smtpcredentialtypes.SMTPCredentialParameters) (*smtpcredentialtypes.SMTPCredentialObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) GetSMTPCredential(ctx context.Context, domain, login string) (*smtpcredentialtypes.SMTPCredentialObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) UpdateSMTPCredential(ctx context.Context, domain, login string, password string) (*smtpcredentialtypes.SMTPCredentialObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) DeleteSMTPCredential(ctx context.Context, domain, login string) error {
+	return errors.New("not implemented")
+}
+
+// Template operations
+func (m *MockComplaintClient) CreateTemplate(ctx context.Context, domain string, template *templatetypes.TemplateParameters) (*templatetypes.TemplateObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) GetTemplate(ctx context.Context, domain, name string) (*templatetypes.TemplateObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) UpdateTemplate(ctx context.Context, domain, name string, template *templatetypes.TemplateParameters) (*templatetypes.TemplateObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) DeleteTemplate(ctx context.Context, domain, name string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) CreateTemplateVersion(ctx context.Context, domain, name string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) GetTemplateVersion(ctx context.Context, domain, name, tag string) (*templatetypes.TemplateVersion, string, error) {
+	return nil, "", errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) UpdateTemplateVersion(ctx context.Context, domain, name, tag string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) DeleteTemplateVersion(ctx context.Context, domain, name, tag string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) ListTemplateVersions(ctx context.Context, domain, name string) ([]templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) RenderTemplate(ctx context.Context, domain, name string, vars map[string]interface{}) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) CreateBounce(ctx context.Context, domain string, bounce *bouncetypes.BounceParameters) (*bouncetypes.BounceObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) GetBounce(ctx context.Context, domain, address string) (*bouncetypes.BounceObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) DeleteBounce(ctx context.Context, domain, address string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe *unsubscribetypes.UnsubscribeParameters) (*unsubscribetypes.UnsubscribeObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) GetUnsubscribe(ctx context.Context, domain, address string) (*unsubscribetypes.UnsubscribeObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) DeleteUnsubscribe(ctx context.Context, domain, address, tag string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) CreateIPPool(ctx context.Context, pool *ippooltypes.IPPoolParameters) (*ippooltypes.IPPoolObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) GetIPPool(ctx context.Context, id string) (*ippooltypes.IPPoolObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) ListDomainIPs(ctx context.Context, name string) ([]string, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) AssignDomainIP(ctx context.Context, name, ip string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) UnassignDomainIP(ctx context.Context, name, ip string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) UpdateIPPool(ctx context.Context, id string, pool *ippooltypes.IPPoolParameters, add, remove []string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockComplaintClient) DeleteIPPool(ctx context.Context, id string) error {
+	return errors.New("not implemented")
+}
+
+func newComplaint(externalName string) *v1beta1.Complaint {
+	cr := &v1beta1.Complaint{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-complaint", Namespace: "test-namespace"},
+		Spec: v1beta1.ComplaintSpec{
+			ForProvider: v1beta1.ComplaintParameters{
+				Address:   "angry@example.com",
+				DomainRef: xpv1.Reference{Name: "example.com"},
+			},
+		},
+	}
+	if externalName != "" {
+		cr.SetAnnotations(map[string]string{"crossplane.io/external-name": externalName})
+	}
+	return cr
+}
+
+func TestComplaintObserve(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		complaints map[string]*v1beta1.ComplaintObservation
+		want       managed.ExternalObservation
+	}{
+		"ComplaintExists": {
+			reason: "An existing complaint should always be up to date",
+			complaints: map[string]*v1beta1.ComplaintObservation{
+				"example.com/angry@example.com": {Address: "angry@example.com", CreatedAt: stringPtr("2025-01-01T00:00:00Z")},
+			},
+			want: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"ComplaintNotFound": {
+			reason: "A missing complaint should be reported as not existing",
+			want:   managed.ExternalObservation{ResourceExists: false},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{service: &MockComplaintClient{complaints: tc.complaints}}
+			cr := newComplaint("")
+
+			got, err := e.Observe(context.Background(), cr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got, tc.reason)
+			assert.Equal(t, "angry@example.com", cr.GetAnnotations()["crossplane.io/external-name"])
+			if tc.want.ResourceExists {
+				assert.Equal(t, "angry@example.com", cr.Status.AtProvider.Address)
+				assert.Equal(t, "2025-01-01T00:00:00Z", *cr.Status.AtProvider.CreatedAt)
+			}
+		})
+	}
+}
+
+func TestComplaintCreate(t *testing.T) {
+	mockClient := &MockComplaintClient{}
+	e := &external{service: mockClient}
+	cr := newComplaint("")
+
+	_, err := e.Create(context.Background(), cr)
+	require.NoError(t, err)
+
+	_, exists := mockClient.complaints["example.com/angry@example.com"]
+	assert.True(t, exists, "Complaint should be created")
+	assert.Equal(t, "angry@example.com", cr.GetAnnotations()["crossplane.io/external-name"])
+	assert.Equal(t, "angry@example.com", cr.Status.AtProvider.Address, "the created complaint should be reported in status")
+}
+
+func TestComplaintDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		complaints map[string]*v1beta1.ComplaintObservation
+		wantErr    bool
+	}{
+		"Exists": {
+			reason: "An existing complaint should be deleted",
+			complaints: map[string]*v1beta1.ComplaintObservation{
+				"example.com/angry@example.com": {Address: "angry@example.com"},
+			},
+		},
+		"AlreadyGone": {
+			reason: "Deleting a complaint that is already gone should succeed",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockComplaintClient{complaints: tc.complaints}
+			e := &external{service: mockClient}
+
+			_, err := e.Delete(context.Background(), newComplaint("angry@example.com"))
+			if tc.wantErr {
+				require.Error(t, err, tc.reason)
+				return
+			}
+			require.NoError(t, err, tc.reason)
+			assert.Empty(t, mockClient.complaints)
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -21,10 +21,13 @@ import (
 	"github.com/rossigee/provider-mailgun/internal/controller/bounce"
 	"github.com/rossigee/provider-mailgun/internal/controller/complaint"
 	"github.com/rossigee/provider-mailgun/internal/controller/domain"
+	"github.com/rossigee/provider-mailgun/internal/controller/ippool"
 	"github.com/rossigee/provider-mailgun/internal/controller/mailinglist"
+	"github.com/rossigee/provider-mailgun/internal/controller/mailinglistmember"
 	"github.com/rossigee/provider-mailgun/internal/controller/route"
 	"github.com/rossigee/provider-mailgun/internal/controller/smtpcredential"
 	"github.com/rossigee/provider-mailgun/internal/controller/template"
+	"github.com/rossigee/provider-mailgun/internal/controller/templateversion"
 	"github.com/rossigee/provider-mailgun/internal/controller/unsubscribe"
 	"github.com/rossigee/provider-mailgun/internal/controller/webhook"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -39,14 +42,20 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		complaint.Setup,
 		// domain controllers
 		domain.Setup,
+		// ippool controllers
+		ippool.Setup,
 		// mailinglist controllers
 		mailinglist.Setup,
+		// mailinglistmember controllers
+		mailinglistmember.Setup,
 		// route controllers
 		route.Setup,
 		// smtpcredential controllers
 		smtpcredential.Setup,
 		// template controllers
 		template.Setup,
+		// templateversion controllers
+		templateversion.Setup,
 		// unsubscribe controllers
 		unsubscribe.Setup,
 		// webhook controllers
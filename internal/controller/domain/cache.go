@@ -0,0 +1,261 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
+)
+
+// warmupTTL bounds how long warm-up results may stand in for a GET
+const warmupTTL = 2 * time.Minute
+
+// warmupTimeout bounds the domains-list calls of a warm-up, which runs
+// outside of any reconcile
+const warmupTimeout = time.Minute
+
+// A warmedAccount holds the domains listed for one Mailgun account until they
+// are taken or expire
+type warmedAccount struct {
+	domains map[string]*v1beta1.DomainObservation
+	expires time.Time
+}
+
+// warmupCache holds the result of a single domains-list call per Mailgun
+// account so the first Observe of each domain after startup can skip its
+// own GET. Entries are consumed on first use, and the domains of an account
+// are dropped together once the TTL has passed, leaving only the record that
+// the account was warmed.
+type warmupCache struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	now      func() time.Time
+	accounts map[string]*warmedAccount
+}
+
+func newWarmupCache(ttl time.Duration) *warmupCache {
+	return &warmupCache{
+		ttl:      ttl,
+		now:      time.Now,
+		accounts: make(map[string]*warmedAccount),
+	}
+}
+
+// accountKey identifies the Mailgun account a client config talks to without
+// keeping the API key itself in memory.
+func accountKey(config *clients.Config) string {
+	sum := sha256.Sum256([]byte(config.BaseURL + "\x00" + config.APIKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// Warm lists every domain of the account once and seeds the cache. Later
+// calls for the same account are no-ops, even if the first one failed, so a
+// failing list call never costs more than one attempt.
+func (w *warmupCache) Warm(ctx context.Context, account string, svc clients.Client) error {
+	w.mu.Lock()
+	if _, ok := w.accounts[account]; ok {
+		w.mu.Unlock()
+		return nil
+	}
+	warmed := &warmedAccount{}
+	w.accounts[account] = warmed
+	w.mu.Unlock()
+
+	all, err := clients.ListAllDomains(ctx, svc)
+	if err != nil {
+		return errors.Wrap(err, "cannot list domains for cache warm-up")
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	warmed.domains = make(map[string]*v1beta1.DomainObservation, len(all))
+	warmed.expires = w.now().Add(w.ttl)
+	for _, d := range all {
+		warmed.domains[d.ID] = d
+	}
+	return nil
+}
+
+// Take returns and removes the cached observation of a domain, if present and
+// not yet expired. Nothing is returned while the account is still being
+// warmed.
+func (w *warmupCache) Take(account, name string) (*v1beta1.DomainObservation, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	warmed, ok := w.accounts[account]
+	if !ok || warmed.domains == nil {
+		return nil, false
+	}
+	if w.now().After(warmed.expires) {
+		warmed.domains = nil
+		return nil, false
+	}
+	domain, ok := warmed.domains[name]
+	delete(warmed.domains, name)
+	return domain, ok
+}
+
+// ttlCache holds one value per Mailgun account for a TTL. Each account has
+// its own lock, held while its value is loaded, so concurrent readers of an
+// account share a single load without waiting on loads for other accounts.
+type ttlCache[V any] struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	now      func() time.Time
+	accounts map[string]*ttlEntry[V]
+}
+
+type ttlEntry[V any] struct {
+	mu      sync.Mutex
+	value   V
+	expires time.Time
+}
+
+func newTTLCache[V any](ttl time.Duration) *ttlCache[V] {
+	return &ttlCache[V]{
+		ttl:      ttl,
+		now:      time.Now,
+		accounts: make(map[string]*ttlEntry[V]),
+	}
+}
+
+// entry returns the entry of account, adding it if there is none. Expired
+// entries of other accounts are dropped when one is added, unless they are
+// being loaded.
+func (c *ttlCache[V]) entry(account string) *ttlEntry[V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.accounts[account]; ok {
+		return e
+	}
+	now := c.now()
+	for key, e := range c.accounts {
+		if !e.mu.TryLock() {
+			continue
+		}
+		if !now.Before(e.expires) {
+			delete(c.accounts, key)
+		}
+		e.mu.Unlock()
+	}
+	e := &ttlEntry[V]{}
+	c.accounts[account] = e
+	return e
+}
+
+// Get returns the value of account, calling load if it has expired. Failed
+// loads are not cached.
+func (c *ttlCache[V]) Get(account string, load func() (V, error)) (V, error) {
+	e := c.entry(account)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := c.now()
+	if now.Before(e.expires) {
+		return e.value, nil
+	}
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+	e.value, e.expires = value, now.Add(c.ttl)
+	return value, nil
+}
+
+// Set replaces the value of account and restarts its TTL.
+func (c *ttlCache[V]) Set(account string, value V) {
+	e := c.entry(account)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.value, e.expires = value, c.now().Add(c.ttl)
+}
+
+// Update replaces the value of account with the result of fn, if it has not
+// expired. The TTL is left as it is.
+func (c *ttlCache[V]) Update(account string, fn func(V) V) {
+	e := c.entry(account)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if c.now().Before(e.expires) {
+		e.value = fn(e.value)
+	}
+}
+
+// spamActionCache holds the spam actions the domains list reports, which the
+// domain's own endpoint does not always include. The list is read at most
+// once per TTL for each Mailgun account and shared by all its Domains, rather
+// than paged through by each of them.
+type spamActionCache struct {
+	*ttlCache[map[string]string]
+}
+
+func newSpamActionCache(ttl time.Duration) *spamActionCache {
+	return &spamActionCache{newTTLCache[map[string]string](ttl)}
+}
+
+// Get returns the listed spam action of the named domain, listing the
+// domains of the account if its listing has expired. It is empty when the
+// domain is not listed or the list does not report it. A nil cache lists the
+// domains on every call.
+func (s *spamActionCache) Get(ctx context.Context, account, name string, svc clients.Client) (string, error) {
+	if s == nil {
+		actions, err := listSpamActions(ctx, svc)
+		return actions[name], err
+	}
+	actions, err := s.ttlCache.Get(account, func() (map[string]string, error) {
+		return listSpamActions(ctx, svc)
+	})
+	return actions[name], err
+}
+
+// Record updates the cached spam action of a domain after the provider has
+// set it, so that a listing read before the change is not reported as drift.
+func (s *spamActionCache) Record(account, name, action string) {
+	if s == nil {
+		return
+	}
+	// The listing is copied, as earlier callers of Get may still read it
+	s.Update(account, func(actions map[string]string) map[string]string {
+		updated := maps.Clone(actions)
+		updated[name] = action
+		return updated
+	})
+}
+
+func listSpamActions(ctx context.Context, svc clients.Client) (map[string]string, error) {
+	all, err := clients.ListAllDomains(ctx, svc)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot list domains to read their spam actions")
+	}
+	actions := make(map[string]string, len(all))
+	for _, d := range all {
+		if d.SpamAction != "" {
+			actions[d.ID] = d.SpamAction
+		}
+	}
+	return actions, nil
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package domain
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
+)
+
+func newDomainCR(name string) *v1beta1.Domain {
+	return &v1beta1.Domain{
+		Spec: v1beta1.DomainSpec{
+			ForProvider: v1beta1.DomainParameters{Name: name},
+		},
+	}
+}
+
+// verifiedRecords returns DNS records of domain that were all found valid
+func verifiedRecords(domain string) []v1beta1.DNSRecord {
+	return []v1beta1.DNSRecord{{Name: domain, Type: "TXT", Value: "v=spf1 include:mailgun.org ~all", Valid: boolPtr(true)}}
+}
+
+func TestWarmupCacheSeedsObserve(t *testing.T) {
+	mockClient := &MockDomainClient{
+		domains: map[string]*v1beta1.DomainObservation{
+			"a.example.com": {ID: "a.example.com", State: "active", SMTPLogin: "postmaster@a.example.com"},
+			"b.example.com": {ID: "b.example.com", State: "unverified"},
+		},
+	}
+	cache := newWarmupCache(time.Minute)
+
+	require.NoError(t, cache.Warm(context.Background(), "account", mockClient))
+	require.NoError(t, cache.Warm(context.Background(), "account", mockClient))
+	assert.Equal(t, 1, mockClient.listCalls, "warm-up should list domains once per account")
+
+	e := &external{service: mockClient, warmup: cache, account: "account"}
+
+	records := verifiedRecords("a.example.com")
+	crA := newDomainCR("a.example.com")
+	crA.Status.AtProvider.SendingDNSRecords = records
+	obs, err := e.Observe(context.Background(), crA)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+	assert.Equal(t, "active", crA.Status.AtProvider.State)
+	assert.Equal(t, records, crA.Status.AtProvider.SendingDNSRecords, "DNS records should survive a cached observe")
+
+	crB := newDomainCR("b.example.com")
+	crB.Status.AtProvider.SendingDNSRecords = verifiedRecords("b.example.com")
+	_, err = e.Observe(context.Background(), crB)
+	require.NoError(t, err)
+	assert.Equal(t, "unverified", crB.Status.AtProvider.State)
+
+	assert.Equal(t, 0, mockClient.getCalls, "first observes should be served by the warm-up cache")
+
+	// Entries are consumed, so the next round goes to the API.
+	_, err = e.Observe(context.Background(), crA)
+	require.NoError(t, err)
+	assert.Equal(t, 1, mockClient.getCalls)
+}
+
+func TestWarmupCacheSkipsUnverifiedRecords(t *testing.T) {
+	cases := map[string]struct {
+		records []v1beta1.DNSRecord
+	}{
+		"NoRecords": {},
+		"NotYetValid": {
+			records: []v1beta1.DNSRecord{{Name: "example.com", Type: "TXT", Value: "v=spf1 include:mailgun.org ~all", Valid: boolPtr(false)}},
+		},
+		"Unchecked": {
+			records: []v1beta1.DNSRecord{{Name: "example.com", Type: "TXT", Value: "v=spf1 include:mailgun.org ~all"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			live := verifiedRecords("example.com")
+			mockClient := &MockDomainClient{
+				domains: map[string]*v1beta1.DomainObservation{
+					"example.com": {ID: "example.com", State: "active", SendingDNSRecords: live},
+				},
+			}
+			cache := newWarmupCache(time.Minute)
+			require.NoError(t, cache.Warm(context.Background(), "account", mockClient))
+			e := &external{service: mockClient, warmup: cache, account: "account"}
+
+			cr := newDomainCR("example.com")
+			cr.Status.AtProvider.SendingDNSRecords = tc.records
+			_, err := e.Observe(context.Background(), cr)
+			require.NoError(t, err)
+			assert.Equal(t, 1, mockClient.getCalls, "records not known to be valid should be fetched live")
+			assert.Equal(t, live, cr.Status.AtProvider.SendingDNSRecords)
+		})
+	}
+}
+
+func TestWarmupCachePaginates(t *testing.T) {
+	mockClient := &MockDomainClient{domains: map[string]*v1beta1.DomainObservation{}}
+	for i := 0; i < clients.DomainPageSize+5; i++ {
+		name := fmt.Sprintf("d%03d.example.com", i)
+		mockClient.domains[name] = &v1beta1.DomainObservation{ID: name}
+	}
+	cache := newWarmupCache(time.Minute)
+
+	require.NoError(t, cache.Warm(context.Background(), "account", mockClient))
+	assert.Equal(t, 2, mockClient.listCalls)
+	assert.Len(t, cache.accounts["account"].domains, clients.DomainPageSize+5)
+}
+
+func TestWarmupCacheExpires(t *testing.T) {
+	mockClient := &MockDomainClient{
+		domains: map[string]*v1beta1.DomainObservation{
+			"a.example.com": {ID: "a.example.com", State: "active"},
+		},
+	}
+	now := time.Now()
+	cache := newWarmupCache(time.Minute)
+	cache.now = func() time.Time { return now }
+	require.NoError(t, cache.Warm(context.Background(), "account", mockClient))
+
+	now = now.Add(2 * time.Minute)
+	_, ok := cache.Take("account", "a.example.com")
+	assert.False(t, ok, "expired entries must not be served")
+	assert.Nil(t, cache.accounts["account"].domains, "the domains of an expired account should be dropped")
+
+	require.NoError(t, cache.Warm(context.Background(), "account", mockClient))
+	assert.Equal(t, 1, mockClient.listCalls, "an expired account should not be warmed again")
+}
+
+func TestWarmupCacheNotReady(t *testing.T) {
+	cache := newWarmupCache(time.Minute)
+	cache.accounts["account"] = &warmedAccount{}
+
+	_, ok := cache.Take("account", "a.example.com")
+	assert.False(t, ok, "nothing should be served while the account is being warmed")
+}
+
+func TestWarmupCacheListFailure(t *testing.T) {
+	mockClient := &MockDomainClient{err: errors.New("API request failed with status 500")}
+	cache := newWarmupCache(time.Minute)
+
+	require.Error(t, cache.Warm(context.Background(), "account", mockClient))
+	require.NoError(t, cache.Warm(context.Background(), "account", mockClient))
+	assert.Equal(t, 1, mockClient.listCalls, "a failed warm-up should not be retried")
+}
+
+func TestSpamActionCacheSharesListing(t *testing.T) {
+	mockClient := &MockDomainClient{
+		domains: map[string]*v1beta1.DomainObservation{
+			"a.example.com": {ID: "a.example.com", State: "active"},
+			"b.example.com": {ID: "b.example.com", State: "active"},
+		},
+		listedSpamActions: map[string]string{"a.example.com": "tag", "b.example.com": "block"},
+	}
+	now := time.Now()
+	cache := newSpamActionCache(time.Minute)
+	cache.now = func() time.Time { return now }
+	e := &external{service: mockClient, spamActions: cache, account: "account"}
+
+	for _, name := range []string{"a.example.com", "b.example.com", "a.example.com"} {
+		cr := newDomainCR(name)
+		cr.Spec.ForProvider.SpamAction = stringPtr("tag")
+		_, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, mockClient.listedSpamActions[name], cr.Status.AtProvider.SpamAction)
+	}
+	assert.Equal(t, 1, mockClient.listCalls, "the domains of an account should be listed once per TTL")
+
+	// The spam action the provider sets is not reported as drift
+	cr := newDomainCR("b.example.com")
+	cr.Spec.ForProvider.SpamAction = stringPtr("tag")
+	_, err := e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, "tag", cr.Status.AtProvider.SpamAction)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.Equal(t, 1, mockClient.listCalls)
+
+	now = now.Add(2 * time.Minute)
+	_, err = e.Observe(context.Background(), newDomainCR("a.example.com"))
+	require.NoError(t, err)
+	_, err = cache.Get(context.Background(), "account", "a.example.com", mockClient)
+	require.NoError(t, err)
+	assert.Equal(t, 2, mockClient.listCalls, "an expired listing should be read again")
+}
+
+func TestSpamActionCacheListFailure(t *testing.T) {
+	mockClient := &MockDomainClient{listErr: errors.New("API request failed with status 500")}
+	cache := newSpamActionCache(time.Minute)
+
+	_, err := cache.Get(context.Background(), "account", "a.example.com", mockClient)
+	require.Error(t, err)
+	_, err = cache.Get(context.Background(), "account", "a.example.com", mockClient)
+	require.Error(t, err)
+	assert.Equal(t, 2, mockClient.listCalls, "a failed listing should not be cached")
+}
+
+func TestTTLCacheLocksPerAccount(t *testing.T) {
+	cache := newTTLCache[string](time.Minute)
+	loading, release := make(chan struct{}), make(chan struct{})
+	done := make(chan string)
+	go func() {
+		value, _ := cache.Get("slow", func() (string, error) {
+			close(loading)
+			<-release
+			return "slow-value", nil
+		})
+		done <- value
+	}()
+	<-loading
+
+	// Other accounts are served while the slow one is loading
+	value, err := cache.Get("fast", func() (string, error) { return "fast-value", nil })
+	require.NoError(t, err)
+	assert.Equal(t, "fast-value", value)
+	cache.Set("other", "set-value")
+
+	// Readers of the loading account wait for its load rather than repeat it
+	waiting := make(chan string)
+	go func() {
+		value, _ := cache.Get("slow", func() (string, error) { return "second-load", nil })
+		waiting <- value
+	}()
+	close(release)
+	assert.Equal(t, "slow-value", <-done)
+	assert.Equal(t, "slow-value", <-waiting)
+}
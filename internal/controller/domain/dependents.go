@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package domain
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bouncev1beta1 "github.com/rossigee/provider-mailgun/apis/bounce/v1beta1"
+	complaintv1beta1 "github.com/rossigee/provider-mailgun/apis/complaint/v1beta1"
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+	smtpcredentialv1beta1 "github.com/rossigee/provider-mailgun/apis/smtpcredential/v1beta1"
+	templatev1beta1 "github.com/rossigee/provider-mailgun/apis/template/v1beta1"
+	templateversionv1beta1 "github.com/rossigee/provider-mailgun/apis/templateversion/v1beta1"
+	unsubscribev1beta1 "github.com/rossigee/provider-mailgun/apis/unsubscribe/v1beta1"
+	webhookv1beta1 "github.com/rossigee/provider-mailgun/apis/webhook/v1beta1"
+)
+
+const (
+	errListDependents = "cannot list resources that reference the domain"
+	errHasDependents  = "domain is still referenced by %s; waiting for them to be deleted first"
+)
+
+// dependents returns the resources in the namespace of cr whose domainRef
+// names it, as Kind/name. A domainRef may name either the Domain object or
+// the Mailgun domain, so both count, as does a domain resolved from a
+// reference to it. Templates also count when cr is one of the further
+// domains they are kept on, and TemplateVersions when they name its domain.
+// Resources already being deleted are included until they are gone, as their
+// Mailgun resources may still exist.
+func (c *external) dependents(ctx context.Context, cr *v1beta1.Domain) ([]string, error) {
+	refs := map[string]bool{cr.GetName(): true}
+	if cr.Spec.ForProvider.Name != "" {
+		refs[cr.Spec.ForProvider.Name] = true
+	}
+
+	var found []string
+	add := func(kind, name, ref string) {
+		if refs[ref] {
+			found = append(found, kind+"/"+name)
+		}
+	}
+	addResolved := func(kind, name string, ref *xpv1.NamespacedReference, domain string) {
+		switch {
+		case ref != nil && ref.Name == cr.GetName():
+			found = append(found, kind+"/"+name)
+		case domain != "":
+			add(kind, name, domain)
+		}
+	}
+	in := client.InNamespace(cr.GetNamespace())
+
+	webhooks := &webhookv1beta1.WebhookList{}
+	if err := c.kube.List(ctx, webhooks, in); err != nil {
+		return nil, errors.Wrap(err, errListDependents)
+	}
+	for _, w := range webhooks.Items {
+		addResolved(webhookv1beta1.WebhookKind, w.GetName(), w.Spec.ForProvider.DomainRef, w.Spec.ForProvider.Domain)
+	}
+
+	credentials := &smtpcredentialv1beta1.SMTPCredentialList{}
+	if err := c.kube.List(ctx, credentials, in); err != nil {
+		return nil, errors.Wrap(err, errListDependents)
+	}
+	for _, sc := range credentials.Items {
+		addResolved(smtpcredentialv1beta1.SMTPCredentialKind, sc.GetName(), sc.Spec.ForProvider.DomainRef, sc.Spec.ForProvider.Domain)
+	}
+
+	templates := &templatev1beta1.TemplateList{}
+	if err := c.kube.List(ctx, templates, in); err != nil {
+		return nil, errors.Wrap(err, errListDependents)
+	}
+	for _, t := range templates.Items {
+		if slices.ContainsFunc(t.Spec.ForProvider.Domains, func(domain string) bool { return refs[domain] }) {
+			found = append(found, templatev1beta1.TemplateKind+"/"+t.GetName())
+			continue
+		}
+		addResolved(templatev1beta1.TemplateKind, t.GetName(), t.Spec.ForProvider.DomainRef, t.Spec.ForProvider.Domain)
+	}
+
+	versions := &templateversionv1beta1.TemplateVersionList{}
+	if err := c.kube.List(ctx, versions, in); err != nil {
+		return nil, errors.Wrap(err, errListDependents)
+	}
+	for _, v := range versions.Items {
+		add(templateversionv1beta1.TemplateVersionKind, v.GetName(), v.Spec.ForProvider.Domain)
+	}
+
+	bounces := &bouncev1beta1.BounceList{}
+	if err := c.kube.List(ctx, bounces, in); err != nil {
+		return nil, errors.Wrap(err, errListDependents)
+	}
+	for _, b := range bounces.Items {
+		add(bouncev1beta1.BounceKind, b.GetName(), b.Spec.ForProvider.DomainRef.Name)
+	}
+
+	complaints := &complaintv1beta1.ComplaintList{}
+	if err := c.kube.List(ctx, complaints, in); err != nil {
+		return nil, errors.Wrap(err, errListDependents)
+	}
+	for _, cp := range complaints.Items {
+		add(complaintv1beta1.ComplaintKind, cp.GetName(), cp.Spec.ForProvider.DomainRef.Name)
+	}
+
+	unsubscribes := &unsubscribev1beta1.UnsubscribeList{}
+	if err := c.kube.List(ctx, unsubscribes, in); err != nil {
+		return nil, errors.Wrap(err, errListDependents)
+	}
+	for _, u := range unsubscribes.Items {
+		add(unsubscribev1beta1.UnsubscribeKind, u.GetName(), u.Spec.ForProvider.DomainRef.Name)
+	}
+
+	sort.Strings(found)
+	return found, nil
+}
+
+// checkDependents fails while resources referencing cr still exist, so that
+// the Domain keeps its finalizer and Mailgun is never asked to delete a
+// domain that other managed resources are still attached to.
+func (c *external) checkDependents(ctx context.Context, cr *v1beta1.Domain) error {
+	if !c.waitForDependents || c.kube == nil {
+		return nil
+	}
+	found, err := c.dependents(ctx, cr)
+	if err != nil {
+		return err
+	}
+	if len(found) > 0 {
+		return errors.Errorf(errHasDependents, strings.Join(found, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package domain
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+)
+
+// defaultDKIMKeySize is the size of the DKIM key Mailgun generates when the
+// spec does not set one
+const defaultDKIMKeySize = 1024
+
+// dkimRotationRequested returns the value of the DKIM rotation annotation if
+// it asks for a rotation that has not been performed yet.
+func dkimRotationRequested(cr *v1beta1.Domain) (string, bool) {
+	requested := cr.GetAnnotations()[v1beta1.AnnotationRotateDKIMKey]
+	return requested, requested != "" && requested != cr.Status.AtProvider.DKIMKeyRotation
+}
+
+// rotateDKIMKey replaces the domain's DKIM key if the rotation annotation
+// asks for it. The DKIM records of the previous key are removed from status
+// and the DNS record of the new key reported instead, until the next
+// observation reads the records back from Mailgun.
+func (c *external) rotateDKIMKey(ctx context.Context, cr *v1beta1.Domain) error {
+	requested, ok := dkimRotationRequested(cr)
+	if !ok {
+		return nil
+	}
+	keySize := defaultDKIMKeySize
+	if cr.Spec.ForProvider.DKIMKeySize != nil {
+		keySize = *cr.Spec.ForProvider.DKIMKeySize
+	}
+	records, err := c.service.UpdateDKIMKey(ctx, cr.Spec.ForProvider.Name, keySize)
+	if err != nil {
+		return errors.Wrap(err, "failed to rotate DKIM key")
+	}
+	cr.Status.AtProvider.DKIMKeyRotation = requested
+	if len(records) > 0 {
+		cr.Status.AtProvider.SendingDNSRecords = append(withoutDKIMRecords(cr.Status.AtProvider.SendingDNSRecords), records...)
+	}
+	return nil
+}
+
+// withoutDKIMRecords returns the records that do not publish a DKIM key
+func withoutDKIMRecords(records []v1beta1.DNSRecord) []v1beta1.DNSRecord {
+	var kept []v1beta1.DNSRecord
+	for _, r := range records {
+		if !strings.Contains(r.Name, "._domainkey.") {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
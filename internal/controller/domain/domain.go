@@ -18,14 +18,18 @@ package domain
 
 import (
 	"context"
+	"strings"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -33,6 +37,20 @@ import (
 	v1beta1 "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
 	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
 	"github.com/rossigee/provider-mailgun/internal/clients"
+	mgerrors "github.com/rossigee/provider-mailgun/internal/errors"
+	"github.com/rossigee/provider-mailgun/internal/eventlabel"
+	"github.com/rossigee/provider-mailgun/internal/failfast"
+	"github.com/rossigee/provider-mailgun/internal/features"
+	"github.com/rossigee/provider-mailgun/internal/immutable"
+	"github.com/rossigee/provider-mailgun/internal/metrics"
+	"github.com/rossigee/provider-mailgun/internal/observecache"
+	"github.com/rossigee/provider-mailgun/internal/ratelimit"
+	"github.com/rossigee/provider-mailgun/internal/readonly"
+	"github.com/rossigee/provider-mailgun/internal/resilience"
+	"github.com/rossigee/provider-mailgun/internal/shutdown"
+	"github.com/rossigee/provider-mailgun/internal/statickeys"
+	"github.com/rossigee/provider-mailgun/internal/strictdelete"
+	"github.com/rossigee/provider-mailgun/internal/watchdog"
 )
 
 const (
@@ -40,22 +58,50 @@ const (
 	errTrackPCUsage = "cannot track ProviderConfig usage"
 	errGetPC        = "cannot get ProviderConfig"
 	errGetCreds     = "cannot get credentials"
+	errTerminal     = "Mailgun rejected the domain at this generation of its spec; see the TerminalError condition"
+)
+
+// domainTypeReceiving is the spec type of domains that accept inbound mail
+const domainTypeReceiving = "receiving"
+
+// Domain states reported by Mailgun
+const (
+	stateActive   = "active"
+	stateDisabled = "disabled"
 )
 
 // Setup adds a controller that reconciles Domain managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(v1beta1.DomainKind)
 
-	r := managed.NewReconciler(mgr,
-		resource.ManagedKind(v1beta1.DomainGroupVersionKind),
-		managed.WithExternalConnector(&connector{
-			kube:         mgr.GetClient(),
-			usage:        resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
-			newServiceFn: clients.NewClient,
-		}),
+	conn := &connector{
+		kube:                mgr.GetClient(),
+		usage:               resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+		newServiceFn:        clients.NewClient,
+		log:                 o.Logger.WithValues("controller", name),
+		waitForDependents:   o.Features.Enabled(features.EnableDependentDeletionOrdering),
+		haltOnTerminalError: o.Features.Enabled(features.EnableTerminalErrorHalt),
+		createRetry:         resilience.CreateRetryConfig(),
+	}
+	if o.Features.Enabled(features.EnableDomainCacheWarmup) {
+		conn.warmup = newWarmupCache(warmupTTL)
+	}
+	conn.spamActions = newSpamActionCache(o.PollInterval)
+	conn.signingKeys = newSigningKeyCache(o.PollInterval)
+
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnector(failfast.Wrap(mgr.GetClient(), readonly.Wrap(shutdown.Wrap(strictdelete.Wrap(watchdog.Wrap(observecache.Wrap(ratelimit.Wrap(immutable.Wrap(statickeys.Wrap(conn, staticConnectionDetails, "smtp_login", "smtp_password", connectionKeyWebhookSigningKey), immutableFields))))))))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
+		managed.WithRecorder(eventlabel.Wrap(event.NewAPIRecorder(mgr.GetEventRecorder(name)))),
+	}
+	// Leaving Delete out of the management policies is how a domain is
+	// retired while kept in Mailgun
+	if o.Features.Enabled(features.EnableAlphaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1beta1.DomainGroupVersionKind), opts...)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -65,12 +111,55 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		Complete(r)
 }
 
+// immutableFields returns the fields that identify the Mailgun domain
+func immutableFields(mg resource.Managed) map[string]string {
+	cr, ok := mg.(*v1beta1.Domain)
+	if !ok {
+		return nil
+	}
+	return map[string]string{
+		"spec.forProvider.name": cr.Spec.ForProvider.Name,
+	}
+}
+
+// staticConnectionDetails returns the connection details a Domain publishes
+// as-is
+func staticConnectionDetails(mg resource.Managed) map[string]string {
+	cr, ok := mg.(*v1beta1.Domain)
+	if !ok {
+		return nil
+	}
+	return cr.Spec.ForProvider.StaticConnectionDetails
+}
+
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
 	newServiceFn func(config *clients.Config) clients.Client
+	log          logging.Logger
+
+	// warmup, when set, is seeded from a single domains-list call the first
+	// time each Mailgun account is connected to.
+	warmup *warmupCache
+
+	// spamActions shares the spam actions listed for each Mailgun account
+	spamActions *spamActionCache
+
+	// signingKeys shares the webhook signing key of each Mailgun account
+	signingKeys *signingKeyCache
+
+	// waitForDependents makes Delete wait for resources referencing the
+	// Domain to be deleted first
+	waitForDependents bool
+
+	// haltOnTerminalError stops reconciling a Domain Mailgun rejected as
+	// invalid until its spec changes
+	haltOnTerminalError bool
+
+	// createRetry, when set, bounds retries of server errors during Create
+	createRetry *resilience.RetryConfig
 }
 
 // Connect typically produces an ExternalClient by:
@@ -116,7 +205,7 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	}
 
 	cd := pc.Spec.Credentials
-	_, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	_, err := clients.ExtractCredentials(ctx, c.kube, cd)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
@@ -128,7 +217,25 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 
 	svc := c.newServiceFn(config)
 
-	return &external{service: svc}, nil
+	ext := &external{service: svc, kube: c.kube, spamActions: c.spamActions, signingKeys: c.signingKeys, account: accountKey(config), log: c.log, waitForDependents: c.waitForDependents, haltOnTerminalError: c.haltOnTerminalError, createRetry: c.createRetry, reconcile: metrics.NewReconcileTimer(v1beta1.DomainKind)}
+	if c.warmup == nil {
+		return ext, nil
+	}
+
+	// The warm-up runs in the background so that it does not hold up this
+	// reconcile; Observes fall back to a GET until it has finished.
+	go c.warm(ext.account, svc)
+	ext.warmup = c.warmup
+	return ext, nil
+}
+
+// warm seeds the warm-up cache for account, if it has not been already
+func (c *connector) warm(account string, svc clients.Client) {
+	ctx, cancel := context.WithTimeout(context.Background(), warmupTimeout)
+	defer cancel()
+	if err := c.warmup.Warm(ctx, account, svc); err != nil {
+		c.log.Debug("Domain cache warm-up failed, falling back to per-domain lookups", "error", err)
+	}
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
@@ -137,10 +244,25 @@ type external struct {
 	// A 'client' used to connect to the external resource API. In practice this
 	// would be something like an AWS SDK client.
 	service clients.Client
+	kube    client.Client
+
+	warmup      *warmupCache
+	spamActions *spamActionCache
+	signingKeys *signingKeyCache
+	account     string
+	log         logging.Logger
+
+	waitForDependents   bool
+	haltOnTerminalError bool
+	createRetry         *resilience.RetryConfig
+
+	// reconcile times the work done by this client for one reconcile
+	reconcile *metrics.ReconcileTimer
 }
 
 func (c *external) Disconnect(ctx context.Context) error {
 	// No persistent connections to clean up
+	c.reconcile.Record()
 	return nil
 }
 
@@ -179,12 +301,22 @@ func (e *ExternalForTesting) Delete(ctx context.Context, mg resource.Managed) (m
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.Domain)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotDomain)
 	}
 
-	domain, err := c.service.GetDomain(ctx, cr.Spec.ForProvider.Name)
+	if c.haltOnTerminalError && halted(cr) {
+		// A rejected domain was never created, so there is nothing to delete
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.New(errTerminal)
+	}
+
+	domain, err := c.getDomain(ctx, cr)
 	if err != nil {
 		if clients.IsNotFound(err) {
 			return managed.ExternalObservation{ResourceExists: false}, nil
@@ -192,15 +324,91 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.Wrap(err, "failed to get domain")
 	}
 
-	upToDate := isDomainUpToDate(domain, &cr.Spec.ForProvider)
+	if domain.WebScheme == "" || domain.Wildcard == nil {
+		observed := *domain
+		if observed.WebScheme == "" {
+			observed.WebScheme = cr.Status.AtProvider.WebScheme
+		}
+		if observed.Wildcard == nil {
+			observed.Wildcard = cr.Status.AtProvider.Wildcard
+		}
+		domain = &observed
+	}
 
+	// The spam action is best-effort: when the list cannot be read it is
+	// left unknown, which is not reported as drift
+	if cr.Spec.ForProvider.SpamAction != nil && domain.SpamAction == "" {
+		spamAction, err := c.spamActions.Get(ctx, c.account, cr.Spec.ForProvider.Name, c.service)
+		if err != nil {
+			c.log.Debug("Cannot read the spam action of the domain", "error", err)
+		}
+		observed := *domain
+		observed.SpamAction = spamAction
+		domain = &observed
+	}
+
+	// Mailgun does not apply settings to a disabled domain, so drift is left
+	// for when it is enabled again rather than retried on every reconcile
+	upToDate := isDisabled(domain) || (isDomainUpToDate(domain, &cr.Spec.ForProvider) &&
+		cr.GetCondition(v1beta1.TypePartiallyConfigured).Status != corev1.ConditionTrue)
+
+	// Tracking has its own endpoint, so it is only read when it is managed
+	var tracking *v1beta1.DomainTracking
+	if desired := cr.Spec.ForProvider.Tracking; desired != nil {
+		if tracking, err = c.service.GetDomainTracking(ctx, cr.Spec.ForProvider.Name); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, "failed to get domain tracking")
+		}
+		upToDate = upToDate && (isDisabled(domain) || isTrackingUpToDate(tracking, desired))
+	}
+
+	// So are the connection settings
+	var connection *v1beta1.DomainConnection
+	if desired := cr.Spec.ForProvider.Connection; desired != nil {
+		if connection, err = c.service.GetDomainConnection(ctx, cr.Spec.ForProvider.Name); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, "failed to get domain connection settings")
+		}
+		upToDate = upToDate && (isDisabled(domain) || isConnectionUpToDate(connection, desired))
+	}
+
+	previous := cr.Status.AtProvider
 	cr.Status.AtProvider = *domain
+	cr.Status.AtProvider.Tracking = tracking
+	cr.Status.AtProvider.Connection = connection
+	cr.Status.AtProvider.WebhookSigningKeyRotation = previous.WebhookSigningKeyRotation
+	cr.Status.AtProvider.DKIMKeyRotation = previous.DKIMKeyRotation
+	cr.Status.AtProvider.LastOperationMessage = previous.LastOperationMessage
+	cr.Status.AtProvider.StateHistory = recordStateTransition(previous.StateHistory, previous.State, domain.State, metav1.Now())
+
+	recipientsUpToDate, err := c.observeRecipients(ctx, cr, previous.AuthorizedRecipients)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	upToDate = upToDate && recipientsUpToDate
 
-	if domain.State == "active" {
-		cr.SetConditions(xpv1.Available())
-	} else {
-		cr.SetConditions(xpv1.Creating())
+	ipsUpToDate, err := c.observeIPs(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
 	}
+	upToDate = upToDate && (isDisabled(domain) || ipsUpToDate)
+
+	if _, ok := rotationRequested(cr); ok {
+		upToDate = false
+	}
+	if _, ok := dkimRotationRequested(cr); ok {
+		upToDate = false
+	}
+	details := connectionDetails(cr, domain)
+	if err := c.publishHeldPassword(ctx, cr, domain, details); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if err := c.publishSigningKey(ctx, cr, details); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	setStateConditions(cr, domain)
+	setReceivingCondition(cr)
+
+	adopted := adoptDomain(cr)
 
 	return managed.ExternalObservation{
 		// Return false when the external resource does not exist. This lets
@@ -213,16 +421,31 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		// resource reconciler know that it needs to call Update.
 		ResourceUpToDate: upToDate,
 
+		// Persist the external name of an adopted domain.
+		ResourceLateInitialized: adopted,
+
 		// Return any details that may be required to connect to the external
 		// resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{
-			"smtp_login":    []byte(domain.SMTPLogin),
-			"smtp_password": []byte(domain.SMTPPassword),
-		},
+		ConnectionDetails: details,
 	}, nil
 }
 
+// adoptDomain sets the external name of a domain found by its spec name and
+// reports whether it did. It only does so while the external name is unset or
+// still the object name the reconciler defaults it to, so an existing domain
+// is adopted rather than created and an explicit external name is kept.
+func adoptDomain(cr *v1beta1.Domain) bool {
+	name := cr.Spec.ForProvider.Name
+	if current := meta.GetExternalName(cr); current == name || (current != "" && current != cr.GetName()) {
+		return false
+	}
+	meta.SetExternalName(cr, name)
+	return true
+}
+
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.Domain)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotDomain)
@@ -230,60 +453,130 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	cr.SetConditions(xpv1.Creating())
 
-	domain, err := c.service.CreateDomain(ctx, &cr.Spec.ForProvider)
+	domain, err := c.createDomain(ctx, cr)
 	if err != nil {
-		return managed.ExternalCreation{}, errors.Wrap(err, "failed to create domain")
+		if c.haltOnTerminalError && clients.IsInvalidDomain(err) {
+			cr.SetConditions(v1beta1.TerminalError(clients.ErrorMessage(err), cr.GetGeneration()))
+		}
+		return managed.ExternalCreation{}, errors.Wrap(recordPlanLimit(cr, err), "failed to create domain")
 	}
+	clearPlanLimit(cr)
 
 	meta.SetExternalName(cr, cr.Spec.ForProvider.Name)
 	cr.Status.AtProvider = *domain
+	recordApplied(cr)
+
+	details := connectionDetails(cr, domain)
+	if withholdsConnectionDetails(cr, domain) && domain.SMTPPassword != "" {
+		cr.Status.AtProvider.SMTPPassword = ""
+		if err := c.holdPassword(ctx, cr, domain.SMTPPassword); err != nil {
+			// Publishing the password early beats losing it for good
+			details = smtpConnectionDetails(domain)
+		}
+	}
 
-	if domain.State == "active" {
-		cr.SetConditions(xpv1.Available())
-	} else {
-		cr.SetConditions(xpv1.Creating())
+	// Tracking has its own endpoints. If applying it fails the domain still
+	// exists, so record the gap and let the next reconcile complete it.
+	if err := c.service.UpdateDomainTracking(ctx, cr.Spec.ForProvider.Name, cr.Spec.ForProvider.Tracking); err != nil {
+		cr.SetConditions(v1beta1.TrackingNotApplied(err.Error()))
 	}
 
+	setStateConditions(cr, domain)
+	setReceivingCondition(cr)
+
 	return managed.ExternalCreation{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{
-			"smtp_login":    []byte(domain.SMTPLogin),
-			"smtp_password": []byte(domain.SMTPPassword),
-		},
+		ConnectionDetails: details,
 	}, nil
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.Domain)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotDomain)
 	}
 
-	domain, err := c.service.UpdateDomain(ctx, cr.Spec.ForProvider.Name, &cr.Spec.ForProvider)
-	if err != nil {
-		return managed.ExternalUpdate{}, errors.Wrap(err, "failed to update domain")
+	// Drift in the connection settings or the dedicated IPs alone is
+	// corrected through their own endpoints, without a domain update
+	observed := cr.Status.AtProvider
+	connectionUpToDate := isConnectionUpToDate(observed.Connection, cr.Spec.ForProvider.Connection)
+	assign, unassign := clients.DiffIPs(cr.Spec.ForProvider.IPs, observed.IPs)
+	ipsUpToDate := len(assign)+len(unassign) == 0
+	domain := &observed
+	if (connectionUpToDate && ipsUpToDate) || !isDomainUpToDate(&observed, &cr.Spec.ForProvider) {
+		var err error
+		if domain, err = c.service.UpdateDomain(ctx, cr.Spec.ForProvider.Name, &cr.Spec.ForProvider); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(recordPlanLimit(cr, err), "failed to update domain")
+		}
+		clearPlanLimit(cr)
+		if cr.Spec.ForProvider.SpamAction != nil {
+			c.spamActions.Record(c.account, cr.Spec.ForProvider.Name, *cr.Spec.ForProvider.SpamAction)
+		}
+	}
+	if !connectionUpToDate {
+		if err := c.service.UpdateDomainConnection(ctx, cr.Spec.ForProvider.Name, cr.Spec.ForProvider.Connection); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, "failed to update domain connection settings")
+		}
+	}
+
+	if err := c.service.UpdateDomainTracking(ctx, cr.Spec.ForProvider.Name, cr.Spec.ForProvider.Tracking); err != nil {
+		cr.SetConditions(v1beta1.TrackingNotApplied(err.Error()))
+		return managed.ExternalUpdate{}, errors.Wrap(err, "failed to update domain tracking")
+	}
+	if cr.GetCondition(v1beta1.TypePartiallyConfigured).Status == corev1.ConditionTrue {
+		cr.SetConditions(v1beta1.FullyConfigured())
+	}
+
+	if err := c.updateRecipients(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := c.updateIPs(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
 	}
 
+	details := connectionDetails(cr, domain)
+	if err := c.rotateSigningKey(ctx, cr, details); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// Authorized recipients, the dedicated IPs, tracking, the connection
+	// settings, the key rotations, the wildcard setting and the state history
+	// are not part of the domain response
+	previous := cr.Status.AtProvider
 	cr.Status.AtProvider = *domain
+	cr.Status.AtProvider.AuthorizedRecipients = previous.AuthorizedRecipients
+	cr.Status.AtProvider.IPs = previous.IPs
+	cr.Status.AtProvider.Tracking = previous.Tracking
+	cr.Status.AtProvider.Connection = previous.Connection
+	cr.Status.AtProvider.WebhookSigningKeyRotation = previous.WebhookSigningKeyRotation
+	cr.Status.AtProvider.DKIMKeyRotation = previous.DKIMKeyRotation
+	cr.Status.AtProvider.StateHistory = recordStateTransition(previous.StateHistory, previous.State, domain.State, metav1.Now())
+	if domain.LastOperationMessage == "" {
+		cr.Status.AtProvider.LastOperationMessage = previous.LastOperationMessage
+	}
+	cr.Status.AtProvider.Wildcard = previous.Wildcard
+	recordApplied(cr)
 
-	if domain.State == "active" {
-		cr.SetConditions(xpv1.Available())
-	} else {
-		cr.SetConditions(xpv1.Creating())
+	if err := c.rotateDKIMKey(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
 	}
 
+	setStateConditions(cr, domain)
+	setReceivingCondition(cr)
+
 	return managed.ExternalUpdate{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{
-			"smtp_login":    []byte(domain.SMTPLogin),
-			"smtp_password": []byte(domain.SMTPPassword),
-		},
+		ConnectionDetails: details,
 	}, nil
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.Domain)
 	if !ok {
 		return managed.ExternalDelete{}, errors.New(errNotDomain)
@@ -291,6 +584,10 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	cr.SetConditions(xpv1.Deleting())
 
+	if err := c.checkDependents(ctx, cr); err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
 	err := c.service.DeleteDomain(ctx, cr.Spec.ForProvider.Name)
 	if err != nil && !clients.IsNotFound(err) {
 		return managed.ExternalDelete{}, errors.Wrap(err, "failed to delete domain")
@@ -299,24 +596,273 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 	return managed.ExternalDelete{}, nil
 }
 
+// createDomain creates the domain, retrying server errors within the bounds
+// of createRetry. A failed call may still have created the domain, so each
+// retry first looks it up: domain names are unique within Mailgun, so
+// adopting a domain found this way never duplicates one.
+func (c *external) createDomain(ctx context.Context, cr *v1beta1.Domain) (*v1beta1.DomainObservation, error) {
+	if c.createRetry == nil {
+		return c.service.CreateDomain(ctx, &cr.Spec.ForProvider)
+	}
+
+	var domain *v1beta1.DomainObservation
+	attempted := false
+	err := resilience.WithRetry(ctx, "create_domain", c.createRetry, func() error {
+		if attempted {
+			if existing, err := c.service.GetDomain(ctx, cr.Spec.ForProvider.Name); err == nil {
+				domain = existing
+				return nil
+			}
+		}
+		attempted = true
+
+		var err error
+		domain, err = c.service.CreateDomain(ctx, &cr.Spec.ForProvider)
+		return err
+	})
+	return domain, err
+}
+
+// getDomain returns the domain from the warm-up cache if it has an entry,
+// and from the Mailgun API otherwise. The list endpoint does not return DNS
+// records, so cached observations keep those already in status. Those are
+// only kept once all of them were found valid by a live GET: a domain whose
+// records are missing from status (for example one being adopted) or still
+// being verified always gets a live GET, so its records are never stale.
+func (c *external) getDomain(ctx context.Context, cr *v1beta1.Domain) (*v1beta1.DomainObservation, error) {
+	if c.warmup != nil && dnsRecordsVerified(&cr.Status.AtProvider) {
+		if cached, ok := c.warmup.Take(c.account, cr.Spec.ForProvider.Name); ok {
+			domain := *cached
+			domain.RequiredDNSRecords = cr.Status.AtProvider.RequiredDNSRecords
+			domain.ReceivingDNSRecords = cr.Status.AtProvider.ReceivingDNSRecords
+			domain.SendingDNSRecords = cr.Status.AtProvider.SendingDNSRecords
+			return &domain, nil
+		}
+	}
+	return c.service.GetDomain(ctx, cr.Spec.ForProvider.Name)
+}
+
+// connectionDetails returns the SMTP credentials of a domain as reported by
+// Mailgun. The login is used verbatim since its format varies by account.
+// Values Mailgun did not return are omitted rather than published empty:
+// the password, for example, is only returned when the domain is created.
+// Nothing is returned while the credentials are withheld.
+func connectionDetails(cr *v1beta1.Domain, domain *v1beta1.DomainObservation) managed.ConnectionDetails {
+	if withholdsConnectionDetails(cr, domain) {
+		return managed.ConnectionDetails{}
+	}
+	return smtpConnectionDetails(domain)
+}
+
+// smtpConnectionDetails returns the SMTP credentials Mailgun reported
+func smtpConnectionDetails(domain *v1beta1.DomainObservation) managed.ConnectionDetails {
+	details := managed.ConnectionDetails{}
+	if domain.SMTPLogin != "" {
+		details["smtp_login"] = []byte(domain.SMTPLogin)
+	}
+	if domain.SMTPPassword != "" {
+		details["smtp_password"] = []byte(domain.SMTPPassword)
+	}
+	return details
+}
+
+// setStateConditions derives the Ready and Disabled conditions from the
+// state Mailgun reports for the domain.
+func setStateConditions(cr *v1beta1.Domain, domain *v1beta1.DomainObservation) {
+	switch {
+	case isDisabled(domain):
+		cr.SetConditions(xpv1.Unavailable(), v1beta1.Disabled(domain.DisabledReason))
+		return
+	case domain.State == stateActive:
+		cr.SetConditions(xpv1.Available())
+	default:
+		cr.SetConditions(xpv1.Creating())
+	}
+	if cr.GetCondition(v1beta1.TypeDisabled).Status == corev1.ConditionTrue {
+		cr.SetConditions(v1beta1.Enabled())
+	}
+}
+
+// setReceivingCondition derives the ReceivingReady condition of a receiving
+// domain from the validity of its MX records. An observation without MX
+// records, such as one seeded from the domains list, leaves it unchanged.
+func setReceivingCondition(cr *v1beta1.Domain) {
+	if cr.Spec.ForProvider.Type == nil || *cr.Spec.ForProvider.Type != domainTypeReceiving {
+		return
+	}
+
+	var mx, pending []string
+	for _, record := range cr.Status.AtProvider.ReceivingDNSRecords {
+		if !strings.EqualFold(record.Type, "MX") {
+			continue
+		}
+		mx = append(mx, record.Value)
+		if record.Valid == nil || !*record.Valid {
+			pending = append(pending, record.Value)
+		}
+	}
+
+	switch {
+	case len(mx) == 0:
+	case len(pending) > 0:
+		cr.SetConditions(v1beta1.ReceivingNotReady(pending))
+	default:
+		cr.SetConditions(v1beta1.ReceivingReady())
+	}
+}
+
+// isDisabled reports whether Mailgun has disabled the domain. Older responses
+// only say so through its state.
+func isDisabled(domain *v1beta1.DomainObservation) bool {
+	return domain.Disabled || domain.State == stateDisabled
+}
+
+// recordApplied stores the applied wildcard setting, and the web scheme when
+// Mailgun's response does not report one, in status after a write so drift
+// can still be detected against the last applied values.
+func recordApplied(cr *v1beta1.Domain) {
+	if cr.Status.AtProvider.WebScheme == "" && cr.Spec.ForProvider.WebScheme != nil {
+		cr.Status.AtProvider.WebScheme = *cr.Spec.ForProvider.WebScheme
+	}
+	if cr.Spec.ForProvider.Wildcard != nil {
+		wildcard := *cr.Spec.ForProvider.Wildcard
+		cr.Status.AtProvider.Wildcard = &wildcard
+	}
+}
+
+// maxStateHistory bounds the state transitions kept in a domain's status
+const maxStateHistory = 10
+
+// recordStateTransition appends the change from one state to another to
+// history, dropping the oldest transitions beyond maxStateHistory. Nothing
+// is recorded when the state is unchanged or was not known before.
+func recordStateTransition(history []v1beta1.DomainStateTransition, from, to string, at metav1.Time) []v1beta1.DomainStateTransition {
+	if from == "" || to == "" || from == to {
+		return history
+	}
+	history = append(history, v1beta1.DomainStateTransition{From: from, To: to, ObservedAt: at})
+	if len(history) > maxStateHistory {
+		history = append([]v1beta1.DomainStateTransition(nil), history[len(history)-maxStateHistory:]...)
+	}
+	return history
+}
+
+// dnsRecordsVerified reports whether o has DNS records and all of them are
+// valid
+func dnsRecordsVerified(o *v1beta1.DomainObservation) bool {
+	found := false
+	for _, records := range [][]v1beta1.DNSRecord{o.RequiredDNSRecords, o.ReceivingDNSRecords, o.SendingDNSRecords} {
+		for _, r := range records {
+			if r.Valid == nil || !*r.Valid {
+				return false
+			}
+			found = true
+		}
+	}
+	return found
+}
+
+// recordPlanLimit sets the PlanLimited condition when err was caused by the
+// account plan and returns it as a PlanLimited provider error. Other errors
+// are returned unchanged.
+func recordPlanLimit(cr *v1beta1.Domain, err error) error {
+	if !clients.IsPlanLimited(err) {
+		return err
+	}
+	cr.SetConditions(apisv1beta1.PlanLimited(clients.ErrorMessage(err)))
+	return mgerrors.NewPlanLimitedError(err)
+}
+
+// halted reports whether Mailgun rejected the domain at its current
+// generation. A TerminalError condition left from an earlier generation is
+// cleared, so the new spec is tried.
+func halted(cr *v1beta1.Domain) bool {
+	cond := cr.GetCondition(v1beta1.TypeTerminalError)
+	if cond.Status != corev1.ConditionTrue {
+		return false
+	}
+	if cond.ObservedGeneration == cr.GetGeneration() {
+		return true
+	}
+	cr.SetConditions(v1beta1.TerminalErrorCleared())
+	return false
+}
+
+// clearPlanLimit resets a previously set PlanLimited condition after a
+// successful write.
+func clearPlanLimit(cr *v1beta1.Domain) {
+	if cr.GetCondition(apisv1beta1.TypePlanLimited).Status == corev1.ConditionTrue {
+		cr.SetConditions(apisv1beta1.PlanSupported())
+	}
+}
+
+// isTrackingUpToDate reports whether the tracking settings set in desired
+// match the observed ones. Footers are compared ignoring surrounding
+// whitespace.
+func isTrackingUpToDate(observed, desired *v1beta1.DomainTracking) bool {
+	flags := []struct{ observed, desired *bool }{
+		{observed.Click, desired.Click},
+		{observed.Open, desired.Open},
+		{observed.Unsubscribe, desired.Unsubscribe},
+	}
+	for _, f := range flags {
+		if f.desired != nil && (f.observed == nil || *f.observed != *f.desired) {
+			return false
+		}
+	}
+
+	footers := []struct{ observed, desired *string }{
+		{observed.UnsubscribeHTMLFooter, desired.UnsubscribeHTMLFooter},
+		{observed.UnsubscribeTextFooter, desired.UnsubscribeTextFooter},
+	}
+	for _, f := range footers {
+		if f.desired != nil && (f.observed == nil || strings.TrimSpace(*f.observed) != strings.TrimSpace(*f.desired)) {
+			return false
+		}
+	}
+	return true
+}
+
+// isConnectionUpToDate reports whether the connection settings set in
+// desired match the observed ones. Nothing set is always up to date.
+func isConnectionUpToDate(observed, desired *v1beta1.DomainConnection) bool {
+	if desired == nil {
+		return true
+	}
+	if observed == nil {
+		return false
+	}
+	for _, f := range []struct{ observed, desired *bool }{
+		{observed.RequireTLS, desired.RequireTLS},
+		{observed.SkipVerification, desired.SkipVerification},
+	} {
+		if f.desired != nil && (f.observed == nil || *f.observed != *f.desired) {
+			return false
+		}
+	}
+	return true
+}
+
 // isDomainUpToDate checks if the external resource is up to date
 func isDomainUpToDate(domain *v1beta1.DomainObservation, desired *v1beta1.DomainParameters) bool {
 	// Compare updatable fields only
 	// Note: Most domain fields cannot be updated after creation in Mailgun
 	// We only check the fields that can be modified
 
-	// SpamAction is write-only and cannot be read back from Mailgun API
-	// so we cannot compare it in the observation
-	// WebScheme and Wildcard are write-only fields in Mailgun API
-	// They are not returned in the domain response, so we cannot compare them
-	// We assume they are up to date since they were set during creation/update
-	// Note: These settings can only be verified through separate tracking/subdomain API calls
-	// which are not currently implemented in this provider
-	if desired.WebScheme != nil {
-		_ = desired.WebScheme // prevent unused variable warning
-	}
-	if desired.Wildcard != nil {
-		_ = desired.Wildcard // prevent unused variable warning
+	// SpamAction is only compared when Mailgun reports it, by either the
+	// domain or the domains list
+	if desired.SpamAction != nil && domain.SpamAction != "" && domain.SpamAction != *desired.SpamAction {
+		return false
+	}
+
+	// WebScheme is either reported by Mailgun or the last applied value
+	if desired.WebScheme != nil && domain.WebScheme != *desired.WebScheme {
+		return false
+	}
+
+	// Wildcard is the last applied value, unset until the provider applies it
+	if desired.Wildcard != nil && (domain.Wildcard == nil || *domain.Wildcard != *desired.Wildcard) {
+		return false
 	}
 
 	return true
@@ -18,37 +18,115 @@ package domain
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/rossigee/provider-mailgun/apis"
 	bouncetypes "github.com/rossigee/provider-mailgun/apis/bounce/v1beta1"
+	complainttypes "github.com/rossigee/provider-mailgun/apis/complaint/v1beta1"
 	v1beta1 "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+	ippooltypes "github.com/rossigee/provider-mailgun/apis/ippool/v1beta1"
 	mailinglisttypes "github.com/rossigee/provider-mailgun/apis/mailinglist/v1beta1"
+	mailinglistmembertypes "github.com/rossigee/provider-mailgun/apis/mailinglistmember/v1beta1"
 	routetypes "github.com/rossigee/provider-mailgun/apis/route/v1beta1"
 	smtpcredentialtypes "github.com/rossigee/provider-mailgun/apis/smtpcredential/v1beta1"
 	templatetypes "github.com/rossigee/provider-mailgun/apis/template/v1beta1"
+	templateversiontypes "github.com/rossigee/provider-mailgun/apis/templateversion/v1beta1"
+	unsubscribetypes "github.com/rossigee/provider-mailgun/apis/unsubscribe/v1beta1"
+	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
 	webhooktypes "github.com/rossigee/provider-mailgun/apis/webhook/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
+	mgerrors "github.com/rossigee/provider-mailgun/internal/errors"
+	"github.com/rossigee/provider-mailgun/internal/resilience"
 )
 
 // MockDomainClient for testing
 type MockDomainClient struct {
-	domains map[string]*v1beta1.DomainObservation
-	err     error
+	domains   map[string]*v1beta1.DomainObservation
+	err       error
+	getCalls  int
+	listCalls int
+
+	// updateCalls counts UpdateDomain calls
+	updateCalls int
+
+	trackingErr   error
+	trackingCalls []*v1beta1.DomainTracking
+	tracking      *v1beta1.DomainTracking
+
+	connection      v1beta1.DomainConnection
+	connectionCalls []*v1beta1.DomainConnection
+
+	recipients map[string]bool
+
+	signingKey      string
+	signingKeyErr   error
+	signingKeyCalls int
+	rotationCalls   int
+
+	// dkimKeySizes records the key size of each UpdateDKIMKey call
+	dkimKeySizes []int
+
+	// createErrs are returned by successive CreateDomain calls; createdOnErr
+	// makes those failed calls create the domain anyway
+	createErrs   []error
+	createdOnErr bool
+	createCalls  int
+
+	// createdState is the state of created domains, active if it is empty
+	createdState string
+
+	// ips are the dedicated IPs of the domain, and ipCalls records each
+	// assignment and unassignment. ipErr fails assignments.
+	ips     []string
+	ipCalls []string
+	ipErr   error
+
+	// listedSpamActions are the spam actions ListDomains reports, which
+	// GetDomain does not, and listErr fails ListDomains alone
+	listedSpamActions map[string]string
+	listErr           error
 }
 
 func (m *MockDomainClient) CreateDomain(ctx context.Context, domain *v1beta1.DomainParameters) (*v1beta1.DomainObservation, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
+	m.createCalls++
+	var createErr error
+	if len(m.createErrs) > 0 {
+		createErr, m.createErrs = m.createErrs[0], m.createErrs[1:]
+		if !m.createdOnErr {
+			return nil, createErr
+		}
+	}
 
+	state := "active"
+	if m.createdState != "" {
+		state = m.createdState
+	}
 	result := &v1beta1.DomainObservation{
 		ID:           domain.Name,
-		State:        "active",
+		State:        state,
 		CreatedAt:    "2025-01-01T00:00:00Z",
 		SMTPLogin:    "postmaster@" + domain.Name,
 		SMTPPassword: "generated-password",
@@ -68,10 +146,18 @@ func (m *MockDomainClient) CreateDomain(ctx context.Context, domain *v1beta1.Dom
 	}
 	m.domains[domain.Name] = result
 
-	return result, nil
+	if createErr != nil {
+		return nil, createErr
+	}
+
+	// Mailgun only confirms the operation in the create response
+	created := *result
+	created.LastOperationMessage = "Domain DNS records have been created"
+	return &created, nil
 }
 
 func (m *MockDomainClient) GetDomain(ctx context.Context, name string) (*v1beta1.DomainObservation, error) {
+	m.getCalls++
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -87,6 +173,7 @@ func (m *MockDomainClient) UpdateDomain(ctx context.Context, name string, domain
 	if m.err != nil {
 		return nil, m.err
 	}
+	m.updateCalls++
 
 	if existing, exists := m.domains[name]; exists {
 		// Return the existing domain (no actual updates in mock)
@@ -105,6 +192,155 @@ func (m *MockDomainClient) DeleteDomain(ctx context.Context, name string) error
 	return nil
 }
 
+func (m *MockDomainClient) ListAuthorizedRecipients(ctx context.Context) ([]v1beta1.AuthorizedRecipient, error) {
+	var result []v1beta1.AuthorizedRecipient
+	for email, activated := range m.recipients {
+		result = append(result, v1beta1.AuthorizedRecipient{Email: email, Activated: activated})
+	}
+	return result, nil
+}
+
+func (m *MockDomainClient) AddAuthorizedRecipient(ctx context.Context, email string) (*v1beta1.AuthorizedRecipient, error) {
+	if m.recipients == nil {
+		m.recipients = make(map[string]bool)
+	}
+	m.recipients[email] = false
+	return &v1beta1.AuthorizedRecipient{Email: email}, nil
+}
+
+func (m *MockDomainClient) DeleteAuthorizedRecipient(ctx context.Context, email string) error {
+	delete(m.recipients, email)
+	return nil
+}
+
+func (m *MockDomainClient) GetWebhookSigningKey(ctx context.Context) (string, error) {
+	m.signingKeyCalls++
+	return m.signingKey, m.signingKeyErr
+}
+
+func (m *MockDomainClient) RotateWebhookSigningKey(ctx context.Context) (string, error) {
+	if m.signingKeyErr != nil {
+		return "", m.signingKeyErr
+	}
+	m.rotationCalls++
+	m.signingKey = fmt.Sprintf("key-%d", m.rotationCalls)
+	return m.signingKey, nil
+}
+
+func (m *MockDomainClient) UpdateDomainTracking(ctx context.Context, name string, tracking *v1beta1.DomainTracking) error {
+	if tracking == nil {
+		return nil
+	}
+	m.trackingCalls = append(m.trackingCalls, tracking)
+	if m.trackingErr != nil {
+		return m.trackingErr
+	}
+	// Only the set fields of tracking are applied
+	if m.tracking == nil {
+		m.tracking = &v1beta1.DomainTracking{}
+	}
+	for _, f := range []struct{ set, applied **bool }{
+		{&tracking.Click, &m.tracking.Click},
+		{&tracking.Open, &m.tracking.Open},
+		{&tracking.Unsubscribe, &m.tracking.Unsubscribe},
+	} {
+		if *f.set != nil {
+			v := **f.set
+			*f.applied = &v
+		}
+	}
+	if tracking.UnsubscribeHTMLFooter != nil {
+		m.tracking.UnsubscribeHTMLFooter = tracking.UnsubscribeHTMLFooter
+	}
+	if tracking.UnsubscribeTextFooter != nil {
+		m.tracking.UnsubscribeTextFooter = tracking.UnsubscribeTextFooter
+	}
+	return nil
+}
+
+func (m *MockDomainClient) GetDomainConnection(ctx context.Context, name string) (*v1beta1.DomainConnection, error) {
+	requireTLS, skipVerification := boolPtr(false), boolPtr(false)
+	if m.connection.RequireTLS != nil {
+		requireTLS = m.connection.RequireTLS
+	}
+	if m.connection.SkipVerification != nil {
+		skipVerification = m.connection.SkipVerification
+	}
+	return &v1beta1.DomainConnection{RequireTLS: requireTLS, SkipVerification: skipVerification}, nil
+}
+
+func (m *MockDomainClient) UpdateDomainConnection(ctx context.Context, name string, connection *v1beta1.DomainConnection) error {
+	m.connectionCalls = append(m.connectionCalls, connection)
+	if connection.RequireTLS != nil {
+		m.connection.RequireTLS = connection.RequireTLS
+	}
+	if connection.SkipVerification != nil {
+		m.connection.SkipVerification = connection.SkipVerification
+	}
+	return nil
+}
+
+func (m *MockDomainClient) UpdateDKIMKey(ctx context.Context, name string, keySize int) ([]v1beta1.DNSRecord, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.dkimKeySizes = append(m.dkimKeySizes, keySize)
+	selector := fmt.Sprintf("k%d._domainkey.%s", len(m.dkimKeySizes), name)
+	return []v1beta1.DNSRecord{{Name: selector, Type: "TXT", Value: "k=rsa; p=NEWKEY", Valid: boolPtr(false)}}, nil
+}
+
+func (m *MockDomainClient) ListDomainIPs(ctx context.Context, name string) ([]string, error) {
+	return m.ips, nil
+}
+
+func (m *MockDomainClient) AssignDomainIP(ctx context.Context, name, ip string) error {
+	if m.ipErr != nil {
+		return m.ipErr
+	}
+	m.ipCalls = append(m.ipCalls, "assign "+ip)
+	m.ips = append(m.ips, ip)
+	return nil
+}
+
+func (m *MockDomainClient) UnassignDomainIP(ctx context.Context, name, ip string) error {
+	m.ipCalls = append(m.ipCalls, "unassign "+ip)
+	m.ips = slices.DeleteFunc(m.ips, func(assigned string) bool { return assigned == ip })
+	return nil
+}
+
+func (m *MockDomainClient) GetDomainTracking(ctx context.Context, name string) (*v1beta1.DomainTracking, error) {
+	if m.tracking == nil {
+		off, empty := false, ""
+		return &v1beta1.DomainTracking{Click: &off, Open: &off, Unsubscribe: &off, UnsubscribeHTMLFooter: &empty, UnsubscribeTextFooter: &empty}, nil
+	}
+	observed := *m.tracking
+	return &observed, nil
+}
+
+func (m *MockDomainClient) ListDomains(ctx context.Context, limit, skip int) ([]*v1beta1.DomainObservation, int, error) {
+	m.listCalls++
+	if m.err != nil {
+		return nil, 0, m.err
+	}
+	if m.listErr != nil {
+		return nil, 0, m.listErr
+	}
+
+	names := make([]string, 0, len(m.domains))
+	for name := range m.domains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	page := []*v1beta1.DomainObservation{}
+	for i := skip; i < len(names) && i < skip+limit; i++ {
+		listed := *m.domains[names[i]]
+		listed.SpamAction = m.listedSpamActions[names[i]]
+		page = append(page, &listed)
+	}
+	return page, len(names), nil
+}
+
 // Implement other required client methods as no-ops
 func (m *MockDomainClient) CreateMailingList(ctx context.Context, list *mailinglisttypes.MailingListParameters) (*mailinglisttypes.MailingListObservation, error) {
 	return nil, errors.New("not implemented")
@@ -122,6 +358,30 @@ func (m *MockDomainClient) DeleteMailingList(ctx context.Context, address string
 	return errors.New("not implemented")
 }
 
+func (m *MockDomainClient) CreateMailingListMember(ctx context.Context, listAddress string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockDomainClient) GetMailingListMember(ctx context.Context, listAddress, address string) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockDomainClient) UpdateMailingListMember(ctx context.Context, listAddress, address string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockDomainClient) DeleteMailingListMember(ctx context.Context, listAddress, address string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockDomainClient) AddMailingListMembers(ctx context.Context, listAddress string, members []clients.MailingListMemberSpec, upsert bool) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockDomainClient) ListMailingListMembers(ctx context.Context, listAddress string) ([]*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (m *MockDomainClient) CreateRoute(ctx context.Context, route *routetypes.RouteParameters) (*routetypes.RouteObservation, error) {
 	return nil, errors.New("not implemented")
 }
@@ -138,6 +398,10 @@ func (m *MockDomainClient) DeleteRoute(ctx context.Context, id string) error {
 	return errors.New("not implemented")
 }
 
+func (m *MockDomainClient) ListRoutes(ctx context.Context, limit, skip int) ([]*routetypes.RouteObservation, int, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
 func (m *MockDomainClient) CreateWebhook(ctx context.Context, domain string, webhook *webhooktypes.WebhookParameters) (*webhooktypes.WebhookObservation, error) {
 	return nil, errors.New("not implemented")
 }
@@ -186,6 +450,30 @@ func (m *MockDomainClient) DeleteTemplate(ctx context.Context, domain, name stri
 	return errors.New("not implemented")
 }
 
+func (m *MockDomainClient) CreateTemplateVersion(ctx context.Context, domain, name string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockDomainClient) GetTemplateVersion(ctx context.Context, domain, name, tag string) (*templatetypes.TemplateVersion, string, error) {
+	return nil, "", errors.New("not implemented")
+}
+
+func (m *MockDomainClient) UpdateTemplateVersion(ctx context.Context, domain, name, tag string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockDomainClient) DeleteTemplateVersion(ctx context.Context, domain, name, tag string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockDomainClient) ListTemplateVersions(ctx context.Context, domain, name string) ([]templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockDomainClient) RenderTemplate(ctx context.Context, domain, name string, vars map[string]interface{}) (string, error) {
+	return "", errors.New("not implemented")
+}
+
 // Bounce suppression operations
 func (m *MockDomainClient) CreateBounce(ctx context.Context, domain string, bounce *bouncetypes.BounceParameters) (*bouncetypes.BounceObservation, error) {
 	return nil, errors.New("not implemented")
@@ -200,11 +488,11 @@ func (m *MockDomainClient) DeleteBounce(ctx context.Context, domain, address str
 }
 
 // Complaint suppression operations
-func (m *MockDomainClient) CreateComplaint(ctx context.Context, domain string, complaint interface{}) (interface{}, error) {
+func (m *MockDomainClient) CreateComplaint(ctx context.Context, domain string, complaint *complainttypes.ComplaintParameters) (*complainttypes.ComplaintObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockDomainClient) GetComplaint(ctx context.Context, domain, address string) (interface{}, error) {
+func (m *MockDomainClient) GetComplaint(ctx context.Context, domain, address string) (*complainttypes.ComplaintObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
@@ -213,15 +501,31 @@ func (m *MockDomainClient) DeleteComplaint(ctx context.Context, domain, address
 }
 
 // Unsubscribe suppression operations
-func (m *MockDomainClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe interface{}) (interface{}, error) {
+func (m *MockDomainClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe *unsubscribetypes.UnsubscribeParameters) (*unsubscribetypes.UnsubscribeObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockDomainClient) GetUnsubscribe(ctx context.Context, domain, address string) (*unsubscribetypes.UnsubscribeObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockDomainClient) GetUnsubscribe(ctx context.Context, domain, address string) (interface{}, error) {
+func (m *MockDomainClient) DeleteUnsubscribe(ctx context.Context, domain, address, tag string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockDomainClient) CreateIPPool(ctx context.Context, pool *ippooltypes.IPPoolParameters) (*ippooltypes.IPPoolObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockDomainClient) DeleteUnsubscribe(ctx context.Context, domain, address string) error {
+func (m *MockDomainClient) GetIPPool(ctx context.Context, id string) (*ippooltypes.IPPoolObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockDomainClient) UpdateIPPool(ctx context.Context, id string, pool *ippooltypes.IPPoolParameters, add, remove []string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockDomainClient) DeleteIPPool(ctx context.Context, id string) error {
 	return errors.New("not implemented")
 }
 
@@ -317,6 +621,43 @@ func TestDomainObserve(t *testing.T) {
 	}
 }
 
+func TestDomainAdoptsBySpecName(t *testing.T) {
+	cases := map[string]struct {
+		externalName string
+		wantName     string
+		wantAdopted  bool
+	}{
+		"Unset":           {externalName: "", wantName: "example.com", wantAdopted: true},
+		"DefaultedToName": {externalName: "my-domain", wantName: "example.com", wantAdopted: true},
+		"AlreadyAdopted":  {externalName: "example.com", wantName: "example.com"},
+		"Explicit":        {externalName: "other.example.com", wantName: "other.example.com"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockDomainClient{
+				domains: map[string]*v1beta1.DomainObservation{
+					"example.com": {ID: "example.com", State: "active"},
+				},
+			}
+			cr := &v1beta1.Domain{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-domain"},
+				Spec:       v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{Name: "example.com"}},
+			}
+			if tc.externalName != "" {
+				meta.SetExternalName(cr, tc.externalName)
+			}
+
+			e := &external{service: mockClient}
+			got, err := e.Observe(context.Background(), cr)
+			require.NoError(t, err)
+			assert.True(t, got.ResourceExists)
+			assert.Equal(t, tc.wantAdopted, got.ResourceLateInitialized)
+			assert.Equal(t, tc.wantName, meta.GetExternalName(cr))
+		})
+	}
+}
+
 func TestDomainCreate(t *testing.T) {
 	type args struct {
 		mg resource.Managed
@@ -494,6 +835,1335 @@ func TestDomainDelete(t *testing.T) {
 	}
 }
 
+func TestDomainPlanLimited(t *testing.T) {
+	planErr := &clients.APIError{
+		StatusCode: 403,
+		Message:    "Dedicated IPs are not available on your current plan",
+		Body:       `{"message":"Dedicated IPs are not available on your current plan"}`,
+	}
+
+	t.Run("CreateSetsCondition", func(t *testing.T) {
+		cr := &v1beta1.Domain{
+			Spec: v1beta1.DomainSpec{
+				ForProvider: v1beta1.DomainParameters{
+					Name: "ips.com",
+					IPs:  []string{"192.0.2.10"},
+				},
+			},
+		}
+		e := &external{service: &MockDomainClient{err: planErr}}
+
+		_, err := e.Create(context.Background(), cr)
+		require.Error(t, err)
+		assert.Equal(t, mgerrors.ErrorCodePlanLimited, mgerrors.GetErrorCode(errors.Cause(err)))
+
+		cond := cr.GetCondition(apisv1beta1.TypePlanLimited)
+		assert.Equal(t, corev1.ConditionTrue, cond.Status)
+		assert.Equal(t, apisv1beta1.ReasonPlanLimited, cond.Reason)
+		assert.Equal(t, planErr.Message, cond.Message)
+	})
+
+	t.Run("OtherErrorsDoNotSetCondition", func(t *testing.T) {
+		cr := &v1beta1.Domain{
+			Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{Name: "ips.com"}},
+		}
+		e := &external{service: &MockDomainClient{err: &clients.APIError{StatusCode: 403, Message: "Forbidden"}}}
+
+		_, err := e.Create(context.Background(), cr)
+		require.Error(t, err)
+		assert.Equal(t, corev1.ConditionUnknown, cr.GetCondition(apisv1beta1.TypePlanLimited).Status)
+	})
+
+	t.Run("SuccessfulUpdateClearsCondition", func(t *testing.T) {
+		cr := &v1beta1.Domain{
+			Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{Name: "ips.com"}},
+		}
+		cr.SetConditions(apisv1beta1.PlanLimited(planErr.Message))
+		e := &external{service: &MockDomainClient{
+			domains: map[string]*v1beta1.DomainObservation{"ips.com": {ID: "ips.com", State: "active"}},
+		}}
+
+		_, err := e.Update(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(apisv1beta1.TypePlanLimited).Status)
+	})
+}
+
+func TestDomainTerminalError(t *testing.T) {
+	invalid := &clients.APIError{StatusCode: 400, Message: "'name' parameter is not a valid domain name"}
+	newDomain := func() *v1beta1.Domain {
+		cr := &v1beta1.Domain{
+			Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{Name: "bad_domain"}},
+		}
+		cr.SetGeneration(1)
+		return cr
+	}
+
+	t.Run("HaltsUntilSpecChanges", func(t *testing.T) {
+		ctx := context.Background()
+		mockClient := &MockDomainClient{createErrs: []error{invalid}}
+		e := &external{service: mockClient, haltOnTerminalError: true}
+		cr := newDomain()
+
+		_, err := e.Create(ctx, cr)
+		require.Error(t, err)
+		cond := cr.GetCondition(v1beta1.TypeTerminalError)
+		assert.Equal(t, corev1.ConditionTrue, cond.Status)
+		assert.Equal(t, v1beta1.ReasonRejected, cond.Reason)
+		assert.Contains(t, cond.Message, "not a valid domain name")
+		assert.Equal(t, int64(1), cond.ObservedGeneration)
+
+		// Later reconciles do not call Mailgun
+		_, err = e.Observe(ctx, cr)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "TerminalError")
+		assert.Equal(t, 0, mockClient.getCalls)
+
+		// Fixing the name resumes reconciling
+		cr.Spec.ForProvider.Name = "good.example.com"
+		cr.SetGeneration(2)
+		obs, err := e.Observe(ctx, cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+		assert.Equal(t, 1, mockClient.getCalls)
+		cond = cr.GetCondition(v1beta1.TypeTerminalError)
+		assert.Equal(t, corev1.ConditionFalse, cond.Status)
+		assert.Equal(t, v1beta1.ReasonSpecChanged, cond.Reason)
+
+		_, err = e.Create(ctx, cr)
+		require.NoError(t, err)
+		assert.Equal(t, 2, mockClient.createCalls)
+	})
+
+	t.Run("DeletionIsNotHeldUp", func(t *testing.T) {
+		mockClient := &MockDomainClient{}
+		e := &external{service: mockClient, haltOnTerminalError: true}
+		cr := newDomain()
+		cr.SetConditions(v1beta1.TerminalError(invalid.Message, 1))
+		cr.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+		assert.Equal(t, 0, mockClient.getCalls)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		e := &external{service: &MockDomainClient{createErrs: []error{invalid}}}
+		cr := newDomain()
+
+		_, err := e.Create(context.Background(), cr)
+		require.Error(t, err)
+		assert.Equal(t, corev1.ConditionUnknown, cr.GetCondition(v1beta1.TypeTerminalError).Status)
+	})
+
+	t.Run("OtherErrorsAreRetried", func(t *testing.T) {
+		e := &external{service: &MockDomainClient{createErrs: []error{&clients.APIError{StatusCode: 400, Message: "Missing parameter"}}}, haltOnTerminalError: true}
+		cr := newDomain()
+
+		_, err := e.Create(context.Background(), cr)
+		require.Error(t, err)
+		assert.Equal(t, corev1.ConditionUnknown, cr.GetCondition(v1beta1.TypeTerminalError).Status)
+	})
+}
+
+func TestDomainLastOperationMessage(t *testing.T) {
+	cr := &v1beta1.Domain{
+		Spec: v1beta1.DomainSpec{
+			ForProvider: v1beta1.DomainParameters{Name: "confirmed.com"},
+		},
+	}
+	e := &external{service: &MockDomainClient{}}
+
+	_, err := e.Create(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, "Domain DNS records have been created", cr.Status.AtProvider.LastOperationMessage)
+
+	// Observations carry no message, so the last one is kept
+	_, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, "Domain DNS records have been created", cr.Status.AtProvider.LastOperationMessage)
+}
+
+func TestDomainCreateTrackingFailure(t *testing.T) {
+	cr := &v1beta1.Domain{
+		Spec: v1beta1.DomainSpec{
+			ForProvider: v1beta1.DomainParameters{
+				Name: "tracked.com",
+				Tracking: &v1beta1.DomainTracking{
+					Click: boolPtr(true),
+					Open:  boolPtr(true),
+				},
+			},
+		},
+	}
+	mockClient := &MockDomainClient{trackingErr: errors.New("API request failed with status 500: tracking unavailable")}
+	e := &external{service: mockClient}
+
+	// The create succeeds even though tracking could not be applied.
+	_, err := e.Create(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Len(t, mockClient.trackingCalls, 1)
+	cond := cr.GetCondition(v1beta1.TypePartiallyConfigured)
+	assert.Equal(t, corev1.ConditionTrue, cond.Status)
+	assert.Equal(t, v1beta1.ReasonTrackingNotApplied, cond.Reason)
+	assert.Contains(t, cond.Message, "tracking unavailable")
+
+	// The next observe reports the domain as needing an update.
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+	assert.False(t, obs.ResourceUpToDate)
+
+	// A failing retry keeps the condition.
+	_, err = e.Update(context.Background(), cr)
+	require.Error(t, err)
+	assert.Equal(t, corev1.ConditionTrue, cr.GetCondition(v1beta1.TypePartiallyConfigured).Status)
+
+	// Once the tracking endpoint recovers the update completes the domain.
+	mockClient.trackingErr = nil
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Len(t, mockClient.trackingCalls, 3)
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(v1beta1.TypePartiallyConfigured).Status)
+
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+}
+
+func TestDomainTrackingDrift(t *testing.T) {
+	footer := "Unsubscribe: %unsubscribe_url%"
+	cr := &v1beta1.Domain{
+		Spec: v1beta1.DomainSpec{
+			ForProvider: v1beta1.DomainParameters{
+				Name: "tracked.com",
+				Tracking: &v1beta1.DomainTracking{
+					Click:                 boolPtr(true),
+					UnsubscribeTextFooter: &footer,
+				},
+			},
+		},
+	}
+	mockClient := &MockDomainClient{}
+	e := &external{service: mockClient}
+
+	_, err := e.Create(context.Background(), cr)
+	require.NoError(t, err)
+
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+	require.NotNil(t, cr.Status.AtProvider.Tracking)
+	assert.True(t, *cr.Status.AtProvider.Tracking.Click)
+
+	// Click tracking turned off in the Mailgun console is drift; settings
+	// the spec leaves unset are not
+	mockClient.tracking.Click = boolPtr(false)
+	mockClient.tracking.Open = boolPtr(true)
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate)
+	assert.False(t, *cr.Status.AtProvider.Tracking.Click)
+
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+
+	// Mailgun may pad the footer with whitespace
+	padded := "\n" + footer + "\n"
+	mockClient.tracking.UnsubscribeTextFooter = &padded
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+}
+
+func TestDomainConnectionSettingsDrift(t *testing.T) {
+	cr := &v1beta1.Domain{
+		Spec: v1beta1.DomainSpec{
+			ForProvider: v1beta1.DomainParameters{
+				Name:       "secure.com",
+				Connection: &v1beta1.DomainConnection{RequireTLS: boolPtr(true)},
+			},
+		},
+	}
+	mockClient := &MockDomainClient{}
+	e := &external{service: mockClient}
+
+	_, err := e.Create(context.Background(), cr)
+	require.NoError(t, err)
+
+	// Mailgun creates domains without requiring TLS
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate)
+	assert.False(t, *cr.Status.AtProvider.Connection.RequireTLS)
+
+	// Which is corrected through the connection endpoint alone
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	require.Len(t, mockClient.connectionCalls, 1)
+	assert.True(t, *mockClient.connectionCalls[0].RequireTLS)
+	assert.Equal(t, 0, mockClient.updateCalls, "connection drift should not update the domain")
+
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.True(t, *cr.Status.AtProvider.Connection.RequireTLS)
+
+	// Drift in the domain's own settings still updates it, and leaves the
+	// matching connection settings alone
+	cr.Spec.ForProvider.Wildcard = boolPtr(true)
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate)
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, 1, mockClient.updateCalls)
+	assert.Len(t, mockClient.connectionCalls, 1)
+}
+
+func TestDomainConnectionDetailsUseObservedLogin(t *testing.T) {
+	// Some accounts use logins that do not follow postmaster@<domain>
+	mockClient := &MockDomainClient{
+		domains: map[string]*v1beta1.DomainObservation{
+			"mg.example.com": {ID: "mg.example.com", State: "active", SMTPLogin: "mailer-7f3a@mg.example.com"},
+		},
+	}
+	e := &external{service: mockClient}
+	cr := &v1beta1.Domain{Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{Name: "mg.example.com"}}}
+
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, managed.ConnectionDetails{"smtp_login": []byte("mailer-7f3a@mg.example.com")}, obs.ConnectionDetails,
+		"the login should be published verbatim and the password not blanked when Mailgun omits it")
+	assert.Equal(t, "mailer-7f3a@mg.example.com", cr.Status.AtProvider.SMTPLogin)
+}
+
+func TestDomainConnectionDetailsWhenActive(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	kube := fake.NewClientBuilder().WithScheme(scheme).Build()
+	mockClient := &MockDomainClient{createdState: "unverified"}
+	e := &external{service: mockClient, kube: kube}
+	cr := &v1beta1.Domain{
+		ObjectMeta: metav1.ObjectMeta{Name: "mg", Namespace: "team-a", UID: "uid-1"},
+		Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{
+			Name:                               "mg.example.com",
+			PublishConnectionDetailsWhenActive: boolPtr(true),
+		}},
+	}
+
+	cre, err := e.Create(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Empty(t, cre.ConnectionDetails, "nothing should be published for an unverified domain")
+	assert.Empty(t, cr.Status.AtProvider.SMTPPassword, "the password must not be kept in the status")
+
+	held := &corev1.Secret{}
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Name: "mg-withheld-smtp-password", Namespace: "team-a"}, held))
+	assert.Equal(t, []byte("generated-password"), held.Data["smtp_password"])
+	require.Len(t, held.OwnerReferences, 1)
+	assert.Equal(t, types.UID("uid-1"), held.OwnerReferences[0].UID, "the Secret should be collected with the Domain")
+
+	// Mailgun returns the password only from the create
+	mockClient.domains["mg.example.com"].SMTPPassword = ""
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Empty(t, obs.ConnectionDetails)
+
+	// A failed observation does not lose the password
+	mockClient.domains["mg.example.com"].State = "active"
+	mockClient.err = errors.New("service unavailable")
+	_, err = e.Observe(context.Background(), cr)
+	require.Error(t, err)
+	mockClient.err = nil
+
+	for range 2 {
+		obs, err = e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, managed.ConnectionDetails{
+			"smtp_login":    []byte("postmaster@mg.example.com"),
+			"smtp_password": []byte("generated-password"),
+		}, obs.ConnectionDetails, "the credentials should be published once the domain is active")
+		assert.Empty(t, cr.Status.AtProvider.SMTPPassword)
+	}
+
+	// Without the option an unverified domain publishes them straight away
+	mockClient = &MockDomainClient{createdState: "unverified"}
+	e = &external{service: mockClient, kube: kube}
+	cr = &v1beta1.Domain{Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{Name: "mg.example.com"}}}
+	cre, err = e.Create(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("generated-password"), cre.ConnectionDetails["smtp_password"])
+}
+
+func TestDomainWithheldPasswordDeletedOncePublished(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	kube := fake.NewClientBuilder().WithScheme(scheme).Build()
+	mockClient := &MockDomainClient{createdState: "unverified"}
+	e := &external{service: mockClient, kube: kube}
+	cr := &v1beta1.Domain{
+		ObjectMeta: metav1.ObjectMeta{Name: "mg", Namespace: "team-a", UID: "uid-1"},
+		Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{
+			Name:                               "mg.example.com",
+			PublishConnectionDetailsWhenActive: boolPtr(true),
+		}},
+	}
+	cr.Spec.WriteConnectionSecretToReference = &xpv1.LocalSecretReference{Name: "mg-smtp"}
+	heldName := types.NamespacedName{Name: "mg-withheld-smtp-password", Namespace: "team-a"}
+
+	_, err := e.Create(context.Background(), cr)
+	require.NoError(t, err)
+	mockClient.domains["mg.example.com"].SMTPPassword = ""
+	mockClient.domains["mg.example.com"].State = "active"
+
+	// publish writes the connection secret as the managed reconciler does
+	// after each Observe
+	publish := func(details managed.ConnectionDetails) {
+		conn := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "mg-smtp", Namespace: "team-a"}}
+		if err := kube.Get(context.Background(), types.NamespacedName{Name: "mg-smtp", Namespace: "team-a"}, conn); err == nil {
+			conn.Data = details
+			require.NoError(t, kube.Update(context.Background(), conn))
+			return
+		}
+		conn.Data = details
+		require.NoError(t, kube.Create(context.Background(), conn))
+	}
+
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("generated-password"), obs.ConnectionDetails["smtp_password"])
+	require.NoError(t, kube.Get(context.Background(), heldName, &corev1.Secret{}), "the password should be held until it is published")
+	publish(obs.ConnectionDetails)
+
+	for range 2 {
+		obs, err = e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("generated-password"), obs.ConnectionDetails["smtp_password"], "the published password should be kept")
+		publish(obs.ConnectionDetails)
+	}
+	err = kube.Get(context.Background(), heldName, &corev1.Secret{})
+	assert.True(t, kerrors.IsNotFound(err), "the withheld password should be deleted once published, got %v", err)
+}
+
+func TestDomainWebhookSigningKeyRotation(t *testing.T) {
+	mockClient := &MockDomainClient{
+		domains: map[string]*v1beta1.DomainObservation{
+			"mg.example.com": {ID: "mg.example.com", State: "active", SMTPLogin: "postmaster@mg.example.com"},
+		},
+		signingKey: "key-0",
+	}
+	e := &external{service: mockClient}
+	cr := &v1beta1.Domain{Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{Name: "mg.example.com"}}}
+	cr.Spec.WriteConnectionSecretToReference = &xpv1.LocalSecretReference{Name: "mg-example-com"}
+
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.Equal(t, []byte("key-0"), obs.ConnectionDetails["webhook_signing_key"])
+
+	cr.SetAnnotations(map[string]string{v1beta1.AnnotationRotateWebhookSigningKey: "2025-06-01"})
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate, "a new annotation value should request a rotation")
+
+	upd, err := e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, 1, mockClient.rotationCalls)
+	assert.Equal(t, []byte("key-1"), upd.ConnectionDetails["webhook_signing_key"])
+	assert.Equal(t, []byte("postmaster@mg.example.com"), upd.ConnectionDetails["smtp_login"])
+	assert.Equal(t, "2025-06-01", cr.Status.AtProvider.WebhookSigningKeyRotation)
+
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate, "a handled rotation should not be repeated")
+	assert.Equal(t, []byte("key-1"), obs.ConnectionDetails["webhook_signing_key"])
+	assert.Equal(t, "2025-06-01", cr.Status.AtProvider.WebhookSigningKeyRotation)
+
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, 1, mockClient.rotationCalls)
+}
+
+func TestDomainWebhookSigningKeySharedByAccount(t *testing.T) {
+	mockClient := &MockDomainClient{
+		domains: map[string]*v1beta1.DomainObservation{
+			"a.example.com": {ID: "a.example.com", State: "active"},
+			"b.example.com": {ID: "b.example.com", State: "active"},
+		},
+		signingKey: "key-0",
+	}
+	e := &external{service: mockClient, signingKeys: newSigningKeyCache(time.Minute), account: "account"}
+	newDomain := func(name string) *v1beta1.Domain {
+		cr := &v1beta1.Domain{Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{Name: name}}}
+		cr.Spec.WriteConnectionSecretToReference = &xpv1.LocalSecretReference{Name: name}
+		return cr
+	}
+	a, b := newDomain("a.example.com"), newDomain("b.example.com")
+
+	for _, cr := range []*v1beta1.Domain{a, b, a} {
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("key-0"), obs.ConnectionDetails["webhook_signing_key"])
+	}
+	assert.Equal(t, 1, mockClient.signingKeyCalls, "the key should be read once per account")
+
+	// A rotation through one Domain is published by the others
+	a.SetAnnotations(map[string]string{v1beta1.AnnotationRotateWebhookSigningKey: "2025-06-01"})
+	_, err := e.Update(context.Background(), a)
+	require.NoError(t, err)
+	obs, err := e.Observe(context.Background(), b)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("key-1"), obs.ConnectionDetails["webhook_signing_key"])
+	assert.Equal(t, 1, mockClient.signingKeyCalls)
+}
+
+func TestDomainWebhookSigningKeyRotationUpdatesAccount(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apis.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	providerConfig := func(name, apiKey string) (*apisv1beta1.ProviderConfig, *corev1.Secret) {
+		pc := &apisv1beta1.ProviderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "mail"},
+			Spec: apisv1beta1.ProviderConfigSpec{Credentials: apisv1beta1.ProviderCredentials{
+				Source: xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: &xpv1.SecretKeySelector{
+					SecretReference: xpv1.SecretReference{Name: name + "-creds", Namespace: "mail"},
+					Key:             "credentials",
+				}},
+			}},
+		}
+		creds := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-creds", Namespace: "mail"},
+			Data:       map[string][]byte{"credentials": []byte(apiKey)},
+		}
+		return pc, creds
+	}
+	newDomain := func(name, pc string) (*v1beta1.Domain, *corev1.Secret) {
+		cr := &v1beta1.Domain{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "mail"},
+			Spec:       v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{Name: name + ".example.com"}},
+		}
+		cr.Spec.ProviderConfigReference = &xpv1.ProviderConfigReference{Name: pc}
+		cr.Spec.WriteConnectionSecretToReference = &xpv1.LocalSecretReference{Name: name + "-conn"}
+		conn := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-conn", Namespace: "mail"},
+			Data:       map[string][]byte{"webhook_signing_key": []byte("key-0")},
+		}
+		return cr, conn
+	}
+	pcA, credsA := providerConfig("account-a", "key-0123456789abcdef0123456789abcdef")
+	pcB, credsB := providerConfig("account-b", "key-fedcba9876543210fedcba9876543210")
+	a, connA := newDomain("a", "account-a")
+	b, connB := newDomain("b", "account-a")
+	c, connC := newDomain("c", "account-b")
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pcA, credsA, pcB, credsB, a, connA, b, connB, c, connC).Build()
+
+	config, err := clients.GetConfig(context.Background(), kube, a)
+	require.NoError(t, err)
+	mockClient := &MockDomainClient{
+		domains: map[string]*v1beta1.DomainObservation{
+			"a.example.com": {ID: "a.example.com", State: "active"},
+		},
+		signingKey: "key-0",
+	}
+	e := &external{service: mockClient, kube: kube, account: accountKey(config), log: logging.NewNopLogger()}
+
+	a.SetAnnotations(map[string]string{v1beta1.AnnotationRotateWebhookSigningKey: "2025-06-01"})
+	upd, err := e.Update(context.Background(), a)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("key-1"), upd.ConnectionDetails["webhook_signing_key"])
+
+	got := &corev1.Secret{}
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Namespace: "mail", Name: "b-conn"}, got))
+	assert.Equal(t, []byte("key-1"), got.Data["webhook_signing_key"], "a Domain of the same account should get the new key")
+	require.NoError(t, kube.Get(context.Background(), types.NamespacedName{Namespace: "mail", Name: "c-conn"}, got))
+	assert.Equal(t, []byte("key-0"), got.Data["webhook_signing_key"], "a Domain of another account should keep its key")
+}
+
+func TestDomainDisabled(t *testing.T) {
+	mockClient := &MockDomainClient{
+		domains: map[string]*v1beta1.DomainObservation{
+			"mg.example.com": {ID: "mg.example.com", State: "disabled"},
+		},
+	}
+	e := &external{service: mockClient}
+	cr := &v1beta1.Domain{Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{Name: "mg.example.com"}}}
+
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+	assert.True(t, obs.ResourceUpToDate, "there is nothing an update could do to re-enable the domain")
+
+	disabled := cr.GetCondition(v1beta1.TypeDisabled)
+	assert.Equal(t, corev1.ConditionTrue, disabled.Status)
+	assert.Equal(t, v1beta1.ReasonDomainDisabled, disabled.Reason)
+	ready := cr.GetCondition(xpv1.TypeReady)
+	assert.Equal(t, corev1.ConditionFalse, ready.Status)
+	assert.Equal(t, xpv1.ReasonUnavailable, ready.Reason, "a disabled domain should not be reported as still being created")
+
+	mockClient.domains["mg.example.com"].State = "active"
+	_, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(v1beta1.TypeDisabled).Status)
+	assert.Equal(t, v1beta1.ReasonDomainEnabled, cr.GetCondition(v1beta1.TypeDisabled).Reason)
+	assert.Equal(t, corev1.ConditionTrue, cr.GetCondition(xpv1.TypeReady).Status)
+
+	// Domains that were never disabled do not get the condition
+	other := &v1beta1.Domain{Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{Name: "mg.example.com"}}}
+	_, err = e.Observe(context.Background(), other)
+	require.NoError(t, err)
+	assert.Equal(t, corev1.ConditionUnknown, other.GetCondition(v1beta1.TypeDisabled).Status)
+}
+
+func TestDomainDisabledFlag(t *testing.T) {
+	mockClient := &MockDomainClient{
+		domains: map[string]*v1beta1.DomainObservation{
+			"mg.example.com": {ID: "mg.example.com", State: "active", Disabled: true, DisabledReason: "High bounce rate.", WebScheme: "http"},
+		},
+	}
+	e := &external{service: mockClient}
+	cr := &v1beta1.Domain{Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{
+		Name:      "mg.example.com",
+		WebScheme: stringPtr("https"),
+	}}}
+
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate, "drift on a disabled domain should wait until it is enabled again")
+	assert.True(t, cr.Status.AtProvider.Disabled)
+
+	disabled := cr.GetCondition(v1beta1.TypeDisabled)
+	assert.Equal(t, corev1.ConditionTrue, disabled.Status)
+	assert.Contains(t, disabled.Message, "High bounce rate.")
+	ready := cr.GetCondition(xpv1.TypeReady)
+	assert.Equal(t, corev1.ConditionFalse, ready.Status, "an active state should not override the disabled flag")
+	assert.Equal(t, xpv1.ReasonUnavailable, ready.Reason)
+
+	mockClient.domains["mg.example.com"].Disabled = false
+	mockClient.domains["mg.example.com"].DisabledReason = ""
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate, "drift should be reconciled once the domain is enabled")
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(v1beta1.TypeDisabled).Status)
+	assert.Equal(t, corev1.ConditionTrue, cr.GetCondition(xpv1.TypeReady).Status)
+}
+
+func TestDomainCreateRetriesServerErrors(t *testing.T) {
+	serverErr := &clients.APIError{StatusCode: 503, Message: "Service Unavailable"}
+	retry := &resilience.RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Retryable: clients.IsServerError}
+	newDomain := func() *v1beta1.Domain {
+		return &v1beta1.Domain{Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{Name: "mg.example.com"}}}
+	}
+
+	t.Run("SucceedsAfterServerError", func(t *testing.T) {
+		mockClient := &MockDomainClient{createErrs: []error{serverErr}}
+		e := &external{service: mockClient, createRetry: retry}
+		cr := newDomain()
+
+		_, err := e.Create(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, 2, mockClient.createCalls)
+		assert.Equal(t, "mg.example.com", cr.Status.AtProvider.ID)
+	})
+
+	t.Run("AdoptsDomainCreatedDespiteError", func(t *testing.T) {
+		mockClient := &MockDomainClient{createErrs: []error{serverErr}, createdOnErr: true}
+		e := &external{service: mockClient, createRetry: retry}
+		cr := newDomain()
+
+		_, err := e.Create(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, 1, mockClient.createCalls, "the domain should be looked up rather than created twice")
+		assert.Equal(t, "mg.example.com", cr.Status.AtProvider.ID)
+	})
+
+	t.Run("ClientErrorsAreNotRetried", func(t *testing.T) {
+		mockClient := &MockDomainClient{createErrs: []error{&clients.APIError{StatusCode: 400, Message: "invalid domain"}}}
+		e := &external{service: mockClient, createRetry: retry}
+
+		_, err := e.Create(context.Background(), newDomain())
+		require.Error(t, err)
+		assert.Equal(t, 1, mockClient.createCalls)
+	})
+
+	t.Run("NoRetryByDefault", func(t *testing.T) {
+		mockClient := &MockDomainClient{createErrs: []error{serverErr}}
+		e := &external{service: mockClient}
+
+		_, err := e.Create(context.Background(), newDomain())
+		require.Error(t, err)
+		assert.Equal(t, 1, mockClient.createCalls)
+	})
+}
+
+func TestDomainWebScheme(t *testing.T) {
+	newDomain := func(scheme string) *v1beta1.Domain {
+		return &v1beta1.Domain{Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{
+			Name:      "mg.example.com",
+			WebScheme: stringPtr(scheme),
+		}}}
+	}
+
+	t.Run("ReportedByMailgun", func(t *testing.T) {
+		mockClient := &MockDomainClient{
+			domains: map[string]*v1beta1.DomainObservation{
+				"mg.example.com": {ID: "mg.example.com", State: "active", WebScheme: "http"},
+			},
+		}
+		e := &external{service: mockClient}
+		cr := newDomain("https")
+
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, "http", cr.Status.AtProvider.WebScheme, "status should show the effective scheme")
+		assert.False(t, obs.ResourceUpToDate)
+	})
+
+	t.Run("LastAppliedWhenNotReported", func(t *testing.T) {
+		mockClient := &MockDomainClient{}
+		e := &external{service: mockClient}
+		cr := newDomain("https")
+
+		_, err := e.Create(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, "https", cr.Status.AtProvider.WebScheme)
+
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.Equal(t, "https", cr.Status.AtProvider.WebScheme, "the last applied scheme should survive observation")
+
+		cr.Spec.ForProvider.WebScheme = stringPtr("http")
+		obs, err = e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate, "a scheme change should be detected against the last applied value")
+
+		_, err = e.Update(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, "http", cr.Status.AtProvider.WebScheme)
+
+		obs, err = e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+	})
+}
+
+func TestDomainSpamActionFromList(t *testing.T) {
+	mockClient := &MockDomainClient{
+		domains: map[string]*v1beta1.DomainObservation{
+			"mg.example.com": {ID: "mg.example.com", State: "active"},
+			"other.com":      {ID: "other.com", State: "active"},
+		},
+		listedSpamActions: map[string]string{"mg.example.com": "tag", "other.com": "block"},
+	}
+	e := &external{service: mockClient}
+	cr := &v1beta1.Domain{Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{
+		Name:       "mg.example.com",
+		SpamAction: stringPtr("tag"),
+	}}}
+
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.Equal(t, "tag", cr.Status.AtProvider.SpamAction, "the spam action should be read from the domains list")
+	assert.Equal(t, 1, mockClient.listCalls)
+
+	// A spam action changed outside the provider is drift
+	mockClient.listedSpamActions["mg.example.com"] = "disabled"
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate)
+	assert.Equal(t, "disabled", cr.Status.AtProvider.SpamAction)
+
+	// The list is not read when the domain reports its spam action
+	mockClient.domains["mg.example.com"].SpamAction = "tag"
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.Equal(t, 2, mockClient.listCalls)
+
+	// Nor when the spam action is not managed
+	mockClient.domains["mg.example.com"].SpamAction = ""
+	cr.Spec.ForProvider.SpamAction = nil
+	_, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, 2, mockClient.listCalls)
+
+	// When neither reports it, the spam action is assumed up to date
+	cr.Spec.ForProvider.SpamAction = stringPtr("tag")
+	mockClient.listedSpamActions = nil
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+
+	// Nor does a failing list fail the Observe
+	mockClient.listErr = errors.New("API request failed with status 500")
+	e.log = logging.NewNopLogger()
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+	assert.True(t, obs.ResourceUpToDate)
+}
+
+func TestDomainDedicatedIPs(t *testing.T) {
+	mockClient := &MockDomainClient{
+		domains: map[string]*v1beta1.DomainObservation{
+			"mg.example.com": {ID: "mg.example.com", State: "active"},
+		},
+		ips: []string{"192.0.2.20", "192.0.2.10"},
+	}
+	e := &external{service: mockClient}
+	cr := &v1beta1.Domain{Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{
+		Name: "mg.example.com",
+		IPs:  []string{"192.0.2.10", "192.0.2.30"},
+	}}}
+
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate)
+	assert.Equal(t, []string{"192.0.2.10", "192.0.2.20"}, cr.Status.AtProvider.IPs)
+
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"assign 192.0.2.30", "unassign 192.0.2.20"}, mockClient.ipCalls)
+	assert.Zero(t, mockClient.updateCalls, "IP drift alone should not update the domain")
+
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.Equal(t, []string{"192.0.2.10", "192.0.2.30"}, cr.Status.AtProvider.IPs)
+
+	// Without IPs in the spec, the ones Mailgun assigns are left alone
+	cr.Spec.ForProvider.IPs = nil
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.Empty(t, cr.Status.AtProvider.IPs)
+}
+
+func TestDomainDedicatedIPsPlanLimited(t *testing.T) {
+	mockClient := &MockDomainClient{
+		domains: map[string]*v1beta1.DomainObservation{
+			"mg.example.com": {ID: "mg.example.com", State: "active"},
+		},
+		ipErr: &clients.APIError{
+			StatusCode: 403,
+			Message:    "Dedicated IPs are not available on your current plan",
+			Body:       `{"message":"Dedicated IPs are not available on your current plan"}`,
+		},
+	}
+	e := &external{service: mockClient}
+	cr := &v1beta1.Domain{Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{
+		Name: "mg.example.com",
+		IPs:  []string{"192.0.2.10"},
+	}}}
+
+	_, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	_, err = e.Update(context.Background(), cr)
+	require.Error(t, err)
+	assert.Equal(t, mgerrors.ErrorCodePlanLimited, mgerrors.GetErrorCode(errors.Cause(err)))
+	assert.Equal(t, corev1.ConditionTrue, cr.GetCondition(apisv1beta1.TypePlanLimited).Status)
+}
+
+func TestDomainWildcard(t *testing.T) {
+	t.Run("LastApplied", func(t *testing.T) {
+		e := &external{service: &MockDomainClient{}}
+		cr := &v1beta1.Domain{Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{
+			Name:     "mg.example.com",
+			Wildcard: boolPtr(false),
+		}}}
+
+		_, err := e.Create(context.Background(), cr)
+		require.NoError(t, err)
+		require.NotNil(t, cr.Status.AtProvider.Wildcard)
+		assert.False(t, *cr.Status.AtProvider.Wildcard)
+
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+
+		cr.Spec.ForProvider.Wildcard = boolPtr(true)
+		obs, err = e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate, "a wildcard change should be detected against the last applied value")
+
+		_, err = e.Update(context.Background(), cr)
+		require.NoError(t, err)
+		require.NotNil(t, cr.Status.AtProvider.Wildcard)
+		assert.True(t, *cr.Status.AtProvider.Wildcard)
+
+		obs, err = e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+	})
+
+	t.Run("NeverApplied", func(t *testing.T) {
+		mockClient := &MockDomainClient{
+			domains: map[string]*v1beta1.DomainObservation{
+				"mg.example.com": {ID: "mg.example.com", State: "active"},
+			},
+		}
+		e := &external{service: mockClient}
+		cr := &v1beta1.Domain{Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{
+			Name:     "mg.example.com",
+			Wildcard: boolPtr(true),
+		}}}
+
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate, "a wildcard setting with no applied value should be applied")
+	})
+}
+
+func TestDomainStateHistory(t *testing.T) {
+	mockClient := &MockDomainClient{
+		domains: map[string]*v1beta1.DomainObservation{
+			"mg.example.com": {ID: "mg.example.com", State: "unverified"},
+		},
+	}
+	e := &external{service: mockClient}
+	cr := &v1beta1.Domain{Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{Name: "mg.example.com"}}}
+
+	_, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Empty(t, cr.Status.AtProvider.StateHistory, "the first observed state is not a transition")
+
+	_, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Empty(t, cr.Status.AtProvider.StateHistory, "an unchanged state is not a transition")
+
+	mockClient.domains["mg.example.com"].State = "active"
+	_, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	require.Len(t, cr.Status.AtProvider.StateHistory, 1)
+	transition := cr.Status.AtProvider.StateHistory[0]
+	assert.Equal(t, "unverified", transition.From)
+	assert.Equal(t, "active", transition.To)
+	assert.False(t, transition.ObservedAt.IsZero())
+
+	// Flapping between states must not grow the history without bound
+	for i := 0; i < 3*maxStateHistory; i++ {
+		state := "unverified"
+		if i%2 == 1 {
+			state = "active"
+		}
+		mockClient.domains["mg.example.com"].State = state
+		_, err = e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+	}
+	history := cr.Status.AtProvider.StateHistory
+	require.Len(t, history, maxStateHistory)
+	assert.Equal(t, "unverified", history[len(history)-1].From, "the newest transition should be kept last")
+	assert.Equal(t, "active", history[len(history)-1].To)
+}
+
+func TestDomainDeleteMissing(t *testing.T) {
+	mockClient := &MockDomainClient{err: errors.New("domain not found (404)")}
+	e := &external{service: mockClient}
+	cr := &v1beta1.Domain{Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{Name: "gone.example.com"}}}
+
+	_, err := e.Delete(context.Background(), cr)
+	require.NoError(t, err, "a domain that is already gone should be treated as deleted")
+}
+
+func TestDomainAuthorizedRecipients(t *testing.T) {
+	mockClient := &MockDomainClient{
+		domains: map[string]*v1beta1.DomainObservation{
+			"sandbox123.mailgun.org": {ID: "sandbox123.mailgun.org", State: "active"},
+		},
+		recipients: map[string]bool{
+			// Added outside of this Domain; must never be removed by it.
+			"ops@example.com": true,
+		},
+	}
+	e := &external{service: mockClient}
+	cr := &v1beta1.Domain{
+		Spec: v1beta1.DomainSpec{
+			ForProvider: v1beta1.DomainParameters{
+				Name:                 "sandbox123.mailgun.org",
+				AuthorizedRecipients: []string{"alice@example.com", "bob@example.com"},
+			},
+		},
+	}
+
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate, "missing recipients should trigger an update")
+
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"ops@example.com": true, "alice@example.com": false, "bob@example.com": false}, mockClient.recipients)
+
+	// Added recipients stay pending until they confirm; that is not drift.
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.Equal(t, []v1beta1.AuthorizedRecipient{
+		{Email: "alice@example.com", Activated: false, Added: true},
+		{Email: "bob@example.com", Activated: false, Added: true},
+	}, cr.Status.AtProvider.AuthorizedRecipients)
+	cond := cr.GetCondition(v1beta1.TypeRecipientsPending)
+	assert.Equal(t, corev1.ConditionTrue, cond.Status)
+	assert.Contains(t, cond.Message, "alice@example.com, bob@example.com")
+
+	// Alice confirms and Bob is removed from the spec.
+	mockClient.recipients["alice@example.com"] = true
+	cr.Spec.ForProvider.AuthorizedRecipients = []string{"alice@example.com"}
+
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate, "a removed recipient should trigger an update")
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(v1beta1.TypeRecipientsPending).Status)
+
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"ops@example.com": true, "alice@example.com": true}, mockClient.recipients)
+
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.Equal(t, []v1beta1.AuthorizedRecipient{{Email: "alice@example.com", Activated: true, Added: true}}, cr.Status.AtProvider.AuthorizedRecipients)
+}
+
+func TestDomainAuthorizedRecipientsShared(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apis.AddToScheme(scheme))
+
+	sandbox := func(namespace, name string, recipients ...string) *v1beta1.Domain {
+		return &v1beta1.Domain{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{
+				Name:                 name + ".mailgun.org",
+				AuthorizedRecipients: recipients,
+			}},
+		}
+	}
+	first := sandbox("mail", "first", "shared@example.com")
+	second := sandbox("other", "second", "shared@example.com")
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(first, second).Build()
+
+	mockClient := &MockDomainClient{
+		domains: map[string]*v1beta1.DomainObservation{
+			"first.mailgun.org":  {ID: "first.mailgun.org", State: "active"},
+			"second.mailgun.org": {ID: "second.mailgun.org", State: "active"},
+		},
+	}
+	e := &external{service: mockClient, kube: kube}
+	reconcile := func(cr *v1beta1.Domain) {
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		if !obs.ResourceUpToDate {
+			_, err = e.Update(context.Background(), cr)
+			require.NoError(t, err)
+		}
+	}
+
+	// The first Domain adds the address; the second finds it present and
+	// does not claim it.
+	reconcile(first)
+	reconcile(second)
+	assert.Equal(t, map[string]bool{"shared@example.com": false}, mockClient.recipients)
+	assert.Equal(t, []v1beta1.AuthorizedRecipient{{Email: "shared@example.com", Added: true}}, first.Status.AtProvider.AuthorizedRecipients)
+	assert.Equal(t, []v1beta1.AuthorizedRecipient{{Email: "shared@example.com"}}, second.Status.AtProvider.AuthorizedRecipients)
+
+	// Dropped by the Domain that added it, the address stays while the
+	// other Domain still lists it.
+	first.Spec.ForProvider.AuthorizedRecipients = nil
+	require.NoError(t, kube.Update(context.Background(), first))
+	reconcile(first)
+	reconcile(first)
+	assert.Equal(t, map[string]bool{"shared@example.com": false}, mockClient.recipients)
+	assert.Equal(t, []v1beta1.AuthorizedRecipient{{Email: "shared@example.com", Added: true}}, first.Status.AtProvider.AuthorizedRecipients)
+
+	// Once no Domain lists it, the Domain that added it removes it. The
+	// other Domain never removes what it did not add.
+	second.Spec.ForProvider.AuthorizedRecipients = nil
+	require.NoError(t, kube.Update(context.Background(), second))
+	reconcile(second)
+	assert.Equal(t, map[string]bool{"shared@example.com": false}, mockClient.recipients)
+	reconcile(first)
+	assert.Empty(t, mockClient.recipients)
+	assert.Empty(t, first.Status.AtProvider.AuthorizedRecipients)
+}
+
+func TestDomainObserveRefreshesDNSRecords(t *testing.T) {
+	record := func(value string) []v1beta1.DNSRecord {
+		return []v1beta1.DNSRecord{{Name: "mx._domainkey.example.com", Type: "TXT", Value: value}}
+	}
+	mockClient := &MockDomainClient{
+		domains: map[string]*v1beta1.DomainObservation{
+			"example.com": {ID: "example.com", State: "active", SendingDNSRecords: record("k=rsa; p=OLD")},
+		},
+	}
+	// An empty warm-up cache must not stand in for the live GET either.
+	e := &external{service: mockClient, warmup: newWarmupCache(warmupTTL), account: "account"}
+	cr := &v1beta1.Domain{Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{Name: "example.com"}}}
+
+	_, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, record("k=rsa; p=OLD"), cr.Status.AtProvider.SendingDNSRecords)
+
+	// DKIM rotation happens outside the provider
+	mockClient.domains["example.com"] = &v1beta1.DomainObservation{
+		ID: "example.com", State: "active", SendingDNSRecords: record("k=rsa; p=NEW"),
+	}
+
+	_, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, record("k=rsa; p=NEW"), cr.Status.AtProvider.SendingDNSRecords)
+}
+
+func TestDomainDeletionWaitsForDependents(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apis.AddToScheme(scheme))
+
+	webhook := &webhooktypes.Webhook{
+		ObjectMeta: metav1.ObjectMeta{Name: "deliveries", Namespace: "mail"},
+		Spec: webhooktypes.WebhookSpec{ForProvider: webhooktypes.WebhookParameters{
+			DomainRef: &xpv1.NamespacedReference{Name: "mg-example"},
+			EventType: "delivered",
+			URL:       "https://example.com/hook",
+		}},
+	}
+	bounce := &bouncetypes.Bounce{
+		ObjectMeta: metav1.ObjectMeta{Name: "blocked", Namespace: "mail"},
+		Spec: bouncetypes.BounceSpec{ForProvider: bouncetypes.BounceParameters{
+			DomainRef: xpv1.Reference{Name: "mg.example.com"},
+			Address:   "user@example.com",
+		}},
+	}
+	unrelated := &bouncetypes.Bounce{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "mail"},
+		Spec: bouncetypes.BounceSpec{ForProvider: bouncetypes.BounceParameters{
+			DomainRef: xpv1.Reference{Name: "other.example.com"},
+			Address:   "user@example.com",
+		}},
+	}
+	credential := &smtpcredentialtypes.SMTPCredential{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "mail"},
+		Spec: smtpcredentialtypes.SMTPCredentialSpec{ForProvider: smtpcredentialtypes.SMTPCredentialParameters{
+			DomainRef: &xpv1.NamespacedReference{Name: "mg-example"},
+			Login:     "app",
+		}},
+	}
+	template := &templatetypes.Template{
+		ObjectMeta: metav1.ObjectMeta{Name: "welcome", Namespace: "mail"},
+		Spec: templatetypes.TemplateSpec{ForProvider: templatetypes.TemplateParameters{
+			Domain: "mg.example.com",
+			Name:   "welcome",
+		}},
+	}
+	shared := &templatetypes.Template{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "mail"},
+		Spec: templatetypes.TemplateSpec{ForProvider: templatetypes.TemplateParameters{
+			Domain:  "other.example.com",
+			Domains: []string{"mg.example.com"},
+			Name:    "shared",
+		}},
+	}
+	version := &templateversiontypes.TemplateVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "welcome-v2", Namespace: "mail"},
+		Spec: templateversiontypes.TemplateVersionSpec{ForProvider: templateversiontypes.TemplateVersionParameters{
+			Domain:       "mg.example.com",
+			TemplateName: "welcome",
+			Tag:          "v2",
+		}},
+	}
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(webhook, bounce, unrelated, credential, template, shared, version).Build()
+
+	mockClient := &MockDomainClient{
+		domains: map[string]*v1beta1.DomainObservation{"mg.example.com": {ID: "mg.example.com", State: "active"}},
+	}
+	e := &external{service: mockClient, kube: kube, waitForDependents: true}
+	cr := &v1beta1.Domain{
+		ObjectMeta: metav1.ObjectMeta{Name: "mg-example", Namespace: "mail"},
+		Spec:       v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{Name: "mg.example.com"}},
+	}
+
+	_, err := e.Delete(context.Background(), cr)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Bounce/blocked, SMTPCredential/app, Template/shared, Template/welcome, TemplateVersion/welcome-v2, Webhook/deliveries")
+	assert.NotContains(t, err.Error(), "other")
+	assert.Contains(t, mockClient.domains, "mg.example.com", "the domain should not be deleted while it has dependents")
+
+	require.NoError(t, kube.Delete(context.Background(), webhook))
+	_, err = e.Delete(context.Background(), cr)
+	require.Error(t, err, "every dependent should be gone first")
+	assert.NotContains(t, err.Error(), "Webhook")
+
+	require.NoError(t, kube.Delete(context.Background(), bounce))
+	require.NoError(t, kube.Delete(context.Background(), credential))
+	require.NoError(t, kube.Delete(context.Background(), template))
+	_, err = e.Delete(context.Background(), cr)
+	require.Error(t, err, "a Template kept on the domain through domains is a dependent")
+	assert.Contains(t, err.Error(), "Template/shared")
+
+	require.NoError(t, kube.Delete(context.Background(), shared))
+	_, err = e.Delete(context.Background(), cr)
+	require.Error(t, err, "a TemplateVersion of the domain is a dependent")
+	assert.Contains(t, err.Error(), "TemplateVersion/welcome-v2")
+
+	require.NoError(t, kube.Delete(context.Background(), version))
+	_, err = e.Delete(context.Background(), cr)
+	require.NoError(t, err)
+	assert.NotContains(t, mockClient.domains, "mg.example.com")
+
+	t.Run("Disabled", func(t *testing.T) {
+		mockClient.domains["mg.example.com"] = &v1beta1.DomainObservation{ID: "mg.example.com"}
+		kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(bounce.DeepCopy()).Build()
+		e := &external{service: mockClient, kube: kube}
+
+		_, err := e.Delete(context.Background(), cr)
+		require.NoError(t, err)
+		assert.NotContains(t, mockClient.domains, "mg.example.com")
+	})
+}
+
+func TestDomainReceivingReady(t *testing.T) {
+	valid, invalid := true, false
+	mockClient := &MockDomainClient{
+		domains: map[string]*v1beta1.DomainObservation{
+			"mg.example.com": {
+				ID:    "mg.example.com",
+				State: "active",
+				ReceivingDNSRecords: []v1beta1.DNSRecord{
+					{Type: "MX", Value: "mxa.mailgun.org", Valid: &valid},
+					{Type: "MX", Value: "mxb.mailgun.org", Valid: &invalid},
+				},
+				SendingDNSRecords: []v1beta1.DNSRecord{{Type: "TXT", Value: "v=spf1 include:mailgun.org ~all", Valid: &invalid}},
+			},
+		},
+	}
+	e := &external{service: mockClient}
+	cr := &v1beta1.Domain{Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{
+		Name: "mg.example.com",
+		Type: stringPtr("receiving"),
+	}}}
+
+	_, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	receiving := cr.GetCondition(v1beta1.TypeReceivingReady)
+	assert.Equal(t, corev1.ConditionFalse, receiving.Status)
+	assert.Equal(t, v1beta1.ReasonMXRecordsNotValid, receiving.Reason)
+	assert.Equal(t, "MX records not valid yet: mxb.mailgun.org", receiving.Message)
+
+	// Sending records do not affect receiving readiness
+	mockClient.domains["mg.example.com"].ReceivingDNSRecords[1].Valid = &valid
+	_, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	receiving = cr.GetCondition(v1beta1.TypeReceivingReady)
+	assert.Equal(t, corev1.ConditionTrue, receiving.Status)
+	assert.Equal(t, v1beta1.ReasonMXRecordsValid, receiving.Reason)
+
+	// Unverified records are not ready
+	mockClient.domains["mg.example.com"].ReceivingDNSRecords[0].Valid = nil
+	_, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(v1beta1.TypeReceivingReady).Status)
+
+	// Sending domains do not get the condition
+	sending := &v1beta1.Domain{Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{
+		Name: "mg.example.com",
+		Type: stringPtr("sending"),
+	}}}
+	_, err = e.Observe(context.Background(), sending)
+	require.NoError(t, err)
+	assert.Equal(t, corev1.ConditionUnknown, sending.GetCondition(v1beta1.TypeReceivingReady).Status)
+}
+
+func TestDomainDKIMKeyRotation(t *testing.T) {
+	mockClient := &MockDomainClient{
+		domains: map[string]*v1beta1.DomainObservation{
+			"mg.example.com": {ID: "mg.example.com", State: "active", SendingDNSRecords: []v1beta1.DNSRecord{
+				{Name: "mg.example.com", Type: "TXT", Value: "v=spf1 include:mailgun.org ~all"},
+				{Name: "k0._domainkey.mg.example.com", Type: "TXT", Value: "k=rsa; p=OLDKEY"},
+			}},
+		},
+	}
+	e := &external{service: mockClient}
+	keySize := 2048
+	cr := &v1beta1.Domain{Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{
+		Name:        "mg.example.com",
+		DKIMKeySize: &keySize,
+	}}}
+
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+
+	cr.SetAnnotations(map[string]string{v1beta1.AnnotationRotateDKIMKey: "2025-06-01"})
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate, "a new annotation value should request a rotation")
+
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2048}, mockClient.dkimKeySizes)
+	assert.Equal(t, "2025-06-01", cr.Status.AtProvider.DKIMKeyRotation)
+	require.Len(t, cr.Status.AtProvider.SendingDNSRecords, 2, "the new key's record should replace the old key's")
+	assert.Equal(t, "mg.example.com", cr.Status.AtProvider.SendingDNSRecords[0].Name)
+	assert.Equal(t, "k1._domainkey.mg.example.com", cr.Status.AtProvider.SendingDNSRecords[1].Name)
+
+	// The rotation fires once per annotation value
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Len(t, mockClient.dkimKeySizes, 1)
+
+	cr.SetAnnotations(map[string]string{v1beta1.AnnotationRotateDKIMKey: "2025-12-01"})
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Len(t, mockClient.dkimKeySizes, 2)
+	assert.Equal(t, "2025-12-01", cr.Status.AtProvider.DKIMKeyRotation)
+}
+
+func TestDomainDNSRecordsFollowRegion(t *testing.T) {
+	// The records are Mailgun's own, so an EU account is never pointed at
+	// the US servers
+	cases := map[string]struct {
+		mx  []string
+		spf string
+	}{
+		"US": {mx: []string{"mxa.mailgun.org", "mxb.mailgun.org"}, spf: "v=spf1 include:mailgun.org ~all"},
+		"EU": {mx: []string{"mxa.eu.mailgun.org", "mxb.eu.mailgun.org"}, spf: "v=spf1 include:eu.mailgun.org ~all"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var receiving []v1beta1.DNSRecord
+			for _, mx := range tc.mx {
+				receiving = append(receiving, v1beta1.DNSRecord{Type: "MX", Value: mx, Valid: boolPtr(false)})
+			}
+			mockClient := &MockDomainClient{domains: map[string]*v1beta1.DomainObservation{
+				"mg.example.com": {
+					ID:                  "mg.example.com",
+					State:               "unverified",
+					ReceivingDNSRecords: receiving,
+					SendingDNSRecords:   []v1beta1.DNSRecord{{Name: "mg.example.com", Type: "TXT", Value: tc.spf, Valid: boolPtr(false)}},
+				},
+			}}
+			e := &external{service: mockClient}
+			cr := &v1beta1.Domain{Spec: v1beta1.DomainSpec{ForProvider: v1beta1.DomainParameters{
+				Name: "mg.example.com",
+				Type: stringPtr("receiving"),
+			}}}
+
+			_, err := e.Observe(context.Background(), cr)
+			require.NoError(t, err)
+			assert.Equal(t, receiving, cr.Status.AtProvider.ReceivingDNSRecords)
+			assert.Equal(t, tc.spf, cr.Status.AtProvider.SendingDNSRecords[0].Value)
+			assert.Equal(t, "MX records not valid yet: "+strings.Join(tc.mx, ", "), cr.GetCondition(v1beta1.TypeReceivingReady).Message)
+		})
+	}
+}
+
 // Helper functions
 func stringPtr(s string) *string {
 	return &s
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package domain
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
+)
+
+// observeIPs refreshes the dedicated IPs in status and reports whether they
+// match the desired set. The IPs are only read when the spec sets them, so a
+// domain created without any keeps whatever Mailgun assigns it.
+func (c *external) observeIPs(ctx context.Context, cr *v1beta1.Domain) (bool, error) {
+	desired := cr.Spec.ForProvider.IPs
+	if len(desired) == 0 {
+		return true, nil
+	}
+
+	assigned, err := c.service.ListDomainIPs(ctx, cr.Spec.ForProvider.Name)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to list domain IPs")
+	}
+	assigned = append([]string(nil), assigned...)
+	sort.Strings(assigned)
+	cr.Status.AtProvider.IPs = assigned
+
+	assign, unassign := clients.DiffIPs(desired, assigned)
+	return len(assign) == 0 && len(unassign) == 0, nil
+}
+
+// updateIPs assigns the desired IPs the domain is missing and unassigns the
+// ones it should no longer send from, based on the status written by
+// observeIPs.
+func (c *external) updateIPs(ctx context.Context, cr *v1beta1.Domain) error {
+	if len(cr.Spec.ForProvider.IPs) == 0 {
+		return nil
+	}
+
+	assign, unassign := clients.DiffIPs(cr.Spec.ForProvider.IPs, cr.Status.AtProvider.IPs)
+	for _, ip := range assign {
+		if err := c.service.AssignDomainIP(ctx, cr.Spec.ForProvider.Name, ip); err != nil {
+			return errors.Wrapf(recordPlanLimit(cr, err), "failed to assign IP %s", ip)
+		}
+	}
+	for _, ip := range unassign {
+		if err := c.service.UnassignDomainIP(ctx, cr.Spec.ForProvider.Name, ip); err != nil {
+			return errors.Wrapf(recordPlanLimit(cr, err), "failed to unassign IP %s", ip)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package domain
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+)
+
+// observeRecipients refreshes the authorized recipients in status and
+// reports whether they match the desired set. previous holds the recipients
+// recorded by the last observation; those this Domain added are the only ones
+// it may remove, and it keeps them while another Domain still lists them,
+// since recipients are shared by the whole account. Recipients awaiting
+// confirmation count as present, since there is nothing more the provider can
+// do for them.
+func (c *external) observeRecipients(ctx context.Context, cr *v1beta1.Domain, previous []v1beta1.AuthorizedRecipient) (bool, error) {
+	desired := recipientSet(cr.Spec.ForProvider.AuthorizedRecipients)
+	added := recipientSet(nil)
+	for _, r := range previous {
+		if r.Added {
+			added[strings.ToLower(r.Email)] = true
+		}
+	}
+	if len(desired) == 0 && len(added) == 0 {
+		cr.Status.AtProvider.AuthorizedRecipients = nil
+		return true, nil
+	}
+
+	existing, err := c.service.ListAuthorizedRecipients(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to list authorized recipients")
+	}
+	present := make(map[string]v1beta1.AuthorizedRecipient, len(existing))
+	for _, r := range existing {
+		present[strings.ToLower(r.Email)] = r
+	}
+
+	var elsewhere map[string]bool
+	for email := range added {
+		if _, ok := present[email]; ok && !desired[email] {
+			if elsewhere, err = c.recipientsListedElsewhere(ctx, cr); err != nil {
+				return false, err
+			}
+			break
+		}
+	}
+
+	tracked := recipientSet(nil)
+	for email := range desired {
+		tracked[email] = true
+	}
+	for email := range added {
+		tracked[email] = true
+	}
+
+	upToDate := true
+	var observed []v1beta1.AuthorizedRecipient
+	var pending []string
+	for _, email := range sortedEmails(tracked) {
+		r, ok := present[email]
+		if !ok {
+			upToDate = upToDate && !desired[email]
+			continue
+		}
+		if !desired[email] && !elsewhere[email] {
+			upToDate = false
+		}
+		r.Added = added[email]
+		observed = append(observed, r)
+		if desired[email] && !r.Activated {
+			pending = append(pending, r.Email)
+		}
+	}
+	cr.Status.AtProvider.AuthorizedRecipients = observed
+
+	if len(pending) > 0 {
+		cr.SetConditions(v1beta1.RecipientsPending(pending))
+	} else if cr.GetCondition(v1beta1.TypeRecipientsPending).Status == corev1.ConditionTrue {
+		cr.SetConditions(v1beta1.RecipientsConfirmed())
+	}
+
+	return upToDate, nil
+}
+
+// updateRecipients adds desired recipients that are missing from Mailgun and
+// removes recipients this Domain added that no Domain lists any more, based
+// on the status written by observeRecipients. Status is updated to record the
+// recipients added.
+func (c *external) updateRecipients(ctx context.Context, cr *v1beta1.Domain) error {
+	desired := recipientSet(cr.Spec.ForProvider.AuthorizedRecipients)
+	present := recipientSet(nil)
+	var remove []string
+	for _, r := range cr.Status.AtProvider.AuthorizedRecipients {
+		email := strings.ToLower(r.Email)
+		present[email] = true
+		if r.Added && !desired[email] {
+			remove = append(remove, email)
+		}
+	}
+
+	for _, email := range sortedEmails(desired) {
+		if present[email] {
+			continue
+		}
+		if _, err := c.service.AddAuthorizedRecipient(ctx, email); err != nil {
+			return errors.Wrapf(err, "failed to add authorized recipient %s", email)
+		}
+		cr.Status.AtProvider.AuthorizedRecipients = append(cr.Status.AtProvider.AuthorizedRecipients, v1beta1.AuthorizedRecipient{Email: email, Added: true})
+	}
+	if len(remove) == 0 {
+		return nil
+	}
+
+	elsewhere, err := c.recipientsListedElsewhere(ctx, cr)
+	if err != nil {
+		return err
+	}
+	removed := recipientSet(nil)
+	for _, email := range remove {
+		if elsewhere[email] {
+			continue
+		}
+		if err := c.service.DeleteAuthorizedRecipient(ctx, email); err != nil {
+			return errors.Wrapf(err, "failed to remove authorized recipient %s", email)
+		}
+		removed[email] = true
+	}
+
+	kept := cr.Status.AtProvider.AuthorizedRecipients[:0]
+	for _, r := range cr.Status.AtProvider.AuthorizedRecipients {
+		if !removed[strings.ToLower(r.Email)] {
+			kept = append(kept, r)
+		}
+	}
+	cr.Status.AtProvider.AuthorizedRecipients = kept
+	return nil
+}
+
+// recipientsListedElsewhere returns the lower-cased authorized recipients
+// listed by Domains other than cr that are not being deleted
+func (c *external) recipientsListedElsewhere(ctx context.Context, cr *v1beta1.Domain) (map[string]bool, error) {
+	listed := recipientSet(nil)
+	if c.kube == nil {
+		return listed, nil
+	}
+	domains := &v1beta1.DomainList{}
+	if err := c.kube.List(ctx, domains); err != nil {
+		return nil, errors.Wrap(err, "cannot list Domains sharing the authorized recipients")
+	}
+	for i := range domains.Items {
+		d := &domains.Items[i]
+		if (d.GetNamespace() == cr.GetNamespace() && d.GetName() == cr.GetName()) || meta.WasDeleted(d) {
+			continue
+		}
+		for _, email := range d.Spec.ForProvider.AuthorizedRecipients {
+			listed[strings.ToLower(email)] = true
+		}
+	}
+	return listed, nil
+}
+
+// recipientSet returns the lower-cased set of emails
+func recipientSet(emails []string) map[string]bool {
+	set := make(map[string]bool, len(emails))
+	for _, email := range emails {
+		set[strings.ToLower(email)] = true
+	}
+	return set
+}
+
+func sortedEmails(set map[string]bool) []string {
+	emails := make([]string, 0, len(set))
+	for email := range set {
+		emails = append(emails, email)
+	}
+	sort.Strings(emails)
+	return emails
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
+)
+
+// connectionKeyWebhookSigningKey is the connection detail holding the key
+// Mailgun signs webhook requests with
+const connectionKeyWebhookSigningKey = "webhook_signing_key"
+
+// rotationRequested returns the value of the rotation annotation if it asks
+// for a rotation that has not been performed yet.
+func rotationRequested(cr *v1beta1.Domain) (string, bool) {
+	requested := cr.GetAnnotations()[v1beta1.AnnotationRotateWebhookSigningKey]
+	return requested, requested != "" && requested != cr.Status.AtProvider.WebhookSigningKeyRotation
+}
+
+// signingKeyCache holds the webhook signing key of each Mailgun account. The
+// key belongs to the account rather than to a domain, so it is read at most
+// once per TTL for each account and shared by all its Domains.
+type signingKeyCache struct {
+	*ttlCache[string]
+}
+
+func newSigningKeyCache(ttl time.Duration) *signingKeyCache {
+	return &signingKeyCache{newTTLCache[string](ttl)}
+}
+
+// Get returns the webhook signing key of the account, reading it if the
+// cached one has expired. A nil cache reads the key on every call.
+func (s *signingKeyCache) Get(ctx context.Context, account string, svc clients.Client) (string, error) {
+	if s == nil {
+		return svc.GetWebhookSigningKey(ctx)
+	}
+	return s.ttlCache.Get(account, func() (string, error) {
+		return svc.GetWebhookSigningKey(ctx)
+	})
+}
+
+// Record replaces the cached key of the account after it has been rotated,
+// so that its other Domains publish the new key.
+func (s *signingKeyCache) Record(account, key string) {
+	if s == nil {
+		return
+	}
+	s.Set(account, key)
+}
+
+// publishSigningKey adds the current webhook signing key to details. The
+// connection secret is rewritten from scratch on every reconcile, so the key
+// has to be added each time the Domain publishes one.
+func (c *external) publishSigningKey(ctx context.Context, cr *v1beta1.Domain, details managed.ConnectionDetails) error {
+	if cr.GetWriteConnectionSecretToReference() == nil {
+		return nil
+	}
+	key, err := c.signingKeys.Get(ctx, c.account, c.service)
+	if err != nil {
+		return errors.Wrap(err, "failed to get webhook signing key")
+	}
+	if key != "" {
+		details[connectionKeyWebhookSigningKey] = []byte(key)
+	}
+	return nil
+}
+
+// rotateSigningKey rotates the webhook signing key if the rotation
+// annotation asks for it and adds the new key to details.
+func (c *external) rotateSigningKey(ctx context.Context, cr *v1beta1.Domain, details managed.ConnectionDetails) error {
+	requested, ok := rotationRequested(cr)
+	if !ok {
+		return c.publishSigningKey(ctx, cr, details)
+	}
+	key, err := c.service.RotateWebhookSigningKey(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to rotate webhook signing key")
+	}
+	c.signingKeys.Record(c.account, key)
+	cr.Status.AtProvider.WebhookSigningKeyRotation = requested
+	details[connectionKeyWebhookSigningKey] = []byte(key)
+	c.shareSigningKey(ctx, cr, key)
+	return nil
+}
+
+// shareSigningKey writes a rotated webhook signing key to the connection
+// secrets of the other Domains of the account, which would otherwise hand out
+// the old key, no longer accepted by Mailgun, until their next reconcile.
+// Failures are only logged: the rotation has happened, and those Domains
+// publish the new key on their next reconcile anyway.
+func (c *external) shareSigningKey(ctx context.Context, cr *v1beta1.Domain, key string) {
+	if c.kube == nil {
+		return
+	}
+	domains := &v1beta1.DomainList{}
+	if err := c.kube.List(ctx, domains); err != nil {
+		c.log.Debug("Cannot list the Domains sharing the webhook signing key", "error", err)
+		return
+	}
+	accounts := make(map[string]string)
+	for i := range domains.Items {
+		d := &domains.Items[i]
+		if (d.GetNamespace() == cr.GetNamespace() && d.GetName() == cr.GetName()) || d.GetWriteConnectionSecretToReference() == nil || meta.WasDeleted(d) {
+			continue
+		}
+		if c.accountOf(ctx, d, accounts) != c.account {
+			continue
+		}
+		if err := c.writeSigningKey(ctx, d, key); err != nil {
+			c.log.Debug("Cannot write the rotated webhook signing key", "domain", d.GetNamespace()+"/"+d.GetName(), "error", err)
+		}
+	}
+}
+
+// accountOf returns the account key of the Mailgun account d is managed
+// through, or "" if it cannot be determined. Accounts are looked up once per
+// ProviderConfig and remembered in accounts.
+func (c *external) accountOf(ctx context.Context, d *v1beta1.Domain, accounts map[string]string) string {
+	ref := d.GetProviderConfigReference()
+	if ref == nil {
+		return ""
+	}
+	pc := d.GetNamespace() + "/" + ref.Name
+	if account, ok := accounts[pc]; ok {
+		return account
+	}
+	account := ""
+	if config, err := clients.GetConfig(ctx, c.kube, d); err == nil {
+		account = accountKey(config)
+	}
+	accounts[pc] = account
+	return account
+}
+
+// writeSigningKey replaces the webhook signing key in the connection secret
+// of d, if it has been written.
+func (c *external) writeSigningKey(ctx context.Context, d *v1beta1.Domain, key string) error {
+	secret := &corev1.Secret{}
+	nn := types.NamespacedName{Namespace: d.GetNamespace(), Name: d.GetWriteConnectionSecretToReference().Name}
+	if err := c.kube.Get(ctx, nn, secret); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if string(secret.Data[connectionKeyWebhookSigningKey]) == key {
+		return nil
+	}
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	secret.Data[connectionKeyWebhookSigningKey] = []byte(key)
+	return c.kube.Update(ctx, secret)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package domain
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+)
+
+// withheldPasswordKey is the key of the SMTP password in both the connection
+// secret and the Secret holding a withheld password. Mailgun returns the
+// password of a domain only when it is created. A Domain that withholds its
+// credentials until it is active keeps the password in a Secret it owns
+// meanwhile, so that it is neither stored in plain text in the status nor
+// lost to a failed reconcile. The Secret is deleted once the connection
+// secret holds the password, and is otherwise garbage collected with the
+// Domain.
+const withheldPasswordKey = "smtp_password"
+
+// withheldSecretName returns the name of the Secret holding the withheld
+// password of cr
+func withheldSecretName(cr *v1beta1.Domain) string {
+	return cr.GetName() + "-withheld-smtp-password"
+}
+
+// withholdsConnectionDetails reports whether the SMTP credentials of domain
+// are kept out of the connection secret until it is active.
+func withholdsConnectionDetails(cr *v1beta1.Domain, domain *v1beta1.DomainObservation) bool {
+	return publishesWhenActive(cr) && domain.State != stateActive
+}
+
+func publishesWhenActive(cr *v1beta1.Domain) bool {
+	p := cr.Spec.ForProvider.PublishConnectionDetailsWhenActive
+	return p != nil && *p
+}
+
+// holdPassword stores the password of a domain whose credentials are withheld
+func (c *external) holdPassword(ctx context.Context, cr *v1beta1.Domain, password string) error {
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            withheldSecretName(cr),
+			Namespace:       cr.GetNamespace(),
+			OwnerReferences: []metav1.OwnerReference{meta.AsController(meta.TypedReferenceTo(cr, v1beta1.DomainGroupVersionKind))},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{withheldPasswordKey: []byte(password)},
+	}
+	return errors.Wrap(resource.NewAPIPatchingApplicator(c.kube).Apply(ctx, s), "cannot store the withheld SMTP password")
+}
+
+// publishHeldPassword adds the held password to details once the domain is
+// active, unless Mailgun reported a password itself. The held password is
+// taken from the connection secret after its Secret has been deleted.
+func (c *external) publishHeldPassword(ctx context.Context, cr *v1beta1.Domain, domain *v1beta1.DomainObservation, details managed.ConnectionDetails) error {
+	if !publishesWhenActive(cr) || withholdsConnectionDetails(cr, domain) || domain.SMTPPassword != "" {
+		return nil
+	}
+	published, err := c.publishedPassword(ctx, cr)
+	if err != nil {
+		return err
+	}
+
+	s := &corev1.Secret{}
+	err = c.kube.Get(ctx, types.NamespacedName{Name: withheldSecretName(cr), Namespace: cr.GetNamespace()}, s)
+	if kerrors.IsNotFound(err) {
+		if len(published) > 0 {
+			details[withheldPasswordKey] = published
+		}
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "cannot get the withheld SMTP password")
+	}
+	password := s.Data[withheldPasswordKey]
+	if len(password) == 0 {
+		return nil
+	}
+	details[withheldPasswordKey] = password
+
+	// The connection secret is written after Observe, so the password is
+	// only known to be safe there from the reconcile after it was added
+	if bytes.Equal(published, password) {
+		return errors.Wrap(resource.IgnoreNotFound(c.kube.Delete(ctx, s)), "cannot delete the withheld SMTP password")
+	}
+	return nil
+}
+
+// publishedPassword returns the SMTP password in the connection secret of
+// cr, if it has been written
+func (c *external) publishedPassword(ctx context.Context, cr *v1beta1.Domain) ([]byte, error) {
+	ref := cr.GetWriteConnectionSecretToReference()
+	if ref == nil {
+		return nil, nil
+	}
+	s := &corev1.Secret{}
+	err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: cr.GetNamespace()}, s)
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get the connection secret")
+	}
+	return s.Data[withheldPasswordKey], nil
+}
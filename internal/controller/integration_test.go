@@ -22,11 +22,15 @@ import (
 	"context"
 	"github.com/pkg/errors"
 	"github.com/rossigee/provider-mailgun/apis/bounce/v1beta1"
+	complainttypes "github.com/rossigee/provider-mailgun/apis/complaint/v1beta1"
 	"github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+	ippooltypes "github.com/rossigee/provider-mailgun/apis/ippool/v1beta1"
 	"github.com/rossigee/provider-mailgun/apis/mailinglist/v1beta1"
+	mailinglistmembertypes "github.com/rossigee/provider-mailgun/apis/mailinglistmember/v1beta1"
 	"github.com/rossigee/provider-mailgun/apis/route/v1beta1"
 	"github.com/rossigee/provider-mailgun/apis/smtpcredential/v1beta1"
 	"github.com/rossigee/provider-mailgun/apis/template/v1beta1"
+	unsubscribetypes "github.com/rossigee/provider-mailgun/apis/unsubscribe/v1beta1"
 	"github.com/rossigee/provider-mailgun/apis/webhook/v1beta1"
 	"github.com/rossigee/provider-mailgun/internal/clients"
 	"github.com/rossigee/provider-mailgun/internal/controller/domain"
@@ -195,6 +199,26 @@ func (m *IntegrationMockClient) DeleteMailingList(ctx context.Context, address s
 	return nil
 }
 
+func (m *IntegrationMockClient) CreateMailingListMember(ctx context.Context, listAddress string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *IntegrationMockClient) GetMailingListMember(ctx context.Context, listAddress, address string) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *IntegrationMockClient) UpdateMailingListMember(ctx context.Context, listAddress, address string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *IntegrationMockClient) DeleteMailingListMember(ctx context.Context, listAddress, address string) error {
+	return errors.New("not implemented")
+}
+
+func (m *IntegrationMockClient) AddMailingListMembers(ctx context.Context, listAddress string, members []clients.MailingListMemberSpec, upsert bool) error {
+	return errors.New("not implemented")
+}
+
 // Route operations
 func (m *IntegrationMockClient) CreateRoute(ctx context.Context, route *routev1beta1.RouteParameters) (*routev1beta1.RouteObservation, error) {
 	if m.err != nil {
@@ -477,11 +501,11 @@ func (m *IntegrationMockClient) DeleteBounce(ctx context.Context, domain, addres
 	return errors.New("not implemented")
 }
 
-func (m *IntegrationMockClient) CreateComplaint(ctx context.Context, domain string, complaint interface{}) (interface{}, error) {
+func (m *IntegrationMockClient) CreateComplaint(ctx context.Context, domain string, complaint *complainttypes.ComplaintParameters) (*complainttypes.ComplaintObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *IntegrationMockClient) GetComplaint(ctx context.Context, domain, address string) (interface{}, error) {
+func (m *IntegrationMockClient) GetComplaint(ctx context.Context, domain, address string) (*complainttypes.ComplaintObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
@@ -489,15 +513,43 @@ func (m *IntegrationMockClient) DeleteComplaint(ctx context.Context, domain, add
 	return errors.New("not implemented")
 }
 
-func (m *IntegrationMockClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe interface{}) (interface{}, error) {
+func (m *IntegrationMockClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe *unsubscribetypes.UnsubscribeParameters) (*unsubscribetypes.UnsubscribeObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *IntegrationMockClient) GetUnsubscribe(ctx context.Context, domain, address string) (*unsubscribetypes.UnsubscribeObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *IntegrationMockClient) DeleteUnsubscribe(ctx context.Context, domain, address, tag string) error {
+	return errors.New("not implemented")
+}
+
+func (m *IntegrationMockClient) CreateIPPool(ctx context.Context, pool *ippooltypes.IPPoolParameters) (*ippooltypes.IPPoolObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *IntegrationMockClient) GetIPPool(ctx context.Context, id string) (*ippooltypes.IPPoolObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *IntegrationMockClient) GetUnsubscribe(ctx context.Context, domain, address string) (interface{}, error) {
+func (m *IntegrationMockClient) ListDomainIPs(ctx context.Context, name string) ([]string, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *IntegrationMockClient) DeleteUnsubscribe(ctx context.Context, domain, address string) error {
+func (m *IntegrationMockClient) AssignDomainIP(ctx context.Context, name, ip string) error {
+	return errors.New("not implemented")
+}
+
+func (m *IntegrationMockClient) UnassignDomainIP(ctx context.Context, name, ip string) error {
+	return errors.New("not implemented")
+}
+
+func (m *IntegrationMockClient) UpdateIPPool(ctx context.Context, id string, pool *ippooltypes.IPPoolParameters, add, remove []string) error {
+	return errors.New("not implemented")
+}
+
+func (m *IntegrationMockClient) DeleteIPPool(ctx context.Context, id string) error {
 	return errors.New("not implemented")
 }
 
@@ -0,0 +1,300 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ippool
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/ippool/v1beta1"
+	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
+	mgerrors "github.com/rossigee/provider-mailgun/internal/errors"
+	"github.com/rossigee/provider-mailgun/internal/eventlabel"
+	"github.com/rossigee/provider-mailgun/internal/failfast"
+	"github.com/rossigee/provider-mailgun/internal/metrics"
+	"github.com/rossigee/provider-mailgun/internal/observecache"
+	"github.com/rossigee/provider-mailgun/internal/ratelimit"
+	"github.com/rossigee/provider-mailgun/internal/readonly"
+	"github.com/rossigee/provider-mailgun/internal/shutdown"
+	"github.com/rossigee/provider-mailgun/internal/strictdelete"
+	"github.com/rossigee/provider-mailgun/internal/watchdog"
+)
+
+const (
+	errNotIPPool    = "managed resource is not an IPPool custom resource"
+	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errGetCreds     = "cannot get credentials"
+	errNewClient    = "cannot create new Service"
+	errCreatePool   = "cannot create IP pool"
+	errGetPool      = "cannot get IP pool"
+	errUpdatePool   = "cannot update IP pool"
+	errDeletePool   = "cannot delete IP pool"
+)
+
+// Setup adds a controller that reconciles IPPool managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1beta1.IPPoolGroupKind.String())
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1beta1.IPPoolGroupVersionKind),
+		managed.WithExternalConnector(failfast.Wrap(mgr.GetClient(), readonly.Wrap(shutdown.Wrap(strictdelete.Wrap(watchdog.Wrap(observecache.Wrap(ratelimit.Wrap(&connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			newServiceFn: clients.NewClient,
+		})))))))),
+		// Mailgun assigns the pool ID, so the default initializer's use of
+		// the object name as external name does not apply
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(eventlabel.Wrap(event.NewAPIRecorder(mgr.GetEventRecorder(name)))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1beta1.IPPool{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	newServiceFn func(config *clients.Config) clients.Client
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.IPPool)
+	if !ok {
+		return nil, errors.New(errNotIPPool)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1beta1.ProviderConfig{}
+	pcRef := cr.GetProviderConfigReference()
+
+	// Handle case where no providerConfigRef is specified - default to "default"
+	pcName := "default"
+	if pcRef != nil && pcRef.Name != "" {
+		pcName = pcRef.Name
+	}
+
+	// Try namespaced lookup first (ProviderConfig CRD is scope: Namespaced)
+	pcNamespace := cr.GetNamespace()
+	pcErr := c.kube.Get(ctx, types.NamespacedName{Name: pcName, Namespace: pcNamespace}, pc)
+	if pcErr != nil {
+		// If namespaced lookup fails, try cluster-scoped as fallback
+		clusterErr := c.kube.Get(ctx, types.NamespacedName{Name: pcName}, pc)
+		if clusterErr != nil {
+			// Both lookups failed, return detailed error
+			return nil, errors.Wrapf(pcErr, "cannot get ProviderConfig '%s': tried namespaced lookup in '%s' and cluster-scoped lookup", pcName, pcNamespace)
+		}
+	}
+
+	cd := pc.Spec.Credentials
+	_, err := clients.ExtractCredentials(ctx, c.kube, cd)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	service := c.newServiceFn(config)
+	if service == nil {
+		return nil, errors.New(errNewClient)
+	}
+
+	return &external{service: service, reconcile: metrics.NewReconcileTimer(v1beta1.IPPoolKind)}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	service clients.Client
+
+	// reconcile times the work done by this client for one reconcile
+	reconcile *metrics.ReconcileTimer
+}
+
+func (c *external) Disconnect(ctx context.Context) error {
+	// No persistent connections to clean up
+	c.reconcile.Record()
+	return nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	defer c.reconcile.Start()()
+
+	cr, ok := mg.(*v1beta1.IPPool)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotIPPool)
+	}
+
+	// The external name is the pool ID, which Mailgun assigns on creation
+	id := poolID(cr)
+	if id == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	pool, err := c.service.GetIPPool(ctx, id)
+	if err != nil {
+		if clients.IsNotFound(err) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetPool)
+	}
+
+	cr.Status.AtProvider = *pool
+
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: isPoolUpToDate(&cr.Spec.ForProvider, pool),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	defer c.reconcile.Start()()
+
+	cr, ok := mg.(*v1beta1.IPPool)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotIPPool)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+
+	pool, err := c.service.CreateIPPool(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(recordPlanLimit(cr, err), errCreatePool)
+	}
+	clearPlanLimit(cr)
+
+	meta.SetExternalName(cr, pool.PoolID)
+	cr.Status.AtProvider = *pool
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	defer c.reconcile.Start()()
+
+	cr, ok := mg.(*v1beta1.IPPool)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotIPPool)
+	}
+
+	// Membership is changed IP by IP, against the IPs Observe found
+	add, remove := clients.DiffIPs(cr.Spec.ForProvider.IPs, cr.Status.AtProvider.IPs)
+	if err := c.service.UpdateIPPool(ctx, meta.GetExternalName(cr), &cr.Spec.ForProvider, add, remove); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(recordPlanLimit(cr, err), errUpdatePool)
+	}
+	clearPlanLimit(cr)
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	defer c.reconcile.Start()()
+
+	cr, ok := mg.(*v1beta1.IPPool)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotIPPool)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+
+	id := poolID(cr)
+	if id == "" {
+		return managed.ExternalDelete{}, nil
+	}
+
+	err := c.service.DeleteIPPool(ctx, id)
+	if err != nil && !clients.IsNotFound(err) {
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeletePool)
+	}
+
+	return managed.ExternalDelete{}, nil
+}
+
+// recordPlanLimit sets the PlanLimited condition when err was caused by the
+// account plan not including dedicated IP pools and returns it as a
+// PlanLimited provider error. Other errors are returned unchanged.
+func recordPlanLimit(cr *v1beta1.IPPool, err error) error {
+	if !clients.IsPlanLimited(err) {
+		return err
+	}
+	cr.SetConditions(apisv1beta1.PlanLimited(clients.ErrorMessage(err)))
+	return mgerrors.NewPlanLimitedError(err)
+}
+
+// clearPlanLimit resets a previously set PlanLimited condition after a
+// successful write.
+func clearPlanLimit(cr *v1beta1.IPPool) {
+	if cr.GetCondition(apisv1beta1.TypePlanLimited).Status == corev1.ConditionTrue {
+		cr.SetConditions(apisv1beta1.PlanSupported())
+	}
+}
+
+// poolID returns the ID of the pool, or "" if it has not been created yet.
+// An external name that is the object name was set by the default
+// initializer of earlier versions rather than by Create, and so is no ID.
+func poolID(cr *v1beta1.IPPool) string {
+	id := meta.GetExternalName(cr)
+	if id == cr.GetName() {
+		return ""
+	}
+	return id
+}
+
+// isPoolUpToDate reports whether the pool has the desired name, description
+// and IPs
+func isPoolUpToDate(p *v1beta1.IPPoolParameters, pool *v1beta1.IPPoolObservation) bool {
+	if p.Name != pool.Name {
+		return false
+	}
+	if p.Description != nil && *p.Description != pool.Description {
+		return false
+	}
+	add, remove := clients.DiffIPs(p.IPs, pool.IPs)
+	return len(add) == 0 && len(remove) == 0
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ippool
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/ippool/v1beta1"
+	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
+	mgerrors "github.com/rossigee/provider-mailgun/internal/errors"
+)
+
+// poolClient keeps IP pools by ID
+type poolClient struct {
+	clients.Client
+	pools map[string]*v1beta1.IPPoolObservation
+
+	added, removed []string
+}
+
+func (c *poolClient) CreateIPPool(ctx context.Context, p *v1beta1.IPPoolParameters) (*v1beta1.IPPoolObservation, error) {
+	id := fmt.Sprintf("pool-%d", len(c.pools)+1)
+	pool := &v1beta1.IPPoolObservation{PoolID: id, Name: p.Name, IPs: append([]string(nil), p.IPs...)}
+	if p.Description != nil {
+		pool.Description = *p.Description
+	}
+	c.pools[id] = pool
+	created := *pool
+	return &created, nil
+}
+
+func (c *poolClient) GetIPPool(ctx context.Context, id string) (*v1beta1.IPPoolObservation, error) {
+	pool, ok := c.pools[id]
+	if !ok {
+		return nil, errors.New("IP pool not found (404)")
+	}
+	observed := *pool
+	observed.IPs = append([]string(nil), pool.IPs...)
+	return &observed, nil
+}
+
+func (c *poolClient) UpdateIPPool(ctx context.Context, id string, p *v1beta1.IPPoolParameters, add, remove []string) error {
+	pool, ok := c.pools[id]
+	if !ok {
+		return errors.New("IP pool not found (404)")
+	}
+	c.added = append(c.added, add...)
+	c.removed = append(c.removed, remove...)
+	pool.Name = p.Name
+	if p.Description != nil {
+		pool.Description = *p.Description
+	}
+	kept := pool.IPs[:0]
+	for _, ip := range pool.IPs {
+		if !contains(remove, ip) {
+			kept = append(kept, ip)
+		}
+	}
+	pool.IPs = append(kept, add...)
+	return nil
+}
+
+func (c *poolClient) DeleteIPPool(ctx context.Context, id string) error {
+	if _, ok := c.pools[id]; !ok {
+		return errors.New("IP pool not found (404)")
+	}
+	delete(c.pools, id)
+	return nil
+}
+
+func contains(ips []string, ip string) bool {
+	for _, i := range ips {
+		if i == ip {
+			return true
+		}
+	}
+	return false
+}
+
+func pool() *v1beta1.IPPool {
+	desc := "New IPs warming up"
+	return &v1beta1.IPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "warmup", Namespace: "default"},
+		Spec: v1beta1.IPPoolSpec{
+			ForProvider: v1beta1.IPPoolParameters{
+				Name:        "warmup",
+				Description: &desc,
+				IPs:         []string{"192.0.2.10", "192.0.2.11"},
+			},
+		},
+	}
+}
+
+func TestIPPoolLifecycle(t *testing.T) {
+	ctx := context.Background()
+	pc := &poolClient{pools: map[string]*v1beta1.IPPoolObservation{}}
+	e := &external{service: pc}
+	cr := pool()
+
+	obs, err := e.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceExists)
+
+	_, err = e.Create(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, "pool-1", meta.GetExternalName(cr))
+
+	obs, err = e.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+	assert.True(t, obs.ResourceUpToDate)
+
+	// IPs changed outside the provider are put back, and only they are sent
+	pc.pools["pool-1"].IPs = []string{"192.0.2.11", "192.0.2.99"}
+	cr.Spec.ForProvider.IPs = append(cr.Spec.ForProvider.IPs, "192.0.2.12")
+	obs, err = e.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate)
+
+	_, err = e.Update(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.10", "192.0.2.12"}, pc.added)
+	assert.Equal(t, []string{"192.0.2.99"}, pc.removed)
+
+	obs, err = e.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.ElementsMatch(t, []string{"192.0.2.10", "192.0.2.11", "192.0.2.12"}, cr.Status.AtProvider.IPs)
+
+	// Renaming the pool changes no IPs
+	cr.Spec.ForProvider.Name = "warmup-eu"
+	obs, err = e.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate)
+	_, err = e.Update(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, "warmup-eu", pc.pools["pool-1"].Name)
+	assert.Len(t, pc.added, 2)
+
+	_, err = e.Delete(ctx, cr)
+	require.NoError(t, err)
+	assert.Empty(t, pc.pools)
+}
+
+func TestIPPoolDeleteMissing(t *testing.T) {
+	cr := pool()
+	meta.SetExternalName(cr, "gone")
+
+	e := &external{service: &poolClient{pools: map[string]*v1beta1.IPPoolObservation{}}}
+	_, err := e.Delete(context.Background(), cr)
+	require.NoError(t, err, "a pool that is already gone should be treated as deleted")
+}
+
+// idOnlyPoolClient answers 400 for IDs of pools it does not know, as Mailgun
+// may for values that are not pool IDs at all
+type idOnlyPoolClient struct {
+	*poolClient
+}
+
+func (c *idOnlyPoolClient) GetIPPool(ctx context.Context, id string) (*v1beta1.IPPoolObservation, error) {
+	if _, ok := c.pools[id]; !ok {
+		return nil, &clients.APIError{StatusCode: 400, Message: "invalid pool id"}
+	}
+	return c.poolClient.GetIPPool(ctx, id)
+}
+
+func TestIPPoolObjectNameAsExternalName(t *testing.T) {
+	ctx := context.Background()
+	pc := &poolClient{pools: map[string]*v1beta1.IPPoolObservation{}}
+	e := &external{service: &idOnlyPoolClient{poolClient: pc}}
+
+	// The default initializer of earlier versions set the object name
+	cr := pool()
+	meta.SetExternalName(cr, cr.GetName())
+
+	obs, err := e.Observe(ctx, cr)
+	require.NoError(t, err, "the object name should not be looked up as a pool ID")
+	assert.False(t, obs.ResourceExists)
+
+	_, err = e.Delete(ctx, cr)
+	require.NoError(t, err)
+
+	_, err = e.Create(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, "pool-1", meta.GetExternalName(cr))
+
+	obs, err = e.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+}
+
+// planLimitedPoolClient refuses to create pools while planLimited is set, as
+// Mailgun does for accounts whose plan has no dedicated IPs
+type planLimitedPoolClient struct {
+	*poolClient
+	planLimited bool
+}
+
+func (c *planLimitedPoolClient) CreateIPPool(ctx context.Context, p *v1beta1.IPPoolParameters) (*v1beta1.IPPoolObservation, error) {
+	if c.planLimited {
+		return nil, &clients.APIError{
+			StatusCode: 403,
+			Message:    "IP pools are not available on your current plan",
+			Body:       `{"message":"IP pools are not available on your current plan"}`,
+		}
+	}
+	return c.poolClient.CreateIPPool(ctx, p)
+}
+
+func TestIPPoolPlanLimited(t *testing.T) {
+	ctx := context.Background()
+	client := &planLimitedPoolClient{poolClient: &poolClient{pools: map[string]*v1beta1.IPPoolObservation{}}, planLimited: true}
+	e := &external{service: client}
+	cr := pool()
+
+	_, err := e.Create(ctx, cr)
+	require.Error(t, err)
+	assert.Equal(t, mgerrors.ErrorCodePlanLimited, mgerrors.GetErrorCode(errors.Cause(err)))
+	assert.Equal(t, corev1.ConditionTrue, cr.GetCondition(apisv1beta1.TypePlanLimited).Status)
+
+	// The condition is cleared once the plan allows the pool
+	client.planLimited = false
+	_, err = e.Create(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(apisv1beta1.TypePlanLimited).Status)
+}
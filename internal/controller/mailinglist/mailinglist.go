@@ -18,6 +18,9 @@ package mailinglist
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
@@ -29,6 +32,20 @@ import (
 	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
 
 	"github.com/rossigee/provider-mailgun/internal/clients"
+	"github.com/rossigee/provider-mailgun/internal/description"
+	"github.com/rossigee/provider-mailgun/internal/eventlabel"
+	"github.com/rossigee/provider-mailgun/internal/failfast"
+	"github.com/rossigee/provider-mailgun/internal/features"
+	"github.com/rossigee/provider-mailgun/internal/immutable"
+	"github.com/rossigee/provider-mailgun/internal/metrics"
+	"github.com/rossigee/provider-mailgun/internal/observecache"
+	"github.com/rossigee/provider-mailgun/internal/ratelimit"
+	"github.com/rossigee/provider-mailgun/internal/readonly"
+	"github.com/rossigee/provider-mailgun/internal/shutdown"
+	"github.com/rossigee/provider-mailgun/internal/strictdelete"
+	"github.com/rossigee/provider-mailgun/internal/trigger"
+	"github.com/rossigee/provider-mailgun/internal/watchdog"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -39,22 +56,38 @@ const (
 	errTrackPCUsage   = "cannot track ProviderConfig usage"
 	errGetPC          = "cannot get ProviderConfig"
 	errGetCreds       = "cannot get credentials"
+	errBadAddress     = "mailing list address %q is not a valid email address"
+
+	reasonAccessLevelBlocked     event.Reason = "AccessLevelChangeBlocked"
+	reasonReplyPreferenceChanged event.Reason = "ReplyPreferenceChanged"
 )
 
+// accessLevelRank orders access levels from most to least restrictive
+var accessLevelRank = map[string]int{
+	"readonly": 0,
+	"members":  1,
+	"everyone": 2,
+}
+
 // Setup adds a controller that reconciles MailingList managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(v1beta1.MailingListKind)
+	recorder := eventlabel.Wrap(event.NewAPIRecorder(mgr.GetEventRecorder(name)))
+
+	conn := &connector{
+		kube:              mgr.GetClient(),
+		recorder:          recorder,
+		usage:             resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+		newServiceFn:      clients.NewClient,
+		propagateMetadata: o.Features.Enabled(features.EnableDescriptionMetadata),
+	}
 
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1beta1.MailingListGroupVersionKind),
-		managed.WithExternalConnector(&connector{
-			kube:         mgr.GetClient(),
-			usage:        resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
-			newServiceFn: clients.NewClient,
-		}),
+		managed.WithExternalConnector(failfast.Wrap(mgr.GetClient(), readonly.Wrap(shutdown.Wrap(strictdelete.Wrap(watchdog.Wrap(observecache.Wrap(ratelimit.Wrap(immutable.Wrap(conn, immutableFields))))))))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
+		managed.WithRecorder(recorder))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -64,12 +97,27 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		Complete(r)
 }
 
+// immutableFields returns the fields that identify the Mailgun mailing list
+func immutableFields(mg resource.Managed) map[string]string {
+	cr, ok := mg.(*v1beta1.MailingList)
+	if !ok {
+		return nil
+	}
+	return map[string]string{
+		"spec.forProvider.address": cr.Spec.ForProvider.Address,
+	}
+}
+
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
 	newServiceFn func(config *clients.Config) clients.Client
+	recorder     event.Recorder
+
+	// propagateMetadata appends a managed-by tag to Mailgun descriptions
+	propagateMetadata bool
 }
 
 // Connect typically produces an ExternalClient by:
@@ -109,7 +157,7 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	}
 
 	cd := pc.Spec.Credentials
-	_, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	_, err := clients.ExtractCredentials(ctx, c.kube, cd)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
@@ -121,7 +169,7 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 
 	svc := c.newServiceFn(config)
 
-	return &external{service: svc}, nil
+	return &external{service: svc, recorder: c.recorder, propagateMetadata: c.propagateMetadata, reconcile: metrics.NewReconcileTimer(v1beta1.MailingListKind)}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
@@ -130,10 +178,19 @@ type external struct {
 	// A 'client' used to connect to the external resource API. In practice this
 	// would be something like an AWS SDK client.
 	service clients.Client
+
+	// recorder emits events explaining blocked changes; it may be nil
+	recorder event.Recorder
+
+	propagateMetadata bool
+
+	// reconcile times the work done by this client for one reconcile
+	reconcile *metrics.ReconcileTimer
 }
 
 func (c *external) Disconnect(ctx context.Context) error {
 	// No persistent connections to clean up
+	c.reconcile.Record()
 	return nil
 }
 
@@ -172,6 +229,8 @@ func (e *ExternalForTesting) Delete(ctx context.Context, mg resource.Managed) (m
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.MailingList)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotMailingList)
@@ -185,9 +244,10 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.Wrap(err, "failed to get mailing list")
 	}
 
-	upToDate := isMailingListUpToDate(mailingList, &cr.Spec.ForProvider)
-
 	cr.Status.AtProvider = *mailingList
+	confirmed := c.checkAccessLevel(cr)
+
+	upToDate := isMailingListUpToDate(mailingList, c.parameters(cr))
 
 	return managed.ExternalObservation{
 		// Return false when the external resource does not exist. This lets
@@ -200,6 +260,9 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		// resource reconciler know that it needs to call Update.
 		ResourceUpToDate: upToDate,
 
+		// Persist the removal of a confirmation that has been acted on.
+		ResourceLateInitialized: confirmed,
+
 		// Return any details that may be required to connect to the external
 		// resource. These will be stored as the connection secret.
 		ConnectionDetails: managed.ConnectionDetails{},
@@ -207,6 +270,8 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.MailingList)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotMailingList)
@@ -214,7 +279,11 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	cr.SetConditions(xpv1.Creating())
 
-	mailingList, err := c.service.CreateMailingList(ctx, &cr.Spec.ForProvider)
+	if err := validateAddress(cr.Spec.ForProvider.Address); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	mailingList, err := c.service.CreateMailingList(ctx, c.parameters(cr))
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, "failed to create mailing list")
 	}
@@ -230,17 +299,25 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.MailingList)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotMailingList)
 	}
 
-	mailingList, err := c.service.UpdateMailingList(ctx, cr.Spec.ForProvider.Address, &cr.Spec.ForProvider)
+	if err := validateAddress(cr.Spec.ForProvider.Address); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	previous := cr.Status.AtProvider.ReplyPreference
+	mailingList, err := c.service.UpdateMailingList(ctx, cr.Spec.ForProvider.Address, c.parameters(cr))
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, "failed to update mailing list")
 	}
 
 	cr.Status.AtProvider = *mailingList
+	c.recordReplyPreference(cr, previous)
 
 	return managed.ExternalUpdate{
 		// Optionally return any details that may be required to connect to the
@@ -250,6 +327,8 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.MailingList)
 	if !ok {
 		return managed.ExternalDelete{}, errors.New(errNotMailingList)
@@ -265,7 +344,86 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 	return managed.ExternalDelete{}, nil
 }
 
-// isMailingListUpToDate checks if the external resource is up to date
+// parameters returns the mailing list parameters to send to Mailgun, with
+// the managed-by tag added to the description when propagation is enabled
+func (c *external) parameters(cr *v1beta1.MailingList) *v1beta1.MailingListParameters {
+	params := cr.Spec.ForProvider
+	if c.propagateMetadata {
+		params.Description = description.Compose(cr, params.Description)
+	}
+	if _, _, blocked := blockedChange(cr); blocked {
+		current := cr.Status.AtProvider.AccessLevel
+		params.AccessLevel = &current
+	}
+	return &params
+}
+
+// checkAccessLevel reflects a blocked access level change in the
+// AccessLevelChangeBlocked condition, emitting an event when it is first
+// blocked, and consumes AnnotationConfirmAccessLevel once no change is left
+// to confirm. It reports whether the annotation was removed, which the
+// caller has to persist. It relies on status.atProvider holding the observed
+// list.
+func (c *external) checkAccessLevel(cr *v1beta1.MailingList) bool {
+	isBlocked := cr.GetCondition(v1beta1.TypeAccessLevelChangeBlocked).Status == corev1.ConditionTrue
+
+	current, desired, blocked := blockedChange(cr)
+	switch {
+	case blocked:
+		cond := v1beta1.AccessLevelChangeBlocked(current, desired)
+		if !isBlocked && c.recorder != nil {
+			c.recorder.Event(cr, event.Warning(reasonAccessLevelBlocked, errors.New(cond.Message)))
+		}
+		cr.SetConditions(cond)
+	case isBlocked:
+		cr.SetConditions(v1beta1.AccessLevelInSync())
+	}
+	return current == desired && trigger.Consume(cr, v1beta1.AnnotationConfirmAccessLevel)
+}
+
+// recordReplyPreference reflects the reply preference applied by an update in
+// status, emitting an event when it changed from previous, as that changes
+// where replies to the list are delivered.
+func (c *external) recordReplyPreference(cr *v1beta1.MailingList, previous string) {
+	desired := cr.Spec.ForProvider.ReplyPreference
+	if desired == nil {
+		return
+	}
+	// Mailgun's response may omit the preference it applied
+	if cr.Status.AtProvider.ReplyPreference == "" {
+		cr.Status.AtProvider.ReplyPreference = *desired
+	}
+
+	current := cr.Status.AtProvider.ReplyPreference
+	if previous == "" || previous == current || c.recorder == nil {
+		return
+	}
+	c.recorder.Event(cr, event.Normal(reasonReplyPreferenceChanged,
+		fmt.Sprintf("reply preference changed from %s to %s", previous, current)))
+}
+
+// blockedChange reports whether the desired access level restricts who may
+// post to the observed list, or opens it to everyone, without the change
+// being confirmed by AnnotationConfirmAccessLevel.
+func blockedChange(cr *v1beta1.MailingList) (current, desired string, blocked bool) {
+	if cr.Spec.ForProvider.AccessLevel == nil {
+		return "", "", false
+	}
+	current, desired = cr.Status.AtProvider.AccessLevel, *cr.Spec.ForProvider.AccessLevel
+
+	currentRank, knownCurrent := accessLevelRank[current]
+	desiredRank, knownDesired := accessLevelRank[desired]
+	opensList := desired == "everyone" && current != desired
+	if !knownCurrent || !knownDesired || (desiredRank >= currentRank && !opensList) {
+		return current, desired, false
+	}
+
+	confirmed := strings.TrimSpace(cr.GetAnnotations()[v1beta1.AnnotationConfirmAccessLevel])
+	return current, desired, !strings.EqualFold(confirmed, desired)
+}
+
+// isMailingListUpToDate checks if the external resource is up to date. Name,
+// description, access level and reply preference are compared when set.
 func isMailingListUpToDate(mailingList *v1beta1.MailingListObservation, desired *v1beta1.MailingListParameters) bool {
 	// Compare updatable fields
 	if desired.Name != nil && mailingList.Name != *desired.Name {
@@ -283,3 +441,12 @@ func isMailingListUpToDate(mailingList *v1beta1.MailingListObservation, desired
 
 	return true
 }
+
+// validateAddress rejects a malformed list address before it is submitted
+// by Create or Update, so it is reported instead of Mailgun's error for it
+func validateAddress(address string) error {
+	if !features.IsEmailAddress(address) {
+		return errors.Errorf(errBadAddress, address)
+	}
+	return nil
+}
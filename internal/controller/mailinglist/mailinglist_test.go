@@ -21,19 +21,28 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	bouncetypes "github.com/rossigee/provider-mailgun/apis/bounce/v1beta1"
+	complainttypes "github.com/rossigee/provider-mailgun/apis/complaint/v1beta1"
 	domaintypes "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+	ippooltypes "github.com/rossigee/provider-mailgun/apis/ippool/v1beta1"
 	v1beta1 "github.com/rossigee/provider-mailgun/apis/mailinglist/v1beta1"
+	mailinglistmembertypes "github.com/rossigee/provider-mailgun/apis/mailinglistmember/v1beta1"
 	routetypes "github.com/rossigee/provider-mailgun/apis/route/v1beta1"
 	smtpcredentialtypes "github.com/rossigee/provider-mailgun/apis/smtpcredential/v1beta1"
 	templatetypes "github.com/rossigee/provider-mailgun/apis/template/v1beta1"
+	unsubscribetypes "github.com/rossigee/provider-mailgun/apis/unsubscribe/v1beta1"
 	webhooktypes "github.com/rossigee/provider-mailgun/apis/webhook/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
 )
 
 // MockMailingListClient for testing
@@ -124,6 +133,30 @@ func (m *MockMailingListClient) DeleteMailingList(ctx context.Context, address s
 	return nil
 }
 
+func (m *MockMailingListClient) CreateMailingListMember(ctx context.Context, listAddress string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) GetMailingListMember(ctx context.Context, listAddress, address string) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) UpdateMailingListMember(ctx context.Context, listAddress, address string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) DeleteMailingListMember(ctx context.Context, listAddress, address string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) AddMailingListMembers(ctx context.Context, listAddress string, members []clients.MailingListMemberSpec, upsert bool) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) ListMailingListMembers(ctx context.Context, listAddress string) ([]*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
 // Implement other required client methods as no-ops
 func (m *MockMailingListClient) CreateDomain(ctx context.Context, domain *domaintypes.DomainParameters) (*domaintypes.DomainObservation, error) {
 	return nil, errors.New("not implemented")
@@ -141,6 +174,50 @@ func (m *MockMailingListClient) DeleteDomain(ctx context.Context, name string) e
 	return errors.New("not implemented")
 }
 
+func (m *MockMailingListClient) ListAuthorizedRecipients(ctx context.Context) ([]domaintypes.AuthorizedRecipient, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) AddAuthorizedRecipient(ctx context.Context, email string) (*domaintypes.AuthorizedRecipient, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) DeleteAuthorizedRecipient(ctx context.Context, email string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) GetWebhookSigningKey(ctx context.Context) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) RotateWebhookSigningKey(ctx context.Context) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) UpdateDomainTracking(ctx context.Context, name string, tracking *domaintypes.DomainTracking) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) GetDomainTracking(ctx context.Context, name string) (*domaintypes.DomainTracking, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) GetDomainConnection(ctx context.Context, name string) (*domaintypes.DomainConnection, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) UpdateDomainConnection(ctx context.Context, name string, connection *domaintypes.DomainConnection) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) UpdateDKIMKey(ctx context.Context, name string, keySize int) ([]domaintypes.DNSRecord, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) ListDomains(ctx context.Context, limit, skip int) ([]*domaintypes.DomainObservation, int, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
 func (m *MockMailingListClient) CreateRoute(ctx context.Context, route *routetypes.RouteParameters) (*routetypes.RouteObservation, error) {
 	return nil, errors.New("not implemented")
 }
@@ -157,6 +234,10 @@ func (m *MockMailingListClient) DeleteRoute(ctx context.Context, id string) erro
 	return errors.New("not implemented")
 }
 
+func (m *MockMailingListClient) ListRoutes(ctx context.Context, limit, skip int) ([]*routetypes.RouteObservation, int, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
 func (m *MockMailingListClient) CreateWebhook(ctx context.Context, domain string, webhook *webhooktypes.WebhookParameters) (*webhooktypes.WebhookObservation, error) {
 	return nil, errors.New("not implemented")
 }
@@ -205,6 +286,30 @@ func (m *MockMailingListClient) DeleteTemplate(ctx context.Context, domain, name
 	return errors.New("not implemented")
 }
 
+func (m *MockMailingListClient) CreateTemplateVersion(ctx context.Context, domain, name string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) GetTemplateVersion(ctx context.Context, domain, name, tag string) (*templatetypes.TemplateVersion, string, error) {
+	return nil, "", errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) UpdateTemplateVersion(ctx context.Context, domain, name, tag string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) DeleteTemplateVersion(ctx context.Context, domain, name, tag string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) ListTemplateVersions(ctx context.Context, domain, name string) ([]templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) RenderTemplate(ctx context.Context, domain, name string, vars map[string]interface{}) (string, error) {
+	return "", errors.New("not implemented")
+}
+
 // Bounce suppression operations
 func (m *MockMailingListClient) CreateBounce(ctx context.Context, domain string, bounce *bouncetypes.BounceParameters) (*bouncetypes.BounceObservation, error) {
 	return nil, errors.New("not implemented")
@@ -219,11 +324,11 @@ func (m *MockMailingListClient) DeleteBounce(ctx context.Context, domain, addres
 }
 
 // Complaint suppression operations
-func (m *MockMailingListClient) CreateComplaint(ctx context.Context, domain string, complaint interface{}) (interface{}, error) {
+func (m *MockMailingListClient) CreateComplaint(ctx context.Context, domain string, complaint *complainttypes.ComplaintParameters) (*complainttypes.ComplaintObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockMailingListClient) GetComplaint(ctx context.Context, domain, address string) (interface{}, error) {
+func (m *MockMailingListClient) GetComplaint(ctx context.Context, domain, address string) (*complainttypes.ComplaintObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
@@ -232,15 +337,43 @@ func (m *MockMailingListClient) DeleteComplaint(ctx context.Context, domain, add
 }
 
 // Unsubscribe suppression operations
-func (m *MockMailingListClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe interface{}) (interface{}, error) {
+func (m *MockMailingListClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe *unsubscribetypes.UnsubscribeParameters) (*unsubscribetypes.UnsubscribeObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) GetUnsubscribe(ctx context.Context, domain, address string) (*unsubscribetypes.UnsubscribeObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) DeleteUnsubscribe(ctx context.Context, domain, address, tag string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) CreateIPPool(ctx context.Context, pool *ippooltypes.IPPoolParameters) (*ippooltypes.IPPoolObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) GetIPPool(ctx context.Context, id string) (*ippooltypes.IPPoolObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockMailingListClient) GetUnsubscribe(ctx context.Context, domain, address string) (interface{}, error) {
+func (m *MockMailingListClient) ListDomainIPs(ctx context.Context, name string) ([]string, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockMailingListClient) DeleteUnsubscribe(ctx context.Context, domain, address string) error {
+func (m *MockMailingListClient) AssignDomainIP(ctx context.Context, name, ip string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) UnassignDomainIP(ctx context.Context, name, ip string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) UpdateIPPool(ctx context.Context, id string, pool *ippooltypes.IPPoolParameters, add, remove []string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMailingListClient) DeleteIPPool(ctx context.Context, id string) error {
 	return errors.New("not implemented")
 }
 
@@ -862,6 +995,323 @@ func TestMailingListAccessLevelsAndReplyPreferences(t *testing.T) {
 	}
 }
 
+func TestMailingListDescriptionMetadata(t *testing.T) {
+	mockClient := &MockMailingListClient{}
+	e := &external{service: mockClient, propagateMetadata: true}
+
+	cr := &v1beta1.MailingList{
+		Spec: v1beta1.MailingListSpec{
+			ForProvider: v1beta1.MailingListParameters{
+				Address:     "team@example.com",
+				Description: stringPtr("Team list"),
+			},
+		},
+	}
+	cr.SetName("team")
+
+	_, err := e.Create(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, "Team list (managed-by: crossplane/team)", mockClient.mailingLists["team@example.com"].Description)
+
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+}
+
+func TestMailingListFieldDrift(t *testing.T) {
+	observed := func() *v1beta1.MailingListObservation {
+		return &v1beta1.MailingListObservation{
+			Address:         "team@example.com",
+			Name:            "Team",
+			Description:     "Team list",
+			AccessLevel:     "readonly",
+			ReplyPreference: "list",
+		}
+	}
+	cases := map[string]struct {
+		mutate func(p *v1beta1.MailingListParameters)
+		check  func(t *testing.T, o *v1beta1.MailingListObservation)
+	}{
+		"Description": {
+			mutate: func(p *v1beta1.MailingListParameters) { p.Description = stringPtr("Engineering team list") },
+			check: func(t *testing.T, o *v1beta1.MailingListObservation) {
+				assert.Equal(t, "Engineering team list", o.Description)
+			},
+		},
+		"Name": {
+			mutate: func(p *v1beta1.MailingListParameters) { p.Name = stringPtr("Engineering") },
+			check:  func(t *testing.T, o *v1beta1.MailingListObservation) { assert.Equal(t, "Engineering", o.Name) },
+		},
+		"AccessLevel": {
+			mutate: func(p *v1beta1.MailingListParameters) { p.AccessLevel = stringPtr("members") },
+			check:  func(t *testing.T, o *v1beta1.MailingListObservation) { assert.Equal(t, "members", o.AccessLevel) },
+		},
+		"ReplyPreference": {
+			mutate: func(p *v1beta1.MailingListParameters) { p.ReplyPreference = stringPtr("sender") },
+			check:  func(t *testing.T, o *v1beta1.MailingListObservation) { assert.Equal(t, "sender", o.ReplyPreference) },
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockMailingListClient{
+				mailingLists: map[string]*v1beta1.MailingListObservation{"team@example.com": observed()},
+			}
+			e := &external{service: mockClient}
+			cr := &v1beta1.MailingList{
+				Spec: v1beta1.MailingListSpec{
+					ForProvider: v1beta1.MailingListParameters{
+						Address:         "team@example.com",
+						Name:            stringPtr("Team"),
+						Description:     stringPtr("Team list"),
+						AccessLevel:     stringPtr("readonly"),
+						ReplyPreference: stringPtr("list"),
+					},
+				},
+			}
+
+			obs, err := e.Observe(context.Background(), cr)
+			require.NoError(t, err)
+			require.True(t, obs.ResourceUpToDate)
+
+			tc.mutate(&cr.Spec.ForProvider)
+			obs, err = e.Observe(context.Background(), cr)
+			require.NoError(t, err)
+			assert.False(t, obs.ResourceUpToDate, "a change to only %s should be detected", name)
+
+			_, err = e.Update(context.Background(), cr)
+			require.NoError(t, err)
+			tc.check(t, mockClient.mailingLists["team@example.com"])
+
+			obs, err = e.Observe(context.Background(), cr)
+			require.NoError(t, err)
+			assert.True(t, obs.ResourceUpToDate)
+		})
+	}
+}
+
+// eventRecorder records the events it is given
+type eventRecorder struct {
+	events []event.Event
+}
+
+func (r *eventRecorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *eventRecorder) WithAnnotations(_ ...string) event.Recorder {
+	return r
+}
+
+func TestMailingListAccessLevelRestricted(t *testing.T) {
+	cases := map[string]struct {
+		annotations map[string]string
+		wantLevel   string
+		wantBlocked bool
+	}{
+		"Blocked": {
+			wantLevel:   "everyone",
+			wantBlocked: true,
+		},
+		"ConfirmedForOtherLevel": {
+			annotations: map[string]string{v1beta1.AnnotationConfirmAccessLevel: "members"},
+			wantLevel:   "everyone",
+			wantBlocked: true,
+		},
+		"Confirmed": {
+			annotations: map[string]string{v1beta1.AnnotationConfirmAccessLevel: "readonly"},
+			wantLevel:   "readonly",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockMailingListClient{
+				mailingLists: map[string]*v1beta1.MailingListObservation{
+					"team@example.com": {Address: "team@example.com", Name: "Team", AccessLevel: "everyone"},
+				},
+			}
+			recorder := &eventRecorder{}
+			e := &external{service: mockClient, recorder: recorder}
+			cr := &v1beta1.MailingList{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+				Spec: v1beta1.MailingListSpec{
+					ForProvider: v1beta1.MailingListParameters{
+						Address:     "team@example.com",
+						Name:        stringPtr("Team Renamed"),
+						AccessLevel: stringPtr("readonly"),
+					},
+				},
+			}
+
+			obs, err := e.Observe(context.Background(), cr)
+			require.NoError(t, err)
+			assert.False(t, obs.ResourceUpToDate, "the name change should still be applied")
+
+			_, err = e.Update(context.Background(), cr)
+			require.NoError(t, err)
+			list := mockClient.mailingLists["team@example.com"]
+			assert.Equal(t, "Team Renamed", list.Name)
+			assert.Equal(t, tc.wantLevel, list.AccessLevel)
+
+			obs, err = e.Observe(context.Background(), cr)
+			require.NoError(t, err)
+			assert.True(t, obs.ResourceUpToDate)
+
+			cond := cr.GetCondition(v1beta1.TypeAccessLevelChangeBlocked)
+			if !tc.wantBlocked {
+				assert.NotEqual(t, corev1.ConditionTrue, cond.Status)
+				assert.Empty(t, recorder.events)
+				return
+			}
+			assert.Equal(t, corev1.ConditionTrue, cond.Status)
+			assert.Equal(t, v1beta1.ReasonChangeNotConfirmed, cond.Reason)
+			require.Len(t, recorder.events, 1, "the block should be explained once")
+			assert.Equal(t, event.TypeWarning, recorder.events[0].Type)
+			assert.Contains(t, recorder.events[0].Message, v1beta1.AnnotationConfirmAccessLevel)
+
+			// Confirming the change applies it and clears the condition
+			cr.SetAnnotations(map[string]string{v1beta1.AnnotationConfirmAccessLevel: "readonly"})
+			obs, err = e.Observe(context.Background(), cr)
+			require.NoError(t, err)
+			assert.False(t, obs.ResourceUpToDate)
+			assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(v1beta1.TypeAccessLevelChangeBlocked).Status)
+
+			_, err = e.Update(context.Background(), cr)
+			require.NoError(t, err)
+			assert.Equal(t, "readonly", list.AccessLevel)
+
+			// The confirmation is removed once the change has been applied
+			obs, err = e.Observe(context.Background(), cr)
+			require.NoError(t, err)
+			assert.True(t, obs.ResourceLateInitialized, "the removal of the confirmation should be persisted")
+			assert.NotContains(t, cr.GetAnnotations(), v1beta1.AnnotationConfirmAccessLevel)
+		})
+	}
+}
+
+func TestMailingListAccessLevelOpenToEveryone(t *testing.T) {
+	mockClient := &MockMailingListClient{
+		mailingLists: map[string]*v1beta1.MailingListObservation{
+			"team@example.com": {Address: "team@example.com", Name: "Team", AccessLevel: "readonly"},
+		},
+	}
+	e := &external{service: mockClient, recorder: &eventRecorder{}}
+	cr := &v1beta1.MailingList{
+		Spec: v1beta1.MailingListSpec{
+			ForProvider: v1beta1.MailingListParameters{
+				Address:     "team@example.com",
+				AccessLevel: stringPtr("everyone"),
+			},
+		},
+	}
+
+	_, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, corev1.ConditionTrue, cr.GetCondition(v1beta1.TypeAccessLevelChangeBlocked).Status, "opening a list to everyone should need confirming")
+
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, "readonly", mockClient.mailingLists["team@example.com"].AccessLevel)
+
+	// Letting members post needs no confirmation
+	cr.Spec.ForProvider.AccessLevel = stringPtr("members")
+	_, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(v1beta1.TypeAccessLevelChangeBlocked).Status)
+
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, "members", mockClient.mailingLists["team@example.com"].AccessLevel)
+}
+
+// replyOmittingClient answers updates with a list that does not report its
+// reply preference, recording the parameters it was sent
+type replyOmittingClient struct {
+	*MockMailingListClient
+	updates []*v1beta1.MailingListParameters
+}
+
+func (m *replyOmittingClient) UpdateMailingList(ctx context.Context, address string, list *v1beta1.MailingListParameters) (*v1beta1.MailingListObservation, error) {
+	m.updates = append(m.updates, list)
+	updated := *m.mailingLists[address]
+	updated.ReplyPreference = ""
+	return &updated, nil
+}
+
+func TestMailingListReplyPreferenceChange(t *testing.T) {
+	mockClient := &replyOmittingClient{MockMailingListClient: &MockMailingListClient{
+		mailingLists: map[string]*v1beta1.MailingListObservation{
+			"team@example.com": {Address: "team@example.com", AccessLevel: "readonly", ReplyPreference: "list"},
+		},
+	}}
+	recorder := &eventRecorder{}
+	e := &external{service: mockClient, recorder: recorder}
+	cr := &v1beta1.MailingList{
+		Spec: v1beta1.MailingListSpec{
+			ForProvider: v1beta1.MailingListParameters{
+				Address:         "team@example.com",
+				ReplyPreference: stringPtr("sender"),
+			},
+		},
+	}
+
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate, "a reply preference change should be detected")
+
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	require.Len(t, mockClient.updates, 1)
+	require.NotNil(t, mockClient.updates[0].ReplyPreference)
+	assert.Equal(t, "sender", *mockClient.updates[0].ReplyPreference)
+	assert.Equal(t, "sender", cr.Status.AtProvider.ReplyPreference, "status should show the applied preference")
+
+	require.Len(t, recorder.events, 1)
+	assert.Equal(t, event.TypeNormal, recorder.events[0].Type)
+	assert.Equal(t, reasonReplyPreferenceChanged, recorder.events[0].Reason)
+	assert.Contains(t, recorder.events[0].Message, "from list to sender")
+}
+
+func TestMailingListAddressValidation(t *testing.T) {
+	cases := map[string]struct {
+		address string
+		valid   bool
+	}{
+		"Plain":      {address: "news@example.com", valid: true},
+		"Subdomain":  {address: "dev.team+ops@lists.mg.example.org", valid: true},
+		"NoAt":       {address: "news.example.com"},
+		"NoDomain":   {address: "news@"},
+		"NoTLD":      {address: "news@localhost"},
+		"TwoAts":     {address: "news@team@example.com"},
+		"Whitespace": {address: "news @example.com"},
+		"Empty":      {address: ""},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockMailingListClient{}
+			e := &external{service: mockClient}
+			mg := &v1beta1.MailingList{Spec: v1beta1.MailingListSpec{ForProvider: v1beta1.MailingListParameters{Address: tc.address}}}
+
+			_, err := e.Create(context.Background(), mg)
+			if tc.valid {
+				require.NoError(t, err)
+				assert.Contains(t, mockClient.mailingLists, tc.address)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "is not a valid email address")
+			assert.Empty(t, mockClient.mailingLists, "a malformed address must not be submitted")
+
+			// An address changed to a malformed one after creation is
+			// rejected the same way
+			_, err = e.Update(context.Background(), mg)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "is not a valid email address")
+		})
+	}
+}
+
 // Helper function
 func stringPtr(s string) *string {
 	return &s
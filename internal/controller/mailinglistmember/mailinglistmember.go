@@ -0,0 +1,334 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mailinglistmember
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/mailinglistmember/v1beta1"
+	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
+	"github.com/rossigee/provider-mailgun/internal/eventlabel"
+	"github.com/rossigee/provider-mailgun/internal/failfast"
+	"github.com/rossigee/provider-mailgun/internal/immutable"
+	"github.com/rossigee/provider-mailgun/internal/metrics"
+	"github.com/rossigee/provider-mailgun/internal/observecache"
+	"github.com/rossigee/provider-mailgun/internal/ratelimit"
+	"github.com/rossigee/provider-mailgun/internal/readonly"
+	"github.com/rossigee/provider-mailgun/internal/shutdown"
+	"github.com/rossigee/provider-mailgun/internal/strictdelete"
+	"github.com/rossigee/provider-mailgun/internal/watchdog"
+)
+
+const (
+	errNotMailingListMember = "managed resource is not a MailingListMember custom resource"
+	errTrackPCUsage         = "cannot track ProviderConfig usage"
+	errGetPC                = "cannot get ProviderConfig"
+	errGetCreds             = "cannot get credentials"
+	errUpdateManaged        = "cannot update managed resource"
+
+	// errListMissing is returned while the member's list does not exist, so
+	// that the member is retried until the list is created
+	errListMissing = "mailing list %s does not exist yet"
+)
+
+// Setup adds a controller that reconciles MailingListMember managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1beta1.MailingListMemberKind)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1beta1.MailingListMemberGroupVersionKind),
+		managed.WithExternalConnector(failfast.Wrap(mgr.GetClient(), readonly.Wrap(shutdown.Wrap(strictdelete.Wrap(watchdog.Wrap(observecache.Wrap(ratelimit.Wrap(immutable.Wrap(&connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			newServiceFn: clients.NewClient,
+		}, immutableFields))))))))),
+		managed.WithInitializers(initializer(mgr.GetClient())),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(eventlabel.Wrap(event.NewAPIRecorder(mgr.GetEventRecorder(name)))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1beta1.MailingListMember{}).
+		Complete(r)
+}
+
+// initializer returns an Initializer that names a new member after its
+// address, in place of the default that uses the object name
+func initializer(kube client.Client) managed.Initializer {
+	return managed.InitializerFn(func(ctx context.Context, mg resource.Managed) error {
+		cr, ok := mg.(*v1beta1.MailingListMember)
+		if !ok {
+			return errors.New(errNotMailingListMember)
+		}
+		if meta.GetExternalName(cr) != "" {
+			return nil
+		}
+		meta.SetExternalName(cr, cr.Spec.ForProvider.Address)
+		return errors.Wrap(kube.Update(ctx, cr), errUpdateManaged)
+	})
+}
+
+// memberAddress returns the address the member is known by in Mailgun. An
+// external name that is the object name rather than an address was set by
+// the default initializer of earlier versions and is ignored.
+func memberAddress(cr *v1beta1.MailingListMember) string {
+	externalName := meta.GetExternalName(cr)
+	if externalName == "" || (externalName == cr.GetName() && !strings.Contains(externalName, "@")) {
+		return cr.Spec.ForProvider.Address
+	}
+	return externalName
+}
+
+// immutableFields returns the fields that identify the Mailgun list member
+func immutableFields(mg resource.Managed) map[string]string {
+	cr, ok := mg.(*v1beta1.MailingListMember)
+	if !ok {
+		return nil
+	}
+	return map[string]string{
+		"spec.forProvider.listAddress": cr.Spec.ForProvider.ListAddress,
+		"spec.forProvider.address":     cr.Spec.ForProvider.Address,
+	}
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	newServiceFn func(config *clients.Config) clients.Client
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.MailingListMember)
+	if !ok {
+		return nil, errors.New(errNotMailingListMember)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1beta1.ProviderConfig{}
+	pcRef := cr.GetProviderConfigReference()
+
+	// Handle case where no providerConfigRef is specified - default to "default"
+	pcName := "default"
+	if pcRef != nil && pcRef.Name != "" {
+		pcName = pcRef.Name
+	}
+
+	// Try namespaced lookup first (ProviderConfig CRD is scope: Namespaced)
+	pcNamespace := cr.GetNamespace()
+	pcErr := c.kube.Get(ctx, types.NamespacedName{Name: pcName, Namespace: pcNamespace}, pc)
+	if pcErr != nil {
+		// If namespaced lookup fails, try cluster-scoped as fallback
+		clusterErr := c.kube.Get(ctx, types.NamespacedName{Name: pcName}, pc)
+		if clusterErr != nil {
+			// Both lookups failed, return detailed error
+			return nil, errors.Wrapf(pcErr, "cannot get ProviderConfig '%s': tried namespaced lookup in '%s' and cluster-scoped lookup", pcName, pcNamespace)
+		}
+	}
+
+	cd := pc.Spec.Credentials
+	_, err := clients.ExtractCredentials(ctx, c.kube, cd)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	svc := c.newServiceFn(config)
+
+	return &external{service: svc, kube: c.kube, reconcile: metrics.NewReconcileTimer(v1beta1.MailingListMemberKind)}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	service clients.Client
+	kube    client.Client
+
+	// reconcile times the work done by this client for one reconcile
+	reconcile *metrics.ReconcileTimer
+}
+
+func (c *external) Disconnect(ctx context.Context) error {
+	// No persistent connections to clean up
+	c.reconcile.Record()
+	return nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	defer c.reconcile.Start()()
+
+	cr, ok := mg.(*v1beta1.MailingListMember)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotMailingListMember)
+	}
+
+	// Use the member address as the external name, replacing an object name
+	// left by an earlier version
+	externalName := memberAddress(cr)
+	renamed := meta.GetExternalName(cr) != "" && meta.GetExternalName(cr) != externalName
+	meta.SetExternalName(cr, externalName)
+
+	listAddress := cr.Spec.ForProvider.ListAddress
+	member, err := c.service.GetMailingListMember(ctx, listAddress, externalName)
+	if err != nil {
+		if !clients.IsNotFound(err) {
+			return managed.ExternalObservation{}, errors.Wrap(err, "cannot get mailing list member")
+		}
+		// Mailgun answers 404 for a missing list too; creating the member
+		// would fail the same way, so wait for the list instead. A member
+		// being deleted is gone with its list, so its finalizer can go.
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		if err := c.checkList(ctx, listAddress); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	cr.Status.AtProvider = *member
+
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        isMemberUpToDate(member, &cr.Spec.ForProvider),
+		ResourceLateInitialized: renamed,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	defer c.reconcile.Start()()
+
+	cr, ok := mg.(*v1beta1.MailingListMember)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotMailingListMember)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	member, err := c.service.CreateMailingListMember(ctx, cr.Spec.ForProvider.ListAddress, &cr.Spec.ForProvider)
+	if err != nil {
+		if clients.IsNotFound(err) {
+			return managed.ExternalCreation{}, errors.Wrapf(err, errListMissing, cr.Spec.ForProvider.ListAddress)
+		}
+		return managed.ExternalCreation{}, errors.Wrap(err, "cannot create mailing list member")
+	}
+
+	meta.SetExternalName(cr, cr.Spec.ForProvider.Address)
+	cr.Status.AtProvider = *member
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	defer c.reconcile.Start()()
+
+	cr, ok := mg.(*v1beta1.MailingListMember)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotMailingListMember)
+	}
+
+	member, err := c.service.UpdateMailingListMember(ctx, cr.Spec.ForProvider.ListAddress, memberAddress(cr), &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, "cannot update mailing list member")
+	}
+
+	cr.Status.AtProvider = *member
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	defer c.reconcile.Start()()
+
+	cr, ok := mg.(*v1beta1.MailingListMember)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotMailingListMember)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+
+	// A member whose list is already gone was removed along with it
+	err := c.service.DeleteMailingListMember(ctx, cr.Spec.ForProvider.ListAddress, memberAddress(cr))
+	if err != nil && !clients.IsNotFound(err) {
+		return managed.ExternalDelete{}, errors.Wrap(err, "cannot delete mailing list member")
+	}
+
+	return managed.ExternalDelete{}, nil
+}
+
+// checkList returns an error if the mailing list does not exist
+func (c *external) checkList(ctx context.Context, listAddress string) error {
+	_, err := c.service.GetMailingList(ctx, listAddress)
+	switch {
+	case err == nil:
+		return nil
+	case clients.IsNotFound(err):
+		return errors.Errorf(errListMissing, listAddress)
+	default:
+		return errors.Wrap(err, "cannot get mailing list")
+	}
+}
+
+// isMemberUpToDate checks if the member matches the desired state
+func isMemberUpToDate(member *v1beta1.MailingListMemberObservation, desired *v1beta1.MailingListMemberParameters) bool {
+	// Compare updatable fields
+	if desired.Name != nil && member.Name != *desired.Name {
+		return false
+	}
+	if desired.Vars != nil && !reflect.DeepEqual(member.Vars, desired.Vars) {
+		return false
+	}
+	if desired.Subscribed != nil && member.Subscribed != *desired.Subscribed {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,608 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mailinglistmember
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	bouncetypes "github.com/rossigee/provider-mailgun/apis/bounce/v1beta1"
+	complainttypes "github.com/rossigee/provider-mailgun/apis/complaint/v1beta1"
+	domaintypes "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+	ippooltypes "github.com/rossigee/provider-mailgun/apis/ippool/v1beta1"
+	mailinglisttypes "github.com/rossigee/provider-mailgun/apis/mailinglist/v1beta1"
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/mailinglistmember/v1beta1"
+	routetypes "github.com/rossigee/provider-mailgun/apis/route/v1beta1"
+	smtpcredentialtypes "github.com/rossigee/provider-mailgun/apis/smtpcredential/v1beta1"
+	templatetypes "github.com/rossigee/provider-mailgun/apis/template/v1beta1"
+	unsubscribetypes "github.com/rossigee/provider-mailgun/apis/unsubscribe/v1beta1"
+	webhooktypes "github.com/rossigee/provider-mailgun/apis/webhook/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
+)
+
+// MockMemberClient for testing. Members are keyed by list and address, and
+// only lists in lists exist.
+type MockMemberClient struct {
+	lists   map[string]bool
+	members map[string]*v1beta1.MailingListMemberObservation
+	err     error
+}
+
+func (m *MockMemberClient) GetMailingList(ctx context.Context, address string) (*mailinglisttypes.MailingListObservation, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if !m.lists[address] {
+		return nil, errors.New("mailing list not found (404)")
+	}
+	return &mailinglisttypes.MailingListObservation{Address: address}, nil
+}
+
+func (m *MockMemberClient) CreateMailingListMember(ctx context.Context, listAddress string, member *v1beta1.MailingListMemberParameters) (*v1beta1.MailingListMemberObservation, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if !m.lists[listAddress] {
+		return nil, errors.New("mailing list not found (404)")
+	}
+
+	result := &v1beta1.MailingListMemberObservation{Address: member.Address, Vars: member.Vars, Subscribed: true}
+	if member.Name != nil {
+		result.Name = *member.Name
+	}
+	if member.Subscribed != nil {
+		result.Subscribed = *member.Subscribed
+	}
+
+	if m.members == nil {
+		m.members = make(map[string]*v1beta1.MailingListMemberObservation)
+	}
+	m.members[listAddress+"/"+member.Address] = result
+
+	return result, nil
+}
+
+func (m *MockMemberClient) GetMailingListMember(ctx context.Context, listAddress, address string) (*v1beta1.MailingListMemberObservation, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	if member, exists := m.members[listAddress+"/"+address]; exists {
+		result := *member
+		return &result, nil
+	}
+
+	return nil, errors.New("member not found (404)")
+}
+
+func (m *MockMemberClient) UpdateMailingListMember(ctx context.Context, listAddress, address string, member *v1beta1.MailingListMemberParameters) (*v1beta1.MailingListMemberObservation, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	existing, exists := m.members[listAddress+"/"+address]
+	if !exists {
+		return nil, errors.New("member not found (404)")
+	}
+	if member.Name != nil {
+		existing.Name = *member.Name
+	}
+	if member.Vars != nil {
+		existing.Vars = member.Vars
+	}
+	if member.Subscribed != nil {
+		existing.Subscribed = *member.Subscribed
+	}
+
+	result := *existing
+	return &result, nil
+}
+
+func (m *MockMemberClient) DeleteMailingListMember(ctx context.Context, listAddress, address string) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	key := listAddress + "/" + address
+	if _, exists := m.members[key]; !exists {
+		return errors.New("member not found (404)")
+	}
+	delete(m.members, key)
+	return nil
+}
+
+func (m *MockMemberClient) AddMailingListMembers(ctx context.Context, listAddress string, members []clients.MailingListMemberSpec, upsert bool) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMemberClient) ListMailingListMembers(ctx context.Context, listAddress string) ([]*v1beta1.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+// Implement other required client methods as no-ops with v1beta1 types
+
+// Domain operations
+func (m *MockMemberClient) CreateDomain(ctx context.Context, domain *domaintypes.DomainParameters) (*domaintypes.DomainObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) GetDomain(ctx context.Context, name string) (*domaintypes.DomainObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) UpdateDomain(ctx context.Context, name string, domain *domaintypes.DomainParameters) (*domaintypes.DomainObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) DeleteDomain(ctx context.Context, name string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMemberClient) ListAuthorizedRecipients(ctx context.Context) ([]domaintypes.AuthorizedRecipient, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) AddAuthorizedRecipient(ctx context.Context, email string) (*domaintypes.AuthorizedRecipient, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) DeleteAuthorizedRecipient(ctx context.Context, email string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMemberClient) GetWebhookSigningKey(ctx context.Context) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *MockMemberClient) RotateWebhookSigningKey(ctx context.Context) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *MockMemberClient) UpdateDomainTracking(ctx context.Context, name string, tracking *domaintypes.DomainTracking) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMemberClient) GetDomainTracking(ctx context.Context, name string) (*domaintypes.DomainTracking, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) GetDomainConnection(ctx context.Context, name string) (*domaintypes.DomainConnection, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) UpdateDomainConnection(ctx context.Context, name string, connection *domaintypes.DomainConnection) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMemberClient) UpdateDKIMKey(ctx context.Context, name string, keySize int) ([]domaintypes.DNSRecord, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) ListDomains(ctx context.Context, limit, skip int) ([]*domaintypes.DomainObservation, int, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
+// MailingList operations
+func (m *MockMemberClient) CreateMailingList(ctx context.Context, list *mailinglisttypes.MailingListParameters) (*mailinglisttypes.MailingListObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) UpdateMailingList(ctx context.Context, address string, list *mailinglisttypes.MailingListParameters) (*mailinglisttypes.MailingListObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) DeleteMailingList(ctx context.Context, address string) error {
+	return errors.New("not implemented")
+}
+
+// Route operations
+func (m *MockMemberClient) CreateRoute(ctx context.Context, route *routetypes.RouteParameters) (*routetypes.RouteObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) GetRoute(ctx context.Context, id string) (*routetypes.RouteObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) UpdateRoute(ctx context.Context, id string, route *routetypes.RouteParameters) (*routetypes.RouteObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) DeleteRoute(ctx context.Context, id string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMemberClient) ListRoutes(ctx context.Context, limit, skip int) ([]*routetypes.RouteObservation, int, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
+// Webhook operations
+func (m *MockMemberClient) CreateWebhook(ctx context.Context, domain string, webhook *webhooktypes.WebhookParameters) (*webhooktypes.WebhookObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) GetWebhook(ctx context.Context, domain, eventType string) (*webhooktypes.WebhookObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) UpdateWebhook(ctx context.Context, domain, eventType string, webhook *webhooktypes.WebhookParameters) (*webhooktypes.WebhookObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) DeleteWebhook(ctx context.Context, domain, eventType string) error {
+	return errors.New("not implemented")
+}
+
+// SMTPCredential operations
+func (m *MockMemberClient) CreateSMTPCredential(ctx context.Context, domain string, credential *smtpcredentialtypes.SMTPCredentialParameters) (*smtpcredentialtypes.SMTPCredentialObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) GetSMTPCredential(ctx context.Context, domain, login string) (*smtpcredentialtypes.SMTPCredentialObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) UpdateSMTPCredential(ctx context.Context, domain, login string, password string) (*smtpcredentialtypes.SMTPCredentialObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) DeleteSMTPCredential(ctx context.Context, domain, login string) error {
+	return errors.New("not implemented")
+}
+
+// Template operations
+func (m *MockMemberClient) CreateTemplate(ctx context.Context, domain string, template *templatetypes.TemplateParameters) (*templatetypes.TemplateObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) GetTemplate(ctx context.Context, domain, name string) (*templatetypes.TemplateObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) UpdateTemplate(ctx context.Context, domain, name string, template *templatetypes.TemplateParameters) (*templatetypes.TemplateObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) DeleteTemplate(ctx context.Context, domain, name string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMemberClient) CreateTemplateVersion(ctx context.Context, domain, name string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) GetTemplateVersion(ctx context.Context, domain, name, tag string) (*templatetypes.TemplateVersion, string, error) {
+	return nil, "", errors.New("not implemented")
+}
+
+func (m *MockMemberClient) UpdateTemplateVersion(ctx context.Context, domain, name, tag string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) DeleteTemplateVersion(ctx context.Context, domain, name, tag string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMemberClient) ListTemplateVersions(ctx context.Context, domain, name string) ([]templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) RenderTemplate(ctx context.Context, domain, name string, vars map[string]interface{}) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *MockMemberClient) CreateBounce(ctx context.Context, domain string, bounce *bouncetypes.BounceParameters) (*bouncetypes.BounceObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) GetBounce(ctx context.Context, domain, address string) (*bouncetypes.BounceObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) DeleteBounce(ctx context.Context, domain, address string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMemberClient) CreateComplaint(ctx context.Context, domain string, complaint *complainttypes.ComplaintParameters) (*complainttypes.ComplaintObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) GetComplaint(ctx context.Context, domain, address string) (*complainttypes.ComplaintObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) DeleteComplaint(ctx context.Context, domain, address string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMemberClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe *unsubscribetypes.UnsubscribeParameters) (*unsubscribetypes.UnsubscribeObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) GetUnsubscribe(ctx context.Context, domain, address string) (*unsubscribetypes.UnsubscribeObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) DeleteUnsubscribe(ctx context.Context, domain, address, tag string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMemberClient) CreateIPPool(ctx context.Context, pool *ippooltypes.IPPoolParameters) (*ippooltypes.IPPoolObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) GetIPPool(ctx context.Context, id string) (*ippooltypes.IPPoolObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) ListDomainIPs(ctx context.Context, name string) ([]string, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockMemberClient) AssignDomainIP(ctx context.Context, name, ip string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMemberClient) UnassignDomainIP(ctx context.Context, name, ip string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMemberClient) UpdateIPPool(ctx context.Context, id string, pool *ippooltypes.IPPoolParameters, add, remove []string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockMemberClient) DeleteIPPool(ctx context.Context, id string) error {
+	return errors.New("not implemented")
+}
+
+func newMember(externalName string) *v1beta1.MailingListMember {
+	cr := &v1beta1.MailingListMember{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-member", Namespace: "test-namespace"},
+		Spec: v1beta1.MailingListMemberSpec{
+			ForProvider: v1beta1.MailingListMemberParameters{
+				ListAddress: "team@example.com",
+				Address:     "alice@example.org",
+				Name:        stringPtr("Alice"),
+				Vars:        map[string]string{"role": "admin"},
+				Subscribed:  boolPtr(true),
+			},
+		},
+	}
+	if externalName != "" {
+		cr.SetAnnotations(map[string]string{"crossplane.io/external-name": externalName})
+	}
+	return cr
+}
+
+func TestMailingListMemberObserve(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		lists    map[string]bool
+		members  map[string]*v1beta1.MailingListMemberObservation
+		deleting bool
+		want     managed.ExternalObservation
+		wantErr  string
+	}{
+		"UpToDate": {
+			reason: "A member matching the spec should be up to date",
+			lists:  map[string]bool{"team@example.com": true},
+			members: map[string]*v1beta1.MailingListMemberObservation{
+				"team@example.com/alice@example.org": {Address: "alice@example.org", Name: "Alice", Vars: map[string]string{"role": "admin"}, Subscribed: true},
+			},
+			want: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"Unsubscribed": {
+			reason: "A member whose subscription differs should need an update",
+			lists:  map[string]bool{"team@example.com": true},
+			members: map[string]*v1beta1.MailingListMemberObservation{
+				"team@example.com/alice@example.org": {Address: "alice@example.org", Name: "Alice", Vars: map[string]string{"role": "admin"}},
+			},
+			want: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+		},
+		"VarsChanged": {
+			reason: "A member whose vars differ should need an update",
+			lists:  map[string]bool{"team@example.com": true},
+			members: map[string]*v1beta1.MailingListMemberObservation{
+				"team@example.com/alice@example.org": {Address: "alice@example.org", Name: "Alice", Vars: map[string]string{"role": "user"}, Subscribed: true},
+			},
+			want: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+		},
+		"NotFound": {
+			reason: "A missing member of an existing list should be reported as not existing",
+			lists:  map[string]bool{"team@example.com": true},
+			want:   managed.ExternalObservation{ResourceExists: false},
+		},
+		"ListMissing": {
+			reason:  "A member of a list that does not exist yet should fail so it is retried",
+			wantErr: "mailing list team@example.com does not exist yet",
+		},
+		"ListMissingWhileDeleting": {
+			reason:   "A member being deleted after its list should be gone so its finalizer is removed",
+			deleting: true,
+			want:     managed.ExternalObservation{ResourceExists: false},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{service: &MockMemberClient{lists: tc.lists, members: tc.members}}
+			cr := newMember("")
+			if tc.deleting {
+				now := metav1.Now()
+				cr.SetDeletionTimestamp(&now)
+			}
+
+			got, err := e.Observe(context.Background(), cr)
+			if tc.wantErr != "" {
+				require.Error(t, err, tc.reason)
+				assert.Contains(t, err.Error(), tc.wantErr, tc.reason)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got, tc.reason)
+			assert.Equal(t, "alice@example.org", cr.GetAnnotations()["crossplane.io/external-name"])
+		})
+	}
+}
+
+func TestMailingListMemberCreate(t *testing.T) {
+	t.Run("ListExists", func(t *testing.T) {
+		mockClient := &MockMemberClient{lists: map[string]bool{"team@example.com": true}}
+		e := &external{service: mockClient}
+		cr := newMember("")
+
+		_, err := e.Create(context.Background(), cr)
+		require.NoError(t, err)
+
+		_, exists := mockClient.members["team@example.com/alice@example.org"]
+		assert.True(t, exists, "Member should be created")
+		assert.Equal(t, "alice@example.org", cr.GetAnnotations()["crossplane.io/external-name"])
+		assert.Equal(t, "Alice", cr.Status.AtProvider.Name, "the created member should be reported in status")
+	})
+
+	t.Run("ListMissing", func(t *testing.T) {
+		e := &external{service: &MockMemberClient{}}
+
+		_, err := e.Create(context.Background(), newMember(""))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "mailing list team@example.com does not exist yet")
+	})
+}
+
+func TestMailingListMemberUpdate(t *testing.T) {
+	mockClient := &MockMemberClient{
+		lists: map[string]bool{"team@example.com": true},
+		members: map[string]*v1beta1.MailingListMemberObservation{
+			"team@example.com/alice@example.org": {Address: "alice@example.org", Name: "Al", Subscribed: true},
+		},
+	}
+	e := &external{service: mockClient}
+	cr := newMember("alice@example.org")
+	cr.Spec.ForProvider.Subscribed = boolPtr(false)
+
+	_, err := e.Update(context.Background(), cr)
+	require.NoError(t, err)
+
+	member := mockClient.members["team@example.com/alice@example.org"]
+	assert.Equal(t, "Alice", member.Name)
+	assert.Equal(t, map[string]string{"role": "admin"}, member.Vars)
+	assert.False(t, member.Subscribed)
+	assert.False(t, cr.Status.AtProvider.Subscribed, "the updated member should be reported in status")
+}
+
+func TestMailingListMemberDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		members map[string]*v1beta1.MailingListMemberObservation
+		wantErr bool
+	}{
+		"Exists": {
+			reason: "An existing member should be deleted",
+			members: map[string]*v1beta1.MailingListMemberObservation{
+				"team@example.com/alice@example.org": {Address: "alice@example.org"},
+			},
+		},
+		"AlreadyGone": {
+			reason: "Deleting a member that is already gone should succeed",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockMemberClient{members: tc.members}
+			e := &external{service: mockClient}
+
+			_, err := e.Delete(context.Background(), newMember("alice@example.org"))
+			if tc.wantErr {
+				require.Error(t, err, tc.reason)
+				return
+			}
+			require.NoError(t, err, tc.reason)
+			assert.Empty(t, mockClient.members)
+		})
+	}
+}
+
+func TestMailingListMemberInitializer(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.SchemeBuilder.AddToScheme(scheme))
+
+	cr := newMember("")
+	cr.SetName("support-alice")
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cr).Build()
+
+	// The member is named after its address, not the object
+	require.NoError(t, initializer(kube).Initialize(context.Background(), cr))
+	assert.Equal(t, "alice@example.org", meta.GetExternalName(cr))
+
+	stored := &v1beta1.MailingListMember{}
+	require.NoError(t, kube.Get(context.Background(), client.ObjectKeyFromObject(cr), stored))
+	assert.Equal(t, "alice@example.org", meta.GetExternalName(stored))
+
+	// An existing member is found rather than created again
+	mockClient := &MockMemberClient{
+		lists: map[string]bool{"team@example.com": true},
+		members: map[string]*v1beta1.MailingListMemberObservation{
+			"team@example.com/alice@example.org": {Address: "alice@example.org", Name: "Alice", Vars: map[string]string{"role": "admin"}, Subscribed: true},
+		},
+	}
+	e := &external{service: mockClient}
+	obs, err := e.Observe(context.Background(), stored)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+}
+
+func TestMailingListMemberObjectNameAsExternalName(t *testing.T) {
+	mockClient := &MockMemberClient{
+		lists: map[string]bool{"team@example.com": true},
+		members: map[string]*v1beta1.MailingListMemberObservation{
+			"team@example.com/alice@example.org": {Address: "alice@example.org", Name: "Alice", Vars: map[string]string{"role": "admin"}, Subscribed: true},
+		},
+	}
+	e := &external{service: mockClient}
+
+	// The default initializer of earlier versions named members after the
+	// object
+	cr := newMember("test-member")
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+	assert.True(t, obs.ResourceLateInitialized, "the corrected external name should be persisted")
+	assert.Equal(t, "alice@example.org", meta.GetExternalName(cr))
+
+	_, err = e.Delete(context.Background(), newMember("test-member"))
+	require.NoError(t, err)
+	assert.Empty(t, mockClient.members)
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -18,6 +18,7 @@ package route
 
 import (
 	"context"
+	"strings"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
@@ -33,6 +34,17 @@ import (
 	v1beta1 "github.com/rossigee/provider-mailgun/apis/route/v1beta1"
 	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
 	"github.com/rossigee/provider-mailgun/internal/clients"
+	"github.com/rossigee/provider-mailgun/internal/description"
+	"github.com/rossigee/provider-mailgun/internal/eventlabel"
+	"github.com/rossigee/provider-mailgun/internal/failfast"
+	"github.com/rossigee/provider-mailgun/internal/features"
+	"github.com/rossigee/provider-mailgun/internal/metrics"
+	"github.com/rossigee/provider-mailgun/internal/observecache"
+	"github.com/rossigee/provider-mailgun/internal/ratelimit"
+	"github.com/rossigee/provider-mailgun/internal/readonly"
+	"github.com/rossigee/provider-mailgun/internal/shutdown"
+	"github.com/rossigee/provider-mailgun/internal/strictdelete"
+	"github.com/rossigee/provider-mailgun/internal/watchdog"
 )
 
 const (
@@ -40,22 +52,26 @@ const (
 	errTrackPCUsage = "cannot track ProviderConfig usage"
 	errGetPC        = "cannot get ProviderConfig"
 	errGetCreds     = "cannot get credentials"
+	errTooManyActs  = "route has %d actions but Mailgun allows at most %d per route"
 )
 
 // Setup adds a controller that reconciles Route managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(v1beta1.RouteKind)
 
+	conn := &connector{
+		kube:              mgr.GetClient(),
+		usage:             resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+		newServiceFn:      clients.NewClient,
+		propagateMetadata: o.Features.Enabled(features.EnableDescriptionMetadata),
+	}
+
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1beta1.RouteGroupVersionKind),
-		managed.WithExternalConnector(&connector{
-			kube:         mgr.GetClient(),
-			usage:        resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
-			newServiceFn: clients.NewClient,
-		}),
+		managed.WithExternalConnector(failfast.Wrap(mgr.GetClient(), readonly.Wrap(shutdown.Wrap(strictdelete.Wrap(watchdog.Wrap(observecache.Wrap(ratelimit.Wrap(conn)))))))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
+		managed.WithRecorder(eventlabel.Wrap(event.NewAPIRecorder(mgr.GetEventRecorder(name)))))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -71,6 +87,9 @@ type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
 	newServiceFn func(config *clients.Config) clients.Client
+
+	// propagateMetadata appends a managed-by tag to Mailgun descriptions
+	propagateMetadata bool
 }
 
 // Connect typically produces an ExternalClient by:
@@ -110,7 +129,7 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	}
 
 	cd := pc.Spec.Credentials
-	_, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	_, err := clients.ExtractCredentials(ctx, c.kube, cd)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
@@ -122,7 +141,7 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 
 	svc := c.newServiceFn(config)
 
-	return &external{service: svc}, nil
+	return &external{service: svc, propagateMetadata: c.propagateMetadata, reconcile: metrics.NewReconcileTimer(v1beta1.RouteKind)}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
@@ -131,10 +150,16 @@ type external struct {
 	// A 'client' used to connect to the external resource API. In practice this
 	// would be something like an AWS SDK client.
 	service clients.Client
+
+	propagateMetadata bool
+
+	// reconcile times the work done by this client for one reconcile
+	reconcile *metrics.ReconcileTimer
 }
 
 func (c *external) Disconnect(ctx context.Context) error {
 	// No persistent connections to clean up
+	c.reconcile.Record()
 	return nil
 }
 
@@ -173,6 +198,8 @@ func (e *ExternalForTesting) Delete(ctx context.Context, mg resource.Managed) (m
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.Route)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotRoute)
@@ -186,13 +213,24 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	route, err := c.service.GetRoute(ctx, externalName)
 	if err != nil {
-		if clients.IsNotFound(err) {
+		if !clients.IsNotFound(err) {
+			return managed.ExternalObservation{}, errors.Wrap(err, "failed to get route")
+		}
+		if !meta.WasDeleted(cr) {
 			return managed.ExternalObservation{ResourceExists: false}, nil
 		}
-		return managed.ExternalObservation{}, errors.Wrap(err, "failed to get route")
+		// The stored ID is stale, for example because the route was deleted
+		// and recreated outside the provider. Delete the replacement if it
+		// was created for this resource.
+		replacement, ferr := c.replacementRoute(ctx, cr)
+		if ferr != nil || replacement == nil {
+			return managed.ExternalObservation{ResourceExists: false}, ferr
+		}
+		meta.SetExternalName(cr, replacement.ID)
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
 	}
 
-	upToDate := isRouteUpToDate(route, &cr.Spec.ForProvider)
+	upToDate := isRouteUpToDate(route, c.parameters(cr))
 
 	cr.Status.AtProvider = *route
 
@@ -214,6 +252,8 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.Route)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotRoute)
@@ -221,7 +261,11 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	cr.SetConditions(xpv1.Creating())
 
-	route, err := c.service.CreateRoute(ctx, &cr.Spec.ForProvider)
+	if err := validateActions(&cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	route, err := c.service.CreateRoute(ctx, c.parameters(cr))
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, "failed to create route")
 	}
@@ -237,13 +281,19 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.Route)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotRoute)
 	}
 
+	if err := validateActions(&cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
 	externalName := meta.GetExternalName(cr)
-	route, err := c.service.UpdateRoute(ctx, externalName, &cr.Spec.ForProvider)
+	route, err := c.service.UpdateRoute(ctx, externalName, c.parameters(cr))
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, "failed to update route")
 	}
@@ -258,6 +308,8 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.Route)
 	if !ok {
 		return managed.ExternalDelete{}, errors.New(errNotRoute)
@@ -278,39 +330,96 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 	return managed.ExternalDelete{}, nil
 }
 
-// isRouteUpToDate checks if the external resource is up to date
+// replacementRoute returns a route with the expression of cr that was
+// created for cr, or nil if there is none. With description metadata
+// enabled, that is a route whose description carries the managed-by tag of
+// cr. Otherwise no route carries the tag, and only a route matching the
+// expression, actions and priority of cr exactly is taken. Other routes may
+// belong to something else, so they are never matched.
+func (c *external) replacementRoute(ctx context.Context, cr *v1beta1.Route) (*v1beta1.RouteObservation, error) {
+	matches, err := clients.FindRoutesByExpression(ctx, c.service, cr.Spec.ForProvider.Expression)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot find routes matching the expression")
+	}
+	desired := c.parameters(cr)
+	for _, route := range matches {
+		if c.propagateMetadata && description.ManagedBy(cr, route.Description) {
+			return route, nil
+		}
+		if !c.propagateMetadata && isRouteUpToDate(route, desired) {
+			return route, nil
+		}
+	}
+	return nil, nil
+}
+
+// parameters returns the route parameters to send to Mailgun, with the
+// managed-by tag added to the description when propagation is enabled
+func (c *external) parameters(cr *v1beta1.Route) *v1beta1.RouteParameters {
+	params := cr.Spec.ForProvider
+	if c.propagateMetadata {
+		params.Description = description.Compose(cr, params.Description)
+	}
+	return &params
+}
+
+// isRouteUpToDate reports whether the route in Mailgun matches the desired
+// state, so that changes made outside the provider (for example in the
+// Mailgun dashboard) are detected and reverted.
 func isRouteUpToDate(route *v1beta1.RouteObservation, desired *v1beta1.RouteParameters) bool {
-	// Compare updatable fields
-	if route.Expression != desired.Expression {
+	if strings.TrimSpace(route.Expression) != strings.TrimSpace(desired.Expression) {
 		return false
 	}
-	if desired.Priority != nil && route.Priority != *desired.Priority {
+
+	// An unset priority is applied as Mailgun's default of 0
+	priority := 0
+	if desired.Priority != nil {
+		priority = *desired.Priority
+	}
+	if route.Priority != priority {
 		return false
 	}
+
 	if desired.Description != nil && route.Description != *desired.Description {
 		return false
 	}
 
-	// Compare actions
+	// Actions run in order, so order matters
 	if len(route.Actions) != len(desired.Actions) {
 		return false
 	}
-	for i, action := range route.Actions {
-		if i >= len(desired.Actions) {
-			return false
-		}
-		desiredAction := desired.Actions[i]
-		if action.Type != desiredAction.Type {
-			return false
-		}
-		if desiredAction.Destination != nil && action.Destination != nil {
-			if *action.Destination != *desiredAction.Destination {
-				return false
-			}
-		} else if desiredAction.Destination != action.Destination {
+	for i := range desired.Actions {
+		if !routeActionsEqual(route.Actions[i], desired.Actions[i]) {
 			return false
 		}
 	}
 
 	return true
 }
+
+func routeActionsEqual(observed, desired v1beta1.RouteAction) bool {
+	if !strings.EqualFold(observed.Type, desired.Type) {
+		return false
+	}
+	if strings.EqualFold(desired.Type, "store") {
+		return clients.StoreNotifyURL(observed) == clients.StoreNotifyURL(desired)
+	}
+	return destination(observed) == destination(desired)
+}
+
+// destination returns the action's destination, treating unset as empty
+func destination(action v1beta1.RouteAction) string {
+	if action.Destination == nil {
+		return ""
+	}
+	return *action.Destination
+}
+
+// validateActions rejects routes with more actions than Mailgun accepts, so
+// the limit is reported instead of an opaque API error
+func validateActions(params *v1beta1.RouteParameters) error {
+	if n := len(params.Actions); n > v1beta1.MaxRouteActions {
+		return errors.Errorf(errTooManyActs, n, v1beta1.MaxRouteActions)
+	}
+	return nil
+}
@@ -19,18 +19,26 @@ package route
 import (
 	"context"
 	"fmt"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	"github.com/pkg/errors"
 	v1beta1 "github.com/rossigee/provider-mailgun/apis/route/v1beta1"
 	domaintypes "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+	ippooltypes "github.com/rossigee/provider-mailgun/apis/ippool/v1beta1"
 	mailinglisttypes "github.com/rossigee/provider-mailgun/apis/mailinglist/v1beta1"
+	mailinglistmembertypes "github.com/rossigee/provider-mailgun/apis/mailinglistmember/v1beta1"
 	webhooktypes "github.com/rossigee/provider-mailgun/apis/webhook/v1beta1"
 	smtpcredentialtypes "github.com/rossigee/provider-mailgun/apis/smtpcredential/v1beta1"
 	templatetypes "github.com/rossigee/provider-mailgun/apis/template/v1beta1"
 	bouncetypes "github.com/rossigee/provider-mailgun/apis/bounce/v1beta1"
+	complainttypes "github.com/rossigee/provider-mailgun/apis/complaint/v1beta1"
+	unsubscribetypes "github.com/rossigee/provider-mailgun/apis/unsubscribe/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sort"
 	"testing"
 )
 
@@ -38,6 +46,9 @@ import (
 type MockRouteClient struct {
 	routes map[string]*v1beta1.RouteObservation
 	err    error
+
+	// listErr fails ListRoutes, which unlike other calls never returns 404
+	listErr error
 }
 
 func (m *MockRouteClient) CreateRoute(ctx context.Context, route *v1beta1.RouteParameters) (*v1beta1.RouteObservation, error) {
@@ -65,6 +76,7 @@ func (m *MockRouteClient) CreateRoute(ctx context.Context, route *v1beta1.RouteP
 			result.Actions[i] = v1beta1.RouteAction{
 				Type:        action.Type,
 				Destination: action.Destination,
+				Notify:      action.Notify,
 			}
 		}
 	}
@@ -109,6 +121,7 @@ func (m *MockRouteClient) UpdateRoute(ctx context.Context, id string, route *v1b
 				existing.Actions[i] = v1beta1.RouteAction{
 					Type:        action.Type,
 					Destination: action.Destination,
+					Notify:      action.Notify,
 				}
 			}
 		}
@@ -123,10 +136,31 @@ func (m *MockRouteClient) DeleteRoute(ctx context.Context, id string) error {
 		return m.err
 	}
 
+	if _, exists := m.routes[id]; !exists {
+		return errors.New("route not found (404)")
+	}
 	delete(m.routes, id)
 	return nil
 }
 
+func (m *MockRouteClient) ListRoutes(ctx context.Context, limit, skip int) ([]*v1beta1.RouteObservation, int, error) {
+	if m.listErr != nil {
+		return nil, 0, m.listErr
+	}
+
+	ids := make([]string, 0, len(m.routes))
+	for id := range m.routes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var page []*v1beta1.RouteObservation
+	for i := skip; i < len(ids) && i < skip+limit; i++ {
+		page = append(page, m.routes[ids[i]])
+	}
+	return page, len(ids), nil
+}
+
 // Implement other required client methods as no-ops
 func (m *MockRouteClient) CreateDomain(ctx context.Context, domain *domaintypes.DomainParameters) (*domaintypes.DomainObservation, error) {
 	return nil, errors.New("not implemented")
@@ -144,6 +178,50 @@ func (m *MockRouteClient) DeleteDomain(ctx context.Context, name string) error {
 	return errors.New("not implemented")
 }
 
+func (m *MockRouteClient) ListAuthorizedRecipients(ctx context.Context) ([]domaintypes.AuthorizedRecipient, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRouteClient) AddAuthorizedRecipient(ctx context.Context, email string) (*domaintypes.AuthorizedRecipient, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRouteClient) DeleteAuthorizedRecipient(ctx context.Context, email string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockRouteClient) GetWebhookSigningKey(ctx context.Context) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *MockRouteClient) RotateWebhookSigningKey(ctx context.Context) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *MockRouteClient) UpdateDomainTracking(ctx context.Context, name string, tracking *domaintypes.DomainTracking) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockRouteClient) GetDomainTracking(ctx context.Context, name string) (*domaintypes.DomainTracking, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRouteClient) GetDomainConnection(ctx context.Context, name string) (*domaintypes.DomainConnection, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRouteClient) UpdateDomainConnection(ctx context.Context, name string, connection *domaintypes.DomainConnection) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockRouteClient) UpdateDKIMKey(ctx context.Context, name string, keySize int) ([]domaintypes.DNSRecord, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRouteClient) ListDomains(ctx context.Context, limit, skip int) ([]*domaintypes.DomainObservation, int, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
 func (m *MockRouteClient) CreateMailingList(ctx context.Context, list *mailinglisttypes.MailingListParameters) (*mailinglisttypes.MailingListObservation, error) {
 	return nil, errors.New("not implemented")
 }
@@ -160,6 +238,30 @@ func (m *MockRouteClient) DeleteMailingList(ctx context.Context, address string)
 	return errors.New("not implemented")
 }
 
+func (m *MockRouteClient) CreateMailingListMember(ctx context.Context, listAddress string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRouteClient) GetMailingListMember(ctx context.Context, listAddress, address string) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRouteClient) UpdateMailingListMember(ctx context.Context, listAddress, address string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRouteClient) DeleteMailingListMember(ctx context.Context, listAddress, address string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockRouteClient) AddMailingListMembers(ctx context.Context, listAddress string, members []clients.MailingListMemberSpec, upsert bool) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockRouteClient) ListMailingListMembers(ctx context.Context, listAddress string) ([]*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (m *MockRouteClient) CreateWebhook(ctx context.Context, domain string, webhook *webhooktypes.WebhookParameters) (*webhooktypes.WebhookObservation, error) {
 	return nil, errors.New("not implemented")
 }
@@ -208,6 +310,30 @@ func (m *MockRouteClient) DeleteTemplate(ctx context.Context, domain, name strin
 	return errors.New("not implemented")
 }
 
+func (m *MockRouteClient) CreateTemplateVersion(ctx context.Context, domain, name string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRouteClient) GetTemplateVersion(ctx context.Context, domain, name, tag string) (*templatetypes.TemplateVersion, string, error) {
+	return nil, "", errors.New("not implemented")
+}
+
+func (m *MockRouteClient) UpdateTemplateVersion(ctx context.Context, domain, name, tag string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRouteClient) DeleteTemplateVersion(ctx context.Context, domain, name, tag string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockRouteClient) ListTemplateVersions(ctx context.Context, domain, name string) ([]templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRouteClient) RenderTemplate(ctx context.Context, domain, name string, vars map[string]interface{}) (string, error) {
+	return "", errors.New("not implemented")
+}
+
 // Bounce suppression operations
 func (m *MockRouteClient) CreateBounce(ctx context.Context, domain string, bounce *bouncetypes.BounceParameters) (*bouncetypes.BounceObservation, error) {
 	return nil, errors.New("not implemented")
@@ -222,11 +348,11 @@ func (m *MockRouteClient) DeleteBounce(ctx context.Context, domain, address stri
 }
 
 // Complaint suppression operations
-func (m *MockRouteClient) CreateComplaint(ctx context.Context, domain string, complaint interface{}) (interface{}, error) {
+func (m *MockRouteClient) CreateComplaint(ctx context.Context, domain string, complaint *complainttypes.ComplaintParameters) (*complainttypes.ComplaintObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockRouteClient) GetComplaint(ctx context.Context, domain, address string) (interface{}, error) {
+func (m *MockRouteClient) GetComplaint(ctx context.Context, domain, address string) (*complainttypes.ComplaintObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
@@ -235,15 +361,43 @@ func (m *MockRouteClient) DeleteComplaint(ctx context.Context, domain, address s
 }
 
 // Unsubscribe suppression operations
-func (m *MockRouteClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe interface{}) (interface{}, error) {
+func (m *MockRouteClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe *unsubscribetypes.UnsubscribeParameters) (*unsubscribetypes.UnsubscribeObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRouteClient) GetUnsubscribe(ctx context.Context, domain, address string) (*unsubscribetypes.UnsubscribeObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRouteClient) DeleteUnsubscribe(ctx context.Context, domain, address, tag string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockRouteClient) CreateIPPool(ctx context.Context, pool *ippooltypes.IPPoolParameters) (*ippooltypes.IPPoolObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRouteClient) GetIPPool(ctx context.Context, id string) (*ippooltypes.IPPoolObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockRouteClient) GetUnsubscribe(ctx context.Context, domain, address string) (interface{}, error) {
+func (m *MockRouteClient) ListDomainIPs(ctx context.Context, name string) ([]string, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockRouteClient) DeleteUnsubscribe(ctx context.Context, domain, address string) error {
+func (m *MockRouteClient) AssignDomainIP(ctx context.Context, name, ip string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockRouteClient) UnassignDomainIP(ctx context.Context, name, ip string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockRouteClient) UpdateIPPool(ctx context.Context, id string, pool *ippooltypes.IPPoolParameters, add, remove []string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockRouteClient) DeleteIPPool(ctx context.Context, id string) error {
 	return errors.New("not implemented")
 }
 
@@ -733,6 +887,132 @@ func TestRouteUpdateErrors(t *testing.T) {
 	}
 }
 
+func TestRouteDeleteStaleID(t *testing.T) {
+	expression := `match_recipient(".*@stale.com")`
+	newRoute := func(deleting bool) *v1beta1.Route {
+		r := &v1beta1.Route{
+			ObjectMeta: metav1.ObjectMeta{Name: "stale", Namespace: "mail"},
+			Spec:       v1beta1.RouteSpec{ForProvider: v1beta1.RouteParameters{Expression: expression}},
+		}
+		if deleting {
+			now := metav1.Now()
+			r.SetDeletionTimestamp(&now)
+		}
+		meta.SetExternalName(r, "route_gone")
+		return r
+	}
+
+	cases := map[string]struct {
+		routes            map[string]*v1beta1.RouteObservation
+		listErr           error
+		propagateMetadata bool
+		notDeleting       bool
+		wantErr           bool
+		wantRemaining     []string
+	}{
+		"TaggedReplacementsRemoved": {
+			propagateMetadata: true,
+			routes: map[string]*v1beta1.RouteObservation{
+				"route_recreated": {ID: "route_recreated", Expression: expression, Description: "managed-by: crossplane/mail/stale"},
+				"route_duplicate": {ID: "route_duplicate", Expression: " " + expression, Description: "Inbound (managed-by: crossplane/mail/stale)"},
+				"route_other":     {ID: "route_other", Expression: `match_recipient(".*@other.com")`, Description: "managed-by: crossplane/mail/stale"},
+			},
+			wantRemaining: []string{"route_other"},
+		},
+		"UntaggedSameExpressionKept": {
+			propagateMetadata: true,
+			routes: map[string]*v1beta1.RouteObservation{
+				"route_hand_made": {ID: "route_hand_made", Expression: expression, Description: "Created in the dashboard"},
+				"route_sibling":   {ID: "route_sibling", Expression: expression, Description: "managed-by: crossplane/mail/stale-eu"},
+			},
+			wantRemaining: []string{"route_hand_made", "route_sibling"},
+		},
+		"ExactReplacementRemovedWithoutMetadata": {
+			routes: map[string]*v1beta1.RouteObservation{
+				"route_recreated": {ID: "route_recreated", Expression: expression},
+				"route_priority":  {ID: "route_priority", Expression: expression, Priority: 5},
+				"route_actions":   {ID: "route_actions", Expression: expression, Actions: []v1beta1.RouteAction{{Type: "stop"}}},
+			},
+			wantRemaining: []string{"route_priority", "route_actions"},
+		},
+		"NotDeleting": {
+			routes: map[string]*v1beta1.RouteObservation{
+				"route_recreated": {ID: "route_recreated", Expression: expression, Description: "managed-by: crossplane/mail/stale"},
+			},
+			notDeleting:   true,
+			wantRemaining: []string{"route_recreated"},
+		},
+		"ListFails": {
+			listErr: errors.New("boom"),
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if tc.routes == nil {
+				tc.routes = map[string]*v1beta1.RouteObservation{}
+			}
+			mockClient := &MockRouteClient{routes: tc.routes, listErr: tc.listErr}
+			e := &external{service: mockClient, propagateMetadata: tc.propagateMetadata}
+			cr := newRoute(!tc.notDeleting)
+
+			// Reconcile as the managed reconciler would while deleting:
+			// Delete is only called while Observe reports the route exists
+			for i := 0; i <= len(tc.routes); i++ {
+				obs, err := e.Observe(context.Background(), cr)
+				if tc.wantErr {
+					require.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				if !obs.ResourceExists || tc.notDeleting {
+					break
+				}
+				_, err = e.Delete(context.Background(), cr)
+				require.NoError(t, err)
+			}
+
+			remaining := make([]string, 0, len(mockClient.routes))
+			for id := range mockClient.routes {
+				remaining = append(remaining, id)
+			}
+			assert.ElementsMatch(t, tc.wantRemaining, remaining)
+		})
+	}
+}
+
+func TestRouteTooManyActions(t *testing.T) {
+	actions := make([]v1beta1.RouteAction, v1beta1.MaxRouteActions+1)
+	for i := range actions {
+		actions[i] = v1beta1.RouteAction{Type: "forward", Destination: stringPtr(fmt.Sprintf("user%d@example.com", i))}
+	}
+	cr := &v1beta1.Route{
+		Spec: v1beta1.RouteSpec{
+			ForProvider: v1beta1.RouteParameters{
+				Expression: "match_recipient('.*@example.com')",
+				Actions:    actions,
+			},
+		},
+	}
+	mockClient := &MockRouteClient{routes: map[string]*v1beta1.RouteObservation{}}
+	e := &external{service: mockClient}
+
+	_, err := e.Create(context.Background(), cr)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), fmt.Sprintf("route has 11 actions but Mailgun allows at most %d per route", v1beta1.MaxRouteActions))
+	assert.Empty(t, mockClient.routes, "an over-limit route should not be submitted")
+
+	_, err = e.Update(context.Background(), cr)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at most 10 per route")
+
+	// Exactly the limit is accepted
+	cr.Spec.ForProvider.Actions = actions[:v1beta1.MaxRouteActions]
+	_, err = e.Create(context.Background(), cr)
+	require.NoError(t, err)
+}
+
 func TestRouteDeleteErrors(t *testing.T) {
 	cases := map[string]struct {
 		reason    string
@@ -948,6 +1228,120 @@ func TestRouteActionTypes(t *testing.T) {
 	}
 }
 
+func TestRouteExternalDrift(t *testing.T) {
+	cases := map[string]func(r *v1beta1.RouteObservation){
+		"Expression": func(r *v1beta1.RouteObservation) {
+			r.Expression = "match_recipient(\"sales@example.com\")"
+		},
+		"Priority": func(r *v1beta1.RouteObservation) {
+			r.Priority = 50
+		},
+		"Description": func(r *v1beta1.RouteObservation) {
+			r.Description = "Edited in the dashboard"
+		},
+		"ActionDestination": func(r *v1beta1.RouteObservation) {
+			r.Actions[0].Destination = stringPtr("https://attacker.example.com")
+		},
+		"ActionNotify": func(r *v1beta1.RouteObservation) {
+			r.Actions[1].Notify = stringPtr("https://attacker.example.com/notify")
+		},
+		"ActionRemoved": func(r *v1beta1.RouteObservation) {
+			r.Actions = r.Actions[:1]
+		},
+		"ActionsReordered": func(r *v1beta1.RouteObservation) {
+			r.Actions[0], r.Actions[1] = r.Actions[1], r.Actions[0]
+		},
+	}
+
+	for name, modify := range cases {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockRouteClient{}
+			e := &external{service: mockClient}
+			cr := &v1beta1.Route{
+				Spec: v1beta1.RouteSpec{
+					ForProvider: v1beta1.RouteParameters{
+						Expression:  "match_recipient(\"support@example.com\")",
+						Priority:    intPtr(10),
+						Description: stringPtr("Inbound support"),
+						Actions: []v1beta1.RouteAction{
+							{Type: "forward", Destination: stringPtr("https://example.com/hook")},
+							{Type: "store", Notify: stringPtr("https://example.com/notify")},
+							{Type: "stop"},
+						},
+					},
+				},
+			}
+
+			_, err := e.Create(context.Background(), cr)
+			require.NoError(t, err)
+			obs, err := e.Observe(context.Background(), cr)
+			require.NoError(t, err)
+			require.True(t, obs.ResourceUpToDate)
+
+			modify(mockClient.routes["route_123"])
+
+			obs, err = e.Observe(context.Background(), cr)
+			require.NoError(t, err)
+			assert.False(t, obs.ResourceUpToDate, "external change should be detected")
+
+			_, err = e.Update(context.Background(), cr)
+			require.NoError(t, err)
+
+			obs, err = e.Observe(context.Background(), cr)
+			require.NoError(t, err)
+			assert.True(t, obs.ResourceUpToDate, "update should restore the desired state")
+		})
+	}
+}
+
+func TestRouteStoreNotifyLegacyDestination(t *testing.T) {
+	observed := &v1beta1.RouteObservation{
+		Expression: "catch_all()",
+		Actions:    []v1beta1.RouteAction{{Type: "store", Notify: stringPtr("https://example.com/notify")}},
+	}
+
+	legacy := &v1beta1.RouteParameters{
+		Expression: "catch_all()",
+		Actions:    []v1beta1.RouteAction{{Type: "store", Destination: stringPtr("https://example.com/notify")}},
+	}
+	assert.True(t, isRouteUpToDate(observed, legacy), "a store destination should be treated as its notify URL")
+
+	legacy.Actions[0].Destination = stringPtr("https://example.com/other")
+	assert.False(t, isRouteUpToDate(observed, legacy))
+}
+
+func TestRouteDescriptionMetadata(t *testing.T) {
+	mockClient := &MockRouteClient{}
+	e := &external{service: mockClient, propagateMetadata: true}
+
+	cr := &v1beta1.Route{
+		Spec: v1beta1.RouteSpec{
+			ForProvider: v1beta1.RouteParameters{
+				Expression:  "match_recipient(\"support@example.com\")",
+				Description: stringPtr("Inbound support"),
+				Actions:     []v1beta1.RouteAction{{Type: "stop"}},
+			},
+		},
+	}
+	cr.SetName("support")
+	cr.SetNamespace("mail")
+
+	_, err := e.Create(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, "Inbound support (managed-by: crossplane/mail/support)", mockClient.routes["route_123"].Description)
+	assert.Equal(t, "Inbound support", *cr.Spec.ForProvider.Description, "spec must not be modified")
+
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate, "the composed description should not be reported as drift")
+
+	// Without propagation the tagged description is drift to be corrected
+	e.propagateMetadata = false
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate)
+}
+
 // Helper functions
 func stringPtr(s string) *string {
 	return &s
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package smtpcredential
+
+import (
+	"context"
+	"sync"
+)
+
+// rotations serializes the Creates of each SMTPCredential. A rotation
+// deletes the current credential before creating its replacement, so two
+// reconciles of one resource rotating at once would delete each other's
+// credentials.
+var rotations = newKeyedLock()
+
+// A keyedLock is a set of mutexes, one per key, that exist only while they
+// are held or waited for.
+type keyedLock struct {
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+type keyLock struct {
+	// held has room for one token, which the holder of the lock puts in
+	held chan struct{}
+
+	// waiters counts the callers holding or waiting for the lock
+	waiters int
+}
+
+func newKeyedLock() *keyedLock {
+	return &keyedLock{locks: map[string]*keyLock{}}
+}
+
+// Lock blocks until the lock of key is held, returning the function that
+// releases it, or returns the error of ctx if it is done first.
+func (k *keyedLock) Lock(ctx context.Context, key string) (func(), error) {
+	k.mu.Lock()
+	l, ok := k.locks[key]
+	if !ok {
+		l = &keyLock{held: make(chan struct{}, 1)}
+		k.locks[key] = l
+	}
+	l.waiters++
+	k.mu.Unlock()
+
+	select {
+	case l.held <- struct{}{}:
+		return func() {
+			<-l.held
+			k.release(key, l)
+		}, nil
+	case <-ctx.Done():
+		k.release(key, l)
+		return nil, ctx.Err()
+	}
+}
+
+// release forgets the lock of key once nobody holds or waits for it
+func (k *keyedLock) release(key string, l *keyLock) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	l.waiters--
+	if l.waiters == 0 {
+		delete(k.locks, key)
+	}
+}
@@ -18,6 +18,8 @@ package smtpcredential
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,8 +39,20 @@ import (
 	v1beta1 "github.com/rossigee/provider-mailgun/apis/smtpcredential/v1beta1"
 	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
 	"github.com/rossigee/provider-mailgun/internal/clients"
+	"github.com/rossigee/provider-mailgun/internal/eventlabel"
+	"github.com/rossigee/provider-mailgun/internal/failfast"
+	"github.com/rossigee/provider-mailgun/internal/features"
+	"github.com/rossigee/provider-mailgun/internal/immutable"
 	"github.com/rossigee/provider-mailgun/internal/metrics"
+	"github.com/rossigee/provider-mailgun/internal/observecache"
+	"github.com/rossigee/provider-mailgun/internal/ratelimit"
+	"github.com/rossigee/provider-mailgun/internal/readonly"
+	"github.com/rossigee/provider-mailgun/internal/shutdown"
+	"github.com/rossigee/provider-mailgun/internal/statickeys"
+	"github.com/rossigee/provider-mailgun/internal/strictdelete"
 	"github.com/rossigee/provider-mailgun/internal/tracing"
+	"github.com/rossigee/provider-mailgun/internal/trigger"
+	"github.com/rossigee/provider-mailgun/internal/watchdog"
 )
 
 const (
@@ -46,22 +60,36 @@ const (
 	errTrackPCUsage      = "cannot track ProviderConfig usage"
 	errGetPC             = "cannot get ProviderConfig"
 	errGetCreds          = "cannot get credentials"
+	errMissingKeys       = "required connection keys are missing or empty: %s"
+	errDeletePrevious    = "failed to delete previous SMTP credential %s"
+	errRotationLock      = "cannot wait for the rotation of SMTPCredential %s"
 )
 
+// rotatedSuffix is appended to the local part of Login to name the login
+// that overlapping rotations alternate with it
+const rotatedSuffix = "-rotated"
+
+// reasonEmptyPassword is the reason of the warning emitted when a connection
+// secret is written without a password
+const reasonEmptyPassword event.Reason = "EmptyConnectionPassword"
+
 // Setup adds a controller that reconciles SMTPCredential managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(v1beta1.SMTPCredentialKind)
+	recorder := eventlabel.Wrap(event.NewAPIRecorder(mgr.GetEventRecorder(name)))
 
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1beta1.SMTPCredentialGroupVersionKind),
-		managed.WithExternalConnector(&connector{
-			kube:         mgr.GetClient(),
-			usage:        resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
-			newServiceFn: clients.NewClient,
-		}),
+		managed.WithExternalConnector(failfast.Wrap(mgr.GetClient(), readonly.Wrap(shutdown.Wrap(strictdelete.Wrap(watchdog.Wrap(observecache.Wrap(ratelimit.Wrap(immutable.Wrap(statickeys.Wrap(&connector{
+			kube:               mgr.GetClient(),
+			recorder:           recorder,
+			usage:              resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			newServiceFn:       clients.NewClient,
+			serializeRotations: o.Features.Enabled(features.EnableRotationLock),
+		}, staticConnectionDetails, "smtp_host", "smtp_port", "smtp_username", "smtp_password"), immutableFields))))))))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
+		managed.WithRecorder(recorder))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -71,12 +99,39 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		Complete(r)
 }
 
+// immutableFields returns the fields that identify the Mailgun SMTP credential
+func immutableFields(mg resource.Managed) map[string]string {
+	cr, ok := mg.(*v1beta1.SMTPCredential)
+	if !ok {
+		return nil
+	}
+	return map[string]string{
+		"spec.forProvider.domain": cr.Spec.ForProvider.Domain,
+		"spec.forProvider.login":  cr.Spec.ForProvider.Login,
+	}
+}
+
+// staticConnectionDetails returns the fixed connection details configured
+// for a SMTPCredential
+func staticConnectionDetails(mg resource.Managed) map[string]string {
+	cr, ok := mg.(*v1beta1.SMTPCredential)
+	if !ok {
+		return nil
+	}
+	return cr.Spec.ForProvider.StaticConnectionDetails
+}
+
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
 	kube         client.Client
+	recorder     event.Recorder
 	usage        resource.Tracker
 	newServiceFn func(config *clients.Config) clients.Client
+
+	// serializeRotations makes the Creates of one resource wait for each
+	// other
+	serializeRotations bool
 }
 
 // Connect typically produces an ExternalClient by:
@@ -122,7 +177,7 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	}
 
 	cd := pc.Spec.Credentials
-	_, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	_, err := clients.ExtractCredentials(ctx, c.kube, cd)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
@@ -134,7 +189,7 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 
 	svc := c.newServiceFn(config)
 
-	return &external{service: svc, kube: c.kube}, nil
+	return &external{service: svc, kube: c.kube, recorder: c.recorder, region: config.Region, serializeRotations: c.serializeRotations, reconcile: metrics.NewReconcileTimer(v1beta1.SMTPCredentialKind)}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
@@ -142,10 +197,26 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 type external struct {
 	service clients.Client
 	kube    client.Client
+
+	// recorder emits a warning when a secret is written without a password;
+	// it may be nil
+	recorder event.Recorder
+
+	// region is the region of the account, which picks the published SMTP
+	// server
+	region string
+
+	// serializeRotations holds the rotation lock of the resource for the
+	// whole of Create
+	serializeRotations bool
+
+	// reconcile times the work done by this client for one reconcile
+	reconcile *metrics.ReconcileTimer
 }
 
 func (c *external) Disconnect(ctx context.Context) error {
 	// No persistent connections to clean up
+	c.reconcile.Record()
 	return nil
 }
 
@@ -185,6 +256,8 @@ func (e *ExternalForTesting) Delete(ctx context.Context, mg resource.Managed) (m
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	defer c.reconcile.Start()()
+
 	op := tracing.StartOperation(ctx, tracing.SpanResourceObserve,
 		"crossplane.resource.type", "SMTPCredential",
 		"crossplane.resource.name", mg.GetName(),
@@ -259,20 +332,38 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		op.SetAttribute("resource.exists", true)
 		op.SetAttribute("resource.up_to_date", true)
 
+		// The credential replaced by a rotation is deleted once its overlap
+		// period has ended
+		retired, err := c.retireDue(ctx, cr, time.Now())
+		if err != nil {
+			op.RecordError(err)
+			return managed.ExternalObservation{}, err
+		}
+		op.SetAttribute("rotation.previous_deleted", retired)
+		settled := settleRotation(cr)
+
 		// Resource exists and we have credentials stored
+		login := currentLogin(cr)
+		previous, deleteAfter := previousLogin(cr)
 		cr.Status.AtProvider = v1beta1.SMTPCredentialObservation{
-			Login: cr.Spec.ForProvider.Login,
-			State: "active", // Assume active since we have stored credentials
+			Login:                    login,
+			State:                    "active", // Assume active since we have stored credentials
+			PreviousLogin:            previous,
+			PreviousLoginDeleteAfter: deleteAfter,
 		}
 		cr.SetConditions(xpv1.Available())
+		c.setPasswordCondition(cr, len(secret.Data["smtp_password"]) > 0, false)
 
 		return managed.ExternalObservation{
 			ResourceExists:   true,
 			ResourceUpToDate: true,
+			// Persists the removal of the retired credential's and the
+			// completed rotation's annotations
+			ResourceLateInitialized: retired || settled,
 			ConnectionDetails: managed.ConnectionDetails{
-				"smtp_host":     []byte("smtp.mailgun.org"),
+				"smtp_host":     []byte(clients.SMTPHost(c.region)),
 				"smtp_port":     []byte("587"),
-				"smtp_username": []byte(cr.Spec.ForProvider.Login),
+				"smtp_username": []byte(login),
 				// Password is already stored in the secret, don't overwrite
 			},
 		}, nil
@@ -298,23 +389,15 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	annotations := cr.GetAnnotations()
 
 	// Check for force rotation annotation first - this overrides existing resource detection
-	forceRotate := annotations != nil && annotations["mailgun.crossplane.io/force-rotate-credentials"] != ""
-	if forceRotate {
+	if forceRotation(cr) {
 		logger.Info("force-rotate-credentials annotation detected, triggering credential recreation")
 		op.SetAttribute("force_rotation", true)
 
-		// Remove the force rotation annotation to prevent repeated rotations and set internal flag
-		if annotations == nil {
-			annotations = make(map[string]string)
-		}
-		delete(annotations, "mailgun.crossplane.io/force-rotate-credentials")
-		annotations["mailgun.crossplane.io/internal-force-rotate"] = "true" // Signal to Create method
-		cr.SetAnnotations(annotations)
+		// The trigger stays set until Create succeeds, so that a failed
+		// rotation is retried rather than lost
 
 		// Clear creation annotations to force recreation
-		delete(annotations, "crossplane.io/external-create-succeeded")
-		delete(annotations, "crossplane.io/external-create-pending")
-		cr.SetAnnotations(annotations)
+		meta.RemoveAnnotations(cr, meta.AnnotationKeyExternalCreateSucceeded, meta.AnnotationKeyExternalCreatePending)
 
 		// Return as non-existent to trigger Create flow with rotation
 		timer.RecordResourceOperation("smtpcredential", "observe", "force_rotation")
@@ -325,24 +408,35 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		logger.Info("SMTP credential has successful creation annotation, treating as existing resource",
 			"createSucceededAt", annotations["crossplane.io/external-create-succeeded"])
 
+		retired, err := c.retireDue(ctx, cr, time.Now())
+		if err != nil {
+			op.RecordError(err)
+			return managed.ExternalObservation{}, err
+		}
+
 		// Resource exists based on creation annotation, but connection secret is missing
 		// Set status based on external name and assume active state
+		previous, deleteAfter := previousLogin(cr)
 		cr.Status.AtProvider = v1beta1.SMTPCredentialObservation{
-			Login: externalName,
-			State: "active", // Assume active since we have creation evidence
+			Login:                    externalName,
+			State:                    "active", // Assume active since we have creation evidence
+			PreviousLogin:            previous,
+			PreviousLoginDeleteAfter: deleteAfter,
 		}
 
 		timer.RecordResourceOperation("smtpcredential", "observe", "success")
 		op.SetAttribute("resource.exists", true)
 		op.SetAttribute("resource.up_to_date", true)
 		op.SetAttribute("secret.missing", true)
+		c.setPasswordCondition(cr, false, true)
 
 		// Return that resource exists but provide connection details to recreate the secret
 		return managed.ExternalObservation{
-			ResourceExists:   true,
-			ResourceUpToDate: true,
+			ResourceExists:          true,
+			ResourceUpToDate:        true,
+			ResourceLateInitialized: retired || settleRotation(cr),
 			ConnectionDetails: managed.ConnectionDetails{
-				"smtp_host":     []byte("smtp.mailgun.org"),
+				"smtp_host":     []byte(clients.SMTPHost(c.region)),
 				"smtp_port":     []byte("587"),
 				"smtp_username": []byte(externalName),
 				// Note: password is not available since secret is missing
@@ -368,6 +462,8 @@ func getSecretDataKeys(data map[string][]byte) []string {
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	defer c.reconcile.Start()()
+
 	op := tracing.StartOperation(ctx, tracing.SpanResourceCreate,
 		"crossplane.resource.type", "SMTPCredential",
 		"crossplane.resource.name", mg.GetName(),
@@ -391,6 +487,16 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, err
 	}
 
+	if c.serializeRotations {
+		key := types.NamespacedName{Namespace: cr.GetNamespace(), Name: cr.GetName()}.String()
+		unlock, err := rotations.Lock(ctx, key)
+		if err != nil {
+			op.RecordError(err)
+			return managed.ExternalCreation{}, errors.Wrapf(err, errRotationLock, key)
+		}
+		defer unlock()
+	}
+
 	logger = logger.WithValues(
 		"domain", cr.Spec.ForProvider.Domain,
 		"login", cr.Spec.ForProvider.Login,
@@ -405,13 +511,36 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	// Check if this is an imported resource that needs rotation or if force rotation was requested
 	externalName := meta.GetExternalName(cr)
-	isImported := externalName != "" && externalName != cr.Spec.ForProvider.Login
+	isImported := externalName != "" && externalName != cr.Spec.ForProvider.Login && externalName != alternateLogin(cr.Spec.ForProvider.Login)
 
 	// Also check if this was triggered by force rotation (we detect this via a temporary annotation)
-	annotations := cr.GetAnnotations()
-	wasForceRotation := annotations != nil && annotations["mailgun.crossplane.io/internal-force-rotate"] == "true"
+	wasForceRotation := forceRotation(cr)
+
+	// login is the login the new credential is created under, and previous
+	// the login of the credential it replaces after an overlap period
+	login := cr.Spec.ForProvider.Login
+	previous := ""
+	overlap := cr.Spec.ForProvider.OverlapPeriod
+
+	if (isImported || wasForceRotation) && overlap != nil {
+		// Create the new credential under the other login so that the
+		// current one keeps working until the overlap period has ended
+		previous = currentLogin(cr)
+		if previous == login {
+			login = alternateLogin(login)
+		}
+		logger.Info("creating new SMTP credential before deleting the current one", "previousLogin", previous, "overlapPeriod", overlap.Duration.String())
+		op.SetAttribute("rotation_strategy", true)
+		op.SetAttribute("rotation.overlap", true)
 
-	if isImported || wasForceRotation {
+		// The login may still be held by the credential an earlier rotation
+		// replaced, whose overlap period is cut short
+		if err := c.service.DeleteSMTPCredential(ctx, cr.Spec.ForProvider.Domain, login); err != nil && !clients.IsNotFound(err) {
+			logger.Error(err, "failed to free login for the new SMTP credential")
+			op.RecordError(err)
+			return managed.ExternalCreation{}, errors.Wrapf(err, errDeletePrevious, login)
+		}
+	} else if isImported || wasForceRotation {
 		// Implement rotation strategy: delete existing credential first to get fresh credentials
 		rotationReason := "imported resource"
 		if wasForceRotation {
@@ -419,7 +548,12 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		}
 		logger.Info("deleting existing SMTP credential for rotation", "reason", rotationReason)
 		op.SetAttribute("rotation_strategy", true)
-		err := c.service.DeleteSMTPCredential(ctx, cr.Spec.ForProvider.Domain, cr.Spec.ForProvider.Login)
+		if err := c.retirePrevious(ctx, cr); err != nil {
+			logger.Error(err, "failed to delete previous SMTP credential during rotation")
+			op.RecordError(err)
+			return managed.ExternalCreation{}, err
+		}
+		err := c.service.DeleteSMTPCredential(ctx, cr.Spec.ForProvider.Domain, currentLogin(cr))
 		if err != nil && !clients.IsNotFound(err) {
 			// If deletion fails for reasons other than "not found", that's an error
 			logger.Error(err, "failed to delete existing SMTP credential during rotation")
@@ -450,7 +584,9 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	logger.Info("creating new SMTP credential via Mailgun API")
 	apiTimer := metrics.NewOperationTimer()
-	credential, err := c.service.CreateSMTPCredential(ctx, cr.Spec.ForProvider.Domain, &cr.Spec.ForProvider)
+	params := cr.Spec.ForProvider
+	params.Login = login
+	credential, err := c.service.CreateSMTPCredential(ctx, cr.Spec.ForProvider.Domain, &params)
 	if err != nil {
 		logger.Error(err, "failed to create SMTP credential")
 		apiTimer.RecordMailgunAPIRequest("create_smtp_credential", cr.Spec.ForProvider.Domain, "error")
@@ -498,27 +634,174 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 				return "none"
 			}
 		}())
-	timer.RecordResourceOperation("smtpcredential", "create", "success")
+	details := managed.ConnectionDetails{
+		"smtp_host":     []byte(clients.SMTPHost(c.region)),
+		"smtp_port":     []byte("587"),
+		"smtp_username": []byte(credential.Login),
+		"smtp_password": []byte(connectionPassword),
+	}
 
-	// Clean up the internal force-rotate annotation if it exists
-	currentAnnotations := cr.GetAnnotations()
-	if currentAnnotations != nil && currentAnnotations["mailgun.crossplane.io/internal-force-rotate"] == "true" {
-		delete(currentAnnotations, "mailgun.crossplane.io/internal-force-rotate")
-		cr.SetAnnotations(currentAnnotations)
-		logger.Info("cleaned up internal force-rotate annotation after successful creation")
+	// Fail fast rather than publish a secret consumers cannot use. The
+	// credential is deleted again so that the next attempt starts afresh.
+	if missing := missingConnectionKeys(details, cr.Spec.ForProvider.RequiredConnectionKeys, password != nil); len(missing) > 0 {
+		err := errors.Errorf(errMissingKeys, strings.Join(missing, ", "))
+		logger.Error(err, "required connection keys missing, deleting new SMTP credential")
+		if derr := c.service.DeleteSMTPCredential(ctx, cr.Spec.ForProvider.Domain, credential.Login); derr != nil && !clients.IsNotFound(derr) {
+			err = errors.Wrapf(err, "cannot delete SMTP credential %s: %v", credential.Login, derr)
+		}
+		timer.RecordResourceOperation("smtpcredential", "create", "error")
+		op.RecordError(err)
+		return managed.ExternalCreation{}, err
+	}
+
+	if previous != "" {
+		deleteAfter := time.Now().Add(overlap.Duration)
+		meta.AddAnnotations(cr, map[string]string{
+			v1beta1.AnnotationPreviousLogin:            previous,
+			v1beta1.AnnotationPreviousLoginDeleteAfter: deleteAfter.UTC().Format(time.RFC3339),
+		})
+		cr.Status.AtProvider.PreviousLogin = previous
+		cr.Status.AtProvider.PreviousLoginDeleteAfter = &metav1.Time{Time: deleteAfter}
+	}
+
+	timer.RecordResourceOperation("smtpcredential", "create", "success")
+	c.setPasswordCondition(cr, connectionPassword != "", true)
+
+	// The handled trigger values are recorded rather than only removed, as
+	// the reconciler may restore the trigger when it persists the
+	// annotations of a successful Create
+	requested := trigger.MarkHandled(cr, trigger.ForceRotateCredentials)
+	if trigger.MarkHandled(cr, trigger.InternalForceRotate) || requested {
+		logger.Info("cleared force-rotate annotation after successful creation")
 	}
 
 	return managed.ExternalCreation{
-		ConnectionDetails: managed.ConnectionDetails{
-			"smtp_host":     []byte("smtp.mailgun.org"),
-			"smtp_port":     []byte("587"),
-			"smtp_username": []byte(credential.Login),
-			"smtp_password": []byte(connectionPassword),
-		},
+		ConnectionDetails: details,
 	}, nil
 }
 
+// forceRotation reports whether a rotation of the credential was requested
+// and has not completed yet. InternalForceRotate is honoured for resources
+// that carry it from an earlier version of the provider.
+func forceRotation(cr *v1beta1.SMTPCredential) bool {
+	return trigger.Pending(cr, trigger.ForceRotateCredentials) || trigger.Pending(cr, trigger.InternalForceRotate)
+}
+
+// settleRotation tidies up the triggers of a completed rotation, and
+// reports whether the change has to be persisted
+func settleRotation(cr *v1beta1.SMTPCredential) bool {
+	settled := trigger.Settle(cr, trigger.ForceRotateCredentials)
+	return trigger.Settle(cr, trigger.InternalForceRotate) || settled
+}
+
+// alternateLogin returns the login that overlapping rotations alternate with
+// login, which has rotatedSuffix appended to its local part
+func alternateLogin(login string) string {
+	at := strings.LastIndex(login, "@")
+	if at < 0 {
+		return login + rotatedSuffix
+	}
+	return login[:at] + rotatedSuffix + login[at:]
+}
+
+// currentLogin returns the login of the credential in use, which after an
+// overlapping rotation may be the alternate of Login
+func currentLogin(cr *v1beta1.SMTPCredential) string {
+	login := cr.Spec.ForProvider.Login
+	if alternate := alternateLogin(login); meta.GetExternalName(cr) == alternate {
+		return alternate
+	}
+	return login
+}
+
+// previousLogin returns the login of the credential replaced by the last
+// overlapping rotation and when it is due to be deleted, as recorded in the
+// annotations of cr. A missing or malformed deadline makes it due at once.
+func previousLogin(cr *v1beta1.SMTPCredential) (string, *metav1.Time) {
+	annotations := cr.GetAnnotations()
+	login := annotations[v1beta1.AnnotationPreviousLogin]
+	if login == "" {
+		return "", nil
+	}
+	deleteAfter, err := time.Parse(time.RFC3339, annotations[v1beta1.AnnotationPreviousLoginDeleteAfter])
+	if err != nil {
+		return login, nil
+	}
+	return login, &metav1.Time{Time: deleteAfter}
+}
+
+// previousLoginDue reports whether the credential replaced by the last
+// rotation is due to be deleted at now
+func previousLoginDue(cr *v1beta1.SMTPCredential, now time.Time) bool {
+	login, deleteAfter := previousLogin(cr)
+	return login != "" && (deleteAfter == nil || !now.Before(deleteAfter.Time))
+}
+
+// retireDue deletes the credential replaced by the last rotation once it is
+// due, and reports whether it did. The removal of its annotations then has
+// to be persisted by the caller.
+func (c *external) retireDue(ctx context.Context, cr *v1beta1.SMTPCredential, now time.Time) (bool, error) {
+	if !previousLoginDue(cr, now) {
+		return false, nil
+	}
+	return true, c.retirePrevious(ctx, cr)
+}
+
+// retirePrevious deletes the credential replaced by the last rotation, if
+// any, and forgets it. A recorded login that is the one in use, as after a
+// later rotation whose removal of the record was lost, is only forgotten.
+func (c *external) retirePrevious(ctx context.Context, cr *v1beta1.SMTPCredential) error {
+	previous, _ := previousLogin(cr)
+	if previous == "" {
+		return nil
+	}
+	if previous != currentLogin(cr) {
+		if err := c.service.DeleteSMTPCredential(ctx, cr.Spec.ForProvider.Domain, previous); err != nil && !clients.IsNotFound(err) {
+			return errors.Wrapf(err, errDeletePrevious, previous)
+		}
+	}
+	meta.RemoveAnnotations(cr, v1beta1.AnnotationPreviousLogin, v1beta1.AnnotationPreviousLoginDeleteAfter)
+	cr.Status.AtProvider.PreviousLogin = ""
+	cr.Status.AtProvider.PreviousLoginDeleteAfter = nil
+	return nil
+}
+
+// setPasswordCondition reports in the DegradedConnectionSecret condition
+// whether the connection secret holds a password. When written is true the
+// secret is being written, and a missing password is also warned about.
+func (c *external) setPasswordCondition(cr *v1beta1.SMTPCredential, hasPassword, written bool) {
+	switch {
+	case !hasPassword:
+		if written && c.recorder != nil {
+			c.recorder.Event(cr, event.Warning(reasonEmptyPassword, errors.New(v1beta1.PasswordUnavailable().Message)))
+		}
+		cr.SetConditions(v1beta1.PasswordUnavailable())
+	case cr.GetCondition(v1beta1.TypeDegradedConnectionSecret).Status == corev1.ConditionTrue:
+		cr.SetConditions(v1beta1.PasswordStored())
+	}
+}
+
+// missingConnectionKeys returns the required keys that are absent or empty
+// in details. smtp_password is only required when a password is configured:
+// Mailgun may not return the one it generates, and recreating the credential
+// would not change that, so its absence is reported by the PasswordUnavailable
+// condition instead.
+func missingConnectionKeys(details managed.ConnectionDetails, required []string, passwordConfigured bool) []string {
+	var missing []string
+	for _, key := range required {
+		if key == "smtp_password" && !passwordConfigured {
+			continue
+		}
+		if len(details[key]) == 0 {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	defer c.reconcile.Start()()
+
 	op := tracing.StartOperation(ctx, tracing.SpanResourceUpdate,
 		"crossplane.resource.type", "SMTPCredential",
 		"crossplane.resource.name", mg.GetName(),
@@ -541,7 +824,7 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		op.SetAttribute("password.provided", true)
 		_, err := c.service.UpdateSMTPCredential(ctx,
 			cr.Spec.ForProvider.Domain,
-			cr.Spec.ForProvider.Login,
+			currentLogin(cr),
 			*cr.Spec.ForProvider.Password)
 		if err != nil {
 			op.RecordError(err)
@@ -556,6 +839,8 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	defer c.reconcile.Start()()
+
 	op := tracing.StartOperation(ctx, tracing.SpanResourceDelete,
 		"crossplane.resource.type", "SMTPCredential",
 		"crossplane.resource.name", mg.GetName(),
@@ -575,7 +860,12 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	cr.SetConditions(xpv1.Deleting())
 
-	err := c.service.DeleteSMTPCredential(ctx, cr.Spec.ForProvider.Domain, cr.Spec.ForProvider.Login)
+	if err := c.retirePrevious(ctx, cr); err != nil {
+		op.RecordError(err)
+		return managed.ExternalDelete{}, err
+	}
+
+	err := c.service.DeleteSMTPCredential(ctx, cr.Spec.ForProvider.Domain, currentLogin(cr))
 	if err != nil && !clients.IsNotFound(err) {
 		op.RecordError(err)
 		return managed.ExternalDelete{}, errors.Wrap(err, "failed to delete SMTP credential")
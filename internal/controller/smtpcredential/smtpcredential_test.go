@@ -18,6 +18,8 @@ package smtpcredential
 
 import (
 	"context"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
@@ -25,11 +27,17 @@ import (
 	v1beta1 "github.com/rossigee/provider-mailgun/apis/smtpcredential/v1beta1"
 	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
 	domaintypes "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+	ippooltypes "github.com/rossigee/provider-mailgun/apis/ippool/v1beta1"
 	mailinglisttypes "github.com/rossigee/provider-mailgun/apis/mailinglist/v1beta1"
+	mailinglistmembertypes "github.com/rossigee/provider-mailgun/apis/mailinglistmember/v1beta1"
 	routetypes "github.com/rossigee/provider-mailgun/apis/route/v1beta1"
 	templatetypes "github.com/rossigee/provider-mailgun/apis/template/v1beta1"
 	webhooktypes "github.com/rossigee/provider-mailgun/apis/webhook/v1beta1"
 	bouncetypes "github.com/rossigee/provider-mailgun/apis/bounce/v1beta1"
+	complainttypes "github.com/rossigee/provider-mailgun/apis/complaint/v1beta1"
+	unsubscribetypes "github.com/rossigee/provider-mailgun/apis/unsubscribe/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
+	"github.com/rossigee/provider-mailgun/internal/trigger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -38,7 +46,9 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sync"
 	"testing"
+	"time"
 )
 
 // MockSMTPCredentialClient for testing
@@ -125,6 +135,30 @@ func (m *MockSMTPCredentialClient) DeleteTemplate(ctx context.Context, domain, n
 	return errors.New("not implemented")
 }
 
+func (m *MockSMTPCredentialClient) CreateTemplateVersion(ctx context.Context, domain, name string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) GetTemplateVersion(ctx context.Context, domain, name, tag string) (*templatetypes.TemplateVersion, string, error) {
+	return nil, "", errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) UpdateTemplateVersion(ctx context.Context, domain, name, tag string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) DeleteTemplateVersion(ctx context.Context, domain, name, tag string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) ListTemplateVersions(ctx context.Context, domain, name string) ([]templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) RenderTemplate(ctx context.Context, domain, name string, vars map[string]interface{}) (string, error) {
+	return "", errors.New("not implemented")
+}
+
 // Implement other required client methods as no-ops
 func (m *MockSMTPCredentialClient) CreateDomain(ctx context.Context, domain *domaintypes.DomainParameters) (*domaintypes.DomainObservation, error) {
 	return nil, errors.New("not implemented")
@@ -142,6 +176,50 @@ func (m *MockSMTPCredentialClient) DeleteDomain(ctx context.Context, name string
 	return errors.New("not implemented")
 }
 
+func (m *MockSMTPCredentialClient) ListAuthorizedRecipients(ctx context.Context) ([]domaintypes.AuthorizedRecipient, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) AddAuthorizedRecipient(ctx context.Context, email string) (*domaintypes.AuthorizedRecipient, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) DeleteAuthorizedRecipient(ctx context.Context, email string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) GetWebhookSigningKey(ctx context.Context) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) RotateWebhookSigningKey(ctx context.Context) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) UpdateDomainTracking(ctx context.Context, name string, tracking *domaintypes.DomainTracking) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) GetDomainTracking(ctx context.Context, name string) (*domaintypes.DomainTracking, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) GetDomainConnection(ctx context.Context, name string) (*domaintypes.DomainConnection, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) UpdateDomainConnection(ctx context.Context, name string, connection *domaintypes.DomainConnection) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) UpdateDKIMKey(ctx context.Context, name string, keySize int) ([]domaintypes.DNSRecord, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) ListDomains(ctx context.Context, limit, skip int) ([]*domaintypes.DomainObservation, int, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
 func (m *MockSMTPCredentialClient) CreateMailingList(ctx context.Context, list *mailinglisttypes.MailingListParameters) (*mailinglisttypes.MailingListObservation, error) {
 	return nil, errors.New("not implemented")
 }
@@ -158,6 +236,30 @@ func (m *MockSMTPCredentialClient) DeleteMailingList(ctx context.Context, addres
 	return errors.New("not implemented")
 }
 
+func (m *MockSMTPCredentialClient) CreateMailingListMember(ctx context.Context, listAddress string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) GetMailingListMember(ctx context.Context, listAddress, address string) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) UpdateMailingListMember(ctx context.Context, listAddress, address string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) DeleteMailingListMember(ctx context.Context, listAddress, address string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) AddMailingListMembers(ctx context.Context, listAddress string, members []clients.MailingListMemberSpec, upsert bool) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) ListMailingListMembers(ctx context.Context, listAddress string) ([]*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (m *MockSMTPCredentialClient) CreateRoute(ctx context.Context, route *routetypes.RouteParameters) (*routetypes.RouteObservation, error) {
 	return nil, errors.New("not implemented")
 }
@@ -174,6 +276,10 @@ func (m *MockSMTPCredentialClient) DeleteRoute(ctx context.Context, id string) e
 	return errors.New("not implemented")
 }
 
+func (m *MockSMTPCredentialClient) ListRoutes(ctx context.Context, limit, skip int) ([]*routetypes.RouteObservation, int, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
 func (m *MockSMTPCredentialClient) CreateWebhook(ctx context.Context, domain string, webhook *webhooktypes.WebhookParameters) (*webhooktypes.WebhookObservation, error) {
 	return nil, errors.New("not implemented")
 }
@@ -204,11 +310,11 @@ func (m *MockSMTPCredentialClient) DeleteBounce(ctx context.Context, domain, add
 }
 
 // Complaint operations
-func (m *MockSMTPCredentialClient) CreateComplaint(ctx context.Context, domain string, complaint interface{}) (interface{}, error) {
+func (m *MockSMTPCredentialClient) CreateComplaint(ctx context.Context, domain string, complaint *complainttypes.ComplaintParameters) (*complainttypes.ComplaintObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockSMTPCredentialClient) GetComplaint(ctx context.Context, domain, address string) (interface{}, error) {
+func (m *MockSMTPCredentialClient) GetComplaint(ctx context.Context, domain, address string) (*complainttypes.ComplaintObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
@@ -217,15 +323,43 @@ func (m *MockSMTPCredentialClient) DeleteComplaint(ctx context.Context, domain,
 }
 
 // Unsubscribe operations
-func (m *MockSMTPCredentialClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe interface{}) (interface{}, error) {
+func (m *MockSMTPCredentialClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe *unsubscribetypes.UnsubscribeParameters) (*unsubscribetypes.UnsubscribeObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) GetUnsubscribe(ctx context.Context, domain, address string) (*unsubscribetypes.UnsubscribeObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) DeleteUnsubscribe(ctx context.Context, domain, address, tag string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) CreateIPPool(ctx context.Context, pool *ippooltypes.IPPoolParameters) (*ippooltypes.IPPoolObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockSMTPCredentialClient) GetUnsubscribe(ctx context.Context, domain, address string) (interface{}, error) {
+func (m *MockSMTPCredentialClient) GetIPPool(ctx context.Context, id string) (*ippooltypes.IPPoolObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockSMTPCredentialClient) DeleteUnsubscribe(ctx context.Context, domain, address string) error {
+func (m *MockSMTPCredentialClient) ListDomainIPs(ctx context.Context, name string) ([]string, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) AssignDomainIP(ctx context.Context, name, ip string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) UnassignDomainIP(ctx context.Context, name, ip string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) UpdateIPPool(ctx context.Context, id string, pool *ippooltypes.IPPoolParameters, add, remove []string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockSMTPCredentialClient) DeleteIPPool(ctx context.Context, id string) error {
 	return errors.New("not implemented")
 }
 
@@ -490,6 +624,378 @@ func TestSMTPCredentialCreate(t *testing.T) {
 	}
 }
 
+func stringPtr(s string) *string {
+	return &s
+}
+
+// eventRecorder records the events it is given
+type eventRecorder struct {
+	events []event.Event
+}
+
+func (r *eventRecorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *eventRecorder) WithAnnotations(_ ...string) event.Recorder {
+	return r
+}
+
+func TestSMTPCredentialCreateEmptyPassword(t *testing.T) {
+	cases := map[string]struct {
+		password     *string
+		wantDegraded bool
+	}{
+		"GeneratedPassword": {
+			wantDegraded: true,
+		},
+		"SuppliedPassword": {
+			password: stringPtr("s3cret"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			recorder := &eventRecorder{}
+			e := &external{service: &MockSMTPCredentialClient{}, recorder: recorder}
+			cr := &v1beta1.SMTPCredential{
+				Spec: v1beta1.SMTPCredentialSpec{
+					ForProvider: v1beta1.SMTPCredentialParameters{
+						Domain:   "example.com",
+						Login:    "new@example.com",
+						Password: tc.password,
+					},
+				},
+			}
+
+			_, err := e.Create(context.Background(), cr)
+			require.NoError(t, err)
+
+			cond := cr.GetCondition(v1beta1.TypeDegradedConnectionSecret)
+			if !tc.wantDegraded {
+				assert.NotEqual(t, corev1.ConditionTrue, cond.Status)
+				assert.Empty(t, recorder.events)
+				return
+			}
+			assert.Equal(t, corev1.ConditionTrue, cond.Status)
+			assert.Equal(t, v1beta1.ReasonPasswordUnavailable, cond.Reason)
+			require.Len(t, recorder.events, 1)
+			assert.Equal(t, event.TypeWarning, recorder.events[0].Type)
+			assert.Equal(t, reasonEmptyPassword, recorder.events[0].Reason)
+		})
+	}
+}
+
+func TestSMTPCredentialRegionalHost(t *testing.T) {
+	for region, want := range map[string]string{
+		"":               "smtp.mailgun.org",
+		clients.RegionUS: "smtp.mailgun.org",
+		clients.RegionEU: "smtp.eu.mailgun.org",
+	} {
+		t.Run(region, func(t *testing.T) {
+			e := &external{service: &MockSMTPCredentialClient{}, region: region}
+			cr := &v1beta1.SMTPCredential{
+				Spec: v1beta1.SMTPCredentialSpec{
+					ForProvider: v1beta1.SMTPCredentialParameters{
+						Domain:   "example.com",
+						Login:    "new@example.com",
+						Password: stringPtr("s3cret"),
+					},
+				},
+			}
+
+			got, err := e.Create(context.Background(), cr)
+			require.NoError(t, err)
+			assert.Equal(t, want, string(got.ConnectionDetails["smtp_host"]))
+		})
+	}
+}
+
+func TestSMTPCredentialRequiredConnectionKeys(t *testing.T) {
+	cases := map[string]struct {
+		password     string
+		required     []string
+		wantErr      string
+		wantPassword string
+	}{
+		"GeneratedPasswordNotEnforced": {
+			required: []string{"smtp_username", "smtp_password"},
+		},
+		"ProvidedPassword": {
+			password:     "provided-password",
+			required:     []string{"smtp_username", "smtp_password"},
+			wantPassword: "provided-password",
+		},
+		"UnknownKeyMissing": {
+			required: []string{"smtp_username", "smtp_api_key"},
+			wantErr:  "smtp_api_key",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1beta1.SMTPCredential{
+				Spec: v1beta1.SMTPCredentialSpec{
+					ForProvider: v1beta1.SMTPCredentialParameters{
+						Domain:                 "example.com",
+						Login:                  "new@example.com",
+						RequiredConnectionKeys: tc.required,
+					},
+				},
+			}
+			if tc.password != "" {
+				cr.Spec.ForProvider.Password = &tc.password
+			}
+			mockClient := &MockSMTPCredentialClient{}
+			e := &external{service: mockClient}
+
+			got, err := e.Create(context.Background(), cr)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantPassword, string(got.ConnectionDetails["smtp_password"]))
+				assert.Contains(t, mockClient.credentials, "example.com/new@example.com")
+				if tc.password == "" {
+					assert.Equal(t, corev1.ConditionTrue, cr.GetCondition(v1beta1.TypeDegradedConnectionSecret).Status, "a generated password that is not returned should be reported")
+				}
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+			assert.NotContains(t, err.Error(), "smtp_username")
+			assert.Empty(t, got.ConnectionDetails)
+			assert.NotContains(t, mockClient.credentials, "example.com/new@example.com", "the unusable credential should be deleted")
+		})
+	}
+}
+
+func TestSMTPCredentialForceRotateConsumedOnce(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, v1beta1.SchemeBuilder.AddToScheme(scheme))
+
+	cr := &v1beta1.SMTPCredential{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-smtp",
+			Annotations: map[string]string{
+				trigger.ForceRotateCredentials:            "true",
+				meta.AnnotationKeyExternalCreateSucceeded: "2025-01-01T00:00:00Z",
+				meta.AnnotationKeyExternalName:            "existing@example.com",
+			},
+		},
+		Spec: v1beta1.SMTPCredentialSpec{
+			ForProvider: v1beta1.SMTPCredentialParameters{
+				Domain: "example.com",
+				Login:  "existing@example.com",
+			},
+			ManagedResourceSpec: xpv1.ManagedResourceSpec{
+				WriteConnectionSecretToReference: &xpv1.LocalSecretReference{
+					Name: "missing-secret",
+				},
+			},
+		},
+	}
+
+	mockClient := &MockSMTPCredentialClient{
+		credentials: map[string]*v1beta1.SMTPCredentialObservation{
+			"example.com/existing@example.com": {Login: "existing@example.com", State: "active"},
+		},
+	}
+	e := &external{service: mockClient, kube: fake.NewClientBuilder().WithScheme(scheme).Build()}
+
+	// The trigger forces recreation and stays set until it succeeds
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceExists)
+	assert.True(t, trigger.IsSet(cr, trigger.ForceRotateCredentials))
+
+	// A failed rotation is retried on the next reconcile
+	mockClient.err = errors.New("boom")
+	_, err = e.Create(context.Background(), cr)
+	require.Error(t, err)
+	mockClient.err = nil
+	assert.True(t, trigger.IsSet(cr, trigger.ForceRotateCredentials))
+
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceExists)
+
+	// A successful Create rotates and clears the trigger
+	_, err = e.Create(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, trigger.IsSet(cr, trigger.ForceRotateCredentials))
+	assert.False(t, trigger.IsSet(cr, trigger.InternalForceRotate))
+
+	// The next reconcile sees the created credential and no trigger
+	meta.SetExternalCreateSucceeded(cr, time.Now())
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+	assert.True(t, obs.ResourceUpToDate)
+}
+
+func TestSMTPCredentialForceRotateRestoredAfterConflict(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, v1beta1.SchemeBuilder.AddToScheme(scheme))
+
+	cr := &v1beta1.SMTPCredential{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-smtp",
+			Annotations: map[string]string{
+				trigger.ForceRotateCredentials:            "true",
+				meta.AnnotationKeyExternalCreateSucceeded: "2025-01-01T00:00:00Z",
+				meta.AnnotationKeyExternalName:            "existing@example.com",
+			},
+		},
+		Spec: v1beta1.SMTPCredentialSpec{
+			ForProvider: v1beta1.SMTPCredentialParameters{
+				Domain: "example.com",
+				Login:  "existing@example.com",
+			},
+			ManagedResourceSpec: xpv1.ManagedResourceSpec{
+				WriteConnectionSecretToReference: &xpv1.LocalSecretReference{
+					Name: "missing-secret",
+				},
+			},
+		},
+	}
+
+	mockClient := &MockSMTPCredentialClient{
+		credentials: map[string]*v1beta1.SMTPCredentialObservation{
+			"example.com/existing@example.com": {Login: "existing@example.com", State: "active"},
+		},
+	}
+	e := &external{service: mockClient, kube: fake.NewClientBuilder().WithScheme(scheme).Build()}
+
+	_, err := e.Create(context.Background(), cr)
+	require.NoError(t, err)
+	created := mockClient.credentials["example.com/existing@example.com"]
+
+	// On a conflict the reconciler re-reads the object, which still carries
+	// the trigger, and only adds the annotations Create set
+	meta.AddAnnotations(cr, map[string]string{trigger.ForceRotateCredentials: "true"})
+
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists, "a handled trigger must not rotate the credential again")
+	assert.True(t, obs.ResourceLateInitialized)
+	assert.False(t, trigger.IsSet(cr, trigger.ForceRotateCredentials))
+	assert.Same(t, created, mockClient.credentials["example.com/existing@example.com"])
+
+	// Once the trigger is gone a new request rotates again
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceLateInitialized)
+	trigger.Set(cr, trigger.ForceRotateCredentials)
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceExists)
+}
+
+func TestSMTPCredentialRotationOverlap(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, v1beta1.SchemeBuilder.AddToScheme(scheme))
+
+	cr := &v1beta1.SMTPCredential{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-smtp",
+			Namespace: "default",
+			Annotations: map[string]string{
+				trigger.ForceRotateCredentials:            "true",
+				meta.AnnotationKeyExternalCreateSucceeded: "2025-01-01T00:00:00Z",
+				meta.AnnotationKeyExternalName:            "existing@example.com",
+			},
+		},
+		Spec: v1beta1.SMTPCredentialSpec{
+			ForProvider: v1beta1.SMTPCredentialParameters{
+				Domain:        "example.com",
+				Login:         "existing@example.com",
+				OverlapPeriod: &metav1.Duration{Duration: time.Hour},
+			},
+			ManagedResourceSpec: xpv1.ManagedResourceSpec{
+				WriteConnectionSecretToReference: &xpv1.LocalSecretReference{
+					Name: "smtp-secret",
+				},
+			},
+		},
+	}
+
+	mockClient := &MockSMTPCredentialClient{
+		credentials: map[string]*v1beta1.SMTPCredentialObservation{
+			"example.com/existing@example.com": {Login: "existing@example.com", State: "active"},
+		},
+	}
+	kube := fake.NewClientBuilder().WithScheme(scheme).Build()
+	e := &external{service: mockClient, kube: kube}
+
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	require.False(t, obs.ResourceExists)
+
+	// The new credential is created under the alternate login without
+	// deleting the current one
+	creation, err := e.Create(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, "existing-rotated@example.com", meta.GetExternalName(cr))
+	assert.Equal(t, "existing-rotated@example.com", string(creation.ConnectionDetails["smtp_username"]))
+	assert.Contains(t, mockClient.credentials, "example.com/existing@example.com")
+	assert.Contains(t, mockClient.credentials, "example.com/existing-rotated@example.com")
+	assert.Equal(t, "existing@example.com", cr.GetAnnotations()[v1beta1.AnnotationPreviousLogin])
+
+	// The reconciler publishes the new connection details and the
+	// annotations set by Create, but reverts its status changes
+	cr.Status.AtProvider = v1beta1.SMTPCredentialObservation{}
+	require.NoError(t, kube.Create(context.Background(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "smtp-secret", Namespace: "default"},
+		Data:       map[string][]byte{"smtp_username": creation.ConnectionDetails["smtp_username"]},
+	}))
+
+	// Both credentials stay valid during the overlap period
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.Equal(t, "existing-rotated@example.com", string(obs.ConnectionDetails["smtp_username"]))
+	assert.True(t, obs.ResourceLateInitialized, "the record of the handled trigger should be removed")
+	assert.Equal(t, "existing@example.com", cr.Status.AtProvider.PreviousLogin)
+	require.NotNil(t, cr.Status.AtProvider.PreviousLoginDeleteAfter)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), cr.Status.AtProvider.PreviousLoginDeleteAfter.Time, time.Minute)
+	assert.Len(t, mockClient.credentials, 2)
+
+	// Once it has ended the previous credential is deleted, and the removal
+	// of its annotations persisted
+	meta.AddAnnotations(cr, map[string]string{v1beta1.AnnotationPreviousLoginDeleteAfter: time.Now().Add(-time.Second).Format(time.RFC3339)})
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.True(t, obs.ResourceLateInitialized)
+	assert.NotContains(t, mockClient.credentials, "example.com/existing@example.com")
+	assert.Contains(t, mockClient.credentials, "example.com/existing-rotated@example.com")
+	assert.NotContains(t, cr.GetAnnotations(), v1beta1.AnnotationPreviousLogin)
+	assert.Empty(t, cr.Status.AtProvider.PreviousLogin)
+	assert.Nil(t, cr.Status.AtProvider.PreviousLoginDeleteAfter)
+
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.False(t, obs.ResourceLateInitialized)
+
+	// The next rotation returns to Login, and deleting the resource removes
+	// both credentials while they overlap
+	trigger.Set(cr, trigger.InternalForceRotate)
+	_, err = e.Create(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, "existing@example.com", meta.GetExternalName(cr))
+	assert.Equal(t, "existing-rotated@example.com", cr.GetAnnotations()[v1beta1.AnnotationPreviousLogin])
+	assert.Len(t, mockClient.credentials, 2)
+
+	_, err = e.Delete(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Empty(t, mockClient.credentials)
+}
+
 func TestSMTPCredentialUpdate(t *testing.T) {
 	type args struct {
 		mg resource.Managed
@@ -742,3 +1248,90 @@ func TestProviderConfigUsageTracker_TrackIdempotent(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, pcuList.Items, 1, "Should only have one ProviderConfigUsage")
 }
+
+// rotationRecorder records the credential calls of concurrent rotations
+type rotationRecorder struct {
+	*MockSMTPCredentialClient
+
+	mu  sync.Mutex
+	ops []string
+}
+
+func (r *rotationRecorder) record(op string) {
+	r.mu.Lock()
+	r.ops = append(r.ops, op)
+	r.mu.Unlock()
+	// Give other rotations the chance to interleave
+	time.Sleep(time.Millisecond)
+}
+
+func (r *rotationRecorder) CreateSMTPCredential(ctx context.Context, domain string, credential *v1beta1.SMTPCredentialParameters) (*v1beta1.SMTPCredentialObservation, error) {
+	r.record("create " + credential.Login)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.MockSMTPCredentialClient.CreateSMTPCredential(ctx, domain, credential)
+}
+
+func (r *rotationRecorder) DeleteSMTPCredential(ctx context.Context, domain, login string) error {
+	r.record("delete " + login)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.MockSMTPCredentialClient.DeleteSMTPCredential(ctx, domain, login)
+}
+
+func TestSMTPCredentialConcurrentRotations(t *testing.T) {
+	cr := &v1beta1.SMTPCredential{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-smtp",
+			Namespace: "default",
+			Annotations: map[string]string{
+				meta.AnnotationKeyExternalName: "existing@example.com",
+			},
+		},
+		Spec: v1beta1.SMTPCredentialSpec{
+			ForProvider: v1beta1.SMTPCredentialParameters{
+				Domain: "example.com",
+				Login:  "existing@example.com",
+			},
+		},
+	}
+	trigger.Set(cr, trigger.InternalForceRotate)
+
+	recorder := &rotationRecorder{MockSMTPCredentialClient: &MockSMTPCredentialClient{
+		credentials: map[string]*v1beta1.SMTPCredentialObservation{
+			"example.com/existing@example.com": {Login: "existing@example.com", State: "active"},
+		},
+	}}
+	e := &external{service: recorder, serializeRotations: true}
+
+	const reconciles = 8
+	var wg sync.WaitGroup
+	for range reconciles {
+		wg.Add(1)
+		go func(cr *v1beta1.SMTPCredential) {
+			defer wg.Done()
+			_, err := e.Create(context.Background(), cr)
+			assert.NoError(t, err)
+		}(cr.DeepCopy())
+	}
+	wg.Wait()
+
+	// Each rotation deletes the credential and creates it again before the
+	// next one starts
+	require.Len(t, recorder.ops, 2*reconciles)
+	for i := 0; i < len(recorder.ops); i += 2 {
+		assert.Equal(t, []string{"delete existing@example.com", "create existing@example.com"}, recorder.ops[i:i+2])
+	}
+	assert.Len(t, recorder.credentials, 1)
+	assert.Empty(t, rotations.locks, "released locks should be forgotten")
+
+	// A rotation waiting for the lock gives up with its context
+	unlock, err := rotations.Lock(context.Background(), "default/test-smtp")
+	require.NoError(t, err)
+	defer unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = e.Create(ctx, cr.DeepCopy())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot wait for the rotation of SMTPCredential default/test-smtp")
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/template/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
+)
+
+const errDeleteDomains = "cannot delete template from %s"
+
+// observeDomains records the state of the template on each of
+// spec.forProvider.domains and reports whether it is synced on all of them.
+// The error of a domain that is still not synced is kept until it is.
+func (c *external) observeDomains(ctx context.Context, cr *v1beta1.Template) bool {
+	previous := domainStatuses(cr)
+	desired := c.description(cr)
+
+	statuses := make([]v1beta1.TemplateDomainStatus, 0, len(cr.Spec.ForProvider.Domains))
+	synced := true
+	for _, domain := range cr.Spec.ForProvider.Domains {
+		status := v1beta1.TemplateDomainStatus{Domain: domain}
+		template, err := c.client.GetTemplate(ctx, domain, cr.Spec.ForProvider.Name)
+		switch {
+		case err == nil:
+			status.Synced = (desired == nil || *desired == template.Description) && domainContentUpToDate(cr, template)
+		case !clients.IsNotFound(err):
+			status.Error = errors.Wrap(err, errGetTemplate).Error()
+		}
+		if !status.Synced && status.Error == "" {
+			status.Error = previous[domain].Error
+		}
+		synced = synced && status.Synced
+		statuses = append(statuses, status)
+	}
+
+	cr.Status.AtProvider.Domains = statuses
+	setDomainsCondition(cr)
+	return synced
+}
+
+// syncDomains creates or updates the template on each of
+// spec.forProvider.domains it is not synced on. A failure is recorded in the
+// status of its domain rather than returned, so that one failing domain does
+// not hold back the others.
+func (c *external) syncDomains(ctx context.Context, cr *v1beta1.Template) {
+	previous := domainStatuses(cr)
+
+	statuses := make([]v1beta1.TemplateDomainStatus, 0, len(cr.Spec.ForProvider.Domains))
+	for _, domain := range cr.Spec.ForProvider.Domains {
+		status := previous[domain]
+		status.Domain = domain
+		if !status.Synced {
+			status.Error = ""
+			if err := c.syncDomain(ctx, cr, domain); err != nil {
+				status.Error = err.Error()
+			} else {
+				status.Synced = true
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	cr.Status.AtProvider.Domains = statuses
+	setDomainsCondition(cr)
+}
+
+// syncDomain creates the template on domain, or updates its description and
+// the content of its active version if it already exists there
+func (c *external) syncDomain(ctx context.Context, cr *v1beta1.Template, domain string) error {
+	name := cr.Spec.ForProvider.Name
+	template, err := c.client.GetTemplate(ctx, domain, name)
+	switch {
+	case clients.IsNotFound(err):
+		params := cr.Spec.ForProvider
+		params.Domain = domain
+		params.Domains = nil
+		params.Description = c.description(cr)
+		_, err = c.client.CreateTemplate(ctx, domain, &params)
+		return errors.Wrap(err, errCreateTemplate)
+	case err != nil:
+		return errors.Wrap(err, errGetTemplate)
+	}
+
+	if _, err := c.client.UpdateTemplate(ctx, domain, name, &v1beta1.TemplateParameters{Description: c.description(cr)}); err != nil {
+		return errors.Wrap(err, errUpdateTemplate)
+	}
+	if domainContentUpToDate(cr, template) {
+		return nil
+	}
+
+	p := cr.Spec.ForProvider
+	version := &v1beta1.TemplateParameters{
+		Template: p.Template,
+		Comment:  p.Comment,
+	}
+	if template.ActiveVersion == nil || template.ActiveVersion.Tag == "" {
+		tag := contentVersionTag(cr)
+		version.Tag = &tag
+		version.Engine = p.Engine
+		_, err = c.client.CreateTemplateVersion(ctx, domain, name, version, true)
+		return errors.Wrap(err, errCreateVersion)
+	}
+	_, err = c.client.UpdateTemplateVersion(ctx, domain, name, template.ActiveVersion.Tag, version, true)
+	return errors.Wrap(err, errUpdateVersion)
+}
+
+// domainContentUpToDate reports whether the active version of the template
+// on one of spec.forProvider.domains has the desired content
+func domainContentUpToDate(cr *v1beta1.Template, template *v1beta1.TemplateObservation) bool {
+	desired := cr.Spec.ForProvider.Template
+	return desired == nil || template.ActiveContent == *desired
+}
+
+// deleteDomains deletes the template from each of spec.forProvider.domains.
+// It tries every domain and fails if any could not be deleted, so that the
+// template on Domain, which marks the resource as existing, goes last.
+func (c *external) deleteDomains(ctx context.Context, cr *v1beta1.Template) error {
+	var failed []string
+	var first error
+	for _, domain := range cr.Spec.ForProvider.Domains {
+		err := c.client.DeleteTemplate(ctx, domain, cr.Spec.ForProvider.Name)
+		if err != nil && !clients.IsNotFound(err) {
+			failed = append(failed, domain)
+			if first == nil {
+				first = err
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Wrapf(first, errDeleteDomains, strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// domainStatuses returns the recorded status of each domain by name
+func domainStatuses(cr *v1beta1.Template) map[string]v1beta1.TemplateDomainStatus {
+	statuses := make(map[string]v1beta1.TemplateDomainStatus, len(cr.Status.AtProvider.Domains))
+	for _, status := range cr.Status.AtProvider.Domains {
+		statuses[status.Domain] = status
+	}
+	return statuses
+}
+
+// setDomainsCondition reports the domains whose last reconcile failed in the
+// DomainsNotSynced condition
+func setDomainsCondition(cr *v1beta1.Template) {
+	var failed []string
+	for _, status := range cr.Status.AtProvider.Domains {
+		if status.Error != "" {
+			failed = append(failed, status.Domain)
+		}
+	}
+
+	switch {
+	case len(failed) > 0:
+		cr.SetConditions(v1beta1.DomainsNotSynced(failed))
+	case cr.GetCondition(v1beta1.TypeDomainsNotSynced).Status == corev1.ConditionTrue:
+		cr.SetConditions(v1beta1.DomainsSynced())
+	}
+}
@@ -18,13 +18,21 @@ package template
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -32,6 +40,18 @@ import (
 	v1beta1 "github.com/rossigee/provider-mailgun/apis/template/v1beta1"
 	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
 	"github.com/rossigee/provider-mailgun/internal/clients"
+	"github.com/rossigee/provider-mailgun/internal/description"
+	"github.com/rossigee/provider-mailgun/internal/eventlabel"
+	"github.com/rossigee/provider-mailgun/internal/failfast"
+	"github.com/rossigee/provider-mailgun/internal/features"
+	"github.com/rossigee/provider-mailgun/internal/immutable"
+	"github.com/rossigee/provider-mailgun/internal/metrics"
+	"github.com/rossigee/provider-mailgun/internal/observecache"
+	"github.com/rossigee/provider-mailgun/internal/ratelimit"
+	"github.com/rossigee/provider-mailgun/internal/readonly"
+	"github.com/rossigee/provider-mailgun/internal/shutdown"
+	"github.com/rossigee/provider-mailgun/internal/strictdelete"
+	"github.com/rossigee/provider-mailgun/internal/watchdog"
 )
 
 const (
@@ -44,22 +64,51 @@ const (
 	errGetTemplate    = "cannot get template"
 	errUpdateTemplate = "cannot update template"
 	errDeleteTemplate = "cannot delete template"
+	errCreateVersion  = "cannot create template version"
+	errGetVersion     = "cannot get template version"
+	errUpdateVersion  = "cannot update template version"
+	errDeleteVersion  = "cannot delete template version"
+	errExternalName   = "external-name %q must be <name>:<tag> with the name in spec.forProvider.name"
+
+	errPreviewVariables = "cannot parse render-preview variables"
+	errRenderPreview    = "cannot render template preview"
+
+	errEngineChangeNoContent = "cannot recreate template with a new engine: spec.forProvider.template is not set"
+)
+
+// maxPreviewLength bounds the rendered preview kept in status
+const maxPreviewLength = 1024
+
+// initialVersionTag is the tag Mailgun gives the first version of a template
+// created without one
+const initialVersionTag = "initial"
+
+const (
+	// maxVersions is the number of versions Mailgun allows per template
+	maxVersions = 40
+
+	// versionHeadroom is how many versions short of maxVersions a template
+	// is reported as approaching the limit
+	versionHeadroom = 5
 )
 
 // Setup adds a controller that reconciles Template managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(v1beta1.TemplateGroupKind.String())
 
+	conn := &connector{
+		kube:              mgr.GetClient(),
+		usage:             resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+		newServiceFn:      clients.NewClient,
+		propagateMetadata: o.Features.Enabled(features.EnableDescriptionMetadata),
+	}
+
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1beta1.TemplateGroupVersionKind),
-		managed.WithExternalConnector(&connector{
-			kube:         mgr.GetClient(),
-			usage:        resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
-			newServiceFn: clients.NewClient,
-		}),
+		managed.WithExternalConnector(failfast.Wrap(mgr.GetClient(), readonly.Wrap(shutdown.Wrap(strictdelete.Wrap(watchdog.Wrap(observecache.Wrap(ratelimit.Wrap(immutable.Wrap(conn, immutableFields))))))))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
+		managed.WithRecorder(eventlabel.Wrap(event.NewAPIRecorder(mgr.GetEventRecorder(name)))))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -69,12 +118,27 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		Complete(r)
 }
 
+// immutableFields returns the fields that identify the Mailgun template
+func immutableFields(mg resource.Managed) map[string]string {
+	cr, ok := mg.(*v1beta1.Template)
+	if !ok {
+		return nil
+	}
+	return map[string]string{
+		"spec.forProvider.domain": cr.Spec.ForProvider.Domain,
+		"spec.forProvider.name":   cr.Spec.ForProvider.Name,
+	}
+}
+
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
 	newServiceFn func(config *clients.Config) clients.Client
+
+	// propagateMetadata appends a managed-by tag to Mailgun descriptions
+	propagateMetadata bool
 }
 
 // Connect typically produces an ExternalClient by:
@@ -114,7 +178,7 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	}
 
 	cd := pc.Spec.Credentials
-	_, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	_, err := clients.ExtractCredentials(ctx, c.kube, cd)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
@@ -129,17 +193,23 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.New(errNewClient)
 	}
 
-	return &external{client: service}, nil
+	return &external{client: service, propagateMetadata: c.propagateMetadata, reconcile: metrics.NewReconcileTimer(v1beta1.TemplateKind)}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
 	client clients.Client
+
+	propagateMetadata bool
+
+	// reconcile times the work done by this client for one reconcile
+	reconcile *metrics.ReconcileTimer
 }
 
 func (c *external) Disconnect(ctx context.Context) error {
 	// No persistent connections to clean up
+	c.reconcile.Record()
 	return nil
 }
 
@@ -178,6 +248,8 @@ func (e *ExternalForTesting) Delete(ctx context.Context, mg resource.Managed) (m
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.Template)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotTemplate)
@@ -197,8 +269,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	cr.Status.AtProvider.CreatedAt = template.CreatedAt
 	cr.Status.AtProvider.CreatedBy = template.CreatedBy
 
-	// Update version count from observation
-	cr.Status.AtProvider.VersionCount = template.VersionCount
+	c.observeVersionCount(ctx, cr, template)
 
 	// Set active version if available
 	if template.ActiveVersion != nil {
@@ -211,8 +282,41 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		}
 	}
 
+	tag, err := versionTag(cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	c.renderPreview(ctx, cr, template.ActiveContent)
+
 	// Check if resource is up to date
-	upToDate := cr.Spec.ForProvider.Description == nil || *cr.Spec.ForProvider.Description == template.Description
+	desiredDescription := c.description(cr)
+	upToDate := desiredDescription == nil || *desiredDescription == template.Description
+
+	if tag != "" {
+		version, content, err := c.client.GetTemplateVersion(ctx, cr.Spec.ForProvider.Domain, cr.Spec.ForProvider.Name, tag)
+		if err != nil {
+			if clients.IsNotFound(err) {
+				return managed.ExternalObservation{ResourceExists: false}, nil
+			}
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetVersion)
+		}
+		cr.Status.AtProvider.Version = version
+		upToDate = upToDate && clients.VersionUpToDate(version, content, cr.Spec.ForProvider.Template, cr.Spec.ForProvider.Comment, activateVersion(cr))
+	} else if current, desired, changed := engineChange(cr); changed {
+		// An engine change can only be applied by creating a new version
+		if recreateOnEngineChange(cr) {
+			upToDate = false
+		} else {
+			cr.SetConditions(v1beta1.EngineChangeRejected(current, desired))
+		}
+	} else if cr.GetCondition(v1beta1.TypeEngineChangeRejected).Status == corev1.ConditionTrue {
+		cr.SetConditions(v1beta1.EngineInSync())
+	}
+
+	if tag == "" {
+		upToDate = c.observeDomains(ctx, cr) && activeContentUpToDate(cr, template) && upToDate
+	}
 
 	cr.SetConditions(xpv1.Available())
 
@@ -222,7 +326,33 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}, nil
 }
 
+// observeVersionCount records how many versions the template has, and warns
+// when it nears the number Mailgun allows. The count comes from the fetched
+// template when it carries its versions, and otherwise from listing them.
+// The count only feeds a warning, so a failed listing keeps the previous one
+// rather than failing the observation.
+func (c *external) observeVersionCount(ctx context.Context, cr *v1beta1.Template, template *v1beta1.TemplateObservation) {
+	count := template.VersionCount
+	if count == 0 {
+		versions, err := c.client.ListTemplateVersions(ctx, cr.Spec.ForProvider.Domain, cr.Spec.ForProvider.Name)
+		if err != nil {
+			return
+		}
+		count = len(versions)
+	}
+	cr.Status.AtProvider.VersionCount = count
+
+	switch {
+	case count >= maxVersions-versionHeadroom:
+		cr.SetConditions(v1beta1.VersionLimitApproaching(count, maxVersions))
+	case cr.GetCondition(v1beta1.TypeVersionLimitApproaching).Status == corev1.ConditionTrue:
+		cr.SetConditions(v1beta1.VersionLimitClear())
+	}
+}
+
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.Template)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotTemplate)
@@ -230,34 +360,256 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	cr.SetConditions(xpv1.Creating())
 
-	_, err := c.client.CreateTemplate(ctx, cr.Spec.ForProvider.Domain, &cr.Spec.ForProvider)
+	tag, err := versionTag(cr)
 	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := checkSyntax(cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	params := cr.Spec.ForProvider
+	params.Description = c.description(cr)
+
+	if tag != "" {
+		params.Tag = &tag
+		if _, err := clients.CreateVersion(ctx, c.client, params.Domain, params.Name, &params, activateVersion(cr)); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errCreateVersion)
+		}
+		return managed.ExternalCreation{}, nil
+	}
+
+	if _, err := c.client.CreateTemplate(ctx, params.Domain, &params); err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errCreateTemplate)
 	}
 
+	// The template exists once it is on Domain; the other domains are
+	// completed by later reconciles if any of them fails
+	c.syncDomains(ctx, cr)
+
 	return managed.ExternalCreation{}, nil
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.Template)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotTemplate)
 	}
 
-	// Only description can be updated for templates
+	tag, err := versionTag(cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := checkSyntax(cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if tag != "" {
+		version := &v1beta1.TemplateParameters{
+			Template: cr.Spec.ForProvider.Template,
+			Comment:  cr.Spec.ForProvider.Comment,
+		}
+		updated, err := c.client.UpdateTemplateVersion(ctx, cr.Spec.ForProvider.Domain, cr.Spec.ForProvider.Name, tag, version, activateVersion(cr))
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateVersion)
+		}
+		cr.Status.AtProvider.Version = updated
+	} else if _, desired, changed := engineChange(cr); changed && recreateOnEngineChange(cr) {
+		if cr.Spec.ForProvider.Template == nil {
+			return managed.ExternalUpdate{}, errors.New(errEngineChangeNoContent)
+		}
+		tag := fmt.Sprintf("%s-%d", desired, cr.GetGeneration())
+		version := &v1beta1.TemplateParameters{
+			Template: cr.Spec.ForProvider.Template,
+			Engine:   &desired,
+			Tag:      &tag,
+			Comment:  cr.Spec.ForProvider.Comment,
+		}
+		active, err := c.client.CreateTemplateVersion(ctx, cr.Spec.ForProvider.Domain, cr.Spec.ForProvider.Name, version, true)
+		if clients.IsAlreadyExists(err) {
+			// An earlier attempt for this generation created the version
+			// but did not get to activate it
+			version.Engine, version.Tag = nil, nil
+			active, err = c.client.UpdateTemplateVersion(ctx, cr.Spec.ForProvider.Domain, cr.Spec.ForProvider.Name, tag, version, true)
+		}
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errCreateVersion)
+		}
+		cr.Status.AtProvider.ActiveVersion = active
+		cr.Status.AtProvider.ContentVersionTag = tag
+		cr.SetConditions(v1beta1.EngineInSync())
+	} else if cr.Spec.ForProvider.Template != nil {
+		if err := c.updateActiveContent(ctx, cr); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+
+	// The content lives in versions; only the description can be updated
+	// on the template itself
 	updateParams := &v1beta1.TemplateParameters{
-		Description: cr.Spec.ForProvider.Description,
+		Description: c.description(cr),
 	}
 
-	_, err := c.client.UpdateTemplate(ctx, cr.Spec.ForProvider.Domain, cr.Spec.ForProvider.Name, updateParams)
-	if err != nil {
+	if _, err := c.client.UpdateTemplate(ctx, cr.Spec.ForProvider.Domain, cr.Spec.ForProvider.Name, updateParams); err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateTemplate)
 	}
 
+	if tag == "" {
+		c.syncDomains(ctx, cr)
+	}
+
 	return managed.ExternalUpdate{}, nil
 }
 
+// activeContentUpToDate reports whether the active version has the desired
+// content. An active version other than the one the Template writes to is
+// left alone, and reported by the ActiveVersionConflict condition.
+func activeContentUpToDate(cr *v1beta1.Template, template *v1beta1.TemplateObservation) bool {
+	desired := cr.Spec.ForProvider.Template
+	if desired == nil {
+		return true
+	}
+	active := cr.Status.AtProvider.ActiveVersion
+	if active == nil || active.Tag == "" {
+		return false
+	}
+	if !ownsActiveVersion(cr) {
+		cr.SetConditions(v1beta1.ActiveVersionConflict(active.Tag, contentVersionTag(cr)))
+		return true
+	}
+	if cr.GetCondition(v1beta1.TypeActiveVersionConflict).Status == corev1.ConditionTrue {
+		cr.SetConditions(v1beta1.ActiveVersionOwned())
+	}
+	return template.ActiveContent == *desired
+}
+
+// contentVersionTag returns the tag of the version the Template writes its
+// content to: the one it last wrote to, or else the one it was created with
+func contentVersionTag(cr *v1beta1.Template) string {
+	if tag := cr.Status.AtProvider.ContentVersionTag; tag != "" {
+		return tag
+	}
+	if tag := cr.Spec.ForProvider.Tag; tag != nil && *tag != "" {
+		return *tag
+	}
+	return initialVersionTag
+}
+
+// ownsActiveVersion reports whether the active version, if any, is the one
+// the Template writes its content to
+func ownsActiveVersion(cr *v1beta1.Template) bool {
+	active := cr.Status.AtProvider.ActiveVersion
+	return active == nil || active.Tag == "" || active.Tag == contentVersionTag(cr)
+}
+
+// updateActiveContent writes the desired content and comment to the active
+// version, or creates an active version with them if the template has none.
+// An active version the Template does not write to is left alone.
+func (c *external) updateActiveContent(ctx context.Context, cr *v1beta1.Template) error {
+	p := cr.Spec.ForProvider
+	version := &v1beta1.TemplateParameters{
+		Template: p.Template,
+		Comment:  p.Comment,
+	}
+
+	active := cr.Status.AtProvider.ActiveVersion
+	if !ownsActiveVersion(cr) {
+		return nil
+	}
+	if active == nil || active.Tag == "" {
+		tag := contentVersionTag(cr)
+		version.Tag = &tag
+		version.Engine = p.Engine
+		created, err := c.client.CreateTemplateVersion(ctx, p.Domain, p.Name, version, true)
+		if err != nil {
+			return errors.Wrap(err, errCreateVersion)
+		}
+		cr.Status.AtProvider.ActiveVersion = created
+		cr.Status.AtProvider.ContentVersionTag = tag
+		return nil
+	}
+
+	updated, err := c.client.UpdateTemplateVersion(ctx, p.Domain, p.Name, active.Tag, version, true)
+	if err != nil {
+		return errors.Wrap(err, errUpdateVersion)
+	}
+	cr.Status.AtProvider.ActiveVersion = updated
+	cr.Status.AtProvider.ContentVersionTag = active.Tag
+	return nil
+}
+
+// renderPreview renders the active version, whose content is given, with the
+// variables in the render-preview annotation and records the truncated
+// output in status. It only renders again once the variables or the content
+// have changed, or the last render failed. A failed preview is reported in
+// status and never fails the observation.
+func (c *external) renderPreview(ctx context.Context, cr *v1beta1.Template, content string) {
+	raw, ok := cr.GetAnnotations()[v1beta1.AnnotationRenderPreview]
+	if !ok {
+		cr.Status.AtProvider.RenderedPreview = ""
+		cr.Status.AtProvider.RenderPreviewError = ""
+		cr.Status.AtProvider.RenderedPreviewOf = ""
+		return
+	}
+
+	of := previewHash(raw, content)
+	if of == cr.Status.AtProvider.RenderedPreviewOf {
+		return
+	}
+	cr.Status.AtProvider.RenderedPreview = ""
+	cr.Status.AtProvider.RenderPreviewError = ""
+	cr.Status.AtProvider.RenderedPreviewOf = ""
+
+	var vars map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &vars); err != nil {
+		cr.Status.AtProvider.RenderPreviewError = errors.Wrap(err, errPreviewVariables).Error()
+		cr.Status.AtProvider.RenderedPreviewOf = of
+		return
+	}
+
+	rendered, err := c.client.RenderTemplate(ctx, cr.Spec.ForProvider.Domain, cr.Spec.ForProvider.Name, vars)
+	if err != nil {
+		cr.Status.AtProvider.RenderPreviewError = errors.Wrap(err, errRenderPreview).Error()
+		return
+	}
+	cr.Status.AtProvider.RenderedPreview = truncatePreview(rendered)
+	cr.Status.AtProvider.RenderedPreviewOf = of
+}
+
+// previewHash fingerprints the variables and content a preview is rendered
+// from
+func previewHash(vars, content string) string {
+	sum := sha256.Sum256([]byte(vars + "\x00" + content))
+	return hex.EncodeToString(sum[:])
+}
+
+// truncatePreview shortens s to maxPreviewLength bytes without splitting a
+// character
+func truncatePreview(s string) string {
+	if len(s) <= maxPreviewLength {
+		return s
+	}
+	cut := maxPreviewLength - len("...")
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "..."
+}
+
+// description returns the template description to send to Mailgun, with the
+// managed-by tag added when propagation is enabled
+func (c *external) description(cr *v1beta1.Template) *string {
+	if !c.propagateMetadata {
+		return cr.Spec.ForProvider.Description
+	}
+	return description.Compose(cr, cr.Spec.ForProvider.Description)
+}
+
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.Template)
 	if !ok {
 		return managed.ExternalDelete{}, errors.New(errNotTemplate)
@@ -265,10 +617,65 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	cr.SetConditions(xpv1.Deleting())
 
-	err := c.client.DeleteTemplate(ctx, cr.Spec.ForProvider.Domain, cr.Spec.ForProvider.Name)
+	tag, err := versionTag(cr)
 	if err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
+	if tag != "" {
+		err := c.client.DeleteTemplateVersion(ctx, cr.Spec.ForProvider.Domain, cr.Spec.ForProvider.Name, tag)
+		if err != nil && !clients.IsNotFound(err) {
+			return managed.ExternalDelete{}, errors.Wrap(err, errDeleteVersion)
+		}
+		return managed.ExternalDelete{}, nil
+	}
+
+	if err := c.deleteDomains(ctx, cr); err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
+	err = c.client.DeleteTemplate(ctx, cr.Spec.ForProvider.Domain, cr.Spec.ForProvider.Name)
+	if err != nil && !clients.IsNotFound(err) {
 		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteTemplate)
 	}
 
 	return managed.ExternalDelete{}, nil
 }
+
+// versionTag returns the tag of the version targeted by a <name>:<tag>
+// external-name, or "" when the Template manages the whole template. An
+// external-name without a colon, such as the default metadata.name, never
+// targets a version.
+func versionTag(cr *v1beta1.Template) (string, error) {
+	externalName := meta.GetExternalName(cr)
+	name, tag, ok := strings.Cut(externalName, ":")
+	if !ok {
+		return "", nil
+	}
+	if name != cr.Spec.ForProvider.Name || tag == "" {
+		return "", errors.Errorf(errExternalName, externalName)
+	}
+	return tag, nil
+}
+
+// activateVersion reports whether the targeted version should be active.
+func activateVersion(cr *v1beta1.Template) bool {
+	return cr.Spec.ForProvider.ActivateVersion != nil && *cr.Spec.ForProvider.ActivateVersion
+}
+
+// engineChange reports whether the desired engine differs from the engine of
+// the active version, returning both.
+func engineChange(cr *v1beta1.Template) (current, desired string, changed bool) {
+	if cr.Spec.ForProvider.Engine == nil || cr.Status.AtProvider.ActiveVersion == nil {
+		return "", "", false
+	}
+	current = cr.Status.AtProvider.ActiveVersion.Engine
+	desired = *cr.Spec.ForProvider.Engine
+	return current, desired, current != "" && current != desired
+}
+
+// recreateOnEngineChange reports whether engine changes may be applied by
+// creating a new version.
+func recreateOnEngineChange(cr *v1beta1.Template) bool {
+	return cr.Spec.ForProvider.RecreateOnEngineChange != nil && *cr.Spec.ForProvider.RecreateOnEngineChange
+}
@@ -18,33 +18,83 @@ package template
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	bouncetypes "github.com/rossigee/provider-mailgun/apis/bounce/v1beta1"
+	complainttypes "github.com/rossigee/provider-mailgun/apis/complaint/v1beta1"
 	domaintypes "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+	ippooltypes "github.com/rossigee/provider-mailgun/apis/ippool/v1beta1"
 	mailinglisttypes "github.com/rossigee/provider-mailgun/apis/mailinglist/v1beta1"
+	mailinglistmembertypes "github.com/rossigee/provider-mailgun/apis/mailinglistmember/v1beta1"
 	routetypes "github.com/rossigee/provider-mailgun/apis/route/v1beta1"
 	smtpcredentialtypes "github.com/rossigee/provider-mailgun/apis/smtpcredential/v1beta1"
 	v1beta1 "github.com/rossigee/provider-mailgun/apis/template/v1beta1"
+	unsubscribetypes "github.com/rossigee/provider-mailgun/apis/unsubscribe/v1beta1"
 	webhooktypes "github.com/rossigee/provider-mailgun/apis/webhook/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
 )
 
 // MockTemplateClient for testing
 type MockTemplateClient struct {
 	templates map[string]*v1beta1.TemplateObservation
+	versions  []*v1beta1.TemplateParameters
 	err       error
+
+	// domainErrs fails template creation on individual domains
+	domainErrs map[string]error
+
+	// tagged holds versions by domain/name/tag, with their content
+	tagged  map[string]*mockVersion
+	deleted []string
+
+	// render, when set, renders previews; renderVars records its input
+	render     func(vars map[string]interface{}) (string, error)
+	renderVars map[string]interface{}
+}
+
+type mockVersion struct {
+	version *v1beta1.TemplateVersion
+	content string
+}
+
+// tag records a version of a template, deactivating the others if it is
+// active
+func (m *MockTemplateClient) tag(domain, name string, version *v1beta1.TemplateVersion, content string) {
+	if m.tagged == nil {
+		m.tagged = make(map[string]*mockVersion)
+	}
+	prefix := domain + "/" + name + "/"
+	if version.Active {
+		for key, v := range m.tagged {
+			if strings.HasPrefix(key, prefix) && key != prefix+version.Tag {
+				v.version.Active = false
+			}
+		}
+	}
+	m.tagged[prefix+version.Tag] = &mockVersion{version: version, content: content}
 }
 
 func (m *MockTemplateClient) CreateTemplate(ctx context.Context, domain string, template *v1beta1.TemplateParameters) (*v1beta1.TemplateObservation, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
+	if err := m.domainErrs[domain]; err != nil {
+		return nil, err
+	}
 
 	key := domain + "/" + template.Name
 	result := &v1beta1.TemplateObservation{
@@ -58,14 +108,15 @@ func (m *MockTemplateClient) CreateTemplate(ctx context.Context, domain string,
 		result.Description = *template.Description
 	}
 
-	if template.Template != nil {
+	if template.Template != nil && template.Tag == nil {
 		result.ActiveVersion = &v1beta1.TemplateVersion{
-			Tag:       "v1.0",
+			Tag:       "initial",
 			Engine:    "mustache",
 			CreatedAt: "2025-01-01T00:00:00Z",
 			Comment:   "Initial version",
 			Active:    true,
 		}
+		m.tag(domain, template.Name, result.ActiveVersion, *template.Template)
 	}
 
 	if m.templates == nil {
@@ -73,6 +124,13 @@ func (m *MockTemplateClient) CreateTemplate(ctx context.Context, domain string,
 	}
 	m.templates[key] = result
 
+	if template.Tag != nil {
+		// The first version of a template is always active
+		result.ActiveVersion = &v1beta1.TemplateVersion{Tag: *template.Tag, Active: true, Comment: deref(template.Comment)}
+		m.tag(domain, template.Name, result.ActiveVersion, deref(template.Template))
+		result.VersionCount = 1
+	}
+
 	return result, nil
 }
 
@@ -83,6 +141,12 @@ func (m *MockTemplateClient) GetTemplate(ctx context.Context, domain, name strin
 
 	key := domain + "/" + name
 	if template, exists := m.templates[key]; exists {
+		// Like Mailgun with ?active=yes, the active content is returned
+		if template.ActiveVersion != nil {
+			if v, ok := m.tagged[key+"/"+template.ActiveVersion.Tag]; ok {
+				template.ActiveContent = v.content
+			}
+		}
 		return template, nil
 	}
 
@@ -115,6 +179,103 @@ func (m *MockTemplateClient) DeleteTemplate(ctx context.Context, domain, name st
 	return nil
 }
 
+func (m *MockTemplateClient) CreateTemplateVersion(ctx context.Context, domain, name string, version *v1beta1.TemplateParameters, active bool) (*v1beta1.TemplateVersion, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	existing, exists := m.templates[domain+"/"+name]
+	if !exists {
+		return nil, errors.New("template not found (404)")
+	}
+	if _, ok := m.tagged[domain+"/"+name+"/"+*version.Tag]; ok {
+		return nil, &clients.APIError{StatusCode: 400, Message: "template version " + *version.Tag + " already exists"}
+	}
+
+	m.versions = append(m.versions, version)
+	result := &v1beta1.TemplateVersion{
+		Tag:     *version.Tag,
+		Engine:  deref(version.Engine),
+		Comment: deref(version.Comment),
+		Active:  active,
+	}
+	existing.VersionCount++
+	if active {
+		existing.ActiveVersion = result
+	}
+	m.tag(domain, name, result, deref(version.Template))
+	return result, nil
+}
+
+func (m *MockTemplateClient) GetTemplateVersion(ctx context.Context, domain, name, tag string) (*v1beta1.TemplateVersion, string, error) {
+	if m.err != nil {
+		return nil, "", m.err
+	}
+	v, ok := m.tagged[domain+"/"+name+"/"+tag]
+	if !ok {
+		return nil, "", errors.New("template version not found (404)")
+	}
+	version := *v.version
+	return &version, v.content, nil
+}
+
+func (m *MockTemplateClient) UpdateTemplateVersion(ctx context.Context, domain, name, tag string, version *v1beta1.TemplateParameters, active bool) (*v1beta1.TemplateVersion, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	v, ok := m.tagged[domain+"/"+name+"/"+tag]
+	if !ok {
+		return nil, errors.New("template version not found (404)")
+	}
+	if version.Template != nil {
+		v.content = *version.Template
+	}
+	if version.Comment != nil {
+		v.version.Comment = *version.Comment
+	}
+	if active {
+		v.version.Active = true
+		m.tag(domain, name, v.version, v.content)
+		if existing, ok := m.templates[domain+"/"+name]; ok {
+			existing.ActiveVersion = v.version
+		}
+	}
+	updated := *v.version
+	return &updated, nil
+}
+
+func (m *MockTemplateClient) DeleteTemplateVersion(ctx context.Context, domain, name, tag string) error {
+	if m.err != nil {
+		return m.err
+	}
+	key := domain + "/" + name + "/" + tag
+	if _, ok := m.tagged[key]; !ok {
+		return errors.New("template version not found (404)")
+	}
+	delete(m.tagged, key)
+	m.deleted = append(m.deleted, key)
+	return nil
+}
+
+func (m *MockTemplateClient) ListTemplateVersions(ctx context.Context, domain, name string) ([]v1beta1.TemplateVersion, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	existing, ok := m.templates[domain+"/"+name]
+	if !ok {
+		return nil, errors.New("template not found (404)")
+	}
+	return make([]v1beta1.TemplateVersion, existing.VersionCount), nil
+}
+
+func (m *MockTemplateClient) RenderTemplate(ctx context.Context, domain, name string, vars map[string]interface{}) (string, error) {
+	m.renderVars = vars
+	if m.render == nil {
+		return "", errors.New("not implemented")
+	}
+	return m.render(vars)
+}
+
 // Implement other required client methods as no-ops
 func (m *MockTemplateClient) CreateDomain(ctx context.Context, domain *domaintypes.DomainParameters) (*domaintypes.DomainObservation, error) {
 	return nil, errors.New("not implemented")
@@ -132,6 +293,50 @@ func (m *MockTemplateClient) DeleteDomain(ctx context.Context, name string) erro
 	return errors.New("not implemented")
 }
 
+func (m *MockTemplateClient) ListAuthorizedRecipients(ctx context.Context) ([]domaintypes.AuthorizedRecipient, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockTemplateClient) AddAuthorizedRecipient(ctx context.Context, email string) (*domaintypes.AuthorizedRecipient, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockTemplateClient) DeleteAuthorizedRecipient(ctx context.Context, email string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockTemplateClient) GetWebhookSigningKey(ctx context.Context) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *MockTemplateClient) RotateWebhookSigningKey(ctx context.Context) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *MockTemplateClient) UpdateDomainTracking(ctx context.Context, name string, tracking *domaintypes.DomainTracking) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockTemplateClient) GetDomainTracking(ctx context.Context, name string) (*domaintypes.DomainTracking, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockTemplateClient) GetDomainConnection(ctx context.Context, name string) (*domaintypes.DomainConnection, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockTemplateClient) UpdateDomainConnection(ctx context.Context, name string, connection *domaintypes.DomainConnection) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockTemplateClient) UpdateDKIMKey(ctx context.Context, name string, keySize int) ([]domaintypes.DNSRecord, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockTemplateClient) ListDomains(ctx context.Context, limit, skip int) ([]*domaintypes.DomainObservation, int, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
 func (m *MockTemplateClient) CreateMailingList(ctx context.Context, list *mailinglisttypes.MailingListParameters) (*mailinglisttypes.MailingListObservation, error) {
 	return nil, errors.New("not implemented")
 }
@@ -148,6 +353,30 @@ func (m *MockTemplateClient) DeleteMailingList(ctx context.Context, address stri
 	return errors.New("not implemented")
 }
 
+func (m *MockTemplateClient) CreateMailingListMember(ctx context.Context, listAddress string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockTemplateClient) GetMailingListMember(ctx context.Context, listAddress, address string) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockTemplateClient) UpdateMailingListMember(ctx context.Context, listAddress, address string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockTemplateClient) DeleteMailingListMember(ctx context.Context, listAddress, address string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockTemplateClient) AddMailingListMembers(ctx context.Context, listAddress string, members []clients.MailingListMemberSpec, upsert bool) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockTemplateClient) ListMailingListMembers(ctx context.Context, listAddress string) ([]*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (m *MockTemplateClient) CreateRoute(ctx context.Context, route *routetypes.RouteParameters) (*routetypes.RouteObservation, error) {
 	return nil, errors.New("not implemented")
 }
@@ -164,6 +393,10 @@ func (m *MockTemplateClient) DeleteRoute(ctx context.Context, id string) error {
 	return errors.New("not implemented")
 }
 
+func (m *MockTemplateClient) ListRoutes(ctx context.Context, limit, skip int) ([]*routetypes.RouteObservation, int, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
 func (m *MockTemplateClient) CreateWebhook(ctx context.Context, domain string, webhook *webhooktypes.WebhookParameters) (*webhooktypes.WebhookObservation, error) {
 	return nil, errors.New("not implemented")
 }
@@ -210,11 +443,11 @@ func (m *MockTemplateClient) DeleteBounce(ctx context.Context, domain, address s
 }
 
 // Complaint suppression operations
-func (m *MockTemplateClient) CreateComplaint(ctx context.Context, domain string, complaint interface{}) (interface{}, error) {
+func (m *MockTemplateClient) CreateComplaint(ctx context.Context, domain string, complaint *complainttypes.ComplaintParameters) (*complainttypes.ComplaintObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockTemplateClient) GetComplaint(ctx context.Context, domain, address string) (interface{}, error) {
+func (m *MockTemplateClient) GetComplaint(ctx context.Context, domain, address string) (*complainttypes.ComplaintObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
@@ -223,15 +456,43 @@ func (m *MockTemplateClient) DeleteComplaint(ctx context.Context, domain, addres
 }
 
 // Unsubscribe suppression operations
-func (m *MockTemplateClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe interface{}) (interface{}, error) {
+func (m *MockTemplateClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe *unsubscribetypes.UnsubscribeParameters) (*unsubscribetypes.UnsubscribeObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockTemplateClient) GetUnsubscribe(ctx context.Context, domain, address string) (interface{}, error) {
+func (m *MockTemplateClient) GetUnsubscribe(ctx context.Context, domain, address string) (*unsubscribetypes.UnsubscribeObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockTemplateClient) DeleteUnsubscribe(ctx context.Context, domain, address string) error {
+func (m *MockTemplateClient) DeleteUnsubscribe(ctx context.Context, domain, address, tag string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockTemplateClient) CreateIPPool(ctx context.Context, pool *ippooltypes.IPPoolParameters) (*ippooltypes.IPPoolObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockTemplateClient) GetIPPool(ctx context.Context, id string) (*ippooltypes.IPPoolObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockTemplateClient) ListDomainIPs(ctx context.Context, name string) ([]string, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockTemplateClient) AssignDomainIP(ctx context.Context, name, ip string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockTemplateClient) UnassignDomainIP(ctx context.Context, name, ip string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockTemplateClient) UpdateIPPool(ctx context.Context, id string, pool *ippooltypes.IPPoolParameters, add, remove []string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockTemplateClient) DeleteIPPool(ctx context.Context, id string) error {
 	return errors.New("not implemented")
 }
 
@@ -660,9 +921,9 @@ func TestTemplateDeleteErrors(t *testing.T) {
 			expectErr: true,
 		},
 		"TemplateNotFound": {
-			reason:    "Should handle template not found during delete",
+			reason:    "Should treat template not found during delete as deleted",
 			mockErr:   errors.New("template not found (404)"),
-			expectErr: true, // Template controller doesn't handle 404 gracefully yet
+			expectErr: false,
 		},
 	}
 
@@ -866,7 +1127,679 @@ func TestTemplateInvalidManagedResource(t *testing.T) {
 	}
 }
 
-// Helper function
+func TestTemplateEngineChange(t *testing.T) {
+	newTemplate := func(recreate *bool) *v1beta1.Template {
+		return &v1beta1.Template{
+			ObjectMeta: metav1.ObjectMeta{Generation: 3},
+			Spec: v1beta1.TemplateSpec{
+				ForProvider: v1beta1.TemplateParameters{
+					Domain:                 "example.com",
+					Name:                   "welcome",
+					Template:               stringPtr("Hello {{name}}"),
+					Engine:                 stringPtr("handlebars"),
+					RecreateOnEngineChange: recreate,
+				},
+			},
+		}
+	}
+	newMock := func() *MockTemplateClient {
+		initial := &v1beta1.TemplateVersion{
+			Tag:    "initial",
+			Engine: "mustache",
+			Active: true,
+		}
+		m := &MockTemplateClient{
+			templates: map[string]*v1beta1.TemplateObservation{
+				"example.com/welcome": {
+					Name:          "welcome",
+					VersionCount:  1,
+					ActiveVersion: initial,
+				},
+			},
+		}
+		m.tag("example.com", "welcome", initial, "Hello {{name}}")
+		return m
+	}
+
+	t.Run("RejectedWithoutOptIn", func(t *testing.T) {
+		mockClient := newMock()
+		e := &external{client: mockClient}
+		cr := newTemplate(nil)
+
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate, "an engine change that cannot be applied should not trigger updates")
+
+		cond := cr.GetCondition(v1beta1.TypeEngineChangeRejected)
+		assert.Equal(t, corev1.ConditionTrue, cond.Status)
+		assert.Equal(t, v1beta1.ReasonEngineChangeNotAllowed, cond.Reason)
+		assert.Contains(t, cond.Message, "mustache")
+		assert.Contains(t, cond.Message, "handlebars")
+		assert.Empty(t, mockClient.versions)
+	})
+
+	t.Run("RecreatedWithOptIn", func(t *testing.T) {
+		mockClient := newMock()
+		e := &external{client: mockClient}
+		cr := newTemplate(boolPtr(true))
+
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate)
+		assert.Equal(t, corev1.ConditionUnknown, cr.GetCondition(v1beta1.TypeEngineChangeRejected).Status)
+
+		_, err = e.Update(context.Background(), cr)
+		require.NoError(t, err)
+		require.Len(t, mockClient.versions, 1)
+		assert.Equal(t, "handlebars", *mockClient.versions[0].Engine)
+		assert.Equal(t, "handlebars-3", *mockClient.versions[0].Tag)
+		assert.Equal(t, "Hello {{name}}", *mockClient.versions[0].Template)
+		assert.Equal(t, "handlebars", cr.Status.AtProvider.ActiveVersion.Engine)
+		assert.True(t, cr.Status.AtProvider.ActiveVersion.Active)
+
+		obs, err = e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.Equal(t, 2, cr.Status.AtProvider.VersionCount)
+	})
+
+	t.Run("RecreateRetried", func(t *testing.T) {
+		mockClient := newMock()
+		e := &external{client: mockClient}
+		cr := newTemplate(boolPtr(true))
+
+		// An earlier attempt created the version but failed before
+		// activating it
+		mockClient.tag("example.com", "welcome", &v1beta1.TemplateVersion{Tag: "handlebars-3", Engine: "handlebars"}, "Hello {{name}}")
+
+		_, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		_, err = e.Update(context.Background(), cr)
+		require.NoError(t, err, "a version left by an earlier attempt should be reused")
+		assert.Equal(t, "handlebars-3", cr.Status.AtProvider.ActiveVersion.Tag)
+		assert.True(t, cr.Status.AtProvider.ActiveVersion.Active)
+		assert.Equal(t, "handlebars-3", cr.Status.AtProvider.ContentVersionTag)
+	})
+
+	t.Run("RecreateRequiresContent", func(t *testing.T) {
+		e := &external{client: newMock()}
+		cr := newTemplate(boolPtr(true))
+		cr.Spec.ForProvider.Template = nil
+
+		_, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		_, err = e.Update(context.Background(), cr)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.forProvider.template")
+	})
+
+	t.Run("ConditionClearedOnceEnginesMatch", func(t *testing.T) {
+		e := &external{client: newMock()}
+		cr := newTemplate(nil)
+
+		_, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		cr.Spec.ForProvider.Engine = stringPtr("mustache")
+		_, err = e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(v1beta1.TypeEngineChangeRejected).Status)
+	})
+}
+
+func TestTemplateDescriptionMetadata(t *testing.T) {
+	mockClient := &MockTemplateClient{templates: make(map[string]*v1beta1.TemplateObservation)}
+	e := &external{client: mockClient, propagateMetadata: true}
+
+	cr := &v1beta1.Template{
+		Spec: v1beta1.TemplateSpec{
+			ForProvider: v1beta1.TemplateParameters{
+				Name:   "welcome",
+				Domain: "example.com",
+			},
+		},
+	}
+	cr.SetName("welcome")
+	cr.SetNamespace("mail")
+
+	_, err := e.Create(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, "managed-by: crossplane/mail/welcome", mockClient.templates["example.com/welcome"].Description)
+
+	cr.Spec.ForProvider.Description = stringPtr("Welcome email")
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate)
+
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, "Welcome email (managed-by: crossplane/mail/welcome)", mockClient.templates["example.com/welcome"].Description)
+
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+}
+
+func TestTemplateRenderPreview(t *testing.T) {
+	newTemplate := func(annotation string) *v1beta1.Template {
+		cr := &v1beta1.Template{
+			Spec: v1beta1.TemplateSpec{
+				ForProvider: v1beta1.TemplateParameters{Domain: "example.com", Name: "welcome"},
+			},
+		}
+		if annotation != "" {
+			cr.SetAnnotations(map[string]string{v1beta1.AnnotationRenderPreview: annotation})
+		}
+		return cr
+	}
+	mockClient := &MockTemplateClient{
+		templates: map[string]*v1beta1.TemplateObservation{
+			"example.com/welcome": {Name: "welcome"},
+		},
+		render: func(vars map[string]interface{}) (string, error) {
+			return fmt.Sprintf("<p>Hello %v</p>", vars["name"]), nil
+		},
+	}
+	e := &external{client: mockClient}
+
+	t.Run("Rendered", func(t *testing.T) {
+		cr := newTemplate(`{"name": "Alice"}`)
+		_, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"name": "Alice"}, mockClient.renderVars)
+		assert.Equal(t, "<p>Hello Alice</p>", cr.Status.AtProvider.RenderedPreview)
+		assert.Empty(t, cr.Status.AtProvider.RenderPreviewError)
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		cr := newTemplate(fmt.Sprintf(`{"name": %q}`, strings.Repeat("x", 2*maxPreviewLength)))
+		_, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Len(t, cr.Status.AtProvider.RenderedPreview, maxPreviewLength)
+		assert.True(t, strings.HasSuffix(cr.Status.AtProvider.RenderedPreview, "..."))
+	})
+
+	t.Run("InvalidVariables", func(t *testing.T) {
+		cr := newTemplate(`not json`)
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err, "a bad preview must not fail the observation")
+		assert.True(t, obs.ResourceExists)
+		assert.Contains(t, cr.Status.AtProvider.RenderPreviewError, errPreviewVariables)
+		assert.Empty(t, cr.Status.AtProvider.RenderedPreview)
+	})
+
+	t.Run("NoAnnotation", func(t *testing.T) {
+		cr := newTemplate("")
+		cr.Status.AtProvider.RenderedPreview = "stale"
+		_, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Empty(t, cr.Status.AtProvider.RenderedPreview, "removing the annotation should clear the preview")
+	})
+
+	t.Run("RenderedOnChange", func(t *testing.T) {
+		renders := 0
+		counting := &MockTemplateClient{
+			templates: map[string]*v1beta1.TemplateObservation{
+				"example.com/welcome": {Name: "welcome", ActiveContent: "<p>Hello {{name}}</p>"},
+			},
+			render: func(vars map[string]interface{}) (string, error) {
+				renders++
+				return fmt.Sprintf("<p>Hello %v</p>", vars["name"]), nil
+			},
+		}
+		e := &external{client: counting}
+		cr := newTemplate(`{"name": "Alice"}`)
+
+		for range 2 {
+			_, err := e.Observe(context.Background(), cr)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 1, renders, "an unchanged preview should not be rendered again")
+		assert.Equal(t, "<p>Hello Alice</p>", cr.Status.AtProvider.RenderedPreview)
+
+		cr.SetAnnotations(map[string]string{v1beta1.AnnotationRenderPreview: `{"name": "Bob"}`})
+		_, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, 2, renders, "new variables should be rendered")
+		assert.Equal(t, "<p>Hello Bob</p>", cr.Status.AtProvider.RenderedPreview)
+
+		counting.templates["example.com/welcome"].ActiveContent = "<p>Hi {{name}}</p>"
+		_, err = e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.Equal(t, 3, renders, "new content should be rendered")
+	})
+}
+
+func TestTemplateVersionExternalName(t *testing.T) {
+	mockClient := &MockTemplateClient{}
+	e := &external{client: mockClient}
+	ctx := context.Background()
+
+	_, err := mockClient.CreateTemplate(ctx, "example.com", &v1beta1.TemplateParameters{
+		Name:     "welcome",
+		Template: stringPtr("<p>Hello</p>"),
+		Tag:      stringPtr("v1"),
+	})
+	require.NoError(t, err)
+
+	newVersion := func(tag string, activate bool) *v1beta1.Template {
+		cr := &v1beta1.Template{
+			ObjectMeta: metav1.ObjectMeta{Name: "welcome-" + tag, Namespace: "default"},
+			Spec: v1beta1.TemplateSpec{ForProvider: v1beta1.TemplateParameters{
+				Domain:   "example.com",
+				Name:     "welcome",
+				Template: stringPtr("<p>Hello " + tag + "</p>"),
+				Comment:  stringPtr("Release " + tag),
+			}},
+		}
+		if activate {
+			cr.Spec.ForProvider.ActivateVersion = boolPtr(true)
+		}
+		meta.SetExternalName(cr, "welcome:"+tag)
+		return cr
+	}
+
+	t.Run("CreateAndObserve", func(t *testing.T) {
+		cr := newVersion("v2", true)
+
+		obs, err := e.Observe(ctx, cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists, "a missing version should be reported as missing")
+
+		_, err = e.Create(ctx, cr)
+		require.NoError(t, err)
+		require.Len(t, mockClient.versions, 1, "the version should be added to the existing template")
+		assert.Equal(t, "v2", *mockClient.versions[0].Tag)
+
+		obs, err = e.Observe(ctx, cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceExists)
+		assert.True(t, obs.ResourceUpToDate)
+		require.NotNil(t, cr.Status.AtProvider.Version)
+		assert.Equal(t, "v2", cr.Status.AtProvider.Version.Tag)
+		assert.True(t, cr.Status.AtProvider.Version.Active)
+		assert.False(t, mockClient.tagged["example.com/welcome/v1"].version.Active, "activating v2 should deactivate v1")
+	})
+
+	t.Run("UpdateContent", func(t *testing.T) {
+		cr := newVersion("v2", true)
+		cr.Spec.ForProvider.Template = stringPtr("<p>Hello again</p>")
+
+		obs, err := e.Observe(ctx, cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate, "changed content should be detected on the targeted version")
+
+		_, err = e.Update(ctx, cr)
+		require.NoError(t, err)
+		assert.Equal(t, "<p>Hello again</p>", mockClient.tagged["example.com/welcome/v2"].content)
+		assert.Equal(t, "<p>Hello</p>", mockClient.tagged["example.com/welcome/v1"].content, "other versions should be left alone")
+
+		obs, err = e.Observe(ctx, cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+	})
+
+	t.Run("Activate", func(t *testing.T) {
+		cr := newVersion("v3", false)
+		_, err := e.Create(ctx, cr)
+		require.NoError(t, err)
+
+		obs, err := e.Observe(ctx, cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+		assert.False(t, cr.Status.AtProvider.Version.Active)
+
+		cr.Spec.ForProvider.ActivateVersion = boolPtr(true)
+		obs, err = e.Observe(ctx, cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate, "a version that should be active but is not should be updated")
+
+		_, err = e.Update(ctx, cr)
+		require.NoError(t, err)
+		assert.True(t, cr.Status.AtProvider.Version.Active)
+		assert.Equal(t, "v3", mockClient.templates["example.com/welcome"].ActiveVersion.Tag)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		_, err := e.Delete(ctx, newVersion("v3", false))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"example.com/welcome/v3"}, mockClient.deleted)
+		assert.Contains(t, mockClient.templates, "example.com/welcome", "deleting a version should keep the template")
+		assert.Contains(t, mockClient.tagged, "example.com/welcome/v2")
+	})
+
+	t.Run("MissingTemplate", func(t *testing.T) {
+		cr := newVersion("v1", false)
+		cr.Spec.ForProvider.Name = "fresh"
+		meta.SetExternalName(cr, "fresh:v1")
+
+		_, err := e.Create(ctx, cr)
+		require.Error(t, err, "a version should not create the template it belongs to")
+		assert.Contains(t, err.Error(), "template fresh does not exist")
+		assert.NotContains(t, mockClient.templates, "example.com/fresh")
+	})
+
+	t.Run("MismatchedName", func(t *testing.T) {
+		cr := newVersion("v2", false)
+		meta.SetExternalName(cr, "other:v2")
+
+		_, err := e.Observe(ctx, cr)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "other:v2")
+	})
+}
+
+func TestTemplateMultipleDomains(t *testing.T) {
+	mockClient := &MockTemplateClient{
+		domainErrs: map[string]error{"c.example.com": errors.New("API request failed with status 500: internal error")},
+	}
+	e := &external{client: mockClient}
+	ctx := context.Background()
+
+	cr := &v1beta1.Template{
+		ObjectMeta: metav1.ObjectMeta{Name: "welcome", Namespace: "default"},
+		Spec: v1beta1.TemplateSpec{ForProvider: v1beta1.TemplateParameters{
+			Domain:      "example.com",
+			Domains:     []string{"b.example.com", "c.example.com"},
+			Name:        "welcome",
+			Description: stringPtr("Welcome email"),
+			Template:    stringPtr("<p>Hello</p>"),
+		}},
+	}
+
+	_, err := e.Create(ctx, cr)
+	require.NoError(t, err, "a failing additional domain should not fail creation")
+	assert.Contains(t, mockClient.templates, "example.com/welcome")
+	assert.Contains(t, mockClient.templates, "b.example.com/welcome")
+	assert.NotContains(t, mockClient.templates, "c.example.com/welcome")
+
+	require.Len(t, cr.Status.AtProvider.Domains, 2)
+	assert.True(t, cr.Status.AtProvider.Domains[0].Synced)
+	assert.False(t, cr.Status.AtProvider.Domains[1].Synced)
+	assert.Contains(t, cr.Status.AtProvider.Domains[1].Error, "internal error")
+	cond := cr.GetCondition(v1beta1.TypeDomainsNotSynced)
+	assert.Equal(t, corev1.ConditionTrue, cond.Status)
+	assert.Contains(t, cond.Message, "c.example.com")
+	assert.NotContains(t, cond.Message, "b.example.com")
+
+	obs, err := e.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+	assert.False(t, obs.ResourceUpToDate, "the missing domain should trigger an update")
+	assert.Contains(t, cr.Status.AtProvider.Domains[1].Error, "internal error", "the error should be kept until the domain is synced")
+
+	mockClient.domainErrs = nil
+	_, err = e.Update(ctx, cr)
+	require.NoError(t, err)
+	assert.Contains(t, mockClient.templates, "c.example.com/welcome")
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(v1beta1.TypeDomainsNotSynced).Status)
+
+	obs, err = e.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+	for _, status := range cr.Status.AtProvider.Domains {
+		assert.True(t, status.Synced, status.Domain)
+		assert.Empty(t, status.Error, status.Domain)
+	}
+
+	t.Run("DescriptionDrift", func(t *testing.T) {
+		mockClient.templates["b.example.com/welcome"].Description = "Edited by hand"
+
+		obs, err := e.Observe(ctx, cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate)
+
+		_, err = e.Update(ctx, cr)
+		require.NoError(t, err)
+		assert.Equal(t, "Welcome email", mockClient.templates["b.example.com/welcome"].Description)
+	})
+
+	t.Run("ContentChange", func(t *testing.T) {
+		cr.Spec.ForProvider.Template = stringPtr("<p>Hello again</p>")
+
+		obs, err := e.Observe(ctx, cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate)
+		for _, status := range cr.Status.AtProvider.Domains {
+			assert.False(t, status.Synced, "the content should be synced to %s", status.Domain)
+		}
+
+		_, err = e.Update(ctx, cr)
+		require.NoError(t, err)
+		for _, domain := range []string{"b.example.com", "c.example.com"} {
+			template, err := mockClient.GetTemplate(ctx, domain, "welcome")
+			require.NoError(t, err)
+			assert.Equal(t, "<p>Hello again</p>", template.ActiveContent, domain)
+		}
+
+		obs, err = e.Observe(ctx, cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		_, err := e.Delete(ctx, cr)
+		require.NoError(t, err)
+		assert.Empty(t, mockClient.templates, "the template should be deleted from every domain")
+	})
+}
+
+func TestTemplateVersionLimit(t *testing.T) {
+	ctx := context.Background()
+	observed := &v1beta1.TemplateObservation{Name: "welcome", VersionCount: 12}
+	mockClient := &MockTemplateClient{templates: map[string]*v1beta1.TemplateObservation{"example.com/welcome": observed}}
+	e := &external{client: mockClient}
+	cr := &v1beta1.Template{
+		Spec: v1beta1.TemplateSpec{ForProvider: v1beta1.TemplateParameters{Domain: "example.com", Name: "welcome"}},
+	}
+
+	_, err := e.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, 12, cr.Status.AtProvider.VersionCount)
+	assert.Equal(t, corev1.ConditionUnknown, cr.GetCondition(v1beta1.TypeVersionLimitApproaching).Status)
+
+	observed.VersionCount = maxVersions - versionHeadroom
+	_, err = e.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, maxVersions-versionHeadroom, cr.Status.AtProvider.VersionCount)
+	cond := cr.GetCondition(v1beta1.TypeVersionLimitApproaching)
+	assert.Equal(t, corev1.ConditionTrue, cond.Status)
+	assert.Equal(t, v1beta1.ReasonNearVersionLimit, cond.Reason)
+	assert.Contains(t, cond.Message, "35 of at most 40")
+
+	// Pruning versions clears the warning
+	observed.VersionCount = 20
+	_, err = e.Observe(ctx, cr)
+	require.NoError(t, err)
+	cond = cr.GetCondition(v1beta1.TypeVersionLimitApproaching)
+	assert.Equal(t, corev1.ConditionFalse, cond.Status)
+	assert.Equal(t, v1beta1.ReasonBelowVersionLimit, cond.Reason)
+}
+
+// versionListFailingClient fails to list template versions
+type versionListFailingClient struct {
+	*MockTemplateClient
+}
+
+func (m *versionListFailingClient) ListTemplateVersions(ctx context.Context, domain, name string) ([]v1beta1.TemplateVersion, error) {
+	return nil, errors.New("API request failed with status 503: unavailable")
+}
+
+func TestTemplateVersionCountBestEffort(t *testing.T) {
+	mockClient := &MockTemplateClient{templates: map[string]*v1beta1.TemplateObservation{"example.com/welcome": {Name: "welcome"}}}
+	e := &external{client: &versionListFailingClient{mockClient}}
+	cr := &v1beta1.Template{
+		Spec: v1beta1.TemplateSpec{ForProvider: v1beta1.TemplateParameters{Domain: "example.com", Name: "welcome"}},
+	}
+	cr.Status.AtProvider.VersionCount = 7
+
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err, "a failed version listing should not fail the observation")
+	assert.True(t, obs.ResourceExists)
+	assert.Equal(t, 7, cr.Status.AtProvider.VersionCount, "the previous count should be kept")
+}
+
+func TestTemplateResolveDomainReference(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, domaintypes.SchemeBuilder.AddToScheme(scheme))
+	newDomain := func(name, mailgunName string, ready bool) *domaintypes.Domain {
+		d := &domaintypes.Domain{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "mail", Labels: map[string]string{"team": name}},
+			Spec:       domaintypes.DomainSpec{ForProvider: domaintypes.DomainParameters{Name: mailgunName}},
+		}
+		if ready {
+			d.SetConditions(xpv1.Available())
+		}
+		return d
+	}
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newDomain("ready", "mg.example.com", true),
+		newDomain("pending", "pending.example.com", false),
+	).Build()
+
+	cases := map[string]struct {
+		domain   string
+		ref      *xpv1.NamespacedReference
+		selector *xpv1.NamespacedSelector
+		want     string
+		err      string
+	}{
+		"Reference":     {ref: &xpv1.NamespacedReference{Name: "ready"}, want: "mg.example.com"},
+		"Selector":      {selector: &xpv1.NamespacedSelector{MatchLabels: map[string]string{"team": "ready"}}, want: "mg.example.com"},
+		"DomainSet":     {domain: "other.example.com", ref: &xpv1.NamespacedReference{Name: "ready"}, want: "other.example.com"},
+		"DomainOnly":    {domain: "other.example.com", want: "other.example.com"},
+		"NotReady":      {ref: &xpv1.NamespacedReference{Name: "pending"}, err: "referenced field was empty"},
+		"MissingDomain": {ref: &xpv1.NamespacedReference{Name: "absent"}, err: "cannot get referenced resource"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1beta1.Template{ObjectMeta: metav1.ObjectMeta{Name: "welcome", Namespace: "mail"}}
+			cr.Spec.ForProvider = v1beta1.TemplateParameters{Domain: tc.domain, DomainRef: tc.ref, DomainSelector: tc.selector, Name: "welcome"}
+
+			err := cr.ResolveReferences(context.Background(), kube)
+			if tc.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, cr.Spec.ForProvider.Domain)
+			if tc.selector != nil {
+				require.NotNil(t, cr.Spec.ForProvider.DomainRef, "the selected Domain should be referenced")
+				assert.Equal(t, "ready", cr.Spec.ForProvider.DomainRef.Name)
+			}
+		})
+	}
+}
+
+func TestTemplateContentUpdate(t *testing.T) {
+	mockClient := &MockTemplateClient{}
+	e := &external{client: mockClient}
+	cr := &v1beta1.Template{
+		Spec: v1beta1.TemplateSpec{
+			ForProvider: v1beta1.TemplateParameters{
+				Domain:   "example.com",
+				Name:     "welcome",
+				Template: stringPtr("<p>Hello {{name}}</p>"),
+			},
+		},
+	}
+
+	_, err := e.Create(context.Background(), cr)
+	require.NoError(t, err)
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+
+	// Editing the body is drift, fixed by writing it to the active version
+	cr.Spec.ForProvider.Template = stringPtr("<p>Hi {{name}}</p>")
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate, "a changed body should be reported as drift")
+
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, "<p>Hi {{name}}</p>", mockClient.tagged["example.com/welcome/initial"].content)
+	assert.Empty(t, mockClient.versions, "the active version should be updated in place")
+
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+
+	// A template without versions gets an active one with the body
+	delete(mockClient.tagged, "example.com/welcome/initial")
+	mockClient.templates["example.com/welcome"].ActiveVersion = nil
+	cr.Status.AtProvider.ActiveVersion = nil
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate)
+
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	require.Len(t, mockClient.versions, 1)
+	assert.Equal(t, "initial", *mockClient.versions[0].Tag)
+	assert.Equal(t, "<p>Hi {{name}}</p>", mockClient.tagged["example.com/welcome/initial"].content)
+	assert.True(t, cr.Status.AtProvider.ActiveVersion.Active)
+}
+
+func TestTemplateContentLeavesOtherActiveVersion(t *testing.T) {
+	mockClient := &MockTemplateClient{}
+	e := &external{client: mockClient}
+	cr := &v1beta1.Template{
+		Spec: v1beta1.TemplateSpec{
+			ForProvider: v1beta1.TemplateParameters{
+				Domain:   "example.com",
+				Name:     "welcome",
+				Template: stringPtr("<p>Hello {{name}}</p>"),
+			},
+		},
+	}
+
+	_, err := e.Create(context.Background(), cr)
+	require.NoError(t, err)
+
+	// A TemplateVersion activates a version of its own
+	promo := &v1beta1.TemplateVersion{Tag: "promo", Engine: "mustache", Active: true}
+	mockClient.tag("example.com", "welcome", promo, "<p>Sale!</p>")
+	mockClient.templates["example.com/welcome"].ActiveVersion = promo
+
+	obs, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate, "content of a version the Template does not write to is not drift")
+	cond := cr.GetCondition(v1beta1.TypeActiveVersionConflict)
+	assert.Equal(t, corev1.ConditionTrue, cond.Status)
+	assert.Contains(t, cond.Message, `"promo"`)
+	assert.Contains(t, cond.Message, `"initial"`)
+
+	// Updates made for other drift leave the active version alone
+	cr.Spec.ForProvider.Description = stringPtr("Welcome mail")
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, "<p>Sale!</p>", mockClient.tagged["example.com/welcome/promo"].content)
+	assert.Empty(t, mockClient.versions)
+
+	// Activating the Template's version again resumes content management
+	initial := mockClient.tagged["example.com/welcome/initial"].version
+	mockClient.tag("example.com", "welcome", initial, mockClient.tagged["example.com/welcome/initial"].content)
+	initial.Active = true
+	mockClient.templates["example.com/welcome"].ActiveVersion = initial
+	obs, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(v1beta1.TypeActiveVersionConflict).Status)
+}
+
+// Helper functions
 func stringPtr(s string) *string {
 	return &s
 }
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/template/v1beta1"
+)
+
+const (
+	errInvalidTemplate = "template content is invalid"
+
+	errUnopenedTag     = "line %d: }} without a matching {{"
+	errUnclosedTag     = "line %d: {{ is not closed"
+	errUnclosedTriple  = "line %d: {{{ must be closed with }}}"
+	errUnopenedSection = "line %d: {{/%s}} closes a section that is not open"
+	errMismatchSection = "line %d: {{/%s}} closes {{#%s}} opened on line %d"
+	errUnclosedSection = "line %d: {{#%s}} is never closed"
+)
+
+const engineHandlebars = "handlebars"
+
+// validateSyntax reports the first syntax error in a template body: a {{ or
+// }} without its counterpart, or a section that is not closed in order.
+// Handlebars comments may contain }}, so {{!-- --}} is read as one tag. A
+// mustache template that changes its delimiters is only checked up to the
+// change.
+func validateSyntax(engine, body string) error {
+	type section struct {
+		name string
+		line int
+	}
+	var open []section
+
+	lineAt := func(i int) int { return strings.Count(body[:i], "\n") + 1 }
+
+	for i := 0; i < len(body); {
+		start := strings.Index(body[i:], "{{")
+		if start < 0 {
+			start = len(body) - i
+		}
+		if stray := strings.Index(body[i:i+start], "}}"); stray >= 0 {
+			return errors.Errorf(errUnopenedTag, lineAt(i+stray))
+		}
+		start += i
+		if start == len(body) {
+			break
+		}
+
+		closing := "}}"
+		if engine == engineHandlebars && strings.HasPrefix(body[start:], "{{!--") {
+			closing = "--}}"
+		}
+		end := strings.Index(body[start+2:], closing)
+		tag := ""
+		if end >= 0 {
+			tag = body[start+2 : start+2+end]
+		}
+		if end < 0 || (closing == "}}" && strings.Contains(tag, "{{")) {
+			return errors.Errorf(errUnclosedTag, lineAt(start))
+		}
+		i = start + 2 + end + len(closing)
+
+		if strings.HasPrefix(tag, "{") {
+			if i >= len(body) || body[i] != '}' {
+				return errors.Errorf(errUnclosedTriple, lineAt(start))
+			}
+			i++
+			continue
+		}
+
+		tag = strings.TrimSpace(strings.Trim(tag, "~"))
+		if tag == "" {
+			continue
+		}
+		if engine != engineHandlebars && tag[0] == '=' {
+			// {{=<% %>=}} switches to delimiters this check does not track
+			return nil
+		}
+
+		switch tag[0] {
+		case '#', '^':
+			// A bare {{^}} is the handlebars form of {{else}}
+			if name := sectionName(tag[1:]); name != "" {
+				open = append(open, section{name: name, line: lineAt(start)})
+			}
+		case '/':
+			name := sectionName(tag[1:])
+			if len(open) == 0 {
+				return errors.Errorf(errUnopenedSection, lineAt(start), name)
+			}
+			top := open[len(open)-1]
+			if top.name != name {
+				return errors.Errorf(errMismatchSection, lineAt(start), name, top.name, top.line)
+			}
+			open = open[:len(open)-1]
+		}
+	}
+
+	if len(open) > 0 {
+		top := open[len(open)-1]
+		return errors.Errorf(errUnclosedSection, top.line, top.name)
+	}
+	return nil
+}
+
+// sectionName returns the name a section tag is opened or closed with: its
+// first word, without the markers of handlebars partial and decorator blocks
+func sectionName(tag string) string {
+	fields := strings.Fields(strings.TrimLeft(tag, ">*"))
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// checkSyntax validates the content of cr before it is submitted, setting
+// the TemplateInvalid condition from the result
+func checkSyntax(cr *v1beta1.Template) error {
+	params := cr.Spec.ForProvider
+	if params.Template == nil || (params.SkipValidation != nil && *params.SkipValidation) {
+		return nil
+	}
+
+	engine := "mustache"
+	if params.Engine != nil {
+		engine = *params.Engine
+	}
+	if err := validateSyntax(engine, *params.Template); err != nil {
+		cr.SetConditions(v1beta1.TemplateInvalid(err.Error()))
+		return errors.Wrap(err, errInvalidTemplate)
+	}
+	if cr.GetCondition(v1beta1.TypeTemplateInvalid).Status == corev1.ConditionTrue {
+		cr.SetConditions(v1beta1.TemplateValid())
+	}
+	return nil
+}
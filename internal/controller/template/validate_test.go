@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/template/v1beta1"
+)
+
+func TestValidateSyntax(t *testing.T) {
+	cases := map[string]struct {
+		engine  string
+		body    string
+		wantErr string
+	}{
+		"PlainText":        {engine: "mustache", body: "<p>Hello</p>"},
+		"Variables":        {engine: "mustache", body: "<p>Hello {{name}}, {{{html}}} {{& raw}}</p>"},
+		"Sections":         {engine: "mustache", body: "{{#items}}<li>{{name}}</li>{{/items}}{{^items}}none{{/items}}"},
+		"NestedSections":   {engine: "mustache", body: "{{#a}}{{#b}}x{{/b}}{{/a}}"},
+		"Comment":          {engine: "mustache", body: "{{! a comment }}<p>Hi</p>"},
+		"Partial":          {engine: "mustache", body: "{{> footer}}"},
+		"DelimiterChange":  {engine: "mustache", body: "{{=<% %>=}}<% name %> {{"},
+		"HandlebarsBlocks": {engine: "handlebars", body: "{{#if vip}}VIP{{else}}{{#each items as |item|}}{{item}}{{/each}}{{/if}}"},
+		"HandlebarsElse":   {engine: "handlebars", body: "{{#if a}}x{{^}}y{{/if}}"},
+		"HandlebarsTrim":   {engine: "handlebars", body: "{{~#if a~}} x {{~/if~}}"},
+		"HandlebarsComment": {
+			engine: "handlebars",
+			body:   "{{!-- a }} inside a comment --}}<p>Hi</p>",
+		},
+
+		"UnclosedTag": {
+			engine:  "mustache",
+			body:    "<p>Hello</p>\n<p>{{name</p>",
+			wantErr: "line 2: {{ is not closed",
+		},
+		"TagOpenedTwice": {
+			engine:  "mustache",
+			body:    "{{first {{second}}",
+			wantErr: "line 1: {{ is not closed",
+		},
+		"UnopenedTag": {
+			engine:  "mustache",
+			body:    "<p>Hello name}}</p>",
+			wantErr: "line 1: }} without a matching {{",
+		},
+		"UnclosedTriple": {
+			engine:  "mustache",
+			body:    "{{{html}}",
+			wantErr: "line 1: {{{ must be closed with }}}",
+		},
+		"UnclosedSection": {
+			engine:  "mustache",
+			body:    "{{#items}}\n<li>{{name}}</li>",
+			wantErr: "line 1: {{#items}} is never closed",
+		},
+		"MismatchedSection": {
+			engine:  "mustache",
+			body:    "{{#a}}\n{{#b}}\n{{/a}}",
+			wantErr: "line 3: {{/a}} closes {{#b}} opened on line 2",
+		},
+		"UnopenedSection": {
+			engine:  "handlebars",
+			body:    "{{/if}}",
+			wantErr: "line 1: {{/if}} closes a section that is not open",
+		},
+		"MustacheHasNoBlockComments": {
+			engine:  "mustache",
+			body:    "{{!-- a }} inside --}}",
+			wantErr: "line 1: }} without a matching {{",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validateSyntax(tc.engine, tc.body)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, tc.wantErr, err.Error())
+		})
+	}
+}
+
+func TestTemplateSyntaxCondition(t *testing.T) {
+	mockClient := &MockTemplateClient{}
+	e := &external{client: mockClient}
+	ctx := context.Background()
+
+	cr := &v1beta1.Template{
+		ObjectMeta: metav1.ObjectMeta{Name: "welcome", Namespace: "default"},
+		Spec: v1beta1.TemplateSpec{ForProvider: v1beta1.TemplateParameters{
+			Domain:   "example.com",
+			Name:     "welcome",
+			Template: stringPtr("<p>Hello {{name</p>"),
+		}},
+	}
+
+	_, err := e.Create(ctx, cr)
+	require.Error(t, err)
+	assert.Empty(t, mockClient.templates, "invalid content should not be submitted")
+	invalid := cr.GetCondition(v1beta1.TypeTemplateInvalid)
+	assert.Equal(t, corev1.ConditionTrue, invalid.Status)
+	assert.Equal(t, v1beta1.ReasonSyntaxError, invalid.Reason)
+	assert.Equal(t, "line 1: {{ is not closed", invalid.Message)
+
+	cr.Spec.ForProvider.Template = stringPtr("<p>Hello {{name}}</p>")
+	_, err = e.Create(ctx, cr)
+	require.NoError(t, err)
+	assert.Contains(t, mockClient.templates, "example.com/welcome")
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(v1beta1.TypeTemplateInvalid).Status)
+
+	t.Run("SkipValidation", func(t *testing.T) {
+		cr := cr.DeepCopy()
+		cr.Spec.ForProvider.Name = "custom"
+		cr.Spec.ForProvider.Template = stringPtr("<p>{{name</p>")
+		cr.Spec.ForProvider.SkipValidation = boolPtr(true)
+
+		_, err := e.Create(ctx, cr)
+		require.NoError(t, err)
+		assert.Contains(t, mockClient.templates, "example.com/custom")
+	})
+}
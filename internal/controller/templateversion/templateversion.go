@@ -0,0 +1,303 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templateversion
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	templatetypes "github.com/rossigee/provider-mailgun/apis/template/v1beta1"
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/templateversion/v1beta1"
+	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
+	"github.com/rossigee/provider-mailgun/internal/eventlabel"
+	"github.com/rossigee/provider-mailgun/internal/failfast"
+	"github.com/rossigee/provider-mailgun/internal/immutable"
+	"github.com/rossigee/provider-mailgun/internal/metrics"
+	"github.com/rossigee/provider-mailgun/internal/observecache"
+	"github.com/rossigee/provider-mailgun/internal/ratelimit"
+	"github.com/rossigee/provider-mailgun/internal/readonly"
+	"github.com/rossigee/provider-mailgun/internal/shutdown"
+	"github.com/rossigee/provider-mailgun/internal/strictdelete"
+	"github.com/rossigee/provider-mailgun/internal/watchdog"
+)
+
+const (
+	errNotTemplateVersion = "managed resource is not a TemplateVersion custom resource"
+	errTrackPCUsage       = "cannot track ProviderConfig usage"
+	errGetCreds           = "cannot get credentials"
+	errNewClient          = "cannot create new Service"
+	errCreateVersion      = "cannot create template version"
+	errGetVersion         = "cannot get template version"
+	errUpdateVersion      = "cannot update template version"
+	errDeleteVersion      = "cannot delete template version"
+)
+
+// Setup adds a controller that reconciles TemplateVersion managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1beta1.TemplateVersionGroupKind.String())
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1beta1.TemplateVersionGroupVersionKind),
+		managed.WithExternalConnector(failfast.Wrap(mgr.GetClient(), readonly.Wrap(shutdown.Wrap(strictdelete.Wrap(watchdog.Wrap(observecache.Wrap(ratelimit.Wrap(immutable.Wrap(&connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			newServiceFn: clients.NewClient,
+		}, immutableFields))))))))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(eventlabel.Wrap(event.NewAPIRecorder(mgr.GetEventRecorder(name)))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1beta1.TemplateVersion{}).
+		Complete(r)
+}
+
+// immutableFields returns the fields that identify the Mailgun template
+// version
+func immutableFields(mg resource.Managed) map[string]string {
+	cr, ok := mg.(*v1beta1.TemplateVersion)
+	if !ok {
+		return nil
+	}
+	return map[string]string{
+		"spec.forProvider.domain":       cr.Spec.ForProvider.Domain,
+		"spec.forProvider.templateName": cr.Spec.ForProvider.TemplateName,
+		"spec.forProvider.tag":          cr.Spec.ForProvider.Tag,
+	}
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	newServiceFn func(config *clients.Config) clients.Client
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.TemplateVersion)
+	if !ok {
+		return nil, errors.New(errNotTemplateVersion)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1beta1.ProviderConfig{}
+	pcRef := cr.GetProviderConfigReference()
+
+	// Handle case where no providerConfigRef is specified - default to "default"
+	pcName := "default"
+	if pcRef != nil && pcRef.Name != "" {
+		pcName = pcRef.Name
+	}
+
+	// Try namespaced lookup first (ProviderConfig CRD is scope: Namespaced)
+	pcNamespace := cr.GetNamespace()
+	pcErr := c.kube.Get(ctx, types.NamespacedName{Name: pcName, Namespace: pcNamespace}, pc)
+	if pcErr != nil {
+		// If namespaced lookup fails, try cluster-scoped as fallback
+		clusterErr := c.kube.Get(ctx, types.NamespacedName{Name: pcName}, pc)
+		if clusterErr != nil {
+			// Both lookups failed, return detailed error
+			return nil, errors.Wrapf(pcErr, "cannot get ProviderConfig '%s': tried namespaced lookup in '%s' and cluster-scoped lookup", pcName, pcNamespace)
+		}
+	}
+
+	cd := pc.Spec.Credentials
+	_, err := clients.ExtractCredentials(ctx, c.kube, cd)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	service := c.newServiceFn(config)
+	if service == nil {
+		return nil, errors.New(errNewClient)
+	}
+
+	return &external{service: service, reconcile: metrics.NewReconcileTimer(v1beta1.TemplateVersionKind)}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	service clients.Client
+
+	// reconcile times the work done by this client for one reconcile
+	reconcile *metrics.ReconcileTimer
+}
+
+func (c *external) Disconnect(ctx context.Context) error {
+	// No persistent connections to clean up
+	c.reconcile.Record()
+	return nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	defer c.reconcile.Start()()
+
+	cr, ok := mg.(*v1beta1.TemplateVersion)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTemplateVersion)
+	}
+
+	// Use the version tag as the external name
+	if meta.GetExternalName(cr) == "" {
+		meta.SetExternalName(cr, cr.Spec.ForProvider.Tag)
+	}
+
+	p := cr.Spec.ForProvider
+	version, content, err := c.service.GetTemplateVersion(ctx, p.Domain, p.TemplateName, meta.GetExternalName(cr))
+	if err != nil {
+		if clients.IsNotFound(err) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetVersion)
+	}
+
+	cr.Status.AtProvider = observation(version)
+
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: clients.VersionUpToDate(version, content, &p.Template, p.Comment, isActive(&p)),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	defer c.reconcile.Start()()
+
+	cr, ok := mg.(*v1beta1.TemplateVersion)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTemplateVersion)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+
+	p := cr.Spec.ForProvider
+	params := &templatetypes.TemplateParameters{
+		Name:     p.TemplateName,
+		Template: &p.Template,
+		Engine:   p.Engine,
+		Comment:  p.Comment,
+		Tag:      &p.Tag,
+	}
+
+	version, err := clients.CreateVersion(ctx, c.service, p.Domain, p.TemplateName, params, isActive(&p))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateVersion)
+	}
+	cr.Status.AtProvider = observation(version)
+
+	meta.SetExternalName(cr, p.Tag)
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	defer c.reconcile.Start()()
+
+	cr, ok := mg.(*v1beta1.TemplateVersion)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTemplateVersion)
+	}
+
+	// Only the content and comment of a version can be changed
+	p := cr.Spec.ForProvider
+	params := &templatetypes.TemplateParameters{
+		Template: &p.Template,
+		Comment:  p.Comment,
+	}
+	version, err := c.service.UpdateTemplateVersion(ctx, p.Domain, p.TemplateName, meta.GetExternalName(cr), params, isActive(&p))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateVersion)
+	}
+
+	cr.Status.AtProvider = observation(version)
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	defer c.reconcile.Start()()
+
+	cr, ok := mg.(*v1beta1.TemplateVersion)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotTemplateVersion)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+
+	tag := meta.GetExternalName(cr)
+	if tag == "" {
+		tag = cr.Spec.ForProvider.Tag
+	}
+
+	// A version whose template is already gone was deleted along with it
+	err := c.service.DeleteTemplateVersion(ctx, cr.Spec.ForProvider.Domain, cr.Spec.ForProvider.TemplateName, tag)
+	if err != nil && !clients.IsNotFound(err) {
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteVersion)
+	}
+
+	return managed.ExternalDelete{}, nil
+}
+
+// observation converts a version returned by the client, which may be nil
+// when Mailgun did not echo it, to the observed state of a TemplateVersion
+func observation(version *templatetypes.TemplateVersion) v1beta1.TemplateVersionObservation {
+	if version == nil {
+		return v1beta1.TemplateVersionObservation{}
+	}
+	return v1beta1.TemplateVersionObservation{
+		Tag:       version.Tag,
+		Engine:    version.Engine,
+		CreatedAt: version.CreatedAt,
+		Comment:   version.Comment,
+		Active:    version.Active,
+	}
+}
+
+// isActive reports whether the version should be the active one
+func isActive(p *v1beta1.TemplateVersionParameters) bool {
+	return p.Active != nil && *p.Active
+}
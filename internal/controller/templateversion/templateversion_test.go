@@ -0,0 +1,211 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templateversion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	templatetypes "github.com/rossigee/provider-mailgun/apis/template/v1beta1"
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/templateversion/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
+)
+
+// version is a template version held by versionClient
+type version struct {
+	templatetypes.TemplateVersion
+	content string
+}
+
+// versionClient keeps the versions of the templates of one domain, keyed by
+// template name and then tag
+type versionClient struct {
+	clients.Client
+	templates map[string]map[string]*version
+
+	updates []*templatetypes.TemplateParameters
+}
+
+func (c *versionClient) CreateTemplateVersion(ctx context.Context, domain, name string, t *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	if _, ok := c.templates[name]; !ok {
+		return nil, errors.New("template not found (404)")
+	}
+	return &c.add(name, t, active).TemplateVersion, nil
+}
+
+func (c *versionClient) GetTemplateVersion(ctx context.Context, domain, name, tag string) (*templatetypes.TemplateVersion, string, error) {
+	v, ok := c.templates[name][tag]
+	if !ok {
+		return nil, "", errors.New("template version not found (404)")
+	}
+	observed := v.TemplateVersion
+	return &observed, v.content, nil
+}
+
+func (c *versionClient) UpdateTemplateVersion(ctx context.Context, domain, name, tag string, t *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	c.updates = append(c.updates, t)
+	v, ok := c.templates[name][tag]
+	if !ok {
+		return nil, errors.New("template version not found (404)")
+	}
+	v.content = *t.Template
+	if t.Comment != nil {
+		v.Comment = *t.Comment
+	}
+	if active {
+		c.activate(name, tag)
+	}
+	return &v.TemplateVersion, nil
+}
+
+func (c *versionClient) DeleteTemplateVersion(ctx context.Context, domain, name, tag string) error {
+	if _, ok := c.templates[name][tag]; !ok {
+		return errors.New("template version not found (404)")
+	}
+	delete(c.templates[name], tag)
+	return nil
+}
+
+func (c *versionClient) add(name string, t *templatetypes.TemplateParameters, active bool) *version {
+	v := &version{TemplateVersion: templatetypes.TemplateVersion{Tag: *t.Tag, Engine: "mustache"}, content: *t.Template}
+	if t.Engine != nil {
+		v.Engine = *t.Engine
+	}
+	if t.Comment != nil {
+		v.Comment = *t.Comment
+	}
+	c.templates[name][v.Tag] = v
+	if active {
+		c.activate(name, v.Tag)
+	}
+	return v
+}
+
+func (c *versionClient) activate(name, tag string) {
+	for other, v := range c.templates[name] {
+		v.Active = other == tag
+	}
+}
+
+func templateVersion(tag, content string, active bool) *v1beta1.TemplateVersion {
+	return &v1beta1.TemplateVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "welcome-" + tag, Namespace: "mail"},
+		Spec: v1beta1.TemplateVersionSpec{ForProvider: v1beta1.TemplateVersionParameters{
+			Domain:       "mg.example.com",
+			TemplateName: "welcome",
+			Tag:          tag,
+			Template:     content,
+			Active:       &active,
+		}},
+	}
+}
+
+func TestTemplateVersionLifecycle(t *testing.T) {
+	ctx := context.Background()
+	svc := &versionClient{templates: map[string]map[string]*version{
+		"welcome": {"initial": {TemplateVersion: templatetypes.TemplateVersion{Tag: "initial", Active: true}}},
+	}}
+	e := &external{service: svc}
+
+	// A version is added to the existing template and activated
+	blue := templateVersion("blue", "Hello {{name}}", true)
+	obs, err := e.Observe(ctx, blue)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceExists)
+	_, err = e.Create(ctx, blue)
+	require.NoError(t, err)
+	assert.Equal(t, "blue", meta.GetExternalName(blue))
+	assert.False(t, svc.templates["welcome"]["initial"].Active)
+
+	obs, err = e.Observe(ctx, blue)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+	assert.True(t, obs.ResourceUpToDate)
+	assert.True(t, blue.Status.AtProvider.Active)
+
+	// A further version is added inactive
+	green := templateVersion("green", "Hi {{name}}", false)
+	_, err = e.Create(ctx, green)
+	require.NoError(t, err)
+	assert.False(t, green.Status.AtProvider.Active)
+	assert.True(t, svc.templates["welcome"]["blue"].Active)
+
+	// Flipping it active is drift, applied by Update
+	green.Spec.ForProvider.Active = boolPtr(true)
+	obs, err = e.Observe(ctx, green)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate)
+	_, err = e.Update(ctx, green)
+	require.NoError(t, err)
+	assert.True(t, green.Status.AtProvider.Active)
+	assert.False(t, svc.templates["welcome"]["blue"].Active)
+
+	// Its content and comment are updated in place
+	green.Spec.ForProvider.Template = "Hi there {{name}}"
+	green.Spec.ForProvider.Comment = stringPtr("friendlier")
+	obs, err = e.Observe(ctx, green)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate)
+	_, err = e.Update(ctx, green)
+	require.NoError(t, err)
+	last := svc.updates[len(svc.updates)-1]
+	assert.Equal(t, "Hi there {{name}}", *last.Template)
+	assert.Nil(t, last.Engine, "the engine of a version cannot be updated")
+	obs, err = e.Observe(ctx, green)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+
+	// Deleting a version leaves the others
+	_, err = e.Delete(ctx, blue)
+	require.NoError(t, err)
+	assert.NotContains(t, svc.templates["welcome"], "blue")
+	assert.Contains(t, svc.templates["welcome"], "green")
+}
+
+func TestTemplateVersionMissingTemplate(t *testing.T) {
+	ctx := context.Background()
+	svc := &versionClient{templates: map[string]map[string]*version{}}
+	cr := templateVersion("blue", "Hello", false)
+
+	_, err := (&external{service: svc}).Create(ctx, cr)
+	require.Error(t, err, "a version should not create the template it belongs to")
+	assert.Contains(t, err.Error(), "template welcome does not exist")
+	assert.Empty(t, svc.templates)
+}
+
+func TestTemplateVersionDeleteMissing(t *testing.T) {
+	ctx := context.Background()
+	svc := &versionClient{templates: map[string]map[string]*version{}}
+	cr := templateVersion("blue", "Hello", false)
+
+	_, err := (&external{service: svc}).Delete(ctx, cr)
+	require.NoError(t, err, "a version that is already gone should be deleted")
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -35,6 +35,16 @@ import (
 	v1beta1 "github.com/rossigee/provider-mailgun/apis/unsubscribe/v1beta1"
 	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
 	"github.com/rossigee/provider-mailgun/internal/clients"
+	"github.com/rossigee/provider-mailgun/internal/eventlabel"
+	"github.com/rossigee/provider-mailgun/internal/failfast"
+	"github.com/rossigee/provider-mailgun/internal/immutable"
+	"github.com/rossigee/provider-mailgun/internal/metrics"
+	"github.com/rossigee/provider-mailgun/internal/observecache"
+	"github.com/rossigee/provider-mailgun/internal/ratelimit"
+	"github.com/rossigee/provider-mailgun/internal/readonly"
+	"github.com/rossigee/provider-mailgun/internal/shutdown"
+	"github.com/rossigee/provider-mailgun/internal/strictdelete"
+	"github.com/rossigee/provider-mailgun/internal/watchdog"
 )
 
 const (
@@ -50,14 +60,14 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1beta1.UnsubscribeGroupVersionKind),
-		managed.WithExternalConnector(&connector{
+		managed.WithExternalConnector(failfast.Wrap(mgr.GetClient(), readonly.Wrap(shutdown.Wrap(strictdelete.Wrap(watchdog.Wrap(observecache.Wrap(ratelimit.Wrap(immutable.Wrap(&connector{
 			kube:         mgr.GetClient(),
 			usage:        resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
 			newServiceFn: clients.NewClient,
-		}),
+		}, immutableFields))))))))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
+		managed.WithRecorder(eventlabel.Wrap(event.NewAPIRecorder(mgr.GetEventRecorder(name)))))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -67,6 +77,18 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		Complete(r)
 }
 
+// immutableFields returns the fields that identify the Mailgun unsubscribe
+func immutableFields(mg resource.Managed) map[string]string {
+	cr, ok := mg.(*v1beta1.Unsubscribe)
+	if !ok {
+		return nil
+	}
+	return map[string]string{
+		"spec.forProvider.address":        cr.Spec.ForProvider.Address,
+		"spec.forProvider.domainRef.name": cr.Spec.ForProvider.DomainRef.Name,
+	}
+}
+
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
@@ -112,7 +134,7 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	}
 
 	cd := pc.Spec.Credentials
-	_, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	_, err := clients.ExtractCredentials(ctx, c.kube, cd)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
@@ -124,7 +146,7 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 
 	svc := c.newServiceFn(config)
 
-	return &external{service: svc, kube: c.kube}, nil
+	return &external{service: svc, kube: c.kube, reconcile: metrics.NewReconcileTimer(v1beta1.UnsubscribeKind)}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
@@ -132,14 +154,20 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 type external struct {
 	service clients.Client
 	kube    client.Client
+
+	// reconcile times the work done by this client for one reconcile
+	reconcile *metrics.ReconcileTimer
 }
 
 func (c *external) Disconnect(ctx context.Context) error {
 	// No persistent connections to clean up
+	c.reconcile.Record()
 	return nil
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.Unsubscribe)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotUnsubscribe)
@@ -168,23 +196,37 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.Wrap(err, "cannot get unsubscribe")
 	}
 
-	// unsubscribe is interface{}, convert to Unsubscribe struct for status
-	if unsubscribeData, ok := unsubscribe.(*clients.Unsubscribe); ok {
-		cr.Status.AtProvider = v1beta1.UnsubscribeObservation{
-			CreatedAt: &unsubscribeData.CreatedAt,
-		}
-	}
+	// Mailgun reports every tag of the address; the one this resource last
+	// applied is only known from status
+	desired := clients.UnsubscribeTag(&cr.Spec.ForProvider)
+	applied := cr.Status.AtProvider.Tag
+	unsubscribe.Tag = applied
+	cr.Status.AtProvider = *unsubscribe
 
 	cr.Status.SetConditions(xpv1.Available())
 
+	// The tag identifies which of the address's unsubscribes this resource
+	// manages, so a changed tag needs Update to move it
 	return managed.ExternalObservation{
 		ResourceExists:          true,
-		ResourceUpToDate:        true,
+		ResourceUpToDate:        hasTag(unsubscribe.Tags, desired) && (applied == "" || applied == desired),
 		ResourceLateInitialized: false,
 	}, nil
 }
 
+// hasTag reports whether tags includes tag
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.Unsubscribe)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotUnsubscribe)
@@ -198,28 +240,56 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.Wrap(err, "cannot resolve domain name")
 	}
 
-	unsubscribeSpec := &clients.UnsubscribeSpec{
-		Address: cr.Spec.ForProvider.Address,
-		Tags:    cr.Spec.ForProvider.Tags,
-	}
-
-	_, err = c.service.CreateUnsubscribe(ctx, domainName, unsubscribeSpec)
+	unsubscribe, err := c.service.CreateUnsubscribe(ctx, domainName, &cr.Spec.ForProvider)
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, "cannot create unsubscribe")
 	}
 
 	meta.SetExternalName(cr, cr.Spec.ForProvider.Address)
+	unsubscribe.Tag = clients.UnsubscribeTag(&cr.Spec.ForProvider)
+	cr.Status.AtProvider = *unsubscribe
 
 	return managed.ExternalCreation{}, nil
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	// Unsubscribe entries cannot be updated in Mailgun API
-	// They can only be created or deleted
+	defer c.reconcile.Start()()
+
+	cr, ok := mg.(*v1beta1.Unsubscribe)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotUnsubscribe)
+	}
+
+	// Get domain name from domainRef
+	domainName, err := c.resolveDomainName(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, "cannot resolve domain name")
+	}
+
+	// Mailgun cannot change the tag of an unsubscribe, so unsubscribe the
+	// address from the new tag before resubscribing it to the old one
+	unsubscribe, err := c.service.CreateUnsubscribe(ctx, domainName, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, "cannot create unsubscribe")
+	}
+
+	desired := clients.UnsubscribeTag(&cr.Spec.ForProvider)
+	if applied := cr.Status.AtProvider.Tag; applied != "" && applied != desired {
+		err := c.service.DeleteUnsubscribe(ctx, domainName, meta.GetExternalName(cr), applied)
+		if err != nil && !clients.IsNotFound(err) {
+			return managed.ExternalUpdate{}, errors.Wrap(err, "cannot delete unsubscribe")
+		}
+	}
+
+	unsubscribe.Tag = desired
+	cr.Status.AtProvider = *unsubscribe
+
 	return managed.ExternalUpdate{}, nil
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.Unsubscribe)
 	if !ok {
 		return managed.ExternalDelete{}, errors.New(errNotUnsubscribe)
@@ -238,7 +308,13 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 		externalName = cr.Spec.ForProvider.Address
 	}
 
-	err = c.service.DeleteUnsubscribe(ctx, domainName, externalName)
+	// Only resubscribe the address to the tag this resource manages
+	tag := cr.Status.AtProvider.Tag
+	if tag == "" {
+		tag = clients.UnsubscribeTag(&cr.Spec.ForProvider)
+	}
+
+	err = c.service.DeleteUnsubscribe(ctx, domainName, externalName, tag)
 	if err != nil && !clients.IsNotFound(err) {
 		return managed.ExternalDelete{}, errors.Wrap(err, "cannot delete unsubscribe")
 	}
@@ -0,0 +1,520 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package unsubscribe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	bouncetypes "github.com/rossigee/provider-mailgun/apis/bounce/v1beta1"
+	complainttypes "github.com/rossigee/provider-mailgun/apis/complaint/v1beta1"
+	domaintypes "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+	ippooltypes "github.com/rossigee/provider-mailgun/apis/ippool/v1beta1"
+	mailinglisttypes "github.com/rossigee/provider-mailgun/apis/mailinglist/v1beta1"
+	mailinglistmembertypes "github.com/rossigee/provider-mailgun/apis/mailinglistmember/v1beta1"
+	routetypes "github.com/rossigee/provider-mailgun/apis/route/v1beta1"
+	smtpcredentialtypes "github.com/rossigee/provider-mailgun/apis/smtpcredential/v1beta1"
+	templatetypes "github.com/rossigee/provider-mailgun/apis/template/v1beta1"
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/unsubscribe/v1beta1"
+	webhooktypes "github.com/rossigee/provider-mailgun/apis/webhook/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
+)
+
+// MockUnsubscribeClient for testing. It keeps the tags each address is
+// unsubscribed from, as Mailgun does.
+type MockUnsubscribeClient struct {
+	unsubscribes map[string]*v1beta1.UnsubscribeObservation
+	err          error
+}
+
+func (m *MockUnsubscribeClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe *v1beta1.UnsubscribeParameters) (*v1beta1.UnsubscribeObservation, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	if m.unsubscribes == nil {
+		m.unsubscribes = make(map[string]*v1beta1.UnsubscribeObservation)
+	}
+
+	key := domain + "/" + unsubscribe.Address
+	existing, exists := m.unsubscribes[key]
+	if !exists {
+		existing = &v1beta1.UnsubscribeObservation{
+			Address:   unsubscribe.Address,
+			CreatedAt: stringPtr("2025-01-01T00:00:00Z"),
+		}
+		m.unsubscribes[key] = existing
+	}
+	if tag := clients.UnsubscribeTag(unsubscribe); !hasTag(existing.Tags, tag) {
+		existing.Tags = append(existing.Tags, tag)
+	}
+
+	result := *existing
+	return &result, nil
+}
+
+func (m *MockUnsubscribeClient) GetUnsubscribe(ctx context.Context, domain, address string) (*v1beta1.UnsubscribeObservation, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	key := domain + "/" + address
+	if unsubscribe, exists := m.unsubscribes[key]; exists {
+		result := *unsubscribe
+		result.Tags = append([]string(nil), unsubscribe.Tags...)
+		return &result, nil
+	}
+
+	return nil, errors.New("address not found in unsubscribers table (404)")
+}
+
+func (m *MockUnsubscribeClient) DeleteUnsubscribe(ctx context.Context, domain, address, tag string) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	key := domain + "/" + address
+	unsubscribe, exists := m.unsubscribes[key]
+	if !exists || (tag != "" && !hasTag(unsubscribe.Tags, tag)) {
+		return errors.New("address not found in unsubscribers table (404)")
+	}
+
+	var remaining []string
+	for _, t := range unsubscribe.Tags {
+		if tag != "" && t != tag {
+			remaining = append(remaining, t)
+		}
+	}
+	if len(remaining) == 0 {
+		delete(m.unsubscribes, key)
+		return nil
+	}
+	unsubscribe.Tags = remaining
+	return nil
+}
+
+func (m *MockUnsubscribeClient) CreateIPPool(ctx context.Context, pool *ippooltypes.IPPoolParameters) (*ippooltypes.IPPoolObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) GetIPPool(ctx context.Context, id string) (*ippooltypes.IPPoolObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) ListDomainIPs(ctx context.Context, name string) ([]string, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) AssignDomainIP(ctx context.Context, name, ip string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) UnassignDomainIP(ctx context.Context, name, ip string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) UpdateIPPool(ctx context.Context, id string, pool *ippooltypes.IPPoolParameters, add, remove []string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) DeleteIPPool(ctx context.Context, id string) error {
+	return errors.New("not implemented")
+}
+
+// Implement other required client methods as no-ops with v1beta1 types
+
+// Domain operations
+func (m *MockUnsubscribeClient) CreateDomain(ctx context.Context, domain *domaintypes.DomainParameters) (*domaintypes.DomainObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) GetDomain(ctx context.Context, name string) (*domaintypes.DomainObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) UpdateDomain(ctx context.Context, name string, domain *domaintypes.DomainParameters) (*domaintypes.DomainObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) DeleteDomain(ctx context.Context, name string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) ListAuthorizedRecipients(ctx context.Context) ([]domaintypes.AuthorizedRecipient, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) AddAuthorizedRecipient(ctx context.Context, email string) (*domaintypes.AuthorizedRecipient, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) DeleteAuthorizedRecipient(ctx context.Context, email string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) GetWebhookSigningKey(ctx context.Context) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) RotateWebhookSigningKey(ctx context.Context) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) UpdateDomainTracking(ctx context.Context, name string, tracking *domaintypes.DomainTracking) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) GetDomainTracking(ctx context.Context, name string) (*domaintypes.DomainTracking, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) GetDomainConnection(ctx context.Context, name string) (*domaintypes.DomainConnection, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) UpdateDomainConnection(ctx context.Context, name string, connection *domaintypes.DomainConnection) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) UpdateDKIMKey(ctx context.Context, name string, keySize int) ([]domaintypes.DNSRecord, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) ListDomains(ctx context.Context, limit, skip int) ([]*domaintypes.DomainObservation, int, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
+// MailingList operations
+func (m *MockUnsubscribeClient) CreateMailingList(ctx context.Context, list *mailinglisttypes.MailingListParameters) (*mailinglisttypes.MailingListObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) GetMailingList(ctx context.Context, address string) (*mailinglisttypes.MailingListObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) UpdateMailingList(ctx context.Context, address string, list *mailinglisttypes.MailingListParameters) (*mailinglisttypes.MailingListObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) DeleteMailingList(ctx context.Context, address string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) CreateMailingListMember(ctx context.Context, listAddress string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) GetMailingListMember(ctx context.Context, listAddress, address string) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) UpdateMailingListMember(ctx context.Context, listAddress, address string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) DeleteMailingListMember(ctx context.Context, listAddress, address string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) AddMailingListMembers(ctx context.Context, listAddress string, members []clients.MailingListMemberSpec, upsert bool) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) ListMailingListMembers(ctx context.Context, listAddress string) ([]*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+// Route operations
+func (m *MockUnsubscribeClient) CreateRoute(ctx context.Context, route *routetypes.RouteParameters) (*routetypes.RouteObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) GetRoute(ctx context.Context, id string) (*routetypes.RouteObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) UpdateRoute(ctx context.Context, id string, route *routetypes.RouteParameters) (*routetypes.RouteObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) DeleteRoute(ctx context.Context, id string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) ListRoutes(ctx context.Context, limit, skip int) ([]*routetypes.RouteObservation, int, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
+// Webhook operations
+func (m *MockUnsubscribeClient) CreateWebhook(ctx context.Context, domain string, webhook *webhooktypes.WebhookParameters) (*webhooktypes.WebhookObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) GetWebhook(ctx context.Context, domain, eventType string) (*webhooktypes.WebhookObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) UpdateWebhook(ctx context.Context, domain, eventType string, webhook *webhooktypes.WebhookParameters) (*webhooktypes.WebhookObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) DeleteWebhook(ctx context.Context, domain, eventType string) error {
+	return errors.New("not implemented")
+}
+
+// SMTPCredential operations
+func (m *MockUnsubscribeClient) CreateSMTPCredential(ctx context.Context, domain string, credential *smtpcredentialtypes.SMTPCredentialParameters) (*smtpcredentialtypes.SMTPCredentialObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) GetSMTPCredential(ctx context.Context, domain, login string) (*smtpcredentialtypes.SMTPCredentialObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) UpdateSMTPCredential(ctx context.Context, domain, login string, password string) (*smtpcredentialtypes.SMTPCredentialObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) DeleteSMTPCredential(ctx context.Context, domain, login string) error {
+	return errors.New("not implemented")
+}
+
+// Template operations
+func (m *MockUnsubscribeClient) CreateTemplate(ctx context.Context, domain string, template *templatetypes.TemplateParameters) (*templatetypes.TemplateObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) GetTemplate(ctx context.Context, domain, name string) (*templatetypes.TemplateObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) UpdateTemplate(ctx context.Context, domain, name string, template *templatetypes.TemplateParameters) (*templatetypes.TemplateObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) DeleteTemplate(ctx context.Context, domain, name string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) CreateTemplateVersion(ctx context.Context, domain, name string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) GetTemplateVersion(ctx context.Context, domain, name, tag string) (*templatetypes.TemplateVersion, string, error) {
+	return nil, "", errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) UpdateTemplateVersion(ctx context.Context, domain, name, tag string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) DeleteTemplateVersion(ctx context.Context, domain, name, tag string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) ListTemplateVersions(ctx context.Context, domain, name string) ([]templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) RenderTemplate(ctx context.Context, domain, name string, vars map[string]interface{}) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) CreateBounce(ctx context.Context, domain string, bounce *bouncetypes.BounceParameters) (*bouncetypes.BounceObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) GetBounce(ctx context.Context, domain, address string) (*bouncetypes.BounceObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) DeleteBounce(ctx context.Context, domain, address string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) CreateComplaint(ctx context.Context, domain string, complaint *complainttypes.ComplaintParameters) (*complainttypes.ComplaintObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) GetComplaint(ctx context.Context, domain, address string) (*complainttypes.ComplaintObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockUnsubscribeClient) DeleteComplaint(ctx context.Context, domain, address string) error {
+	return errors.New("not implemented")
+}
+
+func newUnsubscribe(externalName string, tag *string) *v1beta1.Unsubscribe {
+	cr := &v1beta1.Unsubscribe{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-unsubscribe", Namespace: "test-namespace"},
+		Spec: v1beta1.UnsubscribeSpec{
+			ForProvider: v1beta1.UnsubscribeParameters{
+				Address:   "gone@example.com",
+				Tag:       tag,
+				DomainRef: xpv1.Reference{Name: "example.com"},
+			},
+		},
+	}
+	if externalName != "" {
+		cr.SetAnnotations(map[string]string{"crossplane.io/external-name": externalName})
+	}
+	return cr
+}
+
+func TestUnsubscribeObserve(t *testing.T) {
+	cases := map[string]struct {
+		reason       string
+		tag          *string
+		applied      string
+		unsubscribes map[string]*v1beta1.UnsubscribeObservation
+		want         managed.ExternalObservation
+	}{
+		"AllMail": {
+			reason: "An address unsubscribed from all mail should be up to date when no tag is set",
+			unsubscribes: map[string]*v1beta1.UnsubscribeObservation{
+				"example.com/gone@example.com": {Address: "gone@example.com", Tags: []string{"*"}},
+			},
+			want: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"TagMissing": {
+			reason: "An address not unsubscribed from the desired tag should need an update",
+			tag:    stringPtr("newsletter"),
+			unsubscribes: map[string]*v1beta1.UnsubscribeObservation{
+				"example.com/gone@example.com": {Address: "gone@example.com", Tags: []string{"*"}},
+			},
+			want: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+		},
+		"TagChanged": {
+			reason:  "A tag that differs from the one last applied should need an update",
+			tag:     stringPtr("newsletter"),
+			applied: "promotions",
+			unsubscribes: map[string]*v1beta1.UnsubscribeObservation{
+				"example.com/gone@example.com": {Address: "gone@example.com", Tags: []string{"promotions", "newsletter"}},
+			},
+			want: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+		},
+		"NotFound": {
+			reason: "A missing unsubscribe should be reported as not existing",
+			want:   managed.ExternalObservation{ResourceExists: false},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{service: &MockUnsubscribeClient{unsubscribes: tc.unsubscribes}}
+			cr := newUnsubscribe("", tc.tag)
+			cr.Status.AtProvider.Tag = tc.applied
+
+			got, err := e.Observe(context.Background(), cr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got, tc.reason)
+			assert.Equal(t, "gone@example.com", cr.GetAnnotations()["crossplane.io/external-name"])
+			if tc.want.ResourceExists {
+				assert.Equal(t, "gone@example.com", cr.Status.AtProvider.Address)
+				assert.Equal(t, tc.applied, cr.Status.AtProvider.Tag, "the applied tag should be kept in status")
+			}
+		})
+	}
+}
+
+func TestUnsubscribeCreate(t *testing.T) {
+	mockClient := &MockUnsubscribeClient{}
+	e := &external{service: mockClient}
+	cr := newUnsubscribe("", stringPtr("newsletter"))
+
+	_, err := e.Create(context.Background(), cr)
+	require.NoError(t, err)
+
+	unsubscribe, exists := mockClient.unsubscribes["example.com/gone@example.com"]
+	require.True(t, exists, "Unsubscribe should be created")
+	assert.Equal(t, []string{"newsletter"}, unsubscribe.Tags)
+	assert.Equal(t, "gone@example.com", cr.GetAnnotations()["crossplane.io/external-name"])
+	assert.Equal(t, "newsletter", cr.Status.AtProvider.Tag, "the created tag should be reported in status")
+}
+
+func TestUnsubscribeTagChange(t *testing.T) {
+	mockClient := &MockUnsubscribeClient{unsubscribes: map[string]*v1beta1.UnsubscribeObservation{
+		"example.com/gone@example.com": {Address: "gone@example.com", Tags: []string{"promotions"}},
+	}}
+	e := &external{service: mockClient}
+	cr := newUnsubscribe("gone@example.com", stringPtr("newsletter"))
+	cr.Status.AtProvider.Tag = "promotions"
+
+	_, err := e.Update(context.Background(), cr)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"newsletter"}, mockClient.unsubscribes["example.com/gone@example.com"].Tags,
+		"the address should be unsubscribed from the new tag and resubscribed to the old one")
+	assert.Equal(t, "newsletter", cr.Status.AtProvider.Tag)
+
+	got, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, got.ResourceUpToDate, "the moved unsubscribe should be up to date")
+}
+
+func TestUnsubscribeDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason       string
+		unsubscribes map[string]*v1beta1.UnsubscribeObservation
+		wantErr      bool
+		wantTags     []string
+	}{
+		"Exists": {
+			reason: "Deleting should resubscribe the address to the managed tag only",
+			unsubscribes: map[string]*v1beta1.UnsubscribeObservation{
+				"example.com/gone@example.com": {Address: "gone@example.com", Tags: []string{"newsletter", "promotions"}},
+			},
+			wantTags: []string{"promotions"},
+		},
+		"AlreadyGone": {
+			reason: "Deleting an unsubscribe that is already gone should succeed",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockUnsubscribeClient{unsubscribes: tc.unsubscribes}
+			e := &external{service: mockClient}
+			cr := newUnsubscribe("gone@example.com", stringPtr("newsletter"))
+			cr.Status.AtProvider.Tag = "newsletter"
+
+			_, err := e.Delete(context.Background(), cr)
+			if tc.wantErr {
+				require.Error(t, err, tc.reason)
+				return
+			}
+			require.NoError(t, err, tc.reason)
+			if tc.wantTags == nil {
+				assert.Empty(t, mockClient.unsubscribes)
+				return
+			}
+			assert.Equal(t, tc.wantTags, mockClient.unsubscribes["example.com/gone@example.com"].Tags, tc.reason)
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
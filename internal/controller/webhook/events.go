@@ -0,0 +1,242 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/webhook/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
+)
+
+const (
+	errGetEvent     = "failed to get webhook"
+	errCreateEvent  = "failed to create webhook"
+	errUpdateEvent  = "failed to update webhook"
+	errDeleteEvent  = "failed to delete webhook"
+	errDeleteEvents = "cannot delete webhooks of %s"
+)
+
+// desiredEvents returns the event types of spec.forProvider.events in order.
+// EventType is left out as it is always the webhook of the resource itself.
+func desiredEvents(cr *v1beta1.Webhook) []string {
+	events := make([]string, 0, len(cr.Spec.ForProvider.Events))
+	for event := range cr.Spec.ForProvider.Events {
+		if event != cr.Spec.ForProvider.EventType {
+			events = append(events, event)
+		}
+	}
+	sort.Strings(events)
+	return events
+}
+
+// isRemovedEvent reports whether the webhook of a recorded event type is no
+// longer wanted and should be deleted
+func isRemovedEvent(cr *v1beta1.Webhook, event string) bool {
+	_, wanted := cr.Spec.ForProvider.Events[event]
+	return !wanted && event != cr.Spec.ForProvider.EventType
+}
+
+// eventParameters returns the parameters of the webhook of event, which
+// share everything but the event type and URL with desired
+func eventParameters(cr *v1beta1.Webhook, desired *v1beta1.WebhookParameters, event string) *v1beta1.WebhookParameters {
+	params := desired.DeepCopy()
+	params.EventType = event
+	params.Events = nil
+	if url := cr.Spec.ForProvider.Events[event]; url != "" {
+		params.URL = url
+	}
+	return params
+}
+
+// observeEvents records the state of the webhook of each of
+// spec.forProvider.events and reports whether all of them are synced. When
+// force is true every webhook is reported as needing an update. The error
+// of an event that is still not synced is kept until it is.
+func (c *external) observeEvents(ctx context.Context, cr *v1beta1.Webhook, domain string, desired *v1beta1.WebhookParameters, force bool) bool {
+	previous := eventStatuses(cr)
+	events := desiredEvents(cr)
+
+	statuses := make([]v1beta1.WebhookEventStatus, 0, len(events))
+	synced := true
+	for _, event := range events {
+		status := v1beta1.WebhookEventStatus{EventType: event}
+		webhook, err := c.service.GetWebhook(ctx, domain, event)
+		switch {
+		case err == nil:
+			status.URL = webhook.URL
+			status.Synced = !force && isWebhookUpToDate(webhook, eventParameters(cr, desired, event))
+		case !clients.IsNotFound(err):
+			status.Error = errors.Wrap(err, errGetEvent).Error()
+		}
+		if !status.Synced && status.Error == "" {
+			status.Error = previous[event].Error
+		}
+		synced = synced && status.Synced
+		statuses = append(statuses, status)
+	}
+
+	// Removed events stay recorded until their webhooks are deleted
+	for _, status := range cr.Status.AtProvider.Events {
+		if isRemovedEvent(cr, status.EventType) {
+			status.Synced = false
+			statuses = append(statuses, status)
+			synced = false
+		}
+	}
+
+	cr.Status.AtProvider.Events = statuses
+	setEventsCondition(cr)
+	return synced
+}
+
+// syncEvents creates or updates the webhook of each of
+// spec.forProvider.events that is not synced, and deletes those of removed
+// events. A failure is recorded in the status of its event rather than
+// returned, so that one failing event does not hold back the others.
+func (c *external) syncEvents(ctx context.Context, cr *v1beta1.Webhook, domain string, desired *v1beta1.WebhookParameters) {
+	previous := eventStatuses(cr)
+	events := desiredEvents(cr)
+
+	statuses := make([]v1beta1.WebhookEventStatus, 0, len(events))
+	for _, event := range events {
+		status := previous[event]
+		status.EventType = event
+		if !status.Synced {
+			status.Error = ""
+			webhook, err := c.syncEvent(ctx, domain, eventParameters(cr, desired, event))
+			if err != nil {
+				status.Error = err.Error()
+			} else {
+				status.URL = webhook.URL
+				status.Synced = true
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	for _, status := range cr.Status.AtProvider.Events {
+		if !isRemovedEvent(cr, status.EventType) {
+			continue
+		}
+		err := c.service.DeleteWebhook(ctx, domain, status.EventType)
+		if err != nil && !clients.IsNotFound(err) {
+			status.Synced = false
+			status.Error = errors.Wrap(err, errDeleteEvent).Error()
+			statuses = append(statuses, status)
+		}
+	}
+
+	cr.Status.AtProvider.Events = statuses
+	setEventsCondition(cr)
+}
+
+// syncEvent creates the webhook described by params, or updates it if it
+// already exists
+func (c *external) syncEvent(ctx context.Context, domain string, params *v1beta1.WebhookParameters) (*v1beta1.WebhookObservation, error) {
+	_, err := c.service.GetWebhook(ctx, domain, params.EventType)
+	switch {
+	case clients.IsNotFound(err):
+		webhook, err := c.service.CreateWebhook(ctx, domain, params)
+		return webhook, errors.Wrap(err, errCreateEvent)
+	case err != nil:
+		return nil, errors.Wrap(err, errGetEvent)
+	}
+
+	webhook, err := c.service.UpdateWebhook(ctx, domain, params.EventType, params)
+	return webhook, errors.Wrap(err, errUpdateEvent)
+}
+
+// deleteEvents deletes the webhooks of spec.forProvider.events and of any
+// recorded events not yet deleted. It tries every event and fails if any
+// could not be deleted, so that the webhook of EventType, which marks the
+// resource as existing, goes last.
+func (c *external) deleteEvents(ctx context.Context, cr *v1beta1.Webhook, domain string) error {
+	events := desiredEvents(cr)
+	for _, status := range cr.Status.AtProvider.Events {
+		if isRemovedEvent(cr, status.EventType) {
+			events = append(events, status.EventType)
+		}
+	}
+
+	var failed []string
+	var first error
+	for _, event := range events {
+		err := c.service.DeleteWebhook(ctx, domain, event)
+		if err != nil && !clients.IsNotFound(err) {
+			failed = append(failed, event)
+			if first == nil {
+				first = err
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Wrapf(first, errDeleteEvents, strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// eventStatuses returns the recorded status of each event by type
+func eventStatuses(cr *v1beta1.Webhook) map[string]v1beta1.WebhookEventStatus {
+	statuses := make(map[string]v1beta1.WebhookEventStatus, len(cr.Status.AtProvider.Events))
+	for _, status := range cr.Status.AtProvider.Events {
+		statuses[status.EventType] = status
+	}
+	return statuses
+}
+
+// setEventsCondition reports the events whose last reconcile failed in the
+// EventsNotSynced condition
+func setEventsCondition(cr *v1beta1.Webhook) {
+	var failed []string
+	for _, status := range cr.Status.AtProvider.Events {
+		if status.Error != "" {
+			failed = append(failed, status.EventType)
+		}
+	}
+
+	switch {
+	case len(failed) > 0:
+		cr.SetConditions(v1beta1.EventsNotSynced(failed))
+	case cr.GetCondition(v1beta1.TypeEventsNotSynced).Status == corev1.ConditionTrue:
+		cr.SetConditions(v1beta1.EventsSynced())
+	}
+}
+
+// eventsSynced reports whether the webhooks of all further events are synced
+func eventsSynced(cr *v1beta1.Webhook) bool {
+	for _, status := range cr.Status.AtProvider.Events {
+		if !status.Synced {
+			return false
+		}
+	}
+	return true
+}
+
+// setObservation records the observation of the webhook of EventType,
+// keeping the recorded state of the other events
+func setObservation(cr *v1beta1.Webhook, webhook *v1beta1.WebhookObservation) {
+	previous := cr.Status.AtProvider
+	cr.Status.AtProvider = *webhook
+	cr.Status.AtProvider.Events = previous.Events
+	cr.Status.AtProvider.CredentialsRotation = previous.CredentialsRotation
+}
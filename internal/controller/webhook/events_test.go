@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/webhook/v1beta1"
+)
+
+// failingEventClient fails creating the webhook of one event type
+type failingEventClient struct {
+	*MockWebhookClient
+	event string
+}
+
+func (m *failingEventClient) CreateWebhook(ctx context.Context, domain string, webhook *v1beta1.WebhookParameters) (*v1beta1.WebhookObservation, error) {
+	if webhook.EventType == m.event {
+		return nil, errors.New("invalid event type")
+	}
+	return m.MockWebhookClient.CreateWebhook(ctx, domain, webhook)
+}
+
+func multiEventWebhook() *v1beta1.Webhook {
+	return &v1beta1.Webhook{
+		Spec: v1beta1.WebhookSpec{
+			ForProvider: v1beta1.WebhookParameters{
+				Domain:    "example.com",
+				EventType: "delivered",
+				URL:       "https://example.com/events",
+				Events: map[string]string{
+					"opened":  "",
+					"clicked": "https://example.com/clicks",
+				},
+			},
+		},
+	}
+}
+
+func TestWebhookEvents(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &MockWebhookClient{}
+	e := &external{service: mockClient}
+	cr := multiEventWebhook()
+
+	_, err := e.Create(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/events", mockClient.webhooks["example.com/delivered"].URL)
+	assert.Equal(t, "https://example.com/events", mockClient.webhooks["example.com/opened"].URL, "an empty URL uses the URL of EventType")
+	assert.Equal(t, "https://example.com/clicks", mockClient.webhooks["example.com/clicked"].URL)
+	assert.Equal(t, []v1beta1.WebhookEventStatus{
+		{EventType: "clicked", URL: "https://example.com/clicks", Synced: true},
+		{EventType: "opened", URL: "https://example.com/events", Synced: true},
+	}, cr.Status.AtProvider.Events)
+
+	obs, err := e.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+	assert.True(t, obs.ResourceUpToDate)
+
+	t.Run("PartialDrift", func(t *testing.T) {
+		mockClient.webhooks["example.com/clicked"].URL = "https://elsewhere.example.com/"
+
+		obs, err := e.Observe(ctx, cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate, "drift of one event makes the resource out of date")
+		statuses := eventStatuses(cr)
+		assert.False(t, statuses["clicked"].Synced)
+		assert.Equal(t, "https://elsewhere.example.com/", statuses["clicked"].URL)
+		assert.True(t, statuses["opened"].Synced)
+
+		_, err = e.Update(ctx, cr)
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/clicks", mockClient.webhooks["example.com/clicked"].URL)
+
+		obs, err = e.Observe(ctx, cr)
+		require.NoError(t, err)
+		assert.True(t, obs.ResourceUpToDate)
+	})
+
+	t.Run("RemovedEvent", func(t *testing.T) {
+		delete(cr.Spec.ForProvider.Events, "opened")
+
+		obs, err := e.Observe(ctx, cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceUpToDate, "the webhook of a removed event must be deleted")
+
+		_, err = e.Update(ctx, cr)
+		require.NoError(t, err)
+		assert.NotContains(t, mockClient.webhooks, "example.com/opened")
+		assert.Contains(t, mockClient.webhooks, "example.com/delivered")
+		assert.Equal(t, []v1beta1.WebhookEventStatus{
+			{EventType: "clicked", URL: "https://example.com/clicks", Synced: true},
+		}, cr.Status.AtProvider.Events)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		_, err := e.Delete(ctx, cr)
+		require.NoError(t, err)
+		assert.Empty(t, mockClient.webhooks)
+	})
+}
+
+func TestWebhookEventFailure(t *testing.T) {
+	ctx := context.Background()
+	mockClient := &failingEventClient{MockWebhookClient: &MockWebhookClient{}, event: "opened"}
+	e := &external{service: mockClient}
+	cr := multiEventWebhook()
+
+	_, err := e.Create(ctx, cr)
+	require.NoError(t, err, "a failing event must not fail the resource")
+	assert.Contains(t, mockClient.webhooks, "example.com/clicked")
+
+	statuses := eventStatuses(cr)
+	assert.Contains(t, statuses["opened"].Error, "invalid event type")
+	assert.True(t, statuses["clicked"].Synced)
+	c := cr.GetCondition(v1beta1.TypeEventsNotSynced)
+	assert.Equal(t, corev1.ConditionTrue, c.Status)
+	assert.Contains(t, c.Message, "opened")
+
+	obs, err := e.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceUpToDate)
+	assert.Contains(t, eventStatuses(cr)["opened"].Error, "invalid event type", "the error is kept until the event is synced")
+
+	mockClient.event = ""
+	_, err = e.Update(ctx, cr)
+	require.NoError(t, err)
+	assert.True(t, eventStatuses(cr)["opened"].Synced)
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(v1beta1.TypeEventsNotSynced).Status)
+}
@@ -18,6 +18,9 @@ package webhook
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"strconv"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
@@ -26,21 +29,40 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	v1beta1 "github.com/rossigee/provider-mailgun/apis/webhook/v1beta1"
 	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/webhook/v1beta1"
 	"github.com/rossigee/provider-mailgun/internal/clients"
+	"github.com/rossigee/provider-mailgun/internal/eventlabel"
+	"github.com/rossigee/provider-mailgun/internal/failfast"
+	"github.com/rossigee/provider-mailgun/internal/immutable"
+	"github.com/rossigee/provider-mailgun/internal/metrics"
+	"github.com/rossigee/provider-mailgun/internal/observecache"
+	"github.com/rossigee/provider-mailgun/internal/ratelimit"
+	"github.com/rossigee/provider-mailgun/internal/readonly"
+	"github.com/rossigee/provider-mailgun/internal/shutdown"
+	"github.com/rossigee/provider-mailgun/internal/strictdelete"
+	"github.com/rossigee/provider-mailgun/internal/trigger"
+	"github.com/rossigee/provider-mailgun/internal/watchdog"
 )
 
 const (
-	errNotWebhook    = "managed resource is not a Webhook custom resource"
-	errTrackPCUsage  = "cannot track ProviderConfig usage"
-	errGetPC         = "cannot get ProviderConfig"
-	errGetCreds      = "cannot get credentials"
-	errResolveDomain = "cannot resolve domain reference"
+	errNotWebhook     = "managed resource is not a Webhook custom resource"
+	errTrackPCUsage   = "cannot track ProviderConfig usage"
+	errGetPC          = "cannot get ProviderConfig"
+	errGetCreds       = "cannot get credentials"
+	errNoDomain       = "one of domain, domainRef or domainSelector must be set"
+	errNoURL          = "either url or serviceRef must be set"
+	errGetService     = "cannot get service %s/%s"
+	errServicePort    = "service %s/%s has no port %d"
+	errServiceType    = "service %s/%s is not of type LoadBalancer"
+	errServiceIngress = "service %s/%s has no external ingress address yet"
+	errGetPassword    = "cannot get webhook password secret %s/%s"
+	errPasswordKey    = "webhook password secret %s/%s has no key %s"
 )
 
 // Setup adds a controller that reconciles Webhook managed resources.
@@ -49,14 +71,14 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1beta1.WebhookGroupVersionKind),
-		managed.WithExternalConnector(&connector{
+		managed.WithExternalConnector(failfast.Wrap(mgr.GetClient(), readonly.Wrap(shutdown.Wrap(strictdelete.Wrap(watchdog.Wrap(observecache.Wrap(ratelimit.Wrap(immutable.Wrap(&connector{
 			kube:         mgr.GetClient(),
 			usage:        resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
 			newServiceFn: clients.NewClient,
-		}),
+		}, immutableFields))))))))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))))
+		managed.WithRecorder(eventlabel.Wrap(event.NewAPIRecorder(mgr.GetEventRecorder(name)))))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -66,6 +88,18 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		Complete(r)
 }
 
+// immutableFields returns the fields that identify the Mailgun webhook
+func immutableFields(mg resource.Managed) map[string]string {
+	cr, ok := mg.(*v1beta1.Webhook)
+	if !ok {
+		return nil
+	}
+	return map[string]string{
+		"spec.forProvider.domain":    cr.Spec.ForProvider.Domain,
+		"spec.forProvider.eventType": cr.Spec.ForProvider.EventType,
+	}
+}
+
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
@@ -111,7 +145,7 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	}
 
 	cd := pc.Spec.Credentials
-	_, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	_, err := clients.ExtractCredentials(ctx, c.kube, cd)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
@@ -123,7 +157,7 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 
 	svc := c.newServiceFn(config)
 
-	return &external{service: svc, kube: c.kube}, nil
+	return &external{service: svc, kube: c.kube, reconcile: metrics.NewReconcileTimer(v1beta1.WebhookKind)}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
@@ -133,10 +167,14 @@ type external struct {
 	// would be something like an AWS SDK client.
 	service clients.Client
 	kube    client.Client
+
+	// reconcile times the work done by this client for one reconcile
+	reconcile *metrics.ReconcileTimer
 }
 
 func (c *external) Disconnect(ctx context.Context) error {
 	// No persistent connections to clean up
+	c.reconcile.Record()
 	return nil
 }
 
@@ -176,15 +214,25 @@ func (e *ExternalForTesting) Delete(ctx context.Context, mg resource.Managed) (m
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.Webhook)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotWebhook)
 	}
 
-	// Resolve domain reference
-	domainName, err := c.resolveDomainReference(ctx, cr)
+	// A webhook whose domain was never resolved was never created
+	domainName := cr.Spec.ForProvider.Domain
+	if domainName == "" {
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.New(errNoDomain)
+	}
+
+	desired, err := c.desiredParameters(ctx, cr)
 	if err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(err, errResolveDomain)
+		return managed.ExternalObservation{}, err
 	}
 
 	webhook, err := c.service.GetWebhook(ctx, domainName, cr.Spec.ForProvider.EventType)
@@ -195,9 +243,25 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.Wrap(err, "failed to get webhook")
 	}
 
-	upToDate := isWebhookUpToDate(webhook, &cr.Spec.ForProvider)
+	upToDate := isWebhookUpToDate(webhook, desired)
 
-	cr.Status.AtProvider = *webhook
+	// The password is never returned, so a changed one is only pushed on
+	// request. Update records the request in status once it has succeeded,
+	// and only then is the trigger removed, so a failed push is retried.
+	requested := trigger.Value(cr, trigger.ForceRotateCredentials)
+	rotate := requested != "" && requested != cr.Status.AtProvider.CredentialsRotation
+	settled := requested != "" && !rotate && trigger.Consume(cr, trigger.ForceRotateCredentials)
+	if requested == "" {
+		cr.Status.AtProvider.CredentialsRotation = ""
+	}
+	if rotate {
+		upToDate = false
+	}
+
+	setObservation(cr, webhook)
+	if !c.observeEvents(ctx, cr, domainName, desired, rotate) {
+		upToDate = false
+	}
 
 	return managed.ExternalObservation{
 		// Return false when the external resource does not exist. This lets
@@ -210,6 +274,9 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		// resource reconciler know that it needs to call Update.
 		ResourceUpToDate: upToDate,
 
+		// Persist the removal of a trigger that has been acted on.
+		ResourceLateInitialized: settled,
+
 		// Return any details that may be required to connect to the external
 		// resource. These will be stored as the connection secret.
 		ConnectionDetails: managed.ConnectionDetails{},
@@ -217,6 +284,8 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.Webhook)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotWebhook)
@@ -224,13 +293,17 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	cr.SetConditions(xpv1.Creating())
 
-	// Resolve domain reference
-	domainName, err := c.resolveDomainReference(ctx, cr)
+	domainName := cr.Spec.ForProvider.Domain
+	if domainName == "" {
+		return managed.ExternalCreation{}, errors.New(errNoDomain)
+	}
+
+	desired, err := c.desiredParameters(ctx, cr)
 	if err != nil {
-		return managed.ExternalCreation{}, errors.Wrap(err, errResolveDomain)
+		return managed.ExternalCreation{}, err
 	}
 
-	webhook, err := c.service.CreateWebhook(ctx, domainName, &cr.Spec.ForProvider)
+	webhook, err := c.service.CreateWebhook(ctx, domainName, desired)
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, "failed to create webhook")
 	}
@@ -238,7 +311,8 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	// Use domain:eventType as external name
 	externalName := domainName + ":" + cr.Spec.ForProvider.EventType
 	meta.SetExternalName(cr, externalName)
-	cr.Status.AtProvider = *webhook
+	setObservation(cr, webhook)
+	c.syncEvents(ctx, cr, domainName, desired)
 
 	return managed.ExternalCreation{
 		// Optionally return any details that may be required to connect to the
@@ -248,23 +322,33 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.Webhook)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotWebhook)
 	}
 
-	// Resolve domain reference
-	domainName, err := c.resolveDomainReference(ctx, cr)
+	domainName := cr.Spec.ForProvider.Domain
+	if domainName == "" {
+		return managed.ExternalUpdate{}, errors.New(errNoDomain)
+	}
+
+	desired, err := c.desiredParameters(ctx, cr)
 	if err != nil {
-		return managed.ExternalUpdate{}, errors.Wrap(err, errResolveDomain)
+		return managed.ExternalUpdate{}, err
 	}
 
-	webhook, err := c.service.UpdateWebhook(ctx, domainName, cr.Spec.ForProvider.EventType, &cr.Spec.ForProvider)
+	webhook, err := c.service.UpdateWebhook(ctx, domainName, cr.Spec.ForProvider.EventType, desired)
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, "failed to update webhook")
 	}
 
-	cr.Status.AtProvider = *webhook
+	setObservation(cr, webhook)
+	c.syncEvents(ctx, cr, domainName, desired)
+	if eventsSynced(cr) {
+		cr.Status.AtProvider.CredentialsRotation = trigger.Value(cr, trigger.ForceRotateCredentials)
+	}
 
 	return managed.ExternalUpdate{
 		// Optionally return any details that may be required to connect to the
@@ -274,6 +358,8 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	defer c.reconcile.Start()()
+
 	cr, ok := mg.(*v1beta1.Webhook)
 	if !ok {
 		return managed.ExternalDelete{}, errors.New(errNotWebhook)
@@ -281,13 +367,16 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	cr.SetConditions(xpv1.Deleting())
 
-	// Resolve domain reference
-	domainName, err := c.resolveDomainReference(ctx, cr)
-	if err != nil {
-		return managed.ExternalDelete{}, errors.Wrap(err, errResolveDomain)
+	domainName := cr.Spec.ForProvider.Domain
+	if domainName == "" {
+		return managed.ExternalDelete{}, errors.New(errNoDomain)
 	}
 
-	err = c.service.DeleteWebhook(ctx, domainName, cr.Spec.ForProvider.EventType)
+	if err := c.deleteEvents(ctx, cr, domainName); err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
+	err := c.service.DeleteWebhook(ctx, domainName, cr.Spec.ForProvider.EventType)
 	if err != nil && !clients.IsNotFound(err) {
 		return managed.ExternalDelete{}, errors.Wrap(err, "failed to delete webhook")
 	}
@@ -295,17 +384,94 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 	return managed.ExternalDelete{}, nil
 }
 
-// resolveDomainReference resolves the domain reference to get the domain name
-func (c *external) resolveDomainReference(ctx context.Context, cr *v1beta1.Webhook) (string, error) {
-	// For now, use the domain reference name as the domain name
-	// In a full implementation, this would look up the actual Domain resource
-	// and get its external name or domain name
-	if cr.Spec.ForProvider.DomainRef.Name != "" {
-		return cr.Spec.ForProvider.DomainRef.Name, nil
+// desiredParameters returns the parameters to apply in Mailgun, with the URL
+// resolved from the ServiceRef and the password from the PasswordSecretRef
+// when they are set
+func (c *external) desiredParameters(ctx context.Context, cr *v1beta1.Webhook) (*v1beta1.WebhookParameters, error) {
+	params := cr.Spec.ForProvider.DeepCopy()
+	if params.ServiceRef != nil {
+		url, err := c.resolveServiceURL(ctx, cr.GetNamespace(), params.ServiceRef)
+		if err != nil {
+			return nil, err
+		}
+		params.URL = url
+	}
+	if params.URL == "" {
+		return nil, errors.New(errNoURL)
+	}
+
+	if params.PasswordSecretRef != nil {
+		password, err := c.resolvePassword(ctx, cr.GetNamespace(), params.PasswordSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		params.Password = &password
+	}
+	return params, nil
+}
+
+// resolvePassword reads the basic authentication password from the selected
+// Secret key. Errors name the key but never include its value.
+func (c *external) resolvePassword(ctx context.Context, namespace string, ref *xpv1.LocalSecretKeySelector) (string, error) {
+	secret := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+		return "", errors.Wrapf(err, errGetPassword, namespace, ref.Name)
 	}
 
-	// If no reference name, this is an error
-	return "", errors.New("domain reference name is required")
+	password := string(secret.Data[ref.Key])
+	if password == "" {
+		return "", errors.Errorf(errPasswordKey, namespace, ref.Name, ref.Key)
+	}
+	return password, nil
+}
+
+// resolveServiceURL builds the external URL of the referenced Service port
+// from its load balancer ingress. Only LoadBalancer Services in the Webhook's
+// own namespace are resolved, since Mailgun cannot reach in-cluster addresses.
+func (c *external) resolveServiceURL(ctx context.Context, namespace string, ref *v1beta1.ServiceReference) (string, error) {
+	svc := &corev1.Service{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, svc); err != nil {
+		return "", errors.Wrapf(err, errGetService, namespace, ref.Name)
+	}
+
+	hasPort := false
+	for _, port := range svc.Spec.Ports {
+		if port.Port == ref.Port {
+			hasPort = true
+			break
+		}
+	}
+	if !hasPort {
+		return "", errors.Errorf(errServicePort, namespace, ref.Name, ref.Port)
+	}
+	if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		return "", errors.Errorf(errServiceType, namespace, ref.Name)
+	}
+
+	host := ""
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.Hostname != "" {
+			host = ingress.Hostname
+			break
+		}
+		if ingress.IP != "" {
+			host = ingress.IP
+			break
+		}
+	}
+	if host == "" {
+		return "", errors.Errorf(errServiceIngress, namespace, ref.Name)
+	}
+
+	scheme := "http"
+	if ref.Scheme != nil && *ref.Scheme != "" {
+		scheme = *ref.Scheme
+	}
+	path := ""
+	if ref.Path != nil {
+		path = *ref.Path
+	}
+	return fmt.Sprintf("%s://%s%s", scheme, net.JoinHostPort(host, strconv.Itoa(int(ref.Port))), path), nil
 }
 
 // isWebhookUpToDate checks if the external resource is up to date
@@ -18,22 +18,41 @@ package webhook
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	bouncetypes "github.com/rossigee/provider-mailgun/apis/bounce/v1beta1"
+	complainttypes "github.com/rossigee/provider-mailgun/apis/complaint/v1beta1"
 	domaintypes "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+	ippooltypes "github.com/rossigee/provider-mailgun/apis/ippool/v1beta1"
 	mailinglisttypes "github.com/rossigee/provider-mailgun/apis/mailinglist/v1beta1"
+	mailinglistmembertypes "github.com/rossigee/provider-mailgun/apis/mailinglistmember/v1beta1"
 	routetypes "github.com/rossigee/provider-mailgun/apis/route/v1beta1"
 	smtpcredentialtypes "github.com/rossigee/provider-mailgun/apis/smtpcredential/v1beta1"
 	templatetypes "github.com/rossigee/provider-mailgun/apis/template/v1beta1"
+	unsubscribetypes "github.com/rossigee/provider-mailgun/apis/unsubscribe/v1beta1"
+	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
 	v1beta1 "github.com/rossigee/provider-mailgun/apis/webhook/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
+	"github.com/rossigee/provider-mailgun/internal/metrics"
+	"github.com/rossigee/provider-mailgun/internal/trigger"
 )
 
 // MockWebhookClient for testing
@@ -125,6 +144,50 @@ func (m *MockWebhookClient) DeleteDomain(ctx context.Context, name string) error
 	return errors.New("not implemented")
 }
 
+func (m *MockWebhookClient) ListAuthorizedRecipients(ctx context.Context) ([]domaintypes.AuthorizedRecipient, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) AddAuthorizedRecipient(ctx context.Context, email string) (*domaintypes.AuthorizedRecipient, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) DeleteAuthorizedRecipient(ctx context.Context, email string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) GetWebhookSigningKey(ctx context.Context) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) RotateWebhookSigningKey(ctx context.Context) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) UpdateDomainTracking(ctx context.Context, name string, tracking *domaintypes.DomainTracking) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) GetDomainTracking(ctx context.Context, name string) (*domaintypes.DomainTracking, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) GetDomainConnection(ctx context.Context, name string) (*domaintypes.DomainConnection, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) UpdateDomainConnection(ctx context.Context, name string, connection *domaintypes.DomainConnection) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) UpdateDKIMKey(ctx context.Context, name string, keySize int) ([]domaintypes.DNSRecord, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) ListDomains(ctx context.Context, limit, skip int) ([]*domaintypes.DomainObservation, int, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
 func (m *MockWebhookClient) CreateMailingList(ctx context.Context, list *mailinglisttypes.MailingListParameters) (*mailinglisttypes.MailingListObservation, error) {
 	return nil, errors.New("not implemented")
 }
@@ -141,6 +204,30 @@ func (m *MockWebhookClient) DeleteMailingList(ctx context.Context, address strin
 	return errors.New("not implemented")
 }
 
+func (m *MockWebhookClient) CreateMailingListMember(ctx context.Context, listAddress string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) GetMailingListMember(ctx context.Context, listAddress, address string) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) UpdateMailingListMember(ctx context.Context, listAddress, address string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) DeleteMailingListMember(ctx context.Context, listAddress, address string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) AddMailingListMembers(ctx context.Context, listAddress string, members []clients.MailingListMemberSpec, upsert bool) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) ListMailingListMembers(ctx context.Context, listAddress string) ([]*mailinglistmembertypes.MailingListMemberObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (m *MockWebhookClient) CreateRoute(ctx context.Context, route *routetypes.RouteParameters) (*routetypes.RouteObservation, error) {
 	return nil, errors.New("not implemented")
 }
@@ -157,6 +244,10 @@ func (m *MockWebhookClient) DeleteRoute(ctx context.Context, id string) error {
 	return errors.New("not implemented")
 }
 
+func (m *MockWebhookClient) ListRoutes(ctx context.Context, limit, skip int) ([]*routetypes.RouteObservation, int, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
 func (m *MockWebhookClient) CreateSMTPCredential(ctx context.Context, domain string, credential *smtpcredentialtypes.SMTPCredentialParameters) (*smtpcredentialtypes.SMTPCredentialObservation, error) {
 	return nil, errors.New("not implemented")
 }
@@ -189,6 +280,30 @@ func (m *MockWebhookClient) DeleteTemplate(ctx context.Context, domain, name str
 	return errors.New("not implemented")
 }
 
+func (m *MockWebhookClient) CreateTemplateVersion(ctx context.Context, domain, name string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) GetTemplateVersion(ctx context.Context, domain, name, tag string) (*templatetypes.TemplateVersion, string, error) {
+	return nil, "", errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) UpdateTemplateVersion(ctx context.Context, domain, name, tag string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) DeleteTemplateVersion(ctx context.Context, domain, name, tag string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) ListTemplateVersions(ctx context.Context, domain, name string) ([]templatetypes.TemplateVersion, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) RenderTemplate(ctx context.Context, domain, name string, vars map[string]interface{}) (string, error) {
+	return "", errors.New("not implemented")
+}
+
 // Bounce suppression operations
 func (m *MockWebhookClient) CreateBounce(ctx context.Context, domain string, bounce *bouncetypes.BounceParameters) (*bouncetypes.BounceObservation, error) {
 	return nil, errors.New("not implemented")
@@ -203,11 +318,11 @@ func (m *MockWebhookClient) DeleteBounce(ctx context.Context, domain, address st
 }
 
 // Complaint suppression operations
-func (m *MockWebhookClient) CreateComplaint(ctx context.Context, domain string, complaint interface{}) (interface{}, error) {
+func (m *MockWebhookClient) CreateComplaint(ctx context.Context, domain string, complaint *complainttypes.ComplaintParameters) (*complainttypes.ComplaintObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockWebhookClient) GetComplaint(ctx context.Context, domain, address string) (interface{}, error) {
+func (m *MockWebhookClient) GetComplaint(ctx context.Context, domain, address string) (*complainttypes.ComplaintObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
@@ -216,15 +331,43 @@ func (m *MockWebhookClient) DeleteComplaint(ctx context.Context, domain, address
 }
 
 // Unsubscribe suppression operations
-func (m *MockWebhookClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe interface{}) (interface{}, error) {
+func (m *MockWebhookClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe *unsubscribetypes.UnsubscribeParameters) (*unsubscribetypes.UnsubscribeObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) GetUnsubscribe(ctx context.Context, domain, address string) (*unsubscribetypes.UnsubscribeObservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) DeleteUnsubscribe(ctx context.Context, domain, address, tag string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) CreateIPPool(ctx context.Context, pool *ippooltypes.IPPoolParameters) (*ippooltypes.IPPoolObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockWebhookClient) GetUnsubscribe(ctx context.Context, domain, address string) (interface{}, error) {
+func (m *MockWebhookClient) GetIPPool(ctx context.Context, id string) (*ippooltypes.IPPoolObservation, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *MockWebhookClient) DeleteUnsubscribe(ctx context.Context, domain, address string) error {
+func (m *MockWebhookClient) ListDomainIPs(ctx context.Context, name string) ([]string, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) AssignDomainIP(ctx context.Context, name, ip string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) UnassignDomainIP(ctx context.Context, name, ip string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) UpdateIPPool(ctx context.Context, id string, pool *ippooltypes.IPPoolParameters, add, remove []string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockWebhookClient) DeleteIPPool(ctx context.Context, id string) error {
 	return errors.New("not implemented")
 }
 
@@ -248,9 +391,7 @@ func TestWebhookObserve(t *testing.T) {
 				mg: &v1beta1.Webhook{
 					Spec: v1beta1.WebhookSpec{
 						ForProvider: v1beta1.WebhookParameters{
-							DomainRef: xpv1.Reference{
-								Name: "example.com",
-							},
+							Domain:    "example.com",
 							EventType: "delivered",
 							URL:       "https://example.com/webhook",
 						},
@@ -270,9 +411,7 @@ func TestWebhookObserve(t *testing.T) {
 				mg: &v1beta1.Webhook{
 					Spec: v1beta1.WebhookSpec{
 						ForProvider: v1beta1.WebhookParameters{
-							DomainRef: xpv1.Reference{
-								Name: "notfound.com",
-							},
+							Domain:    "notfound.com",
 							EventType: "opened",
 							URL:       "https://notfound.com/webhook",
 						},
@@ -319,6 +458,96 @@ func TestWebhookObserve(t *testing.T) {
 	}
 }
 
+func TestWebhookForceRotateConsumedOnce(t *testing.T) {
+	cr := &v1beta1.Webhook{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{trigger.ForceRotateCredentials: "true"},
+		},
+		Spec: v1beta1.WebhookSpec{
+			ForProvider: v1beta1.WebhookParameters{
+				Domain:    "example.com",
+				EventType: "delivered",
+				URL:       "https://example.com/webhook",
+				Username:  stringPtr("hook"),
+				Password:  stringPtr("rotated"),
+			},
+		},
+	}
+	mockClient := &MockWebhookClient{
+		webhooks: map[string]*v1beta1.WebhookObservation{
+			"example.com/delivered": {
+				EventType: "delivered",
+				URL:       "https://example.com/webhook",
+				Username:  "hook",
+			},
+		},
+	}
+	e := &external{service: mockClient}
+
+	// The trigger forces an Update but stays set until one succeeds
+	got, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, got.ResourceUpToDate)
+	assert.False(t, got.ResourceLateInitialized)
+	assert.True(t, trigger.IsSet(cr, trigger.ForceRotateCredentials))
+
+	mockClient.err = errors.New("boom")
+	_, err = e.Update(context.Background(), cr)
+	require.Error(t, err)
+	mockClient.err = nil
+
+	got, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.False(t, got.ResourceUpToDate, "a failed rotation should be retried")
+
+	_, err = e.Update(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, "true", cr.Status.AtProvider.CredentialsRotation)
+
+	// Once recorded, the trigger is removed without another Update
+	got, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, got.ResourceUpToDate)
+	assert.True(t, got.ResourceLateInitialized)
+	assert.False(t, trigger.IsSet(cr, trigger.ForceRotateCredentials))
+
+	got, err = e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	assert.True(t, got.ResourceUpToDate)
+	assert.False(t, got.ResourceLateInitialized)
+	assert.Empty(t, cr.Status.AtProvider.CredentialsRotation, "the record is cleared so the trigger can be set again")
+}
+
+func TestWebhookReconcileDuration(t *testing.T) {
+	reconciles := func() uint64 {
+		m := &dto.Metric{}
+		require.NoError(t, metrics.ReconcileDuration.WithLabelValues(v1beta1.WebhookKind).(prometheus.Histogram).Write(m))
+		return m.GetHistogram().GetSampleCount()
+	}
+	before := reconciles()
+
+	cr := &v1beta1.Webhook{
+		Spec: v1beta1.WebhookSpec{
+			ForProvider: v1beta1.WebhookParameters{
+				Domain:    "example.com",
+				EventType: "delivered",
+				URL:       "https://example.com/webhook",
+			},
+		},
+	}
+	e := &external{service: &MockWebhookClient{}, reconcile: metrics.NewReconcileTimer(v1beta1.WebhookKind)}
+
+	// Observe and Create make up one reconcile, recorded on Disconnect
+	_, err := e.Observe(context.Background(), cr)
+	require.NoError(t, err)
+	_, err = e.Create(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, before, reconciles())
+
+	require.NoError(t, e.Disconnect(context.Background()))
+	assert.Equal(t, before+1, reconciles())
+}
+
 func TestWebhookCreate(t *testing.T) {
 	type args struct {
 		mg resource.Managed
@@ -339,9 +568,7 @@ func TestWebhookCreate(t *testing.T) {
 				mg: &v1beta1.Webhook{
 					Spec: v1beta1.WebhookSpec{
 						ForProvider: v1beta1.WebhookParameters{
-							DomainRef: xpv1.Reference{
-								Name: "new.com",
-							},
+							Domain:    "new.com",
 							EventType: "clicked",
 							URL:       "https://new.com/webhook",
 							Username:  stringPtr("webhook_user"),
@@ -376,6 +603,298 @@ func TestWebhookCreate(t *testing.T) {
 	}
 }
 
+func TestWebhookServiceRef(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	receiver := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "receiver", Namespace: "hooks"},
+		Spec: corev1.ServiceSpec{
+			Type:  corev1.ServiceTypeLoadBalancer,
+			Ports: []corev1.ServicePort{{Name: "http", Port: 8080}},
+		},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{{Hostname: "hooks.example.com"}},
+			},
+		},
+	}
+	byIP := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "by-ip", Namespace: "hooks"},
+		Spec: corev1.ServiceSpec{
+			Type:  corev1.ServiceTypeLoadBalancer,
+			Ports: []corev1.ServicePort{{Name: "https", Port: 443}},
+		},
+		Status: corev1.ServiceStatus{
+			LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{{IP: "203.0.113.10"}},
+			},
+		},
+	}
+	pending := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "hooks"},
+		Spec: corev1.ServiceSpec{
+			Type:  corev1.ServiceTypeLoadBalancer,
+			Ports: []corev1.ServicePort{{Name: "http", Port: 8080}},
+		},
+	}
+	internal := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "internal", Namespace: "hooks"},
+		Spec: corev1.ServiceSpec{
+			Type:  corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{{Name: "http", Port: 8080}},
+		},
+	}
+
+	cases := map[string]struct {
+		namespace string
+		ref       v1beta1.ServiceReference
+		wantURL   string
+		wantErr   string
+	}{
+		"LoadBalancerHostname": {
+			namespace: "hooks",
+			ref:       v1beta1.ServiceReference{Name: "receiver", Port: 8080, Path: stringPtr("/mailgun")},
+			wantURL:   "http://hooks.example.com:8080/mailgun",
+		},
+		"LoadBalancerIPHTTPS": {
+			namespace: "hooks",
+			ref:       v1beta1.ServiceReference{Name: "by-ip", Port: 443, Scheme: stringPtr("https")},
+			wantURL:   "https://203.0.113.10:443",
+		},
+		"OtherNamespace": {
+			namespace: "default",
+			ref:       v1beta1.ServiceReference{Name: "receiver", Port: 8080},
+			wantErr:   "cannot get service default/receiver",
+		},
+		"NotLoadBalancer": {
+			namespace: "hooks",
+			ref:       v1beta1.ServiceReference{Name: "internal", Port: 8080},
+			wantErr:   "service hooks/internal is not of type LoadBalancer",
+		},
+		"NoIngressYet": {
+			namespace: "hooks",
+			ref:       v1beta1.ServiceReference{Name: "pending", Port: 8080},
+			wantErr:   "service hooks/pending has no external ingress address yet",
+		},
+		"MissingService": {
+			namespace: "hooks",
+			ref:       v1beta1.ServiceReference{Name: "absent", Port: 8080},
+			wantErr:   "cannot get service hooks/absent",
+		},
+		"MissingPort": {
+			namespace: "hooks",
+			ref:       v1beta1.ServiceReference{Name: "receiver", Port: 9090},
+			wantErr:   "service hooks/receiver has no port 9090",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1beta1.Webhook{
+				ObjectMeta: metav1.ObjectMeta{Name: "hook", Namespace: tc.namespace},
+				Spec: v1beta1.WebhookSpec{
+					ForProvider: v1beta1.WebhookParameters{
+						Domain:     "example.com",
+						EventType:  "delivered",
+						URL:        "https://ignored.example.com/webhook",
+						ServiceRef: &tc.ref,
+					},
+				},
+			}
+			kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(receiver, byIP, pending, internal).Build()
+			e := &external{service: &MockWebhookClient{}, kube: kube}
+
+			_, err := e.Create(context.Background(), cr)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantURL, cr.Status.AtProvider.URL)
+			assert.Equal(t, "https://ignored.example.com/webhook", cr.Spec.ForProvider.URL)
+		})
+	}
+}
+
+// logRecorder records every log line as text
+type logRecorder struct {
+	lines *[]string
+}
+
+func (l logRecorder) Info(msg string, keysAndValues ...interface{}) {
+	*l.lines = append(*l.lines, fmt.Sprint(append([]interface{}{msg}, keysAndValues...)...))
+}
+
+func (l logRecorder) Debug(msg string, keysAndValues ...interface{}) {
+	*l.lines = append(*l.lines, fmt.Sprint(append([]interface{}{msg}, keysAndValues...)...))
+}
+
+func (l logRecorder) WithValues(keysAndValues ...interface{}) logging.Logger {
+	*l.lines = append(*l.lines, fmt.Sprint(keysAndValues...))
+	return l
+}
+
+func TestWebhookPasswordSecretRef(t *testing.T) {
+	const password = "s3cret-hook-password"
+
+	var lines []string
+	clients.SetLogger(logRecorder{lines: &lines})
+	defer clients.SetLogger(logging.NewNopLogger())
+
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		sent = append(sent, r.PostForm.Get("password"))
+		_, _ = w.Write([]byte(`{"webhook":{"urls":["https://example.com/webhook"]}}`))
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "hook-auth", Namespace: "hooks"},
+		Data:       map[string][]byte{"password": []byte(password)},
+	}
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+
+	service := clients.NewClient(&clients.Config{APIKey: "test-key", BaseURL: server.URL + "/v3", HTTPClient: &http.Client{}})
+	e := &external{service: service, kube: kube}
+
+	newWebhook := func(key string) *v1beta1.Webhook {
+		return &v1beta1.Webhook{
+			ObjectMeta: metav1.ObjectMeta{Name: "hook", Namespace: "hooks"},
+			Spec: v1beta1.WebhookSpec{
+				ForProvider: v1beta1.WebhookParameters{
+					Domain:    "example.com",
+					EventType: "delivered",
+					URL:       "https://example.com/webhook",
+					Username:  stringPtr("hook"),
+					PasswordSecretRef: &xpv1.LocalSecretKeySelector{
+						LocalSecretReference: xpv1.LocalSecretReference{Name: "hook-auth"},
+						Key:                  key,
+					},
+				},
+			},
+		}
+	}
+
+	// The password is resolved from the secret and sent to Mailgun
+	cr := newWebhook("password")
+	_, err := e.Create(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{password}, sent)
+	assert.Nil(t, cr.Spec.ForProvider.Password, "the resolved password should not be written to the spec")
+
+	// A missing key is reported without reaching Mailgun
+	_, err = e.Create(context.Background(), newWebhook("absent"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "webhook password secret hooks/hook-auth has no key absent")
+	assert.Len(t, sent, 1)
+
+	require.NotEmpty(t, lines)
+	for _, line := range lines {
+		assert.False(t, strings.Contains(line, password), "log line leaks the password: %s", line)
+	}
+}
+
+func TestWebhookResolveDomain(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, domaintypes.SchemeBuilder.AddToScheme(scheme))
+	ready := &domaintypes.Domain{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-domain", Namespace: "hooks", Labels: map[string]string{"region": "eu"}},
+		Spec:       domaintypes.DomainSpec{ForProvider: domaintypes.DomainParameters{Name: "mg.example.com"}},
+	}
+	ready.SetConditions(xpv1.Available())
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ready).Build()
+
+	cases := map[string]struct {
+		ref      *xpv1.NamespacedReference
+		selector *xpv1.NamespacedSelector
+		want     string
+	}{
+		"Reference": {ref: &xpv1.NamespacedReference{Name: "example-domain"}, want: "mg.example.com"},
+		"Selector":  {selector: &xpv1.NamespacedSelector{MatchLabels: map[string]string{"region": "eu"}}, want: "mg.example.com"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1beta1.Webhook{ObjectMeta: metav1.ObjectMeta{Name: "hook", Namespace: "hooks"}}
+			cr.Spec.ForProvider.DomainRef = tc.ref
+			cr.Spec.ForProvider.DomainSelector = tc.selector
+
+			require.NoError(t, cr.ResolveReferences(context.Background(), kube))
+			assert.Equal(t, tc.want, cr.Spec.ForProvider.Domain)
+		})
+	}
+
+	t.Run("Unresolved", func(t *testing.T) {
+		e := &external{service: &MockWebhookClient{}}
+		cr := &v1beta1.Webhook{Spec: v1beta1.WebhookSpec{ForProvider: v1beta1.WebhookParameters{EventType: "delivered"}}}
+
+		_, err := e.Observe(context.Background(), cr)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errNoDomain)
+
+		// A webhook whose domain never resolved has nothing to delete
+		now := metav1.Now()
+		cr.SetDeletionTimestamp(&now)
+		obs, err := e.Observe(context.Background(), cr)
+		require.NoError(t, err)
+		assert.False(t, obs.ResourceExists)
+	})
+}
+
+func TestConnectUsesRotatedAPIKey(t *testing.T) {
+	const (
+		oldKey = "key-0123456789abcdef0123456789abcdef"
+		newKey = "key-fedcba9876543210fedcba9876543210"
+	)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, apisv1beta1.SchemeBuilder.AddToScheme(scheme))
+	creds := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "mailgun", Namespace: "hooks"},
+		Data:       map[string][]byte{"credentials": []byte(oldKey)},
+	}
+	pc := &apisv1beta1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "hooks"},
+		Spec: apisv1beta1.ProviderConfigSpec{Credentials: apisv1beta1.ProviderCredentials{
+			Source: xpv1.CredentialsSourceSecret,
+			CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: &xpv1.SecretKeySelector{
+				SecretReference: xpv1.SecretReference{Name: "mailgun", Namespace: "hooks"},
+				Key:             "credentials",
+			}},
+		}},
+	}
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(creds, pc).Build()
+
+	var keys []string
+	c := &connector{
+		kube:  kube,
+		usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+		newServiceFn: func(config *clients.Config) clients.Client {
+			keys = append(keys, config.APIKey)
+			return &MockWebhookClient{}
+		},
+	}
+	cr := &v1beta1.Webhook{ObjectMeta: metav1.ObjectMeta{Name: "hook", Namespace: "hooks"}}
+	cr.Spec.ProviderConfigReference = &xpv1.ProviderConfigReference{Name: "default"}
+
+	_, err := c.Connect(context.Background(), cr)
+	require.NoError(t, err)
+
+	// Clients are not cached, so the next Connect after the secret changes
+	// uses the new key
+	creds.Data["credentials"] = []byte(newKey)
+	require.NoError(t, kube.Update(context.Background(), creds))
+	_, err = c.Connect(context.Background(), cr)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{oldKey, newKey}, keys)
+}
+
 func TestWebhookUpdate(t *testing.T) {
 	type args struct {
 		mg resource.Managed
@@ -396,9 +915,7 @@ func TestWebhookUpdate(t *testing.T) {
 				mg: &v1beta1.Webhook{
 					Spec: v1beta1.WebhookSpec{
 						ForProvider: v1beta1.WebhookParameters{
-							DomainRef: xpv1.Reference{
-								Name: "existing.com",
-							},
+							Domain:    "existing.com",
 							EventType: "delivered",
 							URL:       "https://updated.com/webhook",
 							Username:  stringPtr("updated_user"),
@@ -461,9 +978,7 @@ func TestWebhookDelete(t *testing.T) {
 				mg: &v1beta1.Webhook{
 					Spec: v1beta1.WebhookSpec{
 						ForProvider: v1beta1.WebhookParameters{
-							DomainRef: xpv1.Reference{
-								Name: "delete.com",
-							},
+							Domain:    "delete.com",
 							EventType: "opened",
 							URL:       "https://delete.com/webhook",
 						},
@@ -504,6 +1019,44 @@ func TestWebhookDelete(t *testing.T) {
 	}
 }
 
+func TestWebhookDeleteDomainGone(t *testing.T) {
+	domainGone := &clients.APIError{StatusCode: 404, Message: "Domain not found: gone.com"}
+	webhookGone := &clients.APIError{StatusCode: 404, Message: "Webhook not found"}
+	cr := func() *v1beta1.Webhook {
+		return &v1beta1.Webhook{
+			Spec: v1beta1.WebhookSpec{
+				ForProvider: v1beta1.WebhookParameters{
+					Domain:    "gone.com",
+					EventType: "opened",
+					URL:       "https://gone.com/webhook",
+				},
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		err     error
+		wantErr bool
+	}{
+		"DomainGone":  {err: domainGone},
+		"WebhookGone": {err: webhookGone},
+		"OtherError":  {err: &clients.APIError{StatusCode: 500, Message: "Internal error"}, wantErr: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{service: &MockWebhookClient{err: tc.err}}
+
+			_, err := e.Delete(context.Background(), cr())
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
 // Helper function
 func stringPtr(s string) *string {
 	return &s
@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package description composes Mailgun description fields that trace a
+// Mailgun object back to the managed resource that owns it.
+package description
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MaxLength bounds composed descriptions. Metadata is truncated to fit; the
+// user-supplied description is never cut.
+const MaxLength = 255
+
+var (
+	mu   sync.RWMutex
+	keys []string
+)
+
+// SetKeys sets the label and annotation keys whose values are propagated
+// alongside the managed-by tag. Labels take precedence over annotations with
+// the same key.
+func SetKeys(k []string) {
+	mu.Lock()
+	defer mu.Unlock()
+	keys = append([]string(nil), k...)
+	sort.Strings(keys)
+}
+
+// Compose returns desc followed by metadata identifying obj, for example
+// "Inbound support (managed-by: crossplane/mail/support; team=ops)". The
+// result is at most MaxLength characters unless desc alone is longer, in
+// which case desc is returned unchanged.
+func Compose(obj metav1.Object, desc *string) *string {
+	meta := tags(obj)
+
+	base := ""
+	if desc != nil {
+		base = *desc
+	}
+
+	var composed string
+	if base == "" {
+		composed = truncate(meta, MaxLength)
+	} else {
+		// Room left for the metadata once the separator and brackets are added
+		room := MaxLength - len(base) - len(" ()")
+		if room <= 0 {
+			return desc
+		}
+		composed = base + " (" + truncate(meta, room) + ")"
+	}
+	return &composed
+}
+
+// ManagedBy reports whether desc carries the managed-by tag Compose adds for
+// obj. A tag cut short by truncation does not count.
+func ManagedBy(obj metav1.Object, desc string) bool {
+	tag := managedByTag(obj)
+	for rest := desc; ; {
+		i := strings.Index(rest, tag)
+		if i < 0 {
+			return false
+		}
+		rest = rest[i+len(tag):]
+		if rest == "" || rest[0] == ';' || rest[0] == ')' {
+			return true
+		}
+	}
+}
+
+func managedByTag(obj metav1.Object) string {
+	owner := obj.GetName()
+	if ns := obj.GetNamespace(); ns != "" {
+		owner = ns + "/" + owner
+	}
+	return "managed-by: crossplane/" + owner
+}
+
+func tags(obj metav1.Object) string {
+	parts := []string{managedByTag(obj)}
+
+	mu.RLock()
+	defer mu.RUnlock()
+	labels, annotations := obj.GetLabels(), obj.GetAnnotations()
+	for _, k := range keys {
+		if v, ok := labels[k]; ok {
+			parts = append(parts, k+"="+v)
+		} else if v, ok := annotations[k]; ok {
+			parts = append(parts, k+"="+v)
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// truncate shortens s to at most n bytes, marking the cut with an ellipsis
+// and never splitting a multi-byte character.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	suffix := "..."
+	if n <= len(suffix) {
+		suffix = ""
+	}
+	cut := n - len(suffix)
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + suffix
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package description
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestCompose(t *testing.T) {
+	defer SetKeys(nil)
+
+	obj := &metav1.ObjectMeta{
+		Name:        "support",
+		Namespace:   "mail",
+		Labels:      map[string]string{"team": "ops"},
+		Annotations: map[string]string{"team": "ignored", "ticket": "OPS-42"},
+	}
+
+	cases := map[string]struct {
+		keys []string
+		desc *string
+		want string
+	}{
+		"NoDescription": {
+			want: "managed-by: crossplane/mail/support",
+		},
+		"WithDescription": {
+			desc: strPtr("Inbound support"),
+			want: "Inbound support (managed-by: crossplane/mail/support)",
+		},
+		"WithKeys": {
+			keys: []string{"ticket", "team", "missing"},
+			desc: strPtr("Inbound support"),
+			want: "Inbound support (managed-by: crossplane/mail/support; team=ops; ticket=OPS-42)",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			SetKeys(tc.keys)
+			got := Compose(obj, tc.desc)
+			assert.Equal(t, tc.want, *got)
+		})
+	}
+}
+
+func TestComposeBounded(t *testing.T) {
+	defer SetKeys(nil)
+	SetKeys([]string{"notes"})
+
+	obj := &metav1.ObjectMeta{
+		Name:        "support",
+		Annotations: map[string]string{"notes": strings.Repeat("é", MaxLength)},
+	}
+
+	got := Compose(obj, strPtr("Inbound support"))
+	assert.LessOrEqual(t, len(*got), MaxLength)
+	assert.True(t, utf8.ValidString(*got), "truncation must not split characters")
+	assert.True(t, strings.HasPrefix(*got, "Inbound support (managed-by: crossplane/support; notes="))
+	assert.True(t, strings.HasSuffix(*got, "...)"))
+
+	// A description that is already too long is left alone.
+	long := strings.Repeat("x", MaxLength+10)
+	assert.Equal(t, long, *Compose(obj, &long))
+}
+
+func TestManagedBy(t *testing.T) {
+	obj := &metav1.ObjectMeta{Name: "support", Namespace: "mail"}
+
+	cases := map[string]struct {
+		desc string
+		want bool
+	}{
+		"TagOnly": {
+			desc: "managed-by: crossplane/mail/support",
+			want: true,
+		},
+		"WithDescription": {
+			desc: "Inbound support (managed-by: crossplane/mail/support)",
+			want: true,
+		},
+		"WithKeys": {
+			desc: "Inbound support (managed-by: crossplane/mail/support; team=ops)",
+			want: true,
+		},
+		"OtherOwnerWithSamePrefix": {
+			desc: "managed-by: crossplane/mail/support-eu",
+			want: false,
+		},
+		"Truncated": {
+			desc: "managed-by: crossplane/mail/sup...",
+			want: false,
+		},
+		"Untagged": {
+			desc: "Inbound support",
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, ManagedBy(obj, tc.desc))
+		})
+	}
+}
@@ -32,6 +32,7 @@ const (
 	ErrorCodeNetworkTimeout     ErrorCode = "NetworkTimeoutError"
 	ErrorCodeRateLimited        ErrorCode = "RateLimitedError"
 	ErrorCodeServiceUnavailable ErrorCode = "ServiceUnavailableError"
+	ErrorCodePlanLimited        ErrorCode = "PlanLimitedError"
 
 	// Resource errors
 	ErrorCodeResourceNotFound ErrorCode = "ResourceNotFoundError"
@@ -97,6 +98,8 @@ func (e *ProviderError) GetConditionReason() string {
 		return "NotFound"
 	case ErrorCodeValidationFailed:
 		return "ValidationFailed"
+	case ErrorCodePlanLimited:
+		return "PlanLimited"
 	default:
 		return "Error"
 	}
@@ -202,6 +205,20 @@ func NewRateLimitError(retryAfter string) *ProviderError {
 	)
 }
 
+// NewPlanLimitedError creates an error for features not included in the
+// account's Mailgun plan
+func NewPlanLimitedError(cause error) *ProviderError {
+	return NewProviderError(
+		ErrorCodePlanLimited,
+		"Feature is not available on the current Mailgun plan",
+		cause,
+	).WithSuggestedAction(
+		"Upgrade the Mailgun plan or remove the plan-gated setting from the resource",
+	).WithTroubleshootURL(
+		"https://www.mailgun.com/pricing/",
+	)
+}
+
 // NewNetworkTimeoutError creates a network timeout error
 func NewNetworkTimeoutError(operation string, cause error) *ProviderError {
 	return NewProviderError(
@@ -248,6 +265,31 @@ func NewResourceNotFoundError(resourceType, identifier string) *ProviderError {
 	).WithResourceRef(identifier)
 }
 
+// CodeFromHTTPStatus returns the error code of an HTTP error status. It agrees
+// with ErrorFromHTTPResponse on the statuses that handles.
+func CodeFromHTTPStatus(status int) ErrorCode {
+	switch {
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		return ErrorCodeValidationFailed
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return ErrorCodeAuthentication
+	case status == http.StatusPaymentRequired:
+		return ErrorCodePlanLimited
+	case status == http.StatusNotFound:
+		return ErrorCodeResourceNotFound
+	case status == http.StatusRequestTimeout:
+		return ErrorCodeNetworkTimeout
+	case status == http.StatusConflict:
+		return ErrorCodeResourceConflict
+	case status == http.StatusTooManyRequests:
+		return ErrorCodeRateLimited
+	case status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout:
+		return ErrorCodeServiceUnavailable
+	default:
+		return ErrorCodeUnknown
+	}
+}
+
 // ErrorFromHTTPResponse creates an appropriate error from HTTP response
 func ErrorFromHTTPResponse(resp *http.Response, operation string) *ProviderError {
 	if resp == nil {
@@ -192,6 +192,28 @@ func TestErrorFromHTTPResponse(t *testing.T) {
 	})
 }
 
+func TestCodeFromHTTPStatus(t *testing.T) {
+	tests := map[int]ErrorCode{
+		400: ErrorCodeValidationFailed,
+		401: ErrorCodeAuthentication,
+		402: ErrorCodePlanLimited,
+		403: ErrorCodeAuthentication,
+		404: ErrorCodeResourceNotFound,
+		408: ErrorCodeNetworkTimeout,
+		409: ErrorCodeResourceConflict,
+		422: ErrorCodeValidationFailed,
+		429: ErrorCodeRateLimited,
+		500: ErrorCodeUnknown,
+		503: ErrorCodeServiceUnavailable,
+	}
+
+	for status, want := range tests {
+		t.Run(fmt.Sprintf("HTTP%d", status), func(t *testing.T) {
+			assert.Equal(t, want, CodeFromHTTPStatus(status))
+		})
+	}
+}
+
 func TestErrorUtilities(t *testing.T) {
 	t.Run("WrapError", func(t *testing.T) {
 		// Test wrapping regular error
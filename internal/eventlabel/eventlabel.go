@@ -0,0 +1,59 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eventlabel labels the Kubernetes events the provider emits, so the
+// events of several provider instances in one cluster can be told apart.
+package eventlabel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var label string
+
+// SetLabel sets the label appended to every event, e.g. the instance name.
+// An empty label leaves events unchanged.
+func SetLabel(l string) {
+	label = strings.TrimSpace(l)
+}
+
+// Wrap returns a recorder that appends the label to the message of every
+// event r records, or r itself when no label is set. The label is carried in
+// the message because event annotations are not forwarded to Kubernetes.
+func Wrap(r event.Recorder) event.Recorder {
+	if label == "" {
+		return r
+	}
+	return &recorder{wrapped: r, label: label}
+}
+
+type recorder struct {
+	wrapped event.Recorder
+	label   string
+}
+
+func (r *recorder) Event(obj runtime.Object, e event.Event) {
+	e.Message = fmt.Sprintf("%s [%s]", e.Message, r.label)
+	r.wrapped.Event(obj, e)
+}
+
+func (r *recorder) WithAnnotations(keysAndValues ...string) event.Recorder {
+	return &recorder{wrapped: r.wrapped.WithAnnotations(keysAndValues...), label: r.label}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventlabel
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// eventRecorder records the events it is given
+type eventRecorder struct {
+	events []event.Event
+}
+
+func (r *eventRecorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *eventRecorder) WithAnnotations(_ ...string) event.Recorder {
+	return r
+}
+
+func TestWrap(t *testing.T) {
+	t.Cleanup(func() { SetLabel("") })
+
+	cases := map[string]struct {
+		label string
+		want  string
+	}{
+		"NoLabel": {
+			want: "cannot create domain",
+		},
+		"Label": {
+			label: " eu-west ",
+			want:  "cannot create domain [eu-west]",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			SetLabel(tc.label)
+			inner := &eventRecorder{}
+
+			Wrap(inner).Event(nil, event.Warning("CannotCreate", errors.New("cannot create domain")))
+			Wrap(inner).WithAnnotations("key", "value").Event(nil, event.Normal("Created", "cannot create domain"))
+
+			require.Len(t, inner.events, 2)
+			for _, e := range inner.events {
+				assert.Equal(t, tc.want, e.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package failfast stops managed resources whose ProviderConfig does not
+// exist from connecting to Mailgun. Such resources are marked with the
+// ProviderConfigNotFound condition and fail before connecting, and are
+// retried as the controller's rate limiter allows, so they back off while the
+// ProviderConfig stays missing and recover on the first retry after it is
+// created.
+package failfast
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
+)
+
+const errMissing = "ProviderConfig %q does not exist"
+
+var enabled atomic.Bool
+
+// SetEnabled turns failing fast on missing ProviderConfigs on or off for the
+// whole provider.
+func SetEnabled(on bool) {
+	enabled.Store(on)
+}
+
+// Enabled reports whether failing fast on missing ProviderConfigs is on.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// Wrap returns a connector that fails fast for resources whose ProviderConfig
+// is missing, or c itself when failing fast is off.
+func Wrap(kube client.Client, c managed.ExternalConnector) managed.ExternalConnector {
+	if !Enabled() {
+		return c
+	}
+	return NewConnector(kube, c)
+}
+
+// NewConnector returns a connector that looks up the ProviderConfig of each
+// resource before connecting. A ProviderConfig that does not exist, in the
+// resource's namespace or cluster-wide, sets the ProviderConfigNotFound
+// condition and fails Connect. Other lookup errors are left for c to report,
+// as they may be transient. Nothing is remembered between reconciles: the
+// lookup is served from the manager's cache, and the failed reconcile is
+// requeued with the controller's backoff.
+func NewConnector(kube client.Client, c managed.ExternalConnector) managed.ExternalConnector {
+	g := &guard{kube: kube}
+	return g.connector(c)
+}
+
+type guard struct {
+	kube client.Client
+}
+
+func (g *guard) connector(c managed.ExternalConnector) managed.ExternalConnector {
+	return managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		if err := g.check(ctx, mg); err != nil {
+			return nil, err
+		}
+		return c.Connect(ctx, mg)
+	})
+}
+
+// check returns an error if the ProviderConfig of mg is missing
+func (g *guard) check(ctx context.Context, mg resource.Managed) error {
+	name := providerConfigName(mg)
+	missing, err := g.missing(ctx, mg.GetNamespace(), name)
+	if err != nil || !missing {
+		if err == nil && mg.GetCondition(apisv1beta1.TypeProviderConfigNotFound).Status == corev1.ConditionTrue {
+			mg.SetConditions(apisv1beta1.ProviderConfigFound())
+		}
+		return nil
+	}
+
+	err = errors.Errorf(errMissing, name)
+	mg.SetConditions(apisv1beta1.ProviderConfigNotFound(err.Error()))
+	return err
+}
+
+// missing reports whether the named ProviderConfig is known not to exist.
+// Like the controllers, it looks in namespace before looking cluster-wide.
+func (g *guard) missing(ctx context.Context, namespace, name string) (bool, error) {
+	for _, ns := range []string{namespace, ""} {
+		err := g.kube.Get(ctx, types.NamespacedName{Name: name, Namespace: ns}, &apisv1beta1.ProviderConfig{})
+		switch {
+		case err == nil:
+			return false, nil
+		case !kerrors.IsNotFound(err):
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// providerConfigName returns the name of the ProviderConfig mg references,
+// which, as in the controllers, is "default" when it references none
+func providerConfigName(mg resource.Managed) string {
+	if r, ok := mg.(interface {
+		GetProviderConfigReference() *xpv1.ProviderConfigReference
+	}); ok {
+		if ref := r.GetProviderConfigReference(); ref != nil && ref.Name != "" {
+			return ref.Name
+		}
+	}
+	return "default"
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package failfast
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/route/v1beta1"
+	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
+)
+
+// counter counts the connects that reach the wrapped connector
+type counter struct {
+	connects int
+}
+
+func (c *counter) connector() managed.ExternalConnector {
+	return managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		c.connects++
+		return &managed.ExternalClientFns{}, nil
+	})
+}
+
+func route(pc string) *v1beta1.Route {
+	cr := &v1beta1.Route{ObjectMeta: metav1.ObjectMeta{Name: "r", Namespace: "team-a", UID: "uid-1"}}
+	cr.Spec.ProviderConfigReference = &xpv1.ProviderConfigReference{Name: pc}
+	return cr
+}
+
+// newGuard returns a guard over a fake client holding objs, whose lookups
+// are counted in gets and fail with getErr when it is set
+func newGuard(t *testing.T, gets *int, getErr error, objs ...client.Object) *guard {
+	t.Helper()
+	s := runtime.NewScheme()
+	require.NoError(t, apisv1beta1.SchemeBuilder.AddToScheme(s))
+
+	kube := fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			*gets++
+			if getErr != nil {
+				return getErr
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+	}).Build()
+	return &guard{kube: kube}
+}
+
+func TestMissingProviderConfig(t *testing.T) {
+	var gets int
+	g := newGuard(t, &gets, nil)
+	inner := &counter{}
+	c := g.connector(inner.connector())
+	cr := route("absent")
+
+	_, err := c.Connect(context.Background(), cr)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `ProviderConfig "absent" does not exist`)
+	assert.Equal(t, 0, inner.connects)
+	assert.Equal(t, 2, gets, "the namespace and then the cluster should be searched")
+
+	notFound := cr.GetCondition(apisv1beta1.TypeProviderConfigNotFound)
+	assert.Equal(t, corev1.ConditionTrue, notFound.Status)
+	assert.Equal(t, apisv1beta1.ReasonPCMissing, notFound.Reason)
+
+	// The next retry notices the ProviderConfig as soon as it is created
+	pc := &apisv1beta1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "absent", Namespace: "team-a"}}
+	require.NoError(t, g.kube.Create(context.Background(), pc))
+	_, err = c.Connect(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, 1, inner.connects)
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(apisv1beta1.TypeProviderConfigNotFound).Status)
+}
+
+func TestProviderConfigFoundAfterMissing(t *testing.T) {
+	var gets int
+	pc := &apisv1beta1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "late", Namespace: "team-a"}}
+	g := newGuard(t, &gets, nil, pc)
+	inner := &counter{}
+	c := g.connector(inner.connector())
+	cr := route("late")
+	cr.SetConditions(apisv1beta1.ProviderConfigNotFound(`ProviderConfig "late" does not exist`))
+
+	_, err := c.Connect(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, 1, inner.connects)
+	assert.Equal(t, 1, gets)
+
+	found := cr.GetCondition(apisv1beta1.TypeProviderConfigNotFound)
+	assert.Equal(t, corev1.ConditionFalse, found.Status)
+	assert.Equal(t, apisv1beta1.ReasonPCFound, found.Reason)
+}
+
+func TestTransientLookupErrorIsNotMissing(t *testing.T) {
+	var gets int
+	g := newGuard(t, &gets, errors.New("apiserver unavailable"))
+	inner := &counter{}
+	c := g.connector(inner.connector())
+	cr := route("default")
+
+	_, err := c.Connect(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, 1, inner.connects, "the wrapped connector should report transient errors")
+	assert.Equal(t, corev1.ConditionUnknown, cr.GetCondition(apisv1beta1.TypeProviderConfigNotFound).Status, "no condition should be set")
+}
+
+func TestWrapDisabled(t *testing.T) {
+	SetEnabled(false)
+	inner := &counter{}
+	c := Wrap(nil, inner.connector())
+
+	_, err := c.Connect(context.Background(), route("absent"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, inner.connects)
+}
@@ -25,4 +25,29 @@ const (
 	// Management Policies. See the below design for more details.
 	// https://github.com/crossplane/crossplane/blob/master/design/design-doc-management-policies.md
 	EnableAlphaManagementPolicies feature.Flag = "EnableAlphaManagementPolicies"
+
+	// EnableDomainCacheWarmup lists all domains of an account once when it
+	// is first connected to, so the first Observe of each Domain after a
+	// restart does not need its own GET.
+	EnableDomainCacheWarmup feature.Flag = "EnableDomainCacheWarmup"
+
+	// EnableDescriptionMetadata appends a managed-by tag, and any configured
+	// label or annotation values, to the description of Mailgun routes,
+	// templates and mailing lists.
+	EnableDescriptionMetadata feature.Flag = "EnableDescriptionMetadata"
+
+	// EnableDependentDeletionOrdering holds the deletion of a Domain until
+	// the webhooks, bounces, complaints and unsubscribes referencing it
+	// through their domainRef are gone.
+	EnableDependentDeletionOrdering feature.Flag = "EnableDependentDeletionOrdering"
+
+	// EnableTerminalErrorHalt stops reconciling a Domain that Mailgun
+	// rejected as invalid until its spec changes, instead of retrying the
+	// same rejected request forever.
+	EnableTerminalErrorHalt feature.Flag = "EnableTerminalErrorHalt"
+
+	// EnableRotationLock serializes the Creates of each SMTPCredential
+	// within the provider, so concurrent reconciles cannot interleave the
+	// deletes and creates of a credential rotation.
+	EnableRotationLock feature.Flag = "EnableRotationLock"
 )
@@ -154,7 +154,9 @@ type EnhancedSMTPCredential struct {
 	UsageStats *UsageStatistics
 }
 
-// UsageStatistics tracks credential usage
+// UsageStatistics tracks credential usage. Mailgun's credentials API reports
+// only the login, mailbox size and creation time, so these fields cannot be
+// observed from Mailgun and are left for callers to populate.
 type UsageStatistics struct {
 	// LastUsed is when the credential was last used
 	LastUsed *time.Time
@@ -502,6 +504,15 @@ func (m *SMTPCredentialManager) ValidateCredentialAccess(
 	return nil
 }
 
+// emailPattern matches the email addresses Mailgun accepts as SMTP logins
+// and mailing list addresses
+var emailPattern = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+
+// IsEmailAddress reports whether s is a well-formed email address
+func IsEmailAddress(s string) bool {
+	return emailPattern.MatchString(s)
+}
+
 // LoginValidator validates SMTP login format
 type LoginValidator struct {
 	allowedDomains    []string
@@ -539,8 +550,7 @@ func (v *LoginValidator) ValidateLogin(login string) error {
 	}
 
 	// Check email format
-	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
-	if !emailRegex.MatchString(login) {
+	if !IsEmailAddress(login) {
 		return errors.NewValidationError("login", "login must be a valid email address")
 	}
 
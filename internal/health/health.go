@@ -18,14 +18,22 @@ package health
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/rossigee/provider-mailgun/apis/v1beta1"
 	"github.com/rossigee/provider-mailgun/internal/clients"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"net/http"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sync"
 	"time"
 )
 
+// MailgunCheckTTL is how long the result of a Mailgun check is reused, so
+// frequent probes do not each call the API
+const MailgunCheckTTL = 30 * time.Second
+
 // HealthChecker provides health checking functionality
 type HealthChecker struct {
 	kubeClient   client.Client
@@ -55,24 +63,61 @@ func CreateMailgunHealthCheck(config *clients.Config) func(context.Context) erro
 	if config == nil {
 		return nil
 	}
+	return Cached(MailgunCheck(clients.NewClient(config)), MailgunCheckTTL)
+}
 
+// MailgunCheck returns a check that lists a single domain, the cheapest
+// authenticated call every API key may make. It fails when Mailgun is
+// unreachable or rejects the API key.
+func MailgunCheck(c clients.Client) func(context.Context) error {
 	return func(ctx context.Context) error {
-		// Create a client with the provided config
-		client := clients.NewClient(config)
+		_, _, err := c.ListDomains(ctx, 1, 0)
+		var apiErr *clients.APIError
+		switch {
+		case err == nil:
+			return nil
+		case errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden):
+			return fmt.Errorf("mailgun API key rejected: %w", err)
+		default:
+			return fmt.Errorf("mailgun API not accessible: %w", err)
+		}
+	}
+}
 
-		// Try to get a non-existent domain to test API connectivity
-		// This should return a 404, which means the API is accessible
-		_, err := client.GetDomain(ctx, "health-check-non-existent-domain.test")
+// ProviderConfigCheck returns a check that runs MailgunCheck with the
+// credentials of the named ProviderConfig, read afresh on each call so that
+// rotated credentials are picked up.
+func ProviderConfigCheck(kube client.Client, name types.NamespacedName) func(context.Context) error {
+	return func(ctx context.Context) error {
+		pc := &v1beta1.ProviderConfig{}
+		if err := kube.Get(ctx, name, pc); err != nil {
+			return fmt.Errorf("cannot get ProviderConfig %s: %w", name, err)
+		}
+		config, err := clients.ConfigFromProviderConfig(ctx, kube, pc)
 		if err != nil {
-			// Check if it's a "not found" error, which means API is working
-			if clients.IsNotFound(err) {
-				return nil // API is accessible
-			}
-			return fmt.Errorf("mailgun API not accessible: %w", err)
+			return fmt.Errorf("cannot configure a client from ProviderConfig %s: %w", name, err)
 		}
+		return MailgunCheck(clients.NewClient(config))(ctx)
+	}
+}
 
-		// If no error, API is accessible (though this is unlikely for a non-existent domain)
-		return nil
+// Cached returns a check that reuses the result of check for ttl after each
+// call. Concurrent callers wait for a single call rather than each making one.
+func Cached(check func(context.Context) error, ttl time.Duration) func(context.Context) error {
+	var (
+		mu      sync.Mutex
+		checked time.Time
+		result  error
+	)
+	return func(ctx context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if !checked.IsZero() && time.Since(checked) < ttl {
+			return result
+		}
+		result = check(ctx)
+		checked = time.Now()
+		return result
 	}
 }
 
@@ -19,15 +19,20 @@ package health
 import (
 	"context"
 	"fmt"
+	"github.com/rossigee/provider-mailgun/apis/v1beta1"
 	"github.com/rossigee/provider-mailgun/internal/clients"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"net/http"
 	"net/http/httptest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"testing"
+	"time"
 )
 
 // mockKubeClient implements the client.Client interface for testing
@@ -171,11 +176,10 @@ func TestCreateMailgunHealthCheck(t *testing.T) {
 	})
 
 	t.Run("HealthCheckExecution", func(t *testing.T) {
-		// Create a test server that responds with 404 for health checks
+		// Create a test server that lists a single domain
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if r.URL.Path == "/v3/domains/health-check-non-existent-domain.test" {
-				w.WriteHeader(http.StatusNotFound)
-				_, _ = w.Write([]byte(`{"message": "Domain not found"}`))
+			if r.URL.Path == "/v3/domains" && r.URL.Query().Get("limit") == "1" {
+				_, _ = w.Write([]byte(`{"total_count": 0, "items": []}`))
 				return
 			}
 			w.WriteHeader(http.StatusInternalServerError)
@@ -193,7 +197,6 @@ func TestCreateMailgunHealthCheck(t *testing.T) {
 		ctx := context.Background()
 		err := checkFunc(ctx)
 
-		// Should succeed because 404 means API is accessible
 		assert.NoError(t, err)
 	})
 
@@ -225,3 +228,57 @@ func TestCreateMailgunHealthCheck(t *testing.T) {
 		assert.Contains(t, err.Error(), "mailgun API not accessible")
 	})
 }
+
+func TestMailgunCheck(t *testing.T) {
+	var requests int
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			_, _ = w.Write([]byte(`{"total_count": 1, "items": [{"name": "example.com"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"message": "Invalid private key"}`))
+	}))
+	defer server.Close()
+
+	client := clients.NewClient(&clients.Config{APIKey: "test-key", BaseURL: server.URL + "/v3", HTTPClient: &http.Client{}})
+
+	t.Run("InvalidAPIKey", func(t *testing.T) {
+		status = http.StatusUnauthorized
+		err := MailgunCheck(client)(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "mailgun API key rejected")
+	})
+
+	t.Run("CachedWithinTTL", func(t *testing.T) {
+		status, requests = http.StatusOK, 0
+		check := Cached(MailgunCheck(client), time.Hour)
+		require.NoError(t, check(context.Background()))
+
+		// A failure after the first call is not seen until the TTL passes
+		status = http.StatusUnauthorized
+		require.NoError(t, check(context.Background()))
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("RecheckedAfterTTL", func(t *testing.T) {
+		status, requests = http.StatusOK, 0
+		check := Cached(MailgunCheck(client), 0)
+		require.NoError(t, check(context.Background()))
+		status = http.StatusUnauthorized
+		assert.Error(t, check(context.Background()))
+		assert.Equal(t, 2, requests)
+	})
+}
+
+func TestProviderConfigCheckMissing(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, v1beta1.SchemeBuilder.AddToScheme(s))
+	kube := fake.NewClientBuilder().WithScheme(s).Build()
+
+	err := ProviderConfigCheck(kube, types.NamespacedName{Namespace: "crossplane-system", Name: "default"})(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot get ProviderConfig crossplane-system/default")
+}
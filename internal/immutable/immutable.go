@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package immutable rejects edits to the spec fields that identify the
+// Mailgun resource behind a managed resource, which would otherwise silently
+// retarget it at a different Mailgun resource.
+package immutable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
+)
+
+// AnnotationKey records the values of a resource's immutable fields when its
+// Mailgun resource was created or first observed. Removing it accepts the
+// current values as identifying the Mailgun resource from then on.
+const AnnotationKey = "mailgun.crossplane.io/immutable-fields"
+
+const (
+	errChanged     = "immutable fields changed since the Mailgun resource was created: %s; revert them, or remove the %s annotation to manage the resource they now identify"
+	errBadRecorded = "cannot parse the %s annotation"
+	errRecord      = "cannot record immutable fields"
+)
+
+// Fields returns the values of the immutable fields of mg keyed by their
+// path, e.g. spec.forProvider.domain.
+type Fields func(mg resource.Managed) map[string]string
+
+// Wrap returns a connector whose clients refuse to reconcile a resource whose
+// immutable fields differ from the recorded ones. The refusal sets the
+// ImmutableFieldChanged condition and fails Observe, so the resource is
+// neither created nor updated until the fields are reverted. A resource being
+// deleted is observed as usual, so that its finalizer can still be removed.
+func Wrap(c managed.ExternalConnector, fields Fields) managed.ExternalConnector {
+	return managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		ec, err := c.Connect(ctx, mg)
+		if err != nil {
+			return nil, err
+		}
+		return &external{client: ec, fields: fields}, nil
+	})
+}
+
+type external struct {
+	client managed.ExternalClient
+	fields Fields
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	if meta.WasDeleted(mg) {
+		return e.client.Observe(ctx, mg)
+	}
+
+	current := e.fields(mg)
+	recorded, ok, err := recordedFields(mg)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if ok {
+		if changed := diff(recorded, current); len(changed) > 0 {
+			err := errors.Errorf(errChanged, strings.Join(changed, ", "), AnnotationKey)
+			mg.SetConditions(apisv1beta1.ImmutableFieldChanged(err.Error()))
+			return managed.ExternalObservation{}, err
+		}
+		if mg.GetCondition(apisv1beta1.TypeImmutableFieldChanged).Status == corev1.ConditionTrue {
+			mg.SetConditions(apisv1beta1.ImmutableFieldsUnchanged())
+		}
+	}
+
+	obs, err := e.client.Observe(ctx, mg)
+	if err != nil || ok || !obs.ResourceExists || len(current) == 0 {
+		return obs, err
+	}
+
+	// The resource predates its fields being recorded, so adopt its current
+	// values. Reporting late initialization has the reconciler persist them.
+	if err := record(mg, current); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	obs.ResourceLateInitialized = true
+	return obs, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cre, err := e.client.Create(ctx, mg)
+	if err != nil {
+		return cre, err
+	}
+	// The reconciler persists annotations set by a successful Create
+	if current := e.fields(mg); len(current) > 0 {
+		if err := record(mg, current); err != nil {
+			return cre, err
+		}
+	}
+	return cre, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	return e.client.Update(ctx, mg)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	return e.client.Delete(ctx, mg)
+}
+
+func (e *external) Disconnect(ctx context.Context) error {
+	return e.client.Disconnect(ctx)
+}
+
+// recordedFields returns the immutable field values recorded on mg, and whether
+// any were.
+func recordedFields(mg resource.Managed) (map[string]string, bool, error) {
+	v, ok := mg.GetAnnotations()[AnnotationKey]
+	if !ok {
+		return nil, false, nil
+	}
+	recorded := map[string]string{}
+	if err := json.Unmarshal([]byte(v), &recorded); err != nil {
+		return nil, false, errors.Wrapf(err, errBadRecorded, AnnotationKey)
+	}
+	return recorded, true, nil
+}
+
+func record(mg resource.Managed, fields map[string]string) error {
+	v, err := json.Marshal(fields)
+	if err != nil {
+		return errors.Wrap(err, errRecord)
+	}
+	meta.AddAnnotations(mg, map[string]string{AnnotationKey: string(v)})
+	return nil
+}
+
+// diff describes each recorded field whose current value differs, in path
+// order. Fields that were not recorded are not compared.
+func diff(recorded, current map[string]string) []string {
+	var changed []string
+	for path, was := range recorded {
+		if now := current[path]; now != was {
+			changed = append(changed, fmt.Sprintf("%s was %q, now %q", path, was, now))
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immutable
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/smtpcredential/v1beta1"
+	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
+)
+
+func fields(mg resource.Managed) map[string]string {
+	cr := mg.(*v1beta1.SMTPCredential)
+	return map[string]string{
+		"spec.forProvider.domain": cr.Spec.ForProvider.Domain,
+		"spec.forProvider.login":  cr.Spec.ForProvider.Login,
+	}
+}
+
+// recorder connects clients that record the operations called on them
+type recorder struct {
+	calls  []string
+	exists bool
+}
+
+func (r *recorder) connector() managed.ExternalConnector {
+	return managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{
+			ObserveFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+				r.calls = append(r.calls, "observe")
+				return managed.ExternalObservation{ResourceExists: r.exists}, nil
+			},
+			CreateFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+				r.calls = append(r.calls, "create")
+				r.exists = true
+				return managed.ExternalCreation{}, nil
+			},
+			DisconnectFn: func(ctx context.Context) error { return nil },
+		}, nil
+	})
+}
+
+func credential(domain, login string) *v1beta1.SMTPCredential {
+	cr := &v1beta1.SMTPCredential{}
+	cr.Spec.ForProvider.Domain = domain
+	cr.Spec.ForProvider.Login = login
+	return cr
+}
+
+func TestCreateRecordsFields(t *testing.T) {
+	ctx := context.Background()
+	cr := credential("example.com", "alice")
+	inner := &recorder{}
+	ec, err := Wrap(inner.connector(), fields).Connect(ctx, cr)
+	require.NoError(t, err)
+
+	obs, err := ec.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceExists)
+	assert.NotContains(t, cr.GetAnnotations(), AnnotationKey)
+
+	_, err = ec.Create(ctx, cr)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"spec.forProvider.domain":"example.com","spec.forProvider.login":"alice"}`, cr.GetAnnotations()[AnnotationKey])
+
+	obs, err = ec.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+	assert.False(t, obs.ResourceLateInitialized)
+}
+
+func TestObserveAdoptsExistingResource(t *testing.T) {
+	ctx := context.Background()
+	cr := credential("example.com", "alice")
+	inner := &recorder{exists: true}
+	ec, err := Wrap(inner.connector(), fields).Connect(ctx, cr)
+	require.NoError(t, err)
+
+	obs, err := ec.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceLateInitialized, "recording the fields must be persisted")
+	assert.Contains(t, cr.GetAnnotations(), AnnotationKey)
+}
+
+func TestChangedFieldIsRejected(t *testing.T) {
+	ctx := context.Background()
+	cr := credential("example.com", "alice")
+	inner := &recorder{exists: true}
+	ec, err := Wrap(inner.connector(), fields).Connect(ctx, cr)
+	require.NoError(t, err)
+	_, err = ec.Observe(ctx, cr)
+	require.NoError(t, err)
+	inner.calls = nil
+
+	cr.Spec.ForProvider.Domain = "other.example.com"
+	_, err = ec.Observe(ctx, cr)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `spec.forProvider.domain was "example.com", now "other.example.com"`)
+	assert.Empty(t, inner.calls, "the new target must not be observed")
+
+	c := cr.GetCondition(apisv1beta1.TypeImmutableFieldChanged)
+	assert.Equal(t, corev1.ConditionTrue, c.Status)
+	assert.Equal(t, apisv1beta1.ReasonFieldChanged, c.Reason)
+
+	// Reverting the edit resumes reconciliation
+	cr.Spec.ForProvider.Domain = "example.com"
+	_, err = ec.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(apisv1beta1.TypeImmutableFieldChanged).Status)
+	assert.Equal(t, []string{"observe"}, inner.calls)
+}
+
+func TestChangedFieldDoesNotBlockDeletion(t *testing.T) {
+	ctx := context.Background()
+	cr := credential("example.com", "alice")
+	cr.SetAnnotations(map[string]string{AnnotationKey: `{"spec.forProvider.domain":"example.com","spec.forProvider.login":"bob"}`})
+	now := metav1.Now()
+	cr.SetDeletionTimestamp(&now)
+	inner := &recorder{}
+	ec, err := Wrap(inner.connector(), fields).Connect(ctx, cr)
+	require.NoError(t, err)
+
+	obs, err := ec.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.False(t, obs.ResourceExists)
+	assert.Equal(t, []string{"observe"}, inner.calls, "a resource being deleted must be observed")
+}
+
+func TestRemovingAnnotationAcceptsNewTarget(t *testing.T) {
+	ctx := context.Background()
+	cr := credential("example.com", "alice")
+	cr.SetAnnotations(map[string]string{AnnotationKey: `{"spec.forProvider.domain":"example.com","spec.forProvider.login":"bob"}`})
+	inner := &recorder{exists: true}
+	ec, err := Wrap(inner.connector(), fields).Connect(ctx, cr)
+	require.NoError(t, err)
+
+	_, err = ec.Observe(ctx, cr)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.forProvider.login")
+
+	cr.SetAnnotations(nil)
+	obs, err := ec.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceLateInitialized)
+	assert.JSONEq(t, `{"spec.forProvider.domain":"example.com","spec.forProvider.login":"alice"}`, cr.GetAnnotations()[AnnotationKey])
+}
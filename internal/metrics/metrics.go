@@ -19,6 +19,7 @@ package metrics
 import (
 	"github.com/prometheus/client_golang/prometheus"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sync"
 	"time"
 )
 
@@ -32,6 +33,9 @@ const (
 	LabelDomain    = "domain"
 	LabelProvider  = "provider_config"
 	LabelResult    = "result"
+	LabelEndpoint  = "endpoint"
+	LabelKind      = "kind"
+	LabelCode      = "code"
 )
 
 var (
@@ -56,6 +60,18 @@ var (
 		[]string{LabelResource, LabelOperation},
 	)
 
+	// ReconcileDuration tracks the time a reconcile spends observing and
+	// creating, updating or deleting the external resource, per kind
+	ReconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: MetricsNamespace,
+			Name:      "reconcile_duration_seconds",
+			Help:      "Time spent in Observe plus Create, Update or Delete per reconcile, in seconds",
+			Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1.0, 2.5, 5.0, 10.0, 30.0},
+		},
+		[]string{LabelKind},
+	)
+
 	// MailgunAPIRequests tracks API requests to Mailgun
 	MailgunAPIRequests = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -77,6 +93,39 @@ var (
 		[]string{LabelOperation, LabelDomain},
 	)
 
+	// DeprecatedAPIRequests counts Mailgun API requests answered with a
+	// Deprecation header
+	DeprecatedAPIRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "mailgun_deprecated_api_requests_total",
+			Help:      "Total number of Mailgun API requests to endpoints Mailgun reports as deprecated",
+		},
+		[]string{LabelEndpoint},
+	)
+
+	// MailgunAPIErrors counts Mailgun API error responses by the provider
+	// error code they map to
+	MailgunAPIErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "mailgun_api_errors_total",
+			Help:      "Total number of Mailgun API error responses by endpoint and error code",
+		},
+		[]string{LabelEndpoint, LabelCode},
+	)
+
+	// RateLimitedRequests counts Mailgun API responses with a 429 status,
+	// including those answered to requests that are retried
+	RateLimitedRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "rate_limited_requests_total",
+			Help:      "Total number of requests Mailgun rejected with 429 Too Many Requests, by endpoint",
+		},
+		[]string{LabelEndpoint},
+	)
+
 	// SecretOperations tracks secret creation/retrieval for SMTP credentials
 	SecretOperations = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -103,8 +152,12 @@ func init() {
 	metrics.Registry.MustRegister(
 		ResourceOperations,
 		OperationDuration,
+		ReconcileDuration,
 		MailgunAPIRequests,
 		MailgunAPILatency,
+		DeprecatedAPIRequests,
+		MailgunAPIErrors,
+		RateLimitedRequests,
 		SecretOperations,
 		ProviderConfigUsage,
 	)
@@ -122,6 +175,21 @@ func RecordMailgunAPIRequest(operation, domain, result string, duration time.Dur
 	MailgunAPILatency.WithLabelValues(operation, domain).Observe(duration.Seconds())
 }
 
+// RecordDeprecatedAPIRequest records a request to a deprecated endpoint
+func RecordDeprecatedAPIRequest(endpoint string) {
+	DeprecatedAPIRequests.WithLabelValues(endpoint).Inc()
+}
+
+// RecordMailgunAPIError records a Mailgun API error response
+func RecordMailgunAPIError(endpoint, code string) {
+	MailgunAPIErrors.WithLabelValues(endpoint, code).Inc()
+}
+
+// RecordRateLimitedRequest records a 429 response from Mailgun
+func RecordRateLimitedRequest(endpoint string) {
+	RateLimitedRequests.WithLabelValues(endpoint).Inc()
+}
+
 // RecordSecretOperation records a Kubernetes secret operation
 func RecordSecretOperation(operation, result string) {
 	SecretOperations.WithLabelValues(operation, result).Inc()
@@ -151,3 +219,42 @@ func (t *OperationTimer) RecordResourceOperation(resource, operation, result str
 func (t *OperationTimer) RecordMailgunAPIRequest(operation, domain, result string) {
 	RecordMailgunAPIRequest(operation, domain, result, time.Since(t.start))
 }
+
+// ReconcileTimer sums the time spent in the external client methods of one
+// reconcile. The managed reconciler connects once per reconcile, so a timer
+// is created in Connect and recorded in Disconnect. A nil timer does nothing.
+type ReconcileTimer struct {
+	kind string
+
+	mu      sync.Mutex
+	elapsed time.Duration
+}
+
+// NewReconcileTimer creates a timer for a reconcile of the supplied kind
+func NewReconcileTimer(kind string) *ReconcileTimer {
+	return &ReconcileTimer{kind: kind}
+}
+
+// Start starts timing a method and returns a func that stops it, so that
+// methods can use defer t.Start()()
+func (t *ReconcileTimer) Start() func() {
+	if t == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.elapsed += time.Since(start)
+	}
+}
+
+// Record records the time accumulated so far as one reconcile
+func (t *ReconcileTimer) Record() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ReconcileDuration.WithLabelValues(t.kind).Observe(t.elapsed.Seconds())
+}
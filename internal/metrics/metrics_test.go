@@ -17,6 +17,7 @@ limitations under the License.
 package metrics
 
 import (
+	"sync"
 	"testing"
 	"time"
 
@@ -75,6 +76,34 @@ func TestOperationTimer(t *testing.T) {
 	})
 }
 
+func TestOperationTimerConcurrency(t *testing.T) {
+	// Run with -race: timers and collectors are shared across goroutines
+	const goroutines, iterations = 50, 100
+
+	ResourceOperations.Reset()
+	MailgunAPIRequests.Reset()
+
+	shared := NewOperationTimer()
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < iterations; j++ {
+				NewOperationTimer().RecordResourceOperation("domain", "observe", "success")
+				shared.RecordMailgunAPIRequest("get_domain", "example.com", "success")
+				RecordSecretOperation("get", "success")
+				SetProviderConfigUsage("default", float64(j))
+			}
+		}()
+	}
+	wg.Wait()
+
+	total := float64(goroutines * iterations)
+	assert.Equal(t, total, testutil.ToFloat64(ResourceOperations.WithLabelValues("domain", "observe", "success")))
+	assert.Equal(t, total, testutil.ToFloat64(MailgunAPIRequests.WithLabelValues("get_domain", "example.com", "success")))
+}
+
 func TestMetricsRegistration(t *testing.T) {
 	t.Run("MetricsAreRegistered", func(t *testing.T) {
 		// Check that our metrics are registered with Prometheus
@@ -151,3 +180,27 @@ func TestConcurrentMetrics(t *testing.T) {
 		assert.Equal(t, float64(10), counter)
 	})
 }
+
+func TestReconcileTimer(t *testing.T) {
+	ReconcileDuration.Reset()
+
+	timer := NewReconcileTimer("Domain")
+	for range 2 {
+		stop := timer.Start()
+		time.Sleep(5 * time.Millisecond)
+		stop()
+	}
+	timer.Record()
+
+	// Both methods count towards a single reconcile
+	metric := &dto.Metric{}
+	_ = ReconcileDuration.WithLabelValues("Domain").(prometheus.Histogram).Write(metric)
+	assert.Equal(t, uint64(1), metric.GetHistogram().GetSampleCount())
+	assert.GreaterOrEqual(t, metric.GetHistogram().GetSampleSum(), (10 * time.Millisecond).Seconds())
+
+	// A nil timer is a no-op
+	var none *ReconcileTimer
+	none.Start()()
+	none.Record()
+	assert.Equal(t, 1, testutil.CollectAndCount(ReconcileDuration))
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package observecache lets repeated Observes of a managed resource reuse the
+// last observed external state for a bounded time instead of calling Mailgun.
+package observecache
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+)
+
+// AnnotationForceReconcile bypasses the cache. Setting it to a new value, such
+// as the current time, makes the next Observe call Mailgun. Any other label or
+// annotation change does the same; this one exists so there is a documented
+// way to ask for it.
+const AnnotationForceReconcile = "mailgun.crossplane.io/force-reconcile"
+
+var (
+	mu         sync.RWMutex
+	defaultTTL time.Duration
+)
+
+// SetDefaultTTL sets how long observations are reused. Zero, the default,
+// disables caching.
+func SetDefaultTTL(ttl time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+	defaultTTL = ttl
+}
+
+// DefaultTTL returns the TTL set by SetDefaultTTL.
+func DefaultTTL() time.Duration {
+	mu.RLock()
+	defer mu.RUnlock()
+	return defaultTTL
+}
+
+// Wrap returns c with observation caching using the default TTL, or c itself
+// when caching is disabled.
+func Wrap(c managed.ExternalConnector) managed.ExternalConnector {
+	ttl := DefaultTTL()
+	if ttl <= 0 {
+		return c
+	}
+	return NewConnector(c, ttl)
+}
+
+type entry struct {
+	observation managed.ExternalObservation
+	generation  int64
+	metadata    uint64
+	expires     time.Time
+}
+
+// A Connector produces ExternalClients whose Observes are cached. The cache
+// is shared by every client it produces.
+type Connector struct {
+	connector managed.ExternalConnector
+	ttl       time.Duration
+	now       func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]entry
+	swept   time.Time
+}
+
+// NewConnector returns a Connector caching observations of c for ttl.
+func NewConnector(c managed.ExternalConnector, ttl time.Duration) *Connector {
+	return &Connector{
+		connector: c,
+		ttl:       ttl,
+		now:       time.Now,
+		entries:   make(map[string]entry),
+	}
+}
+
+// Connect connects the wrapped connector and returns a caching client.
+func (c *Connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.connector.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{client: ec, cache: c}, nil
+}
+
+func key(mg resource.Managed) string {
+	if uid := mg.GetUID(); uid != "" {
+		return string(uid)
+	}
+	return fmt.Sprintf("%T/%s/%s", mg, mg.GetNamespace(), mg.GetName())
+}
+
+// metadataHash fingerprints the labels and annotations of mg
+func metadataHash(mg resource.Managed) uint64 {
+	h := fnv.New64a()
+	for _, m := range []map[string]string{mg.GetLabels(), mg.GetAnnotations()} {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			_, _ = h.Write([]byte(k + "\x00" + m[k] + "\x00"))
+		}
+		_, _ = h.Write([]byte{0xff})
+	}
+	return h.Sum64()
+}
+
+// lookup returns the cached observation of mg, if still valid. Entries are
+// invalid once expired, once the spec has changed (a new generation) or once
+// a label or annotation, such as AnnotationForceReconcile, has changed.
+func (c *Connector) lookup(mg resource.Managed) (managed.ExternalObservation, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := key(mg)
+	e, ok := c.entries[k]
+	if !ok {
+		return managed.ExternalObservation{}, false
+	}
+	if c.now().After(e.expires) || e.generation != mg.GetGeneration() || e.metadata != metadataHash(mg) {
+		delete(c.entries, k)
+		return managed.ExternalObservation{}, false
+	}
+	return e.observation, true
+}
+
+func (c *Connector) store(mg resource.Managed, obs managed.ExternalObservation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	c.sweep(now)
+	c.entries[key(mg)] = entry{
+		observation: obs,
+		generation:  mg.GetGeneration(),
+		metadata:    metadataHash(mg),
+		expires:     now.Add(c.ttl),
+	}
+}
+
+// sweep drops expired entries, at most once per TTL, so that entries of
+// resources that are no longer observed, such as deleted ones, do not pile
+// up. c.mu must be held.
+func (c *Connector) sweep(now time.Time) {
+	if now.Before(c.swept.Add(c.ttl)) {
+		return
+	}
+	c.swept = now
+	for k, e := range c.entries {
+		if now.After(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+}
+
+func (c *Connector) invalidate(mg resource.Managed) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key(mg))
+}
+
+type external struct {
+	client managed.ExternalClient
+	cache  *Connector
+}
+
+// Observe returns the cached observation of mg if there is a valid one and
+// observes the external resource otherwise. The status written by the last
+// real Observe has already been persisted, so a cached observation does not
+// need to touch it. Only observations of existing resources that are not
+// being deleted are cached, and never ones carrying connection details, as
+// those may hold credentials that should not be kept in memory.
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	if meta.WasDeleted(mg) {
+		e.cache.invalidate(mg)
+		return e.client.Observe(ctx, mg)
+	}
+	if obs, ok := e.cache.lookup(mg); ok {
+		return obs, nil
+	}
+
+	obs, err := e.client.Observe(ctx, mg)
+	if err == nil && obs.ResourceExists && len(obs.ConnectionDetails) == 0 {
+		e.cache.store(mg, obs)
+	}
+	return obs, err
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	defer e.cache.invalidate(mg)
+	return e.client.Create(ctx, mg)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	defer e.cache.invalidate(mg)
+	return e.client.Update(ctx, mg)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	defer e.cache.invalidate(mg)
+	return e.client.Delete(ctx, mg)
+}
+
+func (e *external) Disconnect(ctx context.Context) error {
+	return e.client.Disconnect(ctx)
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package observecache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/route/v1beta1"
+)
+
+type counter struct {
+	observes int
+	details  managed.ConnectionDetails
+}
+
+func (c *counter) connector() managed.ExternalConnector {
+	return managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{
+			ObserveFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+				c.observes++
+				return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: c.details}, nil
+			},
+			UpdateFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+				return managed.ExternalUpdate{}, nil
+			},
+			DeleteFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+				return managed.ExternalDelete{}, nil
+			},
+			DisconnectFn: func(ctx context.Context) error { return nil },
+		}, nil
+	})
+}
+
+func newRoute() *v1beta1.Route {
+	return &v1beta1.Route{ObjectMeta: metav1.ObjectMeta{Name: "support", Namespace: "mail", UID: "uid-1", Generation: 1}}
+}
+
+// observe connects and observes once, as each reconcile does
+func observe(t *testing.T, c managed.ExternalConnector, mg resource.Managed) managed.ExternalObservation {
+	t.Helper()
+	ec, err := c.Connect(context.Background(), mg)
+	require.NoError(t, err)
+	obs, err := ec.Observe(context.Background(), mg)
+	require.NoError(t, err)
+	return obs
+}
+
+func TestCacheHitWithinTTL(t *testing.T) {
+	inner := &counter{}
+	now := time.Now()
+	c := NewConnector(inner.connector(), time.Minute)
+	c.now = func() time.Time { return now }
+	cr := newRoute()
+
+	first := observe(t, c, cr)
+	second := observe(t, c, cr)
+	assert.Equal(t, 1, inner.observes, "second observe within the TTL should be served from the cache")
+	assert.Equal(t, first, second)
+
+	now = now.Add(2 * time.Minute)
+	observe(t, c, cr)
+	assert.Equal(t, 2, inner.observes, "expired entries should be observed again")
+}
+
+func TestCacheInvalidation(t *testing.T) {
+	inner := &counter{}
+	c := NewConnector(inner.connector(), time.Hour)
+	cr := newRoute()
+
+	observe(t, c, cr)
+
+	ec, err := c.Connect(context.Background(), cr)
+	require.NoError(t, err)
+	_, err = ec.Update(context.Background(), cr)
+	require.NoError(t, err)
+	observe(t, c, cr)
+	assert.Equal(t, 2, inner.observes, "a write should invalidate the cached observation")
+
+	cr.SetGeneration(2)
+	observe(t, c, cr)
+	assert.Equal(t, 3, inner.observes, "a spec change should invalidate the cached observation")
+
+	cr.SetAnnotations(map[string]string{AnnotationForceReconcile: "2026-01-01T00:00:00Z"})
+	observe(t, c, cr)
+	assert.Equal(t, 4, inner.observes, "the force-reconcile annotation should invalidate the cached observation")
+
+	observe(t, c, cr)
+	assert.Equal(t, 4, inner.observes)
+
+	now := metav1.Now()
+	cr.SetDeletionTimestamp(&now)
+	observe(t, c, cr)
+	assert.Equal(t, 5, inner.observes, "resources being deleted should always be observed")
+}
+
+func TestCacheEviction(t *testing.T) {
+	inner := &counter{}
+	now := time.Now()
+	c := NewConnector(inner.connector(), time.Minute)
+	c.now = func() time.Time { return now }
+
+	gone := newRoute()
+	observe(t, c, gone)
+	ec, err := c.Connect(context.Background(), gone)
+	require.NoError(t, err)
+	_, err = ec.Delete(context.Background(), gone)
+	require.NoError(t, err)
+	assert.Empty(t, c.entries, "a delete should evict the cached observation")
+
+	// The entry of a resource that is no longer observed expires
+	observe(t, c, gone)
+	now = now.Add(2 * time.Minute)
+	other := newRoute()
+	other.SetUID("uid-2")
+	observe(t, c, other)
+	assert.NotContains(t, c.entries, "uid-1", "expired entries should be swept")
+	assert.Contains(t, c.entries, "uid-2")
+}
+
+func TestConnectionDetailsNotCached(t *testing.T) {
+	inner := &counter{details: managed.ConnectionDetails{"password": []byte("s3cret")}}
+	c := NewConnector(inner.connector(), time.Hour)
+	cr := newRoute()
+
+	obs := observe(t, c, cr)
+	assert.Equal(t, []byte("s3cret"), obs.ConnectionDetails["password"])
+	observe(t, c, cr)
+	assert.Equal(t, 2, inner.observes, "observations carrying connection details should not be cached")
+	assert.Empty(t, c.entries)
+}
+
+func TestWrapDisabledByDefault(t *testing.T) {
+	inner := (&counter{}).connector()
+	_, ok := Wrap(inner).(*Connector)
+	assert.False(t, ok, "caching should be opt-in")
+
+	SetDefaultTTL(time.Minute)
+	defer SetDefaultTTL(0)
+	_, ok = Wrap(inner).(*Connector)
+	assert.True(t, ok)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimit reflects Mailgun rate limiting in the RateLimited
+// condition of managed resources.
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	corev1 "k8s.io/api/core/v1"
+
+	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
+)
+
+// Wrap returns a connector whose clients set the RateLimited condition when
+// Mailgun answers with 429 Too Many Requests, and clear it once a call
+// succeeds again.
+func Wrap(c managed.ExternalConnector) managed.ExternalConnector {
+	return managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		ec, err := c.Connect(ctx, mg)
+		if err != nil {
+			return nil, err
+		}
+		return &external{client: ec}, nil
+	})
+}
+
+type external struct {
+	client managed.ExternalClient
+}
+
+// record updates the RateLimited condition of mg from the result of a call.
+// Errors other than 429 leave it untouched, as they say nothing about
+// whether Mailgun is still limiting the provider.
+func record(mg resource.Managed, err error) {
+	switch {
+	case clients.IsRateLimited(err):
+		mg.SetConditions(apisv1beta1.RateLimited(clients.ErrorMessage(err)))
+	case err == nil && mg.GetCondition(apisv1beta1.TypeRateLimited).Status == corev1.ConditionTrue:
+		mg.SetConditions(apisv1beta1.NotRateLimited())
+	}
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	obs, err := e.client.Observe(ctx, mg)
+	record(mg, err)
+	return obs, err
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cre, err := e.client.Create(ctx, mg)
+	record(mg, err)
+	return cre, err
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	upd, err := e.client.Update(ctx, mg)
+	record(mg, err)
+	return upd, err
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	del, err := e.client.Delete(ctx, mg)
+	record(mg, err)
+	return del, err
+}
+
+func (e *external) Disconnect(ctx context.Context) error {
+	return e.client.Disconnect(ctx)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/route/v1beta1"
+	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
+)
+
+// responder fails Observe with err, if set
+type responder struct {
+	err error
+}
+
+func (r *responder) connector() managed.ExternalConnector {
+	return managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{
+			ObserveFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+				if r.err != nil {
+					return managed.ExternalObservation{}, r.err
+				}
+				return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+			},
+			DisconnectFn: func(ctx context.Context) error { return nil },
+		}, nil
+	})
+}
+
+func observe(c managed.ExternalConnector, mg resource.Managed) error {
+	ec, err := c.Connect(context.Background(), mg)
+	if err != nil {
+		return err
+	}
+	_, err = ec.Observe(context.Background(), mg)
+	return err
+}
+
+func TestRateLimitedCondition(t *testing.T) {
+	inner := &responder{}
+	c := Wrap(inner.connector())
+	cr := &v1beta1.Route{}
+
+	// Successes never add the condition to resources that were not limited
+	require.NoError(t, observe(c, cr))
+	assert.Equal(t, corev1.ConditionUnknown, cr.GetCondition(apisv1beta1.TypeRateLimited).Status)
+
+	inner.err = errors.Wrap(&clients.APIError{StatusCode: 429, Message: "Too many requests"}, "failed to get route")
+	require.Error(t, observe(c, cr))
+	limited := cr.GetCondition(apisv1beta1.TypeRateLimited)
+	assert.Equal(t, corev1.ConditionTrue, limited.Status)
+	assert.Equal(t, apisv1beta1.ReasonRateLimited, limited.Reason)
+	assert.Equal(t, "Too many requests", limited.Message)
+
+	// Other errors say nothing about rate limiting
+	inner.err = &clients.APIError{StatusCode: 500, Message: "Internal error"}
+	require.Error(t, observe(c, cr))
+	assert.Equal(t, corev1.ConditionTrue, cr.GetCondition(apisv1beta1.TypeRateLimited).Status)
+
+	inner.err = nil
+	require.NoError(t, observe(c, cr))
+	cleared := cr.GetCondition(apisv1beta1.TypeRateLimited)
+	assert.Equal(t, corev1.ConditionFalse, cleared.Status)
+	assert.Equal(t, apisv1beta1.ReasonNotLimited, cleared.Reason)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package readonly implements the provider-wide read-only mode, in which
+// managed resources are observed but nothing is changed in Mailgun.
+package readonly
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/pkg/errors"
+)
+
+const errReadOnly = "the provider is in read-only mode: refusing to %s"
+
+var enabled atomic.Bool
+
+// SetEnabled turns read-only mode on or off for the whole provider.
+func SetEnabled(on bool) {
+	enabled.Store(on)
+}
+
+// Enabled reports whether read-only mode is on.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// Refuse returns the error reported in place of the operation op while
+// read-only mode is on.
+func Refuse(op string) error {
+	return errors.Errorf(errReadOnly, op)
+}
+
+// Wrap returns a connector whose clients only observe when read-only mode is
+// on, or c itself when it is off. Creates, updates and deletes fail without
+// reaching c, so the reconciler keeps retrying them, and keeps finalizers in
+// place, until read-only mode is turned off.
+func Wrap(c managed.ExternalConnector) managed.ExternalConnector {
+	if !Enabled() {
+		return c
+	}
+	return managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		ec, err := c.Connect(ctx, mg)
+		if err != nil {
+			return nil, err
+		}
+		return &external{client: ec}, nil
+	})
+}
+
+type external struct {
+	client managed.ExternalClient
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	return e.client.Observe(ctx, mg)
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, Refuse("create the external resource")
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, Refuse("update the external resource")
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	return managed.ExternalDelete{}, Refuse("delete the external resource")
+}
+
+func (e *external) Disconnect(ctx context.Context) error {
+	return e.client.Disconnect(ctx)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readonly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/route/v1beta1"
+)
+
+// recorder connects clients that record the operations called on them
+type recorder struct {
+	calls []string
+}
+
+func (r *recorder) connector() managed.ExternalConnector {
+	return managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{
+			ObserveFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+				r.calls = append(r.calls, "observe")
+				return managed.ExternalObservation{ResourceExists: true}, nil
+			},
+			CreateFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+				r.calls = append(r.calls, "create")
+				return managed.ExternalCreation{}, nil
+			},
+			UpdateFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+				r.calls = append(r.calls, "update")
+				return managed.ExternalUpdate{}, nil
+			},
+			DeleteFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+				r.calls = append(r.calls, "delete")
+				return managed.ExternalDelete{}, nil
+			},
+			DisconnectFn: func(ctx context.Context) error { return nil },
+		}, nil
+	})
+}
+
+func TestReadOnly(t *testing.T) {
+	ctx := context.Background()
+	cr := &v1beta1.Route{}
+
+	SetEnabled(true)
+	defer SetEnabled(false)
+
+	inner := &recorder{}
+	ec, err := Wrap(inner.connector()).Connect(ctx, cr)
+	require.NoError(t, err)
+
+	obs, err := ec.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+
+	_, err = ec.Create(ctx, cr)
+	assert.ErrorContains(t, err, "read-only mode")
+	_, err = ec.Update(ctx, cr)
+	assert.ErrorContains(t, err, "read-only mode")
+	_, err = ec.Delete(ctx, cr)
+	assert.ErrorContains(t, err, "read-only mode")
+	require.NoError(t, ec.Disconnect(ctx))
+
+	assert.Equal(t, []string{"observe"}, inner.calls, "only observes should reach the controller's client")
+
+	SetEnabled(false)
+	inner = &recorder{}
+	ec, err = Wrap(inner.connector()).Connect(ctx, cr)
+	require.NoError(t, err)
+	_, err = ec.Create(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"create"}, inner.calls)
+}
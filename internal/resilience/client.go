@@ -20,11 +20,15 @@ import (
 	"context"
 
 	bouncetypes "github.com/rossigee/provider-mailgun/apis/bounce/v1beta1"
+	complainttypes "github.com/rossigee/provider-mailgun/apis/complaint/v1beta1"
 	domaintypes "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+	ippooltypes "github.com/rossigee/provider-mailgun/apis/ippool/v1beta1"
 	mailinglisttypes "github.com/rossigee/provider-mailgun/apis/mailinglist/v1beta1"
+	mailinglistmembertypes "github.com/rossigee/provider-mailgun/apis/mailinglistmember/v1beta1"
 	routetypes "github.com/rossigee/provider-mailgun/apis/route/v1beta1"
 	smtpcredentialtypes "github.com/rossigee/provider-mailgun/apis/smtpcredential/v1beta1"
 	templatetypes "github.com/rossigee/provider-mailgun/apis/template/v1beta1"
+	unsubscribetypes "github.com/rossigee/provider-mailgun/apis/unsubscribe/v1beta1"
 	webhooktypes "github.com/rossigee/provider-mailgun/apis/webhook/v1beta1"
 	"github.com/rossigee/provider-mailgun/internal/clients"
 )
@@ -173,6 +177,100 @@ func (r *ResilientClient) DeleteTemplate(ctx context.Context, domain, name strin
 	})
 }
 
+func (r *ResilientClient) CreateTemplateVersion(ctx context.Context, domain, name string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	var result *templatetypes.TemplateVersion
+	var err error
+
+	retryErr := WithRetry(ctx, "create_template_version", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			result, err = r.client.CreateTemplateVersion(ctx, domain, name, version, active)
+			return err
+		})
+	})
+
+	if retryErr != nil {
+		return nil, retryErr
+	}
+	return result, nil
+}
+
+func (r *ResilientClient) GetTemplateVersion(ctx context.Context, domain, name, tag string) (*templatetypes.TemplateVersion, string, error) {
+	var result *templatetypes.TemplateVersion
+	var content string
+	var err error
+
+	retryErr := WithRetry(ctx, "get_template_version", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			result, content, err = r.client.GetTemplateVersion(ctx, domain, name, tag)
+			return err
+		})
+	})
+
+	if retryErr != nil {
+		return nil, "", retryErr
+	}
+	return result, content, nil
+}
+
+func (r *ResilientClient) UpdateTemplateVersion(ctx context.Context, domain, name, tag string, version *templatetypes.TemplateParameters, active bool) (*templatetypes.TemplateVersion, error) {
+	var result *templatetypes.TemplateVersion
+	var err error
+
+	retryErr := WithRetry(ctx, "update_template_version", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			result, err = r.client.UpdateTemplateVersion(ctx, domain, name, tag, version, active)
+			return err
+		})
+	})
+
+	if retryErr != nil {
+		return nil, retryErr
+	}
+	return result, nil
+}
+
+func (r *ResilientClient) DeleteTemplateVersion(ctx context.Context, domain, name, tag string) error {
+	return WithRetry(ctx, "delete_template_version", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			return r.client.DeleteTemplateVersion(ctx, domain, name, tag)
+		})
+	})
+}
+
+func (r *ResilientClient) ListTemplateVersions(ctx context.Context, domain, name string) ([]templatetypes.TemplateVersion, error) {
+	var result []templatetypes.TemplateVersion
+	var err error
+
+	retryErr := WithRetry(ctx, "list_template_versions", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			result, err = r.client.ListTemplateVersions(ctx, domain, name)
+			return err
+		})
+	})
+
+	if retryErr != nil {
+		return nil, retryErr
+	}
+	return result, nil
+}
+
+func (r *ResilientClient) RenderTemplate(ctx context.Context, domain, name string, vars map[string]interface{}) (string, error) {
+	var result string
+	var err error
+
+	retryErr := WithRetry(ctx, "render_template", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			result, err = r.client.RenderTemplate(ctx, domain, name, vars)
+			return err
+		})
+	})
+
+	if retryErr != nil {
+		return "", retryErr
+	}
+	return result, nil
+}
+
 // Domain operations with resilience
 
 func (r *ResilientClient) CreateDomain(ctx context.Context, domain *domaintypes.DomainParameters) (*domaintypes.DomainObservation, error) {
@@ -234,6 +332,200 @@ func (r *ResilientClient) DeleteDomain(ctx context.Context, name string) error {
 	})
 }
 
+func (r *ResilientClient) ListAuthorizedRecipients(ctx context.Context) ([]domaintypes.AuthorizedRecipient, error) {
+	var result []domaintypes.AuthorizedRecipient
+	var err error
+
+	retryErr := WithRetry(ctx, "list_authorized_recipients", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			result, err = r.client.ListAuthorizedRecipients(ctx)
+			return err
+		})
+	})
+
+	if retryErr != nil {
+		return nil, retryErr
+	}
+	return result, nil
+}
+
+func (r *ResilientClient) AddAuthorizedRecipient(ctx context.Context, email string) (*domaintypes.AuthorizedRecipient, error) {
+	var result *domaintypes.AuthorizedRecipient
+	var err error
+
+	retryErr := WithRetry(ctx, "add_authorized_recipient", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			result, err = r.client.AddAuthorizedRecipient(ctx, email)
+			return err
+		})
+	})
+
+	if retryErr != nil {
+		return nil, retryErr
+	}
+	return result, nil
+}
+
+func (r *ResilientClient) DeleteAuthorizedRecipient(ctx context.Context, email string) error {
+	return WithRetry(ctx, "delete_authorized_recipient", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			return r.client.DeleteAuthorizedRecipient(ctx, email)
+		})
+	})
+}
+
+func (r *ResilientClient) GetWebhookSigningKey(ctx context.Context) (string, error) {
+	var result string
+	var err error
+
+	retryErr := WithRetry(ctx, "get_webhook_signing_key", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			result, err = r.client.GetWebhookSigningKey(ctx)
+			return err
+		})
+	})
+
+	if retryErr != nil {
+		return "", retryErr
+	}
+	return result, nil
+}
+
+func (r *ResilientClient) RotateWebhookSigningKey(ctx context.Context) (string, error) {
+	var result string
+	var err error
+
+	retryErr := WithRetry(ctx, "rotate_webhook_signing_key", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			result, err = r.client.RotateWebhookSigningKey(ctx)
+			return err
+		})
+	})
+
+	if retryErr != nil {
+		return "", retryErr
+	}
+	return result, nil
+}
+
+func (r *ResilientClient) UpdateDomainTracking(ctx context.Context, name string, tracking *domaintypes.DomainTracking) error {
+	return WithRetry(ctx, "update_domain_tracking", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			return r.client.UpdateDomainTracking(ctx, name, tracking)
+		})
+	})
+}
+
+func (r *ResilientClient) GetDomainTracking(ctx context.Context, name string) (*domaintypes.DomainTracking, error) {
+	var result *domaintypes.DomainTracking
+	var err error
+
+	retryErr := WithRetry(ctx, "get_domain_tracking", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			result, err = r.client.GetDomainTracking(ctx, name)
+			return err
+		})
+	})
+
+	if retryErr != nil {
+		return nil, retryErr
+	}
+	return result, nil
+}
+
+func (r *ResilientClient) GetDomainConnection(ctx context.Context, name string) (*domaintypes.DomainConnection, error) {
+	var result *domaintypes.DomainConnection
+	var err error
+
+	retryErr := WithRetry(ctx, "get_domain_connection", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			result, err = r.client.GetDomainConnection(ctx, name)
+			return err
+		})
+	})
+
+	if retryErr != nil {
+		return nil, retryErr
+	}
+	return result, nil
+}
+
+func (r *ResilientClient) UpdateDomainConnection(ctx context.Context, name string, connection *domaintypes.DomainConnection) error {
+	return WithRetry(ctx, "update_domain_connection", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			return r.client.UpdateDomainConnection(ctx, name, connection)
+		})
+	})
+}
+
+func (r *ResilientClient) UpdateDKIMKey(ctx context.Context, name string, keySize int) ([]domaintypes.DNSRecord, error) {
+	var result []domaintypes.DNSRecord
+	var err error
+
+	retryErr := WithRetry(ctx, "update_dkim_key", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			result, err = r.client.UpdateDKIMKey(ctx, name, keySize)
+			return err
+		})
+	})
+
+	if retryErr != nil {
+		return nil, retryErr
+	}
+	return result, nil
+}
+
+func (r *ResilientClient) ListDomainIPs(ctx context.Context, name string) ([]string, error) {
+	var result []string
+	var err error
+
+	retryErr := WithRetry(ctx, "list_domain_ips", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			result, err = r.client.ListDomainIPs(ctx, name)
+			return err
+		})
+	})
+
+	if retryErr != nil {
+		return nil, retryErr
+	}
+	return result, nil
+}
+
+func (r *ResilientClient) AssignDomainIP(ctx context.Context, name, ip string) error {
+	return WithRetry(ctx, "assign_domain_ip", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			return r.client.AssignDomainIP(ctx, name, ip)
+		})
+	})
+}
+
+func (r *ResilientClient) UnassignDomainIP(ctx context.Context, name, ip string) error {
+	return WithRetry(ctx, "unassign_domain_ip", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			return r.client.UnassignDomainIP(ctx, name, ip)
+		})
+	})
+}
+
+func (r *ResilientClient) ListDomains(ctx context.Context, limit, skip int) ([]*domaintypes.DomainObservation, int, error) {
+	var result []*domaintypes.DomainObservation
+	var total int
+	var err error
+
+	retryErr := WithRetry(ctx, "list_domains", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			result, total, err = r.client.ListDomains(ctx, limit, skip)
+			return err
+		})
+	})
+
+	if retryErr != nil {
+		return nil, 0, retryErr
+	}
+	return result, total, nil
+}
+
 // Mailing List operations with resilience
 
 func (r *ResilientClient) CreateMailingList(ctx context.Context, list *mailinglisttypes.MailingListParameters) (*mailinglisttypes.MailingListObservation, error) {
@@ -295,6 +587,92 @@ func (r *ResilientClient) DeleteMailingList(ctx context.Context, address string)
 	})
 }
 
+// MailingListMember operations with resilience
+
+func (r *ResilientClient) CreateMailingListMember(ctx context.Context, listAddress string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	var result *mailinglistmembertypes.MailingListMemberObservation
+	var err error
+
+	retryErr := WithRetry(ctx, "create_mailing_list_member", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			result, err = r.client.CreateMailingListMember(ctx, listAddress, member)
+			return err
+		})
+	})
+
+	if retryErr != nil {
+		return nil, retryErr
+	}
+	return result, nil
+}
+
+func (r *ResilientClient) GetMailingListMember(ctx context.Context, listAddress, address string) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	var result *mailinglistmembertypes.MailingListMemberObservation
+	var err error
+
+	retryErr := WithRetry(ctx, "get_mailing_list_member", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			result, err = r.client.GetMailingListMember(ctx, listAddress, address)
+			return err
+		})
+	})
+
+	if retryErr != nil {
+		return nil, retryErr
+	}
+	return result, nil
+}
+
+func (r *ResilientClient) UpdateMailingListMember(ctx context.Context, listAddress, address string, member *mailinglistmembertypes.MailingListMemberParameters) (*mailinglistmembertypes.MailingListMemberObservation, error) {
+	var result *mailinglistmembertypes.MailingListMemberObservation
+	var err error
+
+	retryErr := WithRetry(ctx, "update_mailing_list_member", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			result, err = r.client.UpdateMailingListMember(ctx, listAddress, address, member)
+			return err
+		})
+	})
+
+	if retryErr != nil {
+		return nil, retryErr
+	}
+	return result, nil
+}
+
+func (r *ResilientClient) DeleteMailingListMember(ctx context.Context, listAddress, address string) error {
+	return WithRetry(ctx, "delete_mailing_list_member", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			return r.client.DeleteMailingListMember(ctx, listAddress, address)
+		})
+	})
+}
+
+func (r *ResilientClient) AddMailingListMembers(ctx context.Context, listAddress string, members []clients.MailingListMemberSpec, upsert bool) error {
+	return WithRetry(ctx, "add_mailing_list_members", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			return r.client.AddMailingListMembers(ctx, listAddress, members, upsert)
+		})
+	})
+}
+
+func (r *ResilientClient) ListMailingListMembers(ctx context.Context, listAddress string) ([]*mailinglistmembertypes.MailingListMemberObservation, error) {
+	var result []*mailinglistmembertypes.MailingListMemberObservation
+	var err error
+
+	retryErr := WithRetry(ctx, "list_mailing_list_members", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			result, err = r.client.ListMailingListMembers(ctx, listAddress)
+			return err
+		})
+	})
+
+	if retryErr != nil {
+		return nil, retryErr
+	}
+	return result, nil
+}
+
 // Route operations with resilience
 
 func (r *ResilientClient) CreateRoute(ctx context.Context, route *routetypes.RouteParameters) (*routetypes.RouteObservation, error) {
@@ -356,6 +734,24 @@ func (r *ResilientClient) DeleteRoute(ctx context.Context, id string) error {
 	})
 }
 
+func (r *ResilientClient) ListRoutes(ctx context.Context, limit, skip int) ([]*routetypes.RouteObservation, int, error) {
+	var result []*routetypes.RouteObservation
+	var total int
+	var err error
+
+	retryErr := WithRetry(ctx, "list_routes", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			result, total, err = r.client.ListRoutes(ctx, limit, skip)
+			return err
+		})
+	})
+
+	if retryErr != nil {
+		return nil, 0, retryErr
+	}
+	return result, total, nil
+}
+
 // Webhook operations with resilience
 
 func (r *ResilientClient) CreateWebhook(ctx context.Context, domain string, webhook *webhooktypes.WebhookParameters) (*webhooktypes.WebhookObservation, error) {
@@ -463,8 +859,8 @@ func (r *ResilientClient) DeleteBounce(ctx context.Context, domain, address stri
 
 // Complaint operations with resilience
 
-func (r *ResilientClient) CreateComplaint(ctx context.Context, domain string, complaint interface{}) (interface{}, error) {
-	var result interface{}
+func (r *ResilientClient) CreateComplaint(ctx context.Context, domain string, complaint *complainttypes.ComplaintParameters) (*complainttypes.ComplaintObservation, error) {
+	var result *complainttypes.ComplaintObservation
 	var err error
 
 	retryErr := WithRetry(ctx, "create_complaint", r.retryConfig, func() error {
@@ -480,8 +876,8 @@ func (r *ResilientClient) CreateComplaint(ctx context.Context, domain string, co
 	return result, nil
 }
 
-func (r *ResilientClient) GetComplaint(ctx context.Context, domain, address string) (interface{}, error) {
-	var result interface{}
+func (r *ResilientClient) GetComplaint(ctx context.Context, domain, address string) (*complainttypes.ComplaintObservation, error) {
+	var result *complainttypes.ComplaintObservation
 	var err error
 
 	retryErr := WithRetry(ctx, "get_complaint", r.retryConfig, func() error {
@@ -507,8 +903,8 @@ func (r *ResilientClient) DeleteComplaint(ctx context.Context, domain, address s
 
 // Unsubscribe operations with resilience
 
-func (r *ResilientClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe interface{}) (interface{}, error) {
-	var result interface{}
+func (r *ResilientClient) CreateUnsubscribe(ctx context.Context, domain string, unsubscribe *unsubscribetypes.UnsubscribeParameters) (*unsubscribetypes.UnsubscribeObservation, error) {
+	var result *unsubscribetypes.UnsubscribeObservation
 	var err error
 
 	retryErr := WithRetry(ctx, "create_unsubscribe", r.retryConfig, func() error {
@@ -524,8 +920,8 @@ func (r *ResilientClient) CreateUnsubscribe(ctx context.Context, domain string,
 	return result, nil
 }
 
-func (r *ResilientClient) GetUnsubscribe(ctx context.Context, domain, address string) (interface{}, error) {
-	var result interface{}
+func (r *ResilientClient) GetUnsubscribe(ctx context.Context, domain, address string) (*unsubscribetypes.UnsubscribeObservation, error) {
+	var result *unsubscribetypes.UnsubscribeObservation
 	var err error
 
 	retryErr := WithRetry(ctx, "get_unsubscribe", r.retryConfig, func() error {
@@ -541,10 +937,62 @@ func (r *ResilientClient) GetUnsubscribe(ctx context.Context, domain, address st
 	return result, nil
 }
 
-func (r *ResilientClient) DeleteUnsubscribe(ctx context.Context, domain, address string) error {
+func (r *ResilientClient) DeleteUnsubscribe(ctx context.Context, domain, address, tag string) error {
 	return WithRetry(ctx, "delete_unsubscribe", r.retryConfig, func() error {
 		return r.circuitBreaker.Execute(ctx, func() error {
-			return r.client.DeleteUnsubscribe(ctx, domain, address)
+			return r.client.DeleteUnsubscribe(ctx, domain, address, tag)
+		})
+	})
+}
+
+// IP pool operations with resilience
+
+func (r *ResilientClient) CreateIPPool(ctx context.Context, pool *ippooltypes.IPPoolParameters) (*ippooltypes.IPPoolObservation, error) {
+	var result *ippooltypes.IPPoolObservation
+	var err error
+
+	retryErr := WithRetry(ctx, "create_ip_pool", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			result, err = r.client.CreateIPPool(ctx, pool)
+			return err
+		})
+	})
+
+	if retryErr != nil {
+		return nil, retryErr
+	}
+	return result, nil
+}
+
+func (r *ResilientClient) GetIPPool(ctx context.Context, id string) (*ippooltypes.IPPoolObservation, error) {
+	var result *ippooltypes.IPPoolObservation
+	var err error
+
+	retryErr := WithRetry(ctx, "get_ip_pool", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			result, err = r.client.GetIPPool(ctx, id)
+			return err
+		})
+	})
+
+	if retryErr != nil {
+		return nil, retryErr
+	}
+	return result, nil
+}
+
+func (r *ResilientClient) UpdateIPPool(ctx context.Context, id string, pool *ippooltypes.IPPoolParameters, add, remove []string) error {
+	return WithRetry(ctx, "update_ip_pool", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			return r.client.UpdateIPPool(ctx, id, pool, add, remove)
+		})
+	})
+}
+
+func (r *ResilientClient) DeleteIPPool(ctx context.Context, id string) error {
+	return WithRetry(ctx, "delete_ip_pool", r.retryConfig, func() error {
+		return r.circuitBreaker.Execute(ctx, func() error {
+			return r.client.DeleteIPPool(ctx, id)
 		})
 	})
 }
@@ -19,6 +19,8 @@ package resilience
 import (
 	"context"
 	"fmt"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rossigee/provider-mailgun/internal/clients"
 	"github.com/stretchr/testify/assert"
 	"strings"
 	"testing"
@@ -57,6 +59,19 @@ func TestRetryConfig(t *testing.T) {
 		assert.Contains(t, config.RetryableErrors, "timeout")
 		assert.Contains(t, config.RetryableErrors, "503 service unavailable")
 	})
+
+	t.Run("CreateRetryConfig", func(t *testing.T) {
+		defer SetDefaultCreateAttempts(3)
+
+		config := CreateRetryConfig()
+		assert.Equal(t, 3, config.MaxAttempts)
+		assert.True(t, config.IsRetryableError(&clients.APIError{StatusCode: 500}))
+		assert.False(t, config.IsRetryableError(&clients.APIError{StatusCode: 429}), "only server errors are retried")
+		assert.False(t, config.IsRetryableError(fmt.Errorf("connection reset")))
+
+		SetDefaultCreateAttempts(1)
+		assert.Nil(t, CreateRetryConfig())
+	})
 }
 
 func TestIsRetryableError(t *testing.T) {
@@ -142,6 +157,46 @@ func TestIsRetryableError(t *testing.T) {
 	})
 }
 
+func TestRetryableMessages(t *testing.T) {
+	SetRetryableMessages([]string{"Domain is being processed", " "})
+	defer SetRetryableMessages(nil)
+
+	processing := &clients.APIError{StatusCode: 400, Message: "The domain is being processed, try again later"}
+	invalid := &clients.APIError{StatusCode: 400, Message: "Invalid domain name"}
+
+	t.Run("IsRetryableError", func(t *testing.T) {
+		config := &RetryConfig{MaxAttempts: 3, Retryable: isCreateRetryable}
+		assert.True(t, config.IsRetryableError(fmt.Errorf("failed to create domain: %w", processing)), "configured messages should match case-insensitively through wrapping")
+		assert.False(t, config.IsRetryableError(invalid))
+		assert.False(t, config.IsRetryableError(fmt.Errorf("domain is being processed")), "only Mailgun API errors should be matched")
+		assert.False(t, config.IsRetryableError(&clients.APIError{StatusCode: 404, Message: processing.Message}), "only 400 and 409 errors should be matched")
+		assert.False(t, (&RetryConfig{Retryable: clients.IsServerError}).IsRetryableError(processing), "configured messages should only extend create retries")
+	})
+
+	t.Run("WithRetry", func(t *testing.T) {
+		config := &RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Retryable: isCreateRetryable}
+
+		calls := 0
+		err := WithRetry(context.Background(), "create_domain", config, func() error {
+			calls++
+			if calls < 3 {
+				return processing
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls, "a configured transient message should be retried")
+
+		calls = 0
+		err = WithRetry(context.Background(), "create_domain", config, func() error {
+			calls++
+			return invalid
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 1, calls, "other messages should not be retried")
+	})
+}
+
 func TestCalculateBackoff(t *testing.T) {
 	t.Run("ExponentialBackoff", func(t *testing.T) {
 		config := &RetryConfig{
@@ -315,6 +370,52 @@ func TestWithRetry(t *testing.T) {
 		assert.True(t, isTimeoutOrCancellation, "Expected timeout or cancellation error, got: %s", errStr)
 	})
 
+	t.Run("RateLimitReset", func(t *testing.T) {
+		ctx := context.Background()
+		config := &RetryConfig{
+			MaxAttempts:    3,
+			InitialBackoff: time.Hour, // Would time the test out if used
+			MaxBackoff:     time.Hour,
+		}
+
+		callCount := 0
+		operation := func() error {
+			callCount++
+			if callCount == 1 {
+				return &clients.APIError{StatusCode: 429, Message: "Rate limit exceeded", RetryAfter: 5 * time.Millisecond}
+			}
+			return nil
+		}
+
+		start := time.Now()
+		err := WithRetry(ctx, "test_operation", config, operation)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, callCount)
+		assert.Less(t, time.Since(start), time.Second, "the wait Mailgun asked for should replace the backoff")
+	})
+
+	t.Run("RateLimitResetBeyondMaxBackoff", func(t *testing.T) {
+		ctx := context.Background()
+		config := &RetryConfig{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     time.Second,
+		}
+
+		callCount := 0
+		operation := func() error {
+			callCount++
+			return &clients.APIError{StatusCode: 429, Message: "Rate limit exceeded", RetryAfter: time.Hour}
+		}
+
+		err := WithRetry(ctx, "test_operation", config, operation)
+
+		assert.Error(t, err)
+		assert.True(t, clients.IsRateLimited(err))
+		assert.Equal(t, 1, callCount, "retrying before the limit resets would be wasted")
+	})
+
 	t.Run("DefaultConfig", func(t *testing.T) {
 		ctx := context.Background()
 
@@ -470,3 +571,25 @@ func TestCircuitBreaker(t *testing.T) {
 		assert.Equal(t, CircuitClosed, state)
 	})
 }
+
+func TestResilienceMetrics(t *testing.T) {
+	t.Run("CircuitBreakerTransitions", func(t *testing.T) {
+		cb := NewCircuitBreaker("metrics-test", 1, time.Millisecond)
+		ctx := context.Background()
+		transitions := func(from, to string) float64 {
+			return testutil.ToFloat64(circuitBreakerTransitions.WithLabelValues("metrics-test", from, to))
+		}
+
+		_ = cb.Execute(ctx, func() error { return fmt.Errorf("failure") })
+		assert.Equal(t, 1.0, transitions("closed", "open"))
+		assert.Equal(t, 1.0, testutil.ToFloat64(circuitBreakerState.WithLabelValues("metrics-test")))
+
+		time.Sleep(2 * time.Millisecond)
+		for range 4 {
+			assert.NoError(t, cb.Execute(ctx, func() error { return nil }))
+		}
+		assert.Equal(t, 1.0, transitions("open", "half-open"))
+		assert.Equal(t, 1.0, transitions("half-open", "closed"))
+		assert.Equal(t, 0.0, testutil.ToFloat64(circuitBreakerState.WithLabelValues("metrics-test")))
+	})
+}
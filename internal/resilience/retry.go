@@ -18,12 +18,15 @@ package resilience
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rossigee/provider-mailgun/internal/clients"
 	"math"
 	"math/rand"
 	"net"
+	"net/http"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"strings"
@@ -59,10 +62,19 @@ var (
 		},
 		[]string{"operation"},
 	)
+
+	circuitBreakerTransitions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "provider_mailgun",
+			Name:      "circuit_breaker_transitions_total",
+			Help:      "Total number of circuit breaker state transitions",
+		},
+		[]string{"operation", "from", "to"},
+	)
 )
 
 func init() {
-	metrics.Registry.MustRegister(retryAttempts, retryBackoffDuration, circuitBreakerState)
+	metrics.Registry.MustRegister(retryAttempts, retryBackoffDuration, circuitBreakerState, circuitBreakerTransitions)
 }
 
 // RetryConfig holds retry configuration
@@ -72,6 +84,10 @@ type RetryConfig struct {
 	MaxBackoff      time.Duration
 	BackoffJitter   float64
 	RetryableErrors []string
+
+	// Retryable, when set, decides which errors are retried in place of the
+	// default checks and RetryableErrors
+	Retryable func(error) bool
 }
 
 // DefaultRetryConfig returns a sensible default retry configuration
@@ -115,11 +131,80 @@ func APIRetryConfig() *RetryConfig {
 	}
 }
 
+var defaultCreateAttempts = 3
+
+// SetDefaultCreateAttempts sets how many attempts CreateRetryConfig allows.
+// One or fewer disables create retries.
+func SetDefaultCreateAttempts(n int) {
+	defaultCreateAttempts = n
+}
+
+// CreateRetryConfig returns the retry config for create calls, or nil when
+// create retries are disabled. Only Mailgun server errors, and errors with a
+// message set by SetRetryableMessages, are retried: anything else is either
+// permanent or handled by the next reconcile.
+func CreateRetryConfig() *RetryConfig {
+	if defaultCreateAttempts <= 1 {
+		return nil
+	}
+	return &RetryConfig{
+		MaxAttempts:    defaultCreateAttempts,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		BackoffJitter:  0.2,
+		Retryable:      isCreateRetryable,
+	}
+}
+
+// isCreateRetryable reports whether a failed create is worth retrying
+func isCreateRetryable(err error) bool {
+	return clients.IsServerError(err) || hasRetryableMessage(err)
+}
+
+var defaultRetryableMessages []string
+
+// SetRetryableMessages sets fragments of Mailgun error messages, such as
+// "domain is being processed", that mark a failed create as transient
+// although Mailgun answered 400 Bad Request or 409 Conflict. They are matched
+// case-insensitively against the message of Mailgun API errors with one of
+// those statuses only, so they should be specific enough not to match
+// permanent errors. None are set by default.
+func SetRetryableMessages(messages []string) {
+	defaultRetryableMessages = nil
+	for _, m := range messages {
+		if m = strings.TrimSpace(m); m != "" {
+			defaultRetryableMessages = append(defaultRetryableMessages, strings.ToLower(m))
+		}
+	}
+}
+
+// hasRetryableMessage reports whether err is a Mailgun 400 or 409 error
+// whose message contains one of the fragments set by SetRetryableMessages
+func hasRetryableMessage(err error) bool {
+	var apiErr *clients.APIError
+	if len(defaultRetryableMessages) == 0 || !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.StatusCode != http.StatusBadRequest && apiErr.StatusCode != http.StatusConflict {
+		return false
+	}
+	msg := strings.ToLower(apiErr.Message)
+	for _, m := range defaultRetryableMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsRetryableError checks if an error should trigger a retry
 func (c *RetryConfig) IsRetryableError(err error) bool {
 	if err == nil {
 		return false
 	}
+	if c.Retryable != nil {
+		return c.Retryable(err)
+	}
 
 	errStr := strings.ToLower(err.Error())
 
@@ -208,8 +293,16 @@ func WithRetry(ctx context.Context, operation string, config *RetryConfig, fn fu
 			break
 		}
 
-		// Calculate backoff and wait
+		// Calculate backoff and wait, for as long as Mailgun asked if it
+		// is limiting the rate of requests
 		backoff := config.CalculateBackoff(attempt)
+		if reset, ok := clients.RateLimitReset(err); ok {
+			if reset > config.MaxBackoff {
+				logger.Info("rate limit resets after the maximum backoff, aborting", "retryAfter", reset)
+				break
+			}
+			backoff = reset
+		}
 		logger.Info("retrying operation after backoff",
 			"attempt", attempt+1,
 			"backoff", backoff,
@@ -238,6 +331,19 @@ const (
 	CircuitHalfOpen
 )
 
+// String returns the name of the state, as used in metric labels
+func (s CircuitBreakerState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("unknown(%d)", int(s))
+}
+
 // CircuitBreaker implements the circuit breaker pattern
 type CircuitBreaker struct {
 	name             string
@@ -281,9 +387,8 @@ func (cb *CircuitBreaker) Execute(ctx context.Context, fn func() error) error {
 	case CircuitOpen:
 		if now.Sub(cb.lastFailureTime) > cb.resetTimeout {
 			// Transition to half-open
-			cb.state = CircuitHalfOpen
+			cb.transition(CircuitHalfOpen)
 			cb.successCount = 0
-			circuitBreakerState.WithLabelValues(cb.name).Set(2)
 			logger.Info("circuit breaker transitioning to half-open")
 		} else {
 			circuitBreakerState.WithLabelValues(cb.name).Set(1)
@@ -315,24 +420,32 @@ func (cb *CircuitBreaker) recordFailure(failureTime time.Time, logger logr.Logge
 	cb.lastFailureTime = failureTime
 
 	if cb.failures >= cb.failureThreshold {
-		cb.state = CircuitOpen
+		cb.transition(CircuitOpen)
 		logger.Info("circuit breaker opened due to failures",
 			"failures", cb.failures,
 			"threshold", cb.failureThreshold)
-		circuitBreakerState.WithLabelValues(cb.name).Set(1)
 	}
 }
 
+// transition moves the circuit to state, counting the change
+func (cb *CircuitBreaker) transition(state CircuitBreakerState) {
+	if cb.state == state {
+		return
+	}
+	circuitBreakerTransitions.WithLabelValues(cb.name, cb.state.String(), state.String()).Inc()
+	cb.state = state
+	circuitBreakerState.WithLabelValues(cb.name).Set(float64(state))
+}
+
 // recordSuccess records a success and potentially closes the circuit
 func (cb *CircuitBreaker) recordSuccess(logger logr.Logger) {
 	switch cb.state {
 	case CircuitHalfOpen:
 		cb.successCount++
 		if cb.successCount >= 3 { // Require 3 successes to close
-			cb.state = CircuitClosed
+			cb.transition(CircuitClosed)
 			cb.failures = 0
 			logger.Info("circuit breaker closed after successful operations")
-			circuitBreakerState.WithLabelValues(cb.name).Set(0)
 		}
 	case CircuitClosed:
 		cb.failures = 0 // Reset failure count on success
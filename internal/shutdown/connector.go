@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shutdown
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+)
+
+// Wrap returns a connector whose clients register every Observe, Create,
+// Update and Delete with the default Drainer. An operation that has started
+// when the provider is asked to stop runs to completion within the grace
+// period, so multi-step changes such as key rotation are not cut off between
+// their Mailgun calls. Operations that have not started are not shielded.
+func Wrap(c managed.ExternalConnector) managed.ExternalConnector {
+	return NewConnector(c, Default())
+}
+
+// NewConnector returns a connector whose clients register their operations
+// with d.
+func NewConnector(c managed.ExternalConnector, d *Drainer) managed.ExternalConnector {
+	return managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		ec, err := c.Connect(ctx, mg)
+		if err != nil {
+			return nil, err
+		}
+		return &external{client: ec, drainer: d}, nil
+	})
+}
+
+type external struct {
+	client  managed.ExternalClient
+	drainer *Drainer
+}
+
+// run calls fn with a context shielded from cancellation of ctx, unless ctx
+// is already cancelled.
+func (e *external) run(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Err() != nil {
+		return fn(ctx)
+	}
+	opCtx, done := e.drainer.Begin(ctx)
+	defer done()
+	return fn(opCtx)
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	var obs managed.ExternalObservation
+	err := e.run(ctx, func(ctx context.Context) error {
+		var err error
+		obs, err = e.client.Observe(ctx, mg)
+		return err
+	})
+	return obs, err
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	var cre managed.ExternalCreation
+	err := e.run(ctx, func(ctx context.Context) error {
+		var err error
+		cre, err = e.client.Create(ctx, mg)
+		return err
+	})
+	return cre, err
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	var upd managed.ExternalUpdate
+	err := e.run(ctx, func(ctx context.Context) error {
+		var err error
+		upd, err = e.client.Update(ctx, mg)
+		return err
+	})
+	return upd, err
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	var del managed.ExternalDelete
+	err := e.run(ctx, func(ctx context.Context) error {
+		var err error
+		del, err = e.client.Delete(ctx, mg)
+		return err
+	})
+	return del, err
+}
+
+func (e *external) Disconnect(ctx context.Context) error {
+	return e.client.Disconnect(ctx)
+}
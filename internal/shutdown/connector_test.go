@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shutdown
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/route/v1beta1"
+)
+
+func TestConnectorShieldsWholeOperation(t *testing.T) {
+	d := NewDrainer(time.Second)
+	parent, stop := context.WithCancel(context.Background())
+
+	// The operation makes two calls, and shutdown begins between them.
+	var steps []error
+	c := NewConnector(managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{
+			UpdateFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+				steps = append(steps, ctx.Err())
+				stop()
+				time.Sleep(20 * time.Millisecond)
+				steps = append(steps, ctx.Err())
+				return managed.ExternalUpdate{}, nil
+			},
+		}, nil
+	}), d)
+
+	ec, err := c.Connect(parent, &v1beta1.Route{})
+	require.NoError(t, err)
+	_, err = ec.Update(parent, &v1beta1.Route{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []error{nil, nil}, steps, "the second step should still run after the shutdown signal")
+	assert.True(t, d.Wait(), "completed operation should have been released")
+}
+
+func TestConnectorDoesNotShieldCancelledOperation(t *testing.T) {
+	d := NewDrainer(time.Second)
+	parent, stop := context.WithCancel(context.Background())
+	stop()
+
+	var got error
+	c := NewConnector(managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{
+			CreateFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+				got = ctx.Err()
+				return managed.ExternalCreation{}, nil
+			},
+		}, nil
+	}), d)
+
+	ec, err := c.Connect(context.Background(), &v1beta1.Route{})
+	require.NoError(t, err)
+	_, err = ec.Create(parent, &v1beta1.Route{})
+	require.NoError(t, err)
+	assert.ErrorIs(t, got, context.Canceled)
+}
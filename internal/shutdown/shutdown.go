@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package shutdown lets in-flight Mailgun writes finish when the provider is
+// asked to stop.
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultGracePeriod is how long in-flight operations may keep running after
+// their parent context has been cancelled.
+const DefaultGracePeriod = 30 * time.Second
+
+// A Drainer tracks in-flight operations and shields them from cancellation
+// of their parent context for a bounded grace period.
+type Drainer struct {
+	mu          sync.RWMutex
+	gracePeriod time.Duration
+	deadline    time.Time
+	wg          sync.WaitGroup
+}
+
+// NewDrainer returns a Drainer using the supplied grace period.
+func NewDrainer(gracePeriod time.Duration) *Drainer {
+	return &Drainer{gracePeriod: gracePeriod}
+}
+
+// SetGracePeriod changes the grace period for operations started afterwards.
+func (d *Drainer) SetGracePeriod(gracePeriod time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.gracePeriod = gracePeriod
+}
+
+// GracePeriod returns the current grace period.
+func (d *Drainer) GracePeriod() time.Duration {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.gracePeriod
+}
+
+// Shutdown starts the grace period, if it has not started yet, and returns
+// the deadline by which in-flight operations are cancelled. The deadline is
+// shared, so operations cancelled by the shutdown and Wait all give up at
+// the same instant rather than each granting a grace period of its own.
+func (d *Drainer) Shutdown() time.Time {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.deadline.IsZero() {
+		d.deadline = time.Now().Add(d.gracePeriod)
+	}
+	return d.deadline
+}
+
+// cancelAt returns when an operation whose parent context was just cancelled
+// is cancelled itself: the shutdown deadline once shutdown has started, or a
+// grace period from now otherwise.
+func (d *Drainer) cancelAt() time.Time {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if !d.deadline.IsZero() {
+		return d.deadline
+	}
+	return time.Now().Add(d.gracePeriod)
+}
+
+// Begin registers an in-flight operation. The returned context keeps the
+// values and deadline of ctx, but when ctx is cancelled (as happens when the
+// manager shuts down) it stays alive until the shutdown deadline, or for the
+// grace period if Shutdown has not been called, so the operation can
+// complete. The returned function must be called once the
+// operation has finished.
+func (d *Drainer) Begin(ctx context.Context) (context.Context, func()) {
+	d.wg.Add(1)
+
+	opCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancelDeadline context.CancelFunc
+		opCtx, cancelDeadline = context.WithDeadline(opCtx, deadline)
+		inner := cancel
+		cancel = func() { cancelDeadline(); inner() }
+	}
+
+	go func() {
+		select {
+		case <-opCtx.Done():
+			return
+		case <-ctx.Done():
+		}
+		// Deadlines are carried over to opCtx and expire on their own; only
+		// cancellation gets a grace period.
+		if !errors.Is(ctx.Err(), context.Canceled) {
+			return
+		}
+		grace := time.Until(d.cancelAt())
+		if grace <= 0 {
+			cancel()
+			return
+		}
+		timer := time.NewTimer(grace)
+		defer timer.Stop()
+		select {
+		case <-opCtx.Done():
+		case <-timer.C:
+			cancel()
+		}
+	}()
+
+	var once sync.Once
+	return opCtx, func() {
+		once.Do(func() {
+			cancel()
+			d.wg.Done()
+		})
+	}
+}
+
+// Wait blocks until all in-flight operations have finished or the shutdown
+// deadline passes, starting the grace period if nothing has yet. It reports
+// whether every operation finished.
+func (d *Drainer) Wait() bool {
+	timer := time.NewTimer(time.Until(d.Shutdown()))
+	defer timer.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+var defaultDrainer = NewDrainer(DefaultGracePeriod)
+
+// Default returns the process-wide Drainer used by the managed resource
+// controllers.
+func Default() *Drainer {
+	return defaultDrainer
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shutdown
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrainerCompletesInFlightOperation(t *testing.T) {
+	d := NewDrainer(time.Second)
+	parent, stop := context.WithCancel(context.Background())
+
+	opCtx, done := d.Begin(parent)
+	completed := make(chan error, 1)
+	go func() {
+		defer done()
+		// Simulate a write that is still running when shutdown begins.
+		select {
+		case <-time.After(100 * time.Millisecond):
+			completed <- nil
+		case <-opCtx.Done():
+			completed <- opCtx.Err()
+		}
+	}()
+
+	// Shutdown signal arrives mid-operation.
+	stop()
+
+	assert.True(t, d.Wait(), "in-flight operation should drain within the grace period")
+	assert.NoError(t, <-completed, "operation context should survive parent cancellation")
+}
+
+func TestDrainerCancelsAfterGracePeriod(t *testing.T) {
+	d := NewDrainer(50 * time.Millisecond)
+	parent, stop := context.WithCancel(context.Background())
+
+	opCtx, done := d.Begin(parent)
+	defer done()
+	stop()
+
+	select {
+	case <-opCtx.Done():
+		assert.ErrorIs(t, opCtx.Err(), context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("operation context was not cancelled after the grace period")
+	}
+	assert.False(t, d.Wait(), "operation has not reported completion")
+}
+
+func TestDrainerHonoursDeadline(t *testing.T) {
+	d := NewDrainer(time.Second)
+	parent, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	opCtx, done := d.Begin(parent)
+	defer done()
+
+	select {
+	case <-opCtx.Done():
+		assert.ErrorIs(t, opCtx.Err(), context.DeadlineExceeded)
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("operation context ignored the parent deadline")
+	}
+}
+
+func TestDrainerSharesShutdownDeadline(t *testing.T) {
+	d := NewDrainer(100 * time.Millisecond)
+	parent, stop := context.WithCancel(context.Background())
+
+	opCtx, done := d.Begin(parent)
+	defer done()
+	start := time.Now()
+	d.Shutdown()
+	stop()
+
+	<-opCtx.Done()
+	// The operation used up the grace period, so Wait must not grant it
+	// another one.
+	assert.False(t, d.Wait(), "operation has not reported completion")
+	assert.Less(t, time.Since(start), 190*time.Millisecond, "Wait should stop at the shared deadline")
+}
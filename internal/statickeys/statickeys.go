@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package statickeys adds fixed key/value pairs from a resource's spec to the
+// connection details it publishes, for compositions that need metadata such
+// as the region or domain name next to the credentials.
+package statickeys
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/pkg/errors"
+)
+
+const errReserved = "static connection details must not set keys the provider publishes: %s"
+
+// Keys returns the static connection details of mg.
+type Keys func(mg resource.Managed) map[string]string
+
+// Wrap returns a connector whose clients add the static connection details
+// of each resource to the ones it publishes. Keys listed in reserved are
+// published by the resource itself; setting any of them fails Observe, so
+// the resource is neither created nor updated until they are removed.
+// Resources being deleted are not checked.
+func Wrap(c managed.ExternalConnector, keys Keys, reserved ...string) managed.ExternalConnector {
+	return managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		ec, err := c.Connect(ctx, mg)
+		if err != nil {
+			return nil, err
+		}
+		return &external{client: ec, keys: keys, reserved: reserved}, nil
+	})
+}
+
+type external struct {
+	client   managed.ExternalClient
+	keys     Keys
+	reserved []string
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	static := e.keys(mg)
+	if err := validate(static, e.reserved...); err != nil {
+		if !meta.WasDeleted(mg) {
+			return managed.ExternalObservation{}, err
+		}
+		static = nil
+	}
+
+	obs, err := e.client.Observe(ctx, mg)
+	if err != nil || !obs.ResourceExists {
+		return obs, err
+	}
+	obs.ConnectionDetails = merge(obs.ConnectionDetails, static)
+	return obs, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cre, err := e.client.Create(ctx, mg)
+	if err != nil {
+		return cre, err
+	}
+	cre.ConnectionDetails = merge(cre.ConnectionDetails, e.keys(mg))
+	return cre, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	upd, err := e.client.Update(ctx, mg)
+	if err != nil {
+		return upd, err
+	}
+	upd.ConnectionDetails = merge(upd.ConnectionDetails, e.keys(mg))
+	return upd, nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	return e.client.Delete(ctx, mg)
+}
+
+func (e *external) Disconnect(ctx context.Context) error {
+	return e.client.Disconnect(ctx)
+}
+
+// validate returns an error naming the keys of static that are reserved.
+func validate(static map[string]string, reserved ...string) error {
+	var clashes []string
+	for _, key := range reserved {
+		if _, ok := static[key]; ok {
+			clashes = append(clashes, key)
+		}
+	}
+	if len(clashes) == 0 {
+		return nil
+	}
+	sort.Strings(clashes)
+	return errors.Errorf(errReserved, strings.Join(clashes, ", "))
+}
+
+// merge adds static to details, which it allocates if need be
+func merge(details managed.ConnectionDetails, static map[string]string) managed.ConnectionDetails {
+	if len(static) == 0 {
+		return details
+	}
+	if details == nil {
+		details = managed.ConnectionDetails{}
+	}
+	for k, v := range static {
+		details[k] = []byte(v)
+	}
+	return details
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statickeys
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/smtpcredential/v1beta1"
+)
+
+func keys(mg resource.Managed) map[string]string {
+	return mg.(*v1beta1.SMTPCredential).Spec.ForProvider.StaticConnectionDetails
+}
+
+// publisher connects clients that publish SMTP connection details
+type publisher struct {
+	exists bool
+}
+
+func (p *publisher) connector() managed.ExternalConnector {
+	details := func() managed.ConnectionDetails {
+		return managed.ConnectionDetails{"smtp_username": []byte("alice@example.com")}
+	}
+	return managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{
+			ObserveFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+				if !p.exists {
+					return managed.ExternalObservation{}, nil
+				}
+				return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: details()}, nil
+			},
+			CreateFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+				p.exists = true
+				return managed.ExternalCreation{ConnectionDetails: details()}, nil
+			},
+			UpdateFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+				return managed.ExternalUpdate{}, nil
+			},
+			DeleteFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+				p.exists = false
+				return managed.ExternalDelete{}, nil
+			},
+			DisconnectFn: func(ctx context.Context) error { return nil },
+		}, nil
+	})
+}
+
+func credential(static map[string]string) *v1beta1.SMTPCredential {
+	cr := &v1beta1.SMTPCredential{}
+	cr.Spec.ForProvider.StaticConnectionDetails = static
+	return cr
+}
+
+func TestStaticKeysPublished(t *testing.T) {
+	ctx := context.Background()
+	cr := credential(map[string]string{"region": "eu", "domain": "example.com"})
+	ec, err := Wrap((&publisher{}).connector(), keys, "smtp_username", "smtp_password").Connect(ctx, cr)
+	require.NoError(t, err)
+
+	obs, err := ec.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.Nil(t, obs.ConnectionDetails, "nothing should be published for a resource that does not exist")
+
+	cre, err := ec.Create(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, managed.ConnectionDetails{
+		"smtp_username": []byte("alice@example.com"),
+		"region":        []byte("eu"),
+		"domain":        []byte("example.com"),
+	}, cre.ConnectionDetails)
+
+	obs, err = ec.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("eu"), obs.ConnectionDetails["region"])
+	assert.Equal(t, []byte("alice@example.com"), obs.ConnectionDetails["smtp_username"])
+
+	// Updates publish them too, even when the client publishes nothing
+	upd, err := ec.Update(ctx, cr)
+	require.NoError(t, err)
+	assert.Equal(t, managed.ConnectionDetails{"region": []byte("eu"), "domain": []byte("example.com")}, upd.ConnectionDetails)
+}
+
+func TestStaticKeysReserved(t *testing.T) {
+	ctx := context.Background()
+	cr := credential(map[string]string{"smtp_password": "hunter2", "smtp_username": "bob", "region": "eu"})
+	inner := &publisher{exists: true}
+	ec, err := Wrap(inner.connector(), keys, "smtp_username", "smtp_password").Connect(ctx, cr)
+	require.NoError(t, err)
+
+	_, err = ec.Observe(ctx, cr)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must not set keys the provider publishes: smtp_password, smtp_username")
+
+	// A resource being deleted is not held up by them
+	cr.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+	obs, err := ec.Observe(ctx, cr)
+	require.NoError(t, err)
+	assert.True(t, obs.ResourceExists)
+	assert.Equal(t, []byte("alice@example.com"), obs.ConnectionDetails["smtp_username"], "reserved keys must not be overwritten")
+	_, err = ec.Delete(ctx, cr)
+	require.NoError(t, err)
+	assert.False(t, inner.exists)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package strictdelete implements the fail-on-missing-delete policy, under
+// which a managed resource whose Mailgun resource was deleted outside of the
+// provider fails to delete instead of quietly losing its finalizer.
+package strictdelete
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+)
+
+const errMissing = "the Mailgun resource was deleted outside of the provider; remove the managed resource's finalizer to finish deleting it"
+
+var enabled atomic.Bool
+
+// SetEnabled turns the policy on or off for the whole provider.
+func SetEnabled(on bool) {
+	enabled.Store(on)
+}
+
+// Wrap returns a connector whose clients fail to observe a resource being
+// deleted whose Mailgun resource is gone although it was last observed
+// available, or c itself when the policy is off. The managed reconciler
+// removes the finalizer as soon as Observe reports the Mailgun resource
+// missing, without calling Delete, so Observe is where the policy applies.
+// Once Delete has been called the resource is Deleting rather than
+// Available, and its disappearance is expected.
+func Wrap(c managed.ExternalConnector) managed.ExternalConnector {
+	if !enabled.Load() {
+		return c
+	}
+	return managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		ec, err := c.Connect(ctx, mg)
+		if err != nil {
+			return nil, err
+		}
+		return &external{client: ec}, nil
+	})
+}
+
+type external struct {
+	client managed.ExternalClient
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	obs, err := e.client.Observe(ctx, mg)
+	if err != nil || obs.ResourceExists || !meta.WasDeleted(mg) {
+		return obs, err
+	}
+	if mg.GetCondition(xpv1.TypeReady).Reason == xpv1.ReasonAvailable {
+		return managed.ExternalObservation{}, errors.New(errMissing)
+	}
+	return obs, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	return e.client.Create(ctx, mg)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	return e.client.Update(ctx, mg)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	return e.client.Delete(ctx, mg)
+}
+
+func (e *external) Disconnect(ctx context.Context) error {
+	return e.client.Disconnect(ctx)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strictdelete
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/route/v1beta1"
+)
+
+// missing connects clients that observe the Mailgun resource as gone
+func missing() managed.ExternalConnector {
+	return managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{
+			ObserveFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+				return managed.ExternalObservation{ResourceExists: false}, nil
+			},
+			DisconnectFn: func(ctx context.Context) error { return nil },
+		}, nil
+	})
+}
+
+func route(deleting bool, ready xpv1.Condition) *v1beta1.Route {
+	cr := &v1beta1.Route{}
+	if deleting {
+		now := metav1.NewTime(time.Now())
+		cr.SetDeletionTimestamp(&now)
+	}
+	cr.SetConditions(ready)
+	return cr
+}
+
+func TestStrictDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		enabled bool
+		cr      *v1beta1.Route
+		wantErr bool
+	}{
+		"DeletedOutOfBand": {
+			reason:  "A resource last observed available that is gone while deleting was deleted outside of the provider",
+			enabled: true,
+			cr:      route(true, xpv1.Available()),
+			wantErr: true,
+		},
+		"DeletedByProvider": {
+			reason:  "A resource that is gone after the provider deleted it has finished deleting",
+			enabled: true,
+			cr:      route(true, xpv1.Deleting()),
+		},
+		"NotDeleting": {
+			reason:  "A resource that is gone while not deleting should be created again",
+			enabled: true,
+			cr:      route(false, xpv1.Available()),
+		},
+		"Disabled": {
+			reason: "Without the policy, a resource deleted outside of the provider loses its finalizer",
+			cr:     route(true, xpv1.Available()),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			SetEnabled(tc.enabled)
+			defer SetEnabled(false)
+
+			ec, err := Wrap(missing()).Connect(context.Background(), tc.cr)
+			require.NoError(t, err)
+
+			obs, err := ec.Observe(context.Background(), tc.cr)
+			if tc.wantErr {
+				assert.ErrorContains(t, err, "deleted outside of the provider", tc.reason)
+				return
+			}
+			require.NoError(t, err, tc.reason)
+			assert.False(t, obs.ResourceExists, tc.reason)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package tracing
 
 import (
 	"context"
+	"fmt"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
@@ -29,6 +30,7 @@ const (
 	AttrDomain         = "mailgun.domain"
 	AttrCredentialType = "mailgun.credential.type"
 	AttrOperation      = "mailgun.operation"
+	AttrRequestID      = "mailgun.request_id"
 	AttrResourceType   = "crossplane.resource.type"
 	AttrResourceName   = "crossplane.resource.name"
 )
@@ -54,6 +56,10 @@ func Init(serviceName string) func(context.Context) {
 	if v, err := strconv.ParseFloat(getEnv("OTEL_SAMPLING_RATIO", "0.1"), 64); err == nil {
 		samplingRatio = v
 	}
+	observeSamplingRatio := samplingRatio
+	if v, err := strconv.ParseFloat(getEnv("OTEL_OBSERVE_SAMPLING_RATIO", ""), 64); err == nil {
+		observeSamplingRatio = v
+	}
 
 	ctx := context.Background()
 
@@ -80,7 +86,7 @@ func Init(serviceName string) func(context.Context) {
 	tp = sdktrace.NewTracerProvider(
 		sdktrace.WithBatcher(exporter),
 		sdktrace.WithResource(res),
-		sdktrace.WithSampler(sdktrace.TraceIDRatioBased(samplingRatio)),
+		sdktrace.WithSampler(NewOperationSampler(samplingRatio, observeSamplingRatio)),
 	)
 
 	otel.SetTracerProvider(tp)
@@ -96,6 +102,47 @@ func Init(serviceName string) func(context.Context) {
 	}
 }
 
+// NewOperationSampler returns a sampler that always samples the spans of
+// Create, Update and Delete operations and samples those of Observe
+// operations, which run far more often, at observeRatio. Other root spans
+// are sampled at ratio, and child spans follow their parent.
+func NewOperationSampler(ratio, observeRatio float64) sdktrace.Sampler {
+	return sdktrace.ParentBased(operationSampler{
+		observe:  sdktrace.TraceIDRatioBased(observeRatio),
+		fallback: sdktrace.TraceIDRatioBased(ratio),
+	})
+}
+
+type operationSampler struct {
+	observe  sdktrace.Sampler
+	fallback sdktrace.Sampler
+}
+
+func (s operationSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	switch spanOperation(p) {
+	case SpanResourceObserve:
+		return s.observe.ShouldSample(p)
+	case SpanResourceCreate, SpanResourceUpdate, SpanResourceDelete:
+		return sdktrace.AlwaysSample().ShouldSample(p)
+	}
+	return s.fallback.ShouldSample(p)
+}
+
+func (s operationSampler) Description() string {
+	return fmt.Sprintf("OperationSampler{observe:%s,default:%s}", s.observe.Description(), s.fallback.Description())
+}
+
+// spanOperation returns the crossplane.operation attribute of a span, or its
+// name when it has none
+func spanOperation(p sdktrace.SamplingParameters) string {
+	for _, attr := range p.Attributes {
+		if string(attr.Key) == operationAttr {
+			return attr.Value.AsString()
+		}
+	}
+	return p.Name
+}
+
 func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
 	if tracer == nil {
 		return ctx, nil
@@ -0,0 +1,57 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestOperationSampler(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder), sdktrace.WithSampler(NewOperationSampler(1, 0.25)))
+	tr := tp.Tracer("test")
+
+	const spans = 2000
+	for range spans {
+		for _, op := range []string{SpanResourceObserve, SpanResourceCreate, SpanResourceUpdate, SpanResourceDelete} {
+			_, span := tr.Start(context.Background(), op, trace.WithAttributes(SpanAttrs("Domain", "example", op)...))
+			span.End()
+		}
+	}
+
+	sampled := map[string]int{}
+	for _, s := range recorder.Ended() {
+		sampled[s.Name()]++
+	}
+	assert.Equal(t, spans, sampled[SpanResourceCreate], "creates should always be sampled")
+	assert.Equal(t, spans, sampled[SpanResourceUpdate], "updates should always be sampled")
+	assert.Equal(t, spans, sampled[SpanResourceDelete], "deletes should always be sampled")
+	assert.InDelta(t, spans/4, sampled[SpanResourceObserve], spans/10, "observes should be sampled at their own ratio")
+}
+
+func TestOperationSamplerWritesAlwaysSampled(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder), sdktrace.WithSampler(NewOperationSampler(0, 0)))
+	tr := tp.Tracer("test")
+
+	// The operation is read from the span's attributes when it has them
+	ctx, observe := tr.Start(context.Background(), "smtp.observe", trace.WithAttributes(SpanAttrs("SMTPCredential", "c", SpanResourceObserve)...))
+	_, call := tr.Start(ctx, "GET /v3/domains")
+	call.End()
+	observe.End()
+
+	ctx, create := tr.Start(context.Background(), "smtp.create", trace.WithAttributes(SpanAttrs("SMTPCredential", "c", SpanResourceCreate)...))
+	_, call = tr.Start(ctx, "POST /v3/domains")
+	call.End()
+	create.End()
+
+	var names []string
+	for _, s := range recorder.Ended() {
+		names = append(names, s.Name())
+	}
+	assert.Equal(t, []string{"POST /v3/domains", "smtp.create"}, names, "only the create and the calls it made should be recorded")
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package trigger manages annotations that ask a controller to perform a
+// one-off action. A trigger is consumed once the action has succeeded so that
+// it is neither repeated on every reconcile nor lost when the action fails.
+package trigger
+
+import (
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ForceRotateCredentials asks for the credentials of a resource to be
+	// rotated. SMTPCredentials are recreated to obtain a new password and
+	// Webhooks have their basic auth credentials pushed to Mailgun again,
+	// as Mailgun never returns them and so cannot detect a change.
+	ForceRotateCredentials = "mailgun.crossplane.io/force-rotate-credentials"
+
+	// InternalForceRotate was set by earlier versions of the provider in
+	// place of a consumed ForceRotateCredentials trigger. It is still honoured
+	// and cleared along with it.
+	InternalForceRotate = "mailgun.crossplane.io/internal-force-rotate"
+)
+
+// IsSet reports whether the trigger key is present on o with a non-empty
+// value.
+func IsSet(o metav1.Object, key string) bool {
+	return o.GetAnnotations()[key] != ""
+}
+
+// Value returns the value of the trigger key on o, or "" if it is not set.
+func Value(o metav1.Object, key string) string {
+	return o.GetAnnotations()[key]
+}
+
+// Set sets the trigger key on o.
+func Set(o metav1.Object, key string) {
+	meta.AddAnnotations(o, map[string]string{key: "true"})
+}
+
+// Consume removes the trigger key from o and reports whether it was set.
+// Only the first call for a given trigger returns true. The removal has to be
+// persisted by the caller, e.g. by returning ResourceLateInitialized from
+// Observe, or the trigger fires again on the next reconcile.
+func Consume(o metav1.Object, key string) bool {
+	if !IsSet(o, key) {
+		return false
+	}
+	meta.RemoveAnnotations(o, key)
+	return true
+}
+
+// handledKey returns the annotation recording the value of the trigger key
+// that was last acted on.
+func handledKey(key string) string {
+	return key + "-handled"
+}
+
+// Pending reports whether the trigger key is set on o with a value that has
+// not been marked handled.
+func Pending(o metav1.Object, key string) bool {
+	v := Value(o, key)
+	return v != "" && o.GetAnnotations()[handledKey(key)] != v
+}
+
+// MarkHandled consumes the trigger key on o, recording its value as handled,
+// and reports whether it was set. It is for actions taken in Create: the
+// reconciler persists the annotations of a successful Create, but when it
+// retries that update after a conflict it only adds them to the latest
+// object, so the trigger may come back. The record of its value is only
+// ever added and stops it from firing again.
+func MarkHandled(o metav1.Object, key string) bool {
+	v := Value(o, key)
+	if v == "" {
+		return false
+	}
+	meta.AddAnnotations(o, map[string]string{handledKey(key): v})
+	meta.RemoveAnnotations(o, key)
+	return true
+}
+
+// Settle tidies up after MarkHandled. A trigger that came back with the
+// handled value is consumed again, and the record is removed once the
+// trigger is gone. It reports whether it changed o, in which case the caller
+// has to persist the change, e.g. by returning ResourceLateInitialized from
+// Observe.
+func Settle(o metav1.Object, key string) bool {
+	handled := o.GetAnnotations()[handledKey(key)]
+	if handled == "" {
+		return false
+	}
+	switch Value(o, key) {
+	case handled:
+		meta.RemoveAnnotations(o, key)
+		return true
+	case "":
+		meta.RemoveAnnotations(o, handledKey(key))
+		return true
+	}
+	return false
+}
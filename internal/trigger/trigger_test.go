@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConsume(t *testing.T) {
+	o := &metav1.ObjectMeta{Annotations: map[string]string{
+		ForceRotateCredentials: "true",
+		"other":                "kept",
+	}}
+
+	if !Consume(o, ForceRotateCredentials) {
+		t.Fatal("Consume() = false for a set trigger")
+	}
+	if Consume(o, ForceRotateCredentials) {
+		t.Error("Consume() = true for an already consumed trigger")
+	}
+	if IsSet(o, ForceRotateCredentials) {
+		t.Error("IsSet() = true after Consume()")
+	}
+	if o.Annotations["other"] != "kept" {
+		t.Error("Consume() removed an unrelated annotation")
+	}
+}
+
+func TestConsumeUnset(t *testing.T) {
+	for name, o := range map[string]*metav1.ObjectMeta{
+		"NoAnnotations": {},
+		"EmptyValue":    {Annotations: map[string]string{InternalForceRotate: ""}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if Consume(o, InternalForceRotate) {
+				t.Error("Consume() = true for an unset trigger")
+			}
+		})
+	}
+}
+
+func TestSet(t *testing.T) {
+	o := &metav1.ObjectMeta{}
+	Set(o, InternalForceRotate)
+	if !IsSet(o, InternalForceRotate) {
+		t.Error("IsSet() = false after Set()")
+	}
+}
+
+func TestMarkHandled(t *testing.T) {
+	o := &metav1.ObjectMeta{Annotations: map[string]string{ForceRotateCredentials: "true"}}
+
+	if !MarkHandled(o, ForceRotateCredentials) {
+		t.Fatal("MarkHandled() = false for a set trigger")
+	}
+	if Pending(o, ForceRotateCredentials) {
+		t.Error("Pending() = true after MarkHandled()")
+	}
+
+	// A retried annotation update brings the consumed trigger back
+	o.Annotations[ForceRotateCredentials] = "true"
+	if Pending(o, ForceRotateCredentials) {
+		t.Error("Pending() = true for a trigger that came back with the handled value")
+	}
+	if !Settle(o, ForceRotateCredentials) || IsSet(o, ForceRotateCredentials) {
+		t.Error("Settle() did not consume the trigger that came back")
+	}
+	if !Settle(o, ForceRotateCredentials) {
+		t.Error("Settle() did not forget the handled value once the trigger was gone")
+	}
+	if Settle(o, ForceRotateCredentials) {
+		t.Error("Settle() = true with nothing to tidy up")
+	}
+
+	// Once settled the same value fires again
+	Set(o, ForceRotateCredentials)
+	if !Pending(o, ForceRotateCredentials) {
+		t.Error("Pending() = false for a new request")
+	}
+}
+
+func TestPendingNewValue(t *testing.T) {
+	o := &metav1.ObjectMeta{Annotations: map[string]string{ForceRotateCredentials: "1"}}
+	MarkHandled(o, ForceRotateCredentials)
+	o.Annotations[ForceRotateCredentials] = "2"
+	if !Pending(o, ForceRotateCredentials) {
+		t.Error("Pending() = false for a new trigger value")
+	}
+	if Settle(o, ForceRotateCredentials) {
+		t.Error("Settle() = true for a pending trigger")
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package warmup makes one lightweight Mailgun call per ProviderConfig when
+// the provider starts, so that the first reconciles do not also pay for DNS
+// lookups, TLS handshakes and connection setup.
+package warmup
+
+import (
+	"context"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
+)
+
+// probeTimeout bounds the warm-up call of each ProviderConfig
+const probeTimeout = 10 * time.Second
+
+// Probe warms up the connections of every ProviderConfig. It is best-effort:
+// failures are logged and never stop the provider.
+type Probe struct {
+	kube      client.Client
+	newClient func(*clients.Config) clients.Client
+	log       logging.Logger
+}
+
+// NewProbe returns a Probe that reads ProviderConfigs through kube.
+func NewProbe(kube client.Client, log logging.Logger) *Probe {
+	return &Probe{kube: kube, newClient: clients.NewClient, log: log}
+}
+
+// Start probes each ProviderConfig in turn, then returns. It implements
+// manager.Runnable so that it runs once the manager's caches have synced.
+func (p *Probe) Start(ctx context.Context) error {
+	pcs := &v1beta1.ProviderConfigList{}
+	if err := p.kube.List(ctx, pcs); err != nil {
+		p.log.Info("Connection warm-up skipped: cannot list ProviderConfigs", "error", err)
+		return nil
+	}
+
+	for i := range pcs.Items {
+		pc := &pcs.Items[i]
+		start := time.Now()
+		if err := p.probe(ctx, pc); err != nil {
+			p.log.Info("Connection warm-up failed", "providerConfig", pc.GetName(), "namespace", pc.GetNamespace(), "error", err)
+			continue
+		}
+		p.log.Debug("Connection warmed up", "providerConfig", pc.GetName(), "namespace", pc.GetNamespace(), "duration", time.Since(start).String())
+	}
+	return nil
+}
+
+// probe lists a single domain, the cheapest call every API key may make.
+func (p *Probe) probe(ctx context.Context, pc *v1beta1.ProviderConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	config, err := clients.ConfigFromProviderConfig(ctx, p.kube, pc)
+	if err != nil {
+		return err
+	}
+	_, _, err = p.newClient(config).ListDomains(ctx, 1, 0)
+	return err
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warmup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	domaintypes "github.com/rossigee/provider-mailgun/apis/domain/v1beta1"
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
+	"github.com/rossigee/provider-mailgun/internal/clients"
+)
+
+// listClient records the domain list calls made through it
+type listClient struct {
+	clients.Client
+	apiKey string
+	calls  *[]string
+	err    error
+}
+
+func (c *listClient) ListDomains(ctx context.Context, limit, skip int) ([]*domaintypes.DomainObservation, int, error) {
+	*c.calls = append(*c.calls, c.apiKey)
+	return nil, 0, c.err
+}
+
+func providerConfig(name, secret string) *v1beta1.ProviderConfig {
+	return &v1beta1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "crossplane-system"},
+		Spec: v1beta1.ProviderConfigSpec{Credentials: v1beta1.ProviderCredentials{
+			Source: xpv1.CredentialsSourceSecret,
+			CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: &xpv1.SecretKeySelector{
+				SecretReference: xpv1.SecretReference{Name: secret, Namespace: "crossplane-system"},
+				Key:             "credentials",
+			}},
+		}},
+	}
+}
+
+func secret(name, apiKey string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "crossplane-system"},
+		Data:       map[string][]byte{"credentials": []byte(apiKey)},
+	}
+}
+
+func TestProbeStart(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, v1beta1.SchemeBuilder.AddToScheme(s))
+	require.NoError(t, corev1.AddToScheme(s))
+
+	const (
+		keyA = "key-0123456789abcdef0123456789abcdef"
+		keyB = "key-fedcba9876543210fedcba9876543210"
+	)
+	kube := fake.NewClientBuilder().WithScheme(s).WithObjects(
+		providerConfig("a", "mailgun-a"), secret("mailgun-a", keyA),
+		providerConfig("b", "mailgun-b"), secret("mailgun-b", keyB),
+		// Missing credentials must not stop the others being warmed up
+		providerConfig("broken", "missing"),
+	).Build()
+
+	var calls []string
+	p := NewProbe(kube, logging.NewNopLogger())
+	p.newClient = func(config *clients.Config) clients.Client {
+		return &listClient{apiKey: config.APIKey, calls: &calls, err: errors.New("boom")}
+	}
+
+	require.NoError(t, p.Start(context.Background()), "warm-up must never fail startup")
+	assert.ElementsMatch(t, []string{keyA, keyB}, calls)
+}
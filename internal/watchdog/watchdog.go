@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package watchdog bounds how long a single reconcile of a managed resource
+// may spend talking to Mailgun, and reports reconciles it had to cancel in
+// the ReconcileTimedOut condition.
+package watchdog
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
+)
+
+const errTimedOut = "%s did not complete within the %s reconcile deadline"
+
+var (
+	mu             sync.RWMutex
+	defaultTimeout time.Duration
+)
+
+// SetDefaultTimeout sets the deadline of each reconcile. Zero, the default,
+// disables the watchdog.
+func SetDefaultTimeout(timeout time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+	defaultTimeout = timeout
+}
+
+// DefaultTimeout returns the deadline set by SetDefaultTimeout.
+func DefaultTimeout() time.Duration {
+	mu.RLock()
+	defer mu.RUnlock()
+	return defaultTimeout
+}
+
+// Wrap returns c with the default reconcile deadline, or c itself when the
+// watchdog is disabled.
+func Wrap(c managed.ExternalConnector) managed.ExternalConnector {
+	timeout := DefaultTimeout()
+	if timeout <= 0 {
+		return c
+	}
+	return NewConnector(c, timeout)
+}
+
+// NewConnector returns a connector whose clients share one deadline, starting
+// at Connect, across every call of a reconcile. A call still running at the
+// deadline has its context cancelled.
+func NewConnector(c managed.ExternalConnector, timeout time.Duration) managed.ExternalConnector {
+	return managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		w := &external{timeout: timeout, deadline: time.Now().Add(timeout)}
+		err := w.run(ctx, mg, "connect", func(ctx context.Context) error {
+			var err error
+			w.client, err = c.Connect(ctx, mg)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return w, nil
+	})
+}
+
+type external struct {
+	client   managed.ExternalClient
+	timeout  time.Duration
+	deadline time.Time
+}
+
+// run calls fn with a context bounded by the reconcile deadline and updates
+// the ReconcileTimedOut condition of mg from its result. A cancellation of
+// the reconcile itself is not reported as a timeout.
+func (e *external) run(ctx context.Context, mg resource.Managed, op string, fn func(ctx context.Context) error) error {
+	bounded, cancel := context.WithDeadline(ctx, e.deadline)
+	defer cancel()
+
+	err := fn(bounded)
+	switch {
+	case err != nil && ctx.Err() == nil && errors.Is(bounded.Err(), context.DeadlineExceeded):
+		err = errors.Wrapf(err, errTimedOut, op, e.timeout)
+		mg.SetConditions(apisv1beta1.ReconcileTimedOut(err.Error()))
+	case err == nil && op != "connect" && mg.GetCondition(apisv1beta1.TypeReconcileTimedOut).Status == corev1.ConditionTrue:
+		mg.SetConditions(apisv1beta1.ReconcileInTime())
+	}
+	return err
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	var obs managed.ExternalObservation
+	err := e.run(ctx, mg, "observe", func(ctx context.Context) error {
+		var err error
+		obs, err = e.client.Observe(ctx, mg)
+		return err
+	})
+	return obs, err
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	var cre managed.ExternalCreation
+	err := e.run(ctx, mg, "create", func(ctx context.Context) error {
+		var err error
+		cre, err = e.client.Create(ctx, mg)
+		return err
+	})
+	return cre, err
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	var upd managed.ExternalUpdate
+	err := e.run(ctx, mg, "update", func(ctx context.Context) error {
+		var err error
+		upd, err = e.client.Update(ctx, mg)
+		return err
+	})
+	return upd, err
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	var del managed.ExternalDelete
+	err := e.run(ctx, mg, "delete", func(ctx context.Context) error {
+		var err error
+		del, err = e.client.Delete(ctx, mg)
+		return err
+	})
+	return del, err
+}
+
+// Disconnect is not bounded by the deadline, so that a timed out reconcile
+// still releases its client.
+func (e *external) Disconnect(ctx context.Context) error {
+	return e.client.Disconnect(ctx)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watchdog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	v1beta1 "github.com/rossigee/provider-mailgun/apis/route/v1beta1"
+	apisv1beta1 "github.com/rossigee/provider-mailgun/apis/v1beta1"
+)
+
+// sleeper observes after delay, or fails once its context is done
+type sleeper struct {
+	delay time.Duration
+}
+
+func (s *sleeper) connector() managed.ExternalConnector {
+	return managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{
+			ObserveFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+				select {
+				case <-time.After(s.delay):
+					return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+				case <-ctx.Done():
+					return managed.ExternalObservation{}, ctx.Err()
+				}
+			},
+			DisconnectFn: func(ctx context.Context) error { return nil },
+		}, nil
+	})
+}
+
+func observe(ctx context.Context, c managed.ExternalConnector, mg resource.Managed) error {
+	ec, err := c.Connect(ctx, mg)
+	if err != nil {
+		return err
+	}
+	_, err = ec.Observe(ctx, mg)
+	return err
+}
+
+func TestReconcileTimedOutCondition(t *testing.T) {
+	inner := &sleeper{delay: time.Minute}
+	c := NewConnector(inner.connector(), 20*time.Millisecond)
+	cr := &v1beta1.Route{}
+
+	start := time.Now()
+	err := observe(context.Background(), c, cr)
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 10*time.Second, "the watchdog should cancel the slow observe")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	timedOut := cr.GetCondition(apisv1beta1.TypeReconcileTimedOut)
+	assert.Equal(t, corev1.ConditionTrue, timedOut.Status)
+	assert.Equal(t, apisv1beta1.ReasonTimedOut, timedOut.Reason)
+	assert.Contains(t, timedOut.Message, "observe did not complete within the 20ms reconcile deadline")
+
+	// The deadline applies afresh to each reconcile
+	inner.delay = 0
+	require.NoError(t, observe(context.Background(), c, cr))
+	cleared := cr.GetCondition(apisv1beta1.TypeReconcileTimedOut)
+	assert.Equal(t, corev1.ConditionFalse, cleared.Status)
+	assert.Equal(t, apisv1beta1.ReasonInTime, cleared.Reason)
+
+	// Cancelling the reconcile itself is not a timeout
+	inner.delay = time.Minute
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Error(t, observe(ctx, c, cr))
+	assert.Equal(t, corev1.ConditionFalse, cr.GetCondition(apisv1beta1.TypeReconcileTimedOut).Status)
+}
+
+func TestWrapDisabled(t *testing.T) {
+	inner := (&sleeper{}).connector()
+	SetDefaultTimeout(0)
+
+	ec, err := Wrap(inner).Connect(context.Background(), &v1beta1.Route{})
+	require.NoError(t, err)
+	_, wrapped := ec.(*external)
+	assert.False(t, wrapped, "a zero timeout should leave clients unwrapped")
+}